@@ -0,0 +1,53 @@
+// Package bytesize implements validation of human-readable byte sizes.
+package bytesize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse parses the given size which must be either empty or a non-negative integer with an
+// optional b, kb, mb or gb (case-insensitive) unit suffix, as accepted by config.ParseSizeInBytes.
+// An empty size parses to zero.
+//
+// Unlike config.ParseSizeInBytes, malformed sizes are rejected instead of being silently mapped
+// to zero which usually means that the corresponding limit is disabled.
+func Parse(sizeStr string) (uint64, error) {
+	s := strings.ToLower(strings.TrimSpace(sizeStr))
+	if s == "" {
+		return 0, nil
+	}
+
+	shift := 0
+	for _, unit := range []struct {
+		suffix string
+		shift  int
+	}{
+		{"kb", 10},
+		{"mb", 20},
+		{"gb", 30},
+		{"b", 0},
+	} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			shift = unit.shift
+			break
+		}
+	}
+
+	size, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed size '%s'", sizeStr)
+	}
+	if size > (uint64(1)<<(63-shift))-1 {
+		return 0, fmt.Errorf("size '%s' is too large", sizeStr)
+	}
+	return size << shift, nil
+}
+
+// Validate checks that the given size can be parsed by Parse.
+func Validate(sizeStr string) error {
+	_, err := Parse(sizeStr)
+	return err
+}
@@ -0,0 +1,40 @@
+package bytesize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		size     string
+		expected uint64
+	}{
+		{"", 0},
+		{"0", 0},
+		{"1024", 1024},
+		{"64kb", 64 << 10},
+		{"10 MB", 10 << 20},
+		{"1gb", 1 << 30},
+		{"100b", 100},
+	} {
+		size, err := Parse(tc.size)
+		require.NoError(err, "size '%s' should be valid", tc.size)
+		require.Equal(tc.expected, size, "size '%s' should be parsed correctly", tc.size)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	for _, size := range []string{"", "0", "1024", "64kb", "10 MB", "1gb", "100b"} {
+		require.NoError(Validate(size), "size '%s' should be valid", size)
+	}
+
+	for _, size := range []string{"abc", "10xb", "-1kb", "1.5mb", "10 tb", "10 mib", "99999999999999gb"} {
+		require.Error(Validate(size), "size '%s' should be rejected", size)
+	}
+}
@@ -0,0 +1,156 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind is the kind of a structured change.
+type ChangeKind string
+
+const (
+	// ChangeAdded is a value that is only present in the new object.
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved is a value that is only present in the old object.
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified is a value that is present in both objects but differs.
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is a single change between two structured objects.
+type Change struct {
+	// Path is the JSON pointer (RFC 6901) of the changed value.
+	Path string `json:"path"`
+	// Kind is the kind of the change.
+	Kind ChangeKind `json:"kind"`
+	// Old is the old value (if any).
+	Old interface{} `json:"old,omitempty"`
+	// New is the new value (if any).
+	New interface{} `json:"new,omitempty"`
+}
+
+// String returns a human-readable representation of the change.
+func (c *Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, compactJSON(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, compactJSON(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, compactJSON(c.Old), compactJSON(c.New))
+	}
+}
+
+func compactJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// Structured returns the list of changes between the JSON representations of
+// the given old and new objects, sorted by path.
+//
+// Objects are compared key by key and arrays are compared element by element,
+// so collections that should be matched by identifier should be converted
+// into maps before being compared.
+func Structured(oldObj, newObj interface{}) ([]Change, error) {
+	oldVal, err := toGeneric(oldObj)
+	if err != nil {
+		return nil, fmt.Errorf("diff: failed to convert old object: %w", err)
+	}
+	newVal, err := toGeneric(newObj)
+	if err != nil {
+		return nil, fmt.Errorf("diff: failed to convert new object: %w", err)
+	}
+
+	var changes []Change
+	walk("", oldVal, newVal, &changes)
+	return changes, nil
+}
+
+func toGeneric(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err = json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func escapePathToken(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
+
+func walk(path string, oldVal, newVal interface{}, changes *[]Change) {
+	switch {
+	case oldVal == nil && newVal == nil:
+		return
+	case oldVal == nil:
+		*changes = append(*changes, Change{Path: path, Kind: ChangeAdded, New: newVal})
+		return
+	case newVal == nil:
+		*changes = append(*changes, Change{Path: path, Kind: ChangeRemoved, Old: oldVal})
+		return
+	}
+
+	switch o := oldVal.(type) {
+	case map[string]interface{}:
+		n, ok := newVal.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make(map[string]struct{}, len(o)+len(n))
+		for k := range o {
+			keys[k] = struct{}{}
+		}
+		for k := range n {
+			keys[k] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+
+		for _, k := range sortedKeys {
+			walk(path+"/"+escapePathToken(k), o[k], n[k], changes)
+		}
+		return
+	case []interface{}:
+		n, ok := newVal.([]interface{})
+		if !ok {
+			break
+		}
+
+		l := len(o)
+		if len(n) > l {
+			l = len(n)
+		}
+		for i := 0; i < l; i++ {
+			var ov, nv interface{}
+			if i < len(o) {
+				ov = o[i]
+			}
+			if i < len(n) {
+				nv = n[i]
+			}
+			walk(path+"/"+strconv.Itoa(i), ov, nv, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(oldVal, newVal) {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeModified, Old: oldVal, New: newVal})
+	}
+}
@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructured(t *testing.T) {
+	require := require.New(t)
+
+	type account struct {
+		Balance string   `json:"balance"`
+		Nonce   uint64   `json:"nonce,omitempty"`
+		Tags    []string `json:"tags,omitempty"`
+	}
+
+	oldObj := map[string]account{
+		"alice":   {Balance: "100", Nonce: 1},
+		"bob":     {Balance: "50", Tags: []string{"a", "b"}},
+		"charlie": {Balance: "10"},
+		"a/b~c":   {Balance: "1"},
+	}
+	newObj := map[string]account{
+		"alice": {Balance: "90", Nonce: 2},
+		"bob":   {Balance: "50", Tags: []string{"a"}},
+		"dave":  {Balance: "5"},
+		"a/b~c": {Balance: "2"},
+	}
+
+	changes, err := Structured(oldObj, newObj)
+	require.NoError(err, "Structured")
+	require.Equal([]Change{
+		{Path: "/a~1b~0c/balance", Kind: ChangeModified, Old: "1", New: "2"},
+		{Path: "/alice/balance", Kind: ChangeModified, Old: "100", New: "90"},
+		{Path: "/alice/nonce", Kind: ChangeModified, Old: float64(1), New: float64(2)},
+		{Path: "/bob/tags/1", Kind: ChangeRemoved, Old: "b"},
+		{Path: "/charlie", Kind: ChangeRemoved, Old: map[string]interface{}{"balance": "10"}},
+		{Path: "/dave", Kind: ChangeAdded, New: map[string]interface{}{"balance": "5"}},
+	}, changes)
+
+	require.Equal(`~ /alice/balance: "100" -> "90"`, changes[1].String())
+	require.Equal(`- /bob/tags/1: "b"`, changes[3].String())
+	require.Equal(`+ /dave: {"balance":"5"}`, changes[5].String())
+
+	changes, err = Structured(oldObj, oldObj)
+	require.NoError(err, "Structured")
+	require.Empty(changes, "identical objects should have no changes")
+}
@@ -52,6 +52,9 @@ type Entity struct { // nolint: maligned
 	// will sign the descriptor with the node signing key rather than the
 	// entity signing key.
 	Nodes []signature.PublicKey `json:"nodes,omitempty"`
+
+	// Metadata is optional entity metadata.
+	Metadata *Metadata `json:"metadata,omitempty"`
 }
 
 // UnmarshalCBOR is a custom deserializer that handles both v1 and v2 Entity
@@ -116,6 +119,11 @@ func (e *Entity) ValidateBasic(strictVersion bool) error {
 			)
 		}
 	}
+	if e.Metadata != nil {
+		if err := e.Metadata.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid entity metadata: %w", err)
+		}
+	}
 	return nil
 }
 
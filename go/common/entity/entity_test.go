@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,3 +59,67 @@ func TestEntityDescriptorVersioning(t *testing.T) {
 	require.EqualValues(ev2.Nodes, uv2t1.Nodes)
 	require.EqualValues(cbor.NewVersioned(2), uv2t1.Versioned)
 }
+
+func TestEntityMetadata(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		md    Metadata
+		valid bool
+		msg   string
+	}{
+		{Metadata{}, false, "empty metadata should be invalid"},
+		{Metadata{Name: "Entity"}, true, "name only should be valid"},
+		{Metadata{Name: "Ěntity Ω"}, true, "unicode name should be valid"},
+		{Metadata{Name: strings.Repeat("a", MaxMetadataNameLength+1)}, false, "too long name should be invalid"},
+		{Metadata{Name: "Entity\n"}, false, "name with control characters should be invalid"},
+		{Metadata{Name: "\xff"}, false, "name with invalid UTF-8 should be invalid"},
+		{Metadata{URL: "https://example.com/entity"}, true, "https url should be valid"},
+		{Metadata{URL: "http://example.com"}, false, "http url should be invalid"},
+		{Metadata{URL: "https://"}, false, "url without host should be invalid"},
+		{Metadata{URL: "example.com"}, false, "relative url should be invalid"},
+		{Metadata{URL: "https://example.com/" + strings.Repeat("a", MaxMetadataURLLength)}, false, "too long url should be invalid"},
+		{Metadata{Email: "entity@example.com"}, true, "email should be valid"},
+		{Metadata{Email: "Entity <entity@example.com>"}, false, "email with display name should be invalid"},
+		{Metadata{Email: "entity"}, false, "malformed email should be invalid"},
+		{Metadata{Email: strings.Repeat("a", MaxMetadataEmailLength) + "@example.com"}, false, "too long email should be invalid"},
+		{Metadata{Keybase: "entity_123"}, true, "keybase handle should be valid"},
+		{Metadata{Keybase: "@entity"}, false, "malformed keybase handle should be invalid"},
+		{Metadata{Keybase: strings.Repeat("a", MaxMetadataKeybaseLength+1)}, false, "too long keybase handle should be invalid"},
+		{Metadata{
+			Name:    "Entity",
+			URL:     "https://example.com",
+			Email:   "entity@example.com",
+			Keybase: "entity",
+		}, true, "full metadata should be valid"},
+	} {
+		err := tc.md.ValidateBasic()
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.Error(err, tc.msg)
+		}
+	}
+
+	k := memorySigner.NewTestSigner("test entity metadata")
+	ent := Entity{
+		Versioned: cbor.NewVersioned(LatestDescriptorVersion),
+		ID:        k.Public(),
+	}
+	raw := cbor.Marshal(ent)
+
+	ent.Metadata = &Metadata{Name: "Entity", URL: "https://example.com"}
+	require.NoError(ent.ValidateBasic(true), "ValidateBasic with valid metadata")
+	require.NotEqual(raw, cbor.Marshal(ent), "metadata should be serialized")
+
+	var dec Entity
+	require.NoError(cbor.Unmarshal(cbor.Marshal(ent), &dec), "unmarshal with metadata")
+	require.EqualValues(ent, dec, "metadata should round-trip")
+
+	ent.Metadata = &Metadata{}
+	require.Error(ent.ValidateBasic(true), "ValidateBasic with empty metadata")
+
+	ent.Metadata = nil
+	require.Equal(raw, cbor.Marshal(ent), "entities without metadata should serialize as before")
+}
@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxMetadataNameLength is the maximum length of the entity name.
+	MaxMetadataNameLength = 50
+	// MaxMetadataURLLength is the maximum length of the entity URL.
+	MaxMetadataURLLength = 64
+	// MaxMetadataEmailLength is the maximum length of the entity e-mail address.
+	MaxMetadataEmailLength = 32
+	// MaxMetadataKeybaseLength is the maximum length of the entity Keybase handle.
+	MaxMetadataKeybaseLength = 32
+)
+
+var keybaseHandleRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Metadata is optional entity metadata which is signed together with the
+// entity descriptor.
+type Metadata struct {
+	// Name is the human readable name of the entity.
+	Name string `json:"name,omitempty"`
+
+	// URL is the URL of the entity's website.
+	URL string `json:"url,omitempty"`
+
+	// Email is the entity's contact e-mail address.
+	Email string `json:"email,omitempty"`
+
+	// Keybase is the entity's Keybase handle.
+	Keybase string `json:"keybase,omitempty"`
+}
+
+// IsEmpty returns true iff none of the metadata fields are set.
+func (m *Metadata) IsEmpty() bool {
+	return m.Name == "" && m.URL == "" && m.Email == "" && m.Keybase == ""
+}
+
+// ValidateBasic performs basic metadata validity checks.
+func (m *Metadata) ValidateBasic() error {
+	if m.IsEmpty() {
+		return fmt.Errorf("empty metadata")
+	}
+
+	if m.Name != "" {
+		if len(m.Name) > MaxMetadataNameLength {
+			return fmt.Errorf("name too long (length: %d max: %d)", len(m.Name), MaxMetadataNameLength)
+		}
+		if !utf8.ValidString(m.Name) {
+			return fmt.Errorf("name is not valid UTF-8")
+		}
+		for _, r := range m.Name {
+			if !unicode.IsPrint(r) {
+				return fmt.Errorf("name contains non-printable characters")
+			}
+		}
+	}
+
+	if m.URL != "" {
+		if len(m.URL) > MaxMetadataURLLength {
+			return fmt.Errorf("url too long (length: %d max: %d)", len(m.URL), MaxMetadataURLLength)
+		}
+		u, err := url.Parse(m.URL)
+		if err != nil {
+			return fmt.Errorf("malformed url: %w", err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("url must be an absolute https url")
+		}
+	}
+
+	if m.Email != "" {
+		if len(m.Email) > MaxMetadataEmailLength {
+			return fmt.Errorf("email too long (length: %d max: %d)", len(m.Email), MaxMetadataEmailLength)
+		}
+		addr, err := mail.ParseAddress(m.Email)
+		if err != nil || addr.Address != m.Email {
+			return fmt.Errorf("malformed email address")
+		}
+	}
+
+	if m.Keybase != "" {
+		if len(m.Keybase) > MaxMetadataKeybaseLength {
+			return fmt.Errorf("keybase handle too long (length: %d max: %d)", len(m.Keybase), MaxMetadataKeybaseLength)
+		}
+		if !keybaseHandleRegexp.MatchString(m.Keybase) {
+			return fmt.Errorf("malformed keybase handle")
+		}
+	}
+
+	return nil
+}
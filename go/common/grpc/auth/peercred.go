@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// PeerCredSecurityProtocol is the security protocol name reported by the peer
+// credentials transport credentials.
+const PeerCredSecurityProtocol = "peercred"
+
+// PeerCredAuthInfo is the authentication information of a client connected
+// over a local (UNIX) socket, as reported by the kernel.
+type PeerCredAuthInfo struct {
+	credentials.CommonAuthInfo
+
+	// PID is the process ID of the client.
+	PID int32
+	// UID is the user ID of the client.
+	UID uint32
+	// GID is the primary group ID of the client.
+	GID uint32
+}
+
+// AuthType returns the type of the authentication information.
+func (PeerCredAuthInfo) AuthType() string {
+	return PeerCredSecurityProtocol
+}
+
+type peerCredentials struct{}
+
+// NewPeerCredentials returns server-side transport credentials that do not
+// secure the connection, but obtain the credentials of clients connecting
+// over local (UNIX) sockets so that they can be used for authorization.
+//
+// Connections over other transports are accepted without any authentication
+// information.
+func NewPeerCredentials() credentials.TransportCredentials {
+	return &peerCredentials{}
+}
+
+func (c *peerCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("grpc: peer credentials are server-side only")
+}
+
+func (c *peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn, nil, nil
+	}
+
+	info, err := getPeerCred(unixConn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, *info, nil
+}
+
+func (c *peerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: PeerCredSecurityProtocol,
+	}
+}
+
+func (c *peerCredentials) Clone() credentials.TransportCredentials {
+	return &peerCredentials{}
+}
+
+func (c *peerCredentials) OverrideServerName(string) error {
+	return nil
+}
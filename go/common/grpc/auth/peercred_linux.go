@@ -0,0 +1,39 @@
+//go:build linux
+// +build linux
+
+package auth
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+
+	"google.golang.org/grpc/credentials"
+)
+
+func getPeerCred(conn *net.UnixConn) (*PeerCredAuthInfo, error) {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return nil, fmt.Errorf("grpc: failed to obtain raw connection: %w", err)
+	}
+
+	var (
+		ucred    *syscall.Ucred
+		ucredErr error
+	)
+	if err = rawConn.Control(func(fd uintptr) {
+		ucred, ucredErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return nil, fmt.Errorf("grpc: failed to access raw connection: %w", err)
+	}
+	if ucredErr != nil {
+		return nil, fmt.Errorf("grpc: failed to obtain peer credentials: %w", ucredErr)
+	}
+
+	return &PeerCredAuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		PID:            ucred.Pid,
+		UID:            ucred.Uid,
+		GID:            ucred.Gid,
+	}, nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package auth
+
+import (
+	"fmt"
+	"net"
+)
+
+func getPeerCred(conn *net.UnixConn) (*PeerCredAuthInfo, error) {
+	return nil, fmt.Errorf("grpc: peer credentials not supported on this platform")
+}
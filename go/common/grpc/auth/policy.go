@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+const (
+	// IdentityAny is the policy identity matching any client.
+	IdentityAny = "*"
+	// IdentityPrefixUID is the policy identity prefix matching local clients
+	// by their user ID.
+	IdentityPrefixUID = "uid:"
+	// IdentityPrefixGID is the policy identity prefix matching local clients
+	// by their primary group ID.
+	IdentityPrefixGID = "gid:"
+	// IdentityPrefixPubKey is the policy identity prefix matching clients by
+	// the public key of their TLS client certificate.
+	IdentityPrefixPubKey = "pubkey:"
+)
+
+// PolicyRule is a single authorization policy rule.
+type PolicyRule struct {
+	// Name is an optional human-readable name of the rule.
+	Name string `yaml:"name,omitempty"`
+	// Identities are the client identities the rule applies to.
+	//
+	// Supported identities are:
+	//   - "*" matching any client,
+	//   - "uid:<uid>" matching local clients running as the given user,
+	//   - "gid:<gid>" matching local clients running with the given primary group,
+	//   - "pubkey:<base64>" matching clients presenting a TLS certificate for the given key.
+	Identities []string `yaml:"identities"`
+	// Methods are the full method name patterns the identities are allowed to call
+	// (e.g., "/oasis-core.Consensus/Get*"). Patterns use path.Match syntax.
+	Methods []string `yaml:"methods"`
+}
+
+// Policy is a declarative per-method authorization policy.
+//
+// A call is allowed iff at least one rule matches both one of the caller's
+// identities and the called method. A policy without any rules allows all
+// calls.
+type Policy struct {
+	// Rules are the policy rules.
+	Rules []PolicyRule `yaml:"rules,omitempty"`
+}
+
+// Validate validates the policy.
+func (p *Policy) Validate() error {
+	for i, rule := range p.Rules {
+		if len(rule.Identities) == 0 {
+			return fmt.Errorf("rule %d: no identities configured", i)
+		}
+		for _, id := range rule.Identities {
+			if err := validateIdentity(id); err != nil {
+				return fmt.Errorf("rule %d: %w", i, err)
+			}
+		}
+		if len(rule.Methods) == 0 {
+			return fmt.Errorf("rule %d: no methods configured", i)
+		}
+		for _, method := range rule.Methods {
+			if !strings.HasPrefix(method, "/") {
+				return fmt.Errorf("rule %d: method pattern must start with '/': %s", i, method)
+			}
+			if _, err := path.Match(method, ""); err != nil {
+				return fmt.Errorf("rule %d: malformed method pattern '%s': %w", i, method, err)
+			}
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true iff the policy has no rules and thus allows all calls.
+func (p *Policy) IsEmpty() bool {
+	return len(p.Rules) == 0
+}
+
+// Allows returns true iff any of the given identities is allowed to call
+// the given method.
+func (p *Policy) Allows(identities []string, fullMethodName string) bool {
+	if p.IsEmpty() {
+		return true
+	}
+
+	for _, rule := range p.Rules {
+		if !rule.matchesIdentity(identities) {
+			continue
+		}
+		for _, method := range rule.Methods {
+			if ok, _ := path.Match(method, fullMethodName); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AuthFunc is an AuthenticationFunction enforcing the policy.
+func (p *Policy) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	identities := PeerIdentities(ctx)
+	if !p.Allows(identities, fullMethodName) {
+		return status.Errorf(codes.PermissionDenied, "grpc: method %s not allowed by policy", fullMethodName)
+	}
+	return nil
+}
+
+func (r *PolicyRule) matchesIdentity(identities []string) bool {
+	for _, allowed := range r.Identities {
+		if allowed == IdentityAny {
+			return true
+		}
+		for _, id := range identities {
+			if allowed == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateIdentity(id string) error {
+	switch {
+	case id == IdentityAny:
+	case strings.HasPrefix(id, IdentityPrefixUID):
+		if _, err := strconv.ParseUint(strings.TrimPrefix(id, IdentityPrefixUID), 10, 32); err != nil {
+			return fmt.Errorf("malformed user ID identity '%s': %w", id, err)
+		}
+	case strings.HasPrefix(id, IdentityPrefixGID):
+		if _, err := strconv.ParseUint(strings.TrimPrefix(id, IdentityPrefixGID), 10, 32); err != nil {
+			return fmt.Errorf("malformed group ID identity '%s': %w", id, err)
+		}
+	case strings.HasPrefix(id, IdentityPrefixPubKey):
+		var pk signature.PublicKey
+		if err := pk.UnmarshalText([]byte(strings.TrimPrefix(id, IdentityPrefixPubKey))); err != nil {
+			return fmt.Errorf("malformed public key identity '%s': %w", id, err)
+		}
+	default:
+		return fmt.Errorf("unsupported identity: %s", id)
+	}
+	return nil
+}
+
+// PeerIdentities returns the policy identities of the peer of the given
+// gRPC call context.
+func PeerIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	switch info := p.AuthInfo.(type) {
+	case PeerCredAuthInfo:
+		return []string{
+			IdentityPrefixUID + strconv.FormatUint(uint64(info.UID), 10),
+			IdentityPrefixGID + strconv.FormatUint(uint64(info.GID), 10),
+		}
+	case credentials.TLSInfo:
+		if len(info.State.PeerCertificates) != 1 {
+			return nil
+		}
+		rawPk, ok := info.State.PeerCertificates[0].PublicKey.(ed25519.PublicKey)
+		if !ok {
+			return nil
+		}
+		var pk signature.PublicKey
+		if err := pk.UnmarshalBinary(rawPk); err != nil {
+			return nil
+		}
+		return []string{IdentityPrefixPubKey + pk.String()}
+	default:
+		return nil
+	}
+}
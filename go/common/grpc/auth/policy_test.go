@@ -0,0 +1,129 @@
+package auth_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	commonGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
+	commonTesting "github.com/oasisprotocol/oasis-core/go/common/grpc/testing"
+)
+
+func TestPolicy(t *testing.T) {
+	require := require.New(t)
+
+	policy := auth.Policy{
+		Rules: []auth.PolicyRule{
+			{
+				Name:       "monitoring",
+				Identities: []string{"uid:1001", "gid:1001"},
+				Methods:    []string{"/oasis-core.Consensus/Get*", "/*/Watch*"},
+			},
+			{
+				Name:       "admin",
+				Identities: []string{"uid:0"},
+				Methods:    []string{"/*/*"},
+			},
+		},
+	}
+	require.NoError(policy.Validate(), "Validate")
+
+	for _, tc := range []struct {
+		identities []string
+		method     string
+		allowed    bool
+	}{
+		{[]string{"uid:1001"}, "/oasis-core.Consensus/GetStatus", true},
+		{[]string{"uid:1002", "gid:1001"}, "/oasis-core.Scheduler/WatchCommittees", true},
+		{[]string{"uid:1001"}, "/oasis-core.Consensus/SubmitTx", false},
+		{[]string{"uid:1001"}, "/oasis-core.NodeController/RequestShutdown", false},
+		{[]string{"uid:0"}, "/oasis-core.NodeController/RequestShutdown", true},
+		{nil, "/oasis-core.Consensus/GetStatus", false},
+	} {
+		require.Equal(tc.allowed, policy.Allows(tc.identities, tc.method), "Allows(%v, %s)", tc.identities, tc.method)
+	}
+
+	var empty auth.Policy
+	require.True(empty.Allows(nil, "/oasis-core.NodeController/RequestShutdown"), "empty policy should allow all calls")
+
+	for _, invalid := range []auth.PolicyRule{
+		{Identities: []string{"uid:foo"}, Methods: []string{"/*/*"}},
+		{Identities: []string{"pubkey:foo"}, Methods: []string{"/*/*"}},
+		{Identities: []string{"user:root"}, Methods: []string{"/*/*"}},
+		{Identities: []string{"*"}, Methods: []string{"oasis-core.Consensus/*"}},
+		{Identities: []string{"*"}, Methods: []string{"/oasis-core.Consensus/["}},
+		{Identities: []string{"*"}},
+		{Methods: []string{"/*/*"}},
+	} {
+		policy := auth.Policy{Rules: []auth.PolicyRule{invalid}}
+		require.Error(policy.Validate(), "invalid rule %+v should be rejected", invalid)
+	}
+}
+
+func TestPolicyPeerCredentials(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on Linux")
+	}
+
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := os.MkdirTemp("", "oasis-grpc-policy-test")
+	require.NoError(err, "MkdirTemp")
+	defer os.RemoveAll(dir)
+
+	uid := auth.IdentityPrefixUID + strconv.Itoa(os.Getuid())
+	policy := &auth.Policy{
+		Rules: []auth.PolicyRule{
+			{
+				Identities: []string{uid},
+				Methods:    []string{commonTesting.MethodPing.FullName()},
+			},
+		},
+	}
+	require.NoError(policy.Validate(), "Validate")
+
+	path := filepath.Join(dir, "internal.sock")
+	grpcServer, err := commonGrpc.NewServer(&commonGrpc.ServerConfig{
+		Name:          "policy-test",
+		Path:          path,
+		AuthFunc:      policy.AuthFunc,
+		CustomOptions: []grpc.ServerOption{grpc.Creds(auth.NewPeerCredentials())},
+	})
+	require.NoError(err, "NewServer")
+	commonTesting.RegisterService(grpcServer.Server(), commonTesting.NewPingServer(policy.AuthFunc))
+	require.NoError(grpcServer.Start(), "Start")
+	defer func() {
+		grpcServer.Stop()
+		grpcServer.Cleanup()
+	}()
+
+	conn, err := grpc.DialContext(
+		ctx,
+		"unix:"+path,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(&commonGrpc.CBORCodec{})),
+	)
+	require.NoError(err, "DialContext")
+	defer conn.Close()
+
+	client := commonTesting.NewPingClient(conn)
+	_, err = client.Ping(ctx, &commonTesting.PingQuery{})
+	require.NoError(err, "Ping should be allowed for the current user")
+
+	// Restrict the policy to a different user.
+	policy.Rules[0].Identities = []string{auth.IdentityPrefixUID + strconv.Itoa(os.Getuid()+1)}
+	_, err = client.Ping(ctx, &commonTesting.PingQuery{})
+	require.Error(err, "Ping should be denied for other users")
+	require.Equal(codes.PermissionDenied, status.Code(err), "denied calls should fail with PermissionDenied")
+}
@@ -95,6 +95,7 @@ var (
 		grpcServerCalls,
 		grpcServerLatency,
 		grpcServerStreamWrites,
+		grpcServerThrottledCalls,
 	}
 
 	serverKeepAliveParams = keepalive.ServerParameters{
@@ -440,6 +441,11 @@ type ServerConfig struct { // nolint: maligned
 	// ClientCommonName is the expected common name on client TLS certificates. If not specified,
 	// the default identity.CommonName will be used.
 	ClientCommonName string
+	// ClientLimits are optional per-client limits enforced by the server.
+	ClientLimits *ClientLimits
+	// MaxRequestSize is the maximum size of a received message in bytes. If not specified,
+	// the default maximum message size will be used.
+	MaxRequestSize int
 	// CustomOptions is an array of extra options for the grpc server.
 	CustomOptions []grpc.ServerOption
 }
@@ -616,17 +622,27 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		// Default to identity.CommonName.
 		config.ClientCommonName = identity.CommonName
 	}
+	recvMsgSize := maxRecvMsgSize
+	if config.MaxRequestSize > 0 {
+		recvMsgSize = config.MaxRequestSize
+	}
 	var wrapper *grpcWrapper
 	unaryInterceptors := []grpc.UnaryServerInterceptor{
 		logAdapter.unaryLogger,
 		serverUnaryErrorMapper,
-		auth.UnaryServerInterceptor(config.AuthFunc),
 	}
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		logAdapter.streamLogger,
 		serverStreamErrorMapper,
-		auth.StreamServerInterceptor(config.AuthFunc),
 	}
+	if config.ClientLimits != nil {
+		// Throttle before authentication so that rejecting clients is cheap.
+		limiter := newClientLimiter(config.Name, *config.ClientLimits)
+		unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
+	}
+	unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(config.AuthFunc))
+	streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(config.AuthFunc))
 	if config.InstallWrapper {
 		wrapper = newWrapper()
 		unaryInterceptors = append(unaryInterceptors, wrapper.unaryInterceptor)
@@ -635,7 +651,7 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	sOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 		grpc.ChainStreamInterceptor(streamInterceptors...),
-		grpc.MaxRecvMsgSize(maxRecvMsgSize),
+		grpc.MaxRecvMsgSize(recvMsgSize),
 		grpc.MaxSendMsgSize(maxSendMsgSize),
 		grpc.KeepaliveParams(serverKeepAliveParams),
 		grpc.ForceServerCodec(&CBORCodec{}),
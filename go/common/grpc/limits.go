@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/ratelimit"
+)
+
+const (
+	throttleReasonRate    = "rate"
+	throttleReasonStreams = "streams"
+)
+
+var grpcServerThrottledCalls = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "oasis_grpc_server_throttled_calls",
+		Help: "Number of gRPC calls rejected due to per-client limits.",
+	},
+	[]string{"server", "call", "reason"},
+)
+
+// ClientLimits are per-client limits enforced by a gRPC server. Clients are
+// identified by their IP address.
+type ClientLimits struct {
+	// RequestRate is the maximum number of calls per second (0 = unlimited).
+	RequestRate float64
+	// RequestBurst is the maximum number of calls in a burst.
+	RequestBurst int
+	// MaxConcurrentStreams is the maximum number of concurrent streaming calls
+	// (0 = unlimited).
+	MaxConcurrentStreams int
+}
+
+type clientLimiter struct {
+	sync.Mutex
+
+	name    string
+	limits  ClientLimits
+	rate    *ratelimit.KeyedLimiter
+	streams map[string]int
+}
+
+func (l *clientLimiter) throttled(fullMethod, reason string) error {
+	grpcServerThrottledCalls.With(prometheus.Labels{
+		"server": l.name,
+		"call":   fullMethod,
+		"reason": reason,
+	}).Inc()
+	return status.Errorf(codes.ResourceExhausted, "grpc: too many requests")
+}
+
+func (l *clientLimiter) acquireStream(client string) bool {
+	if l.limits.MaxConcurrentStreams <= 0 {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.streams[client] >= l.limits.MaxConcurrentStreams {
+		return false
+	}
+	l.streams[client]++
+	return true
+}
+
+func (l *clientLimiter) releaseStream(client string) {
+	if l.limits.MaxConcurrentStreams <= 0 {
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.streams[client]--
+	if l.streams[client] <= 0 {
+		delete(l.streams, client)
+	}
+}
+
+func (l *clientLimiter) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !l.rate.Allow(clientAddress(ctx)) {
+		return nil, l.throttled(info.FullMethod, throttleReasonRate)
+	}
+	return handler(ctx, req)
+}
+
+func (l *clientLimiter) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	client := clientAddress(ss.Context())
+	if !l.rate.Allow(client) {
+		return l.throttled(info.FullMethod, throttleReasonRate)
+	}
+	if !l.acquireStream(client) {
+		return l.throttled(info.FullMethod, throttleReasonStreams)
+	}
+	defer l.releaseStream(client)
+
+	return handler(srv, ss)
+}
+
+func newClientLimiter(name string, limits ClientLimits) *clientLimiter {
+	return &clientLimiter{
+		name:    name,
+		limits:  limits,
+		rate:    ratelimit.NewKeyedLimiter(limits.RequestRate, limits.RequestBurst),
+		streams: make(map[string]int),
+	}
+}
+
+// clientAddress returns the IP address of the peer of the given gRPC call
+// context, or an empty string if it cannot be determined.
+func clientAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	switch addr := p.Addr.(type) {
+	case *net.TCPAddr:
+		return addr.IP.String()
+	default:
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return addr.String()
+		}
+		return host
+	}
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type limitsTestStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *limitsTestStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestClientLimiter(t *testing.T) {
+	require := require.New(t)
+
+	limiter := newClientLimiter("test", ClientLimits{
+		RequestRate:          0.001,
+		RequestBurst:         2,
+		MaxConcurrentStreams: 1,
+	})
+
+	clientCtx := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+		})
+	}
+	unaryInfo := &grpc.UnaryServerInfo{FullMethod: "/oasis-core.Test/Ping"}
+	unaryHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	ctxA := clientCtx("10.0.0.1")
+	for i := 0; i < 2; i++ {
+		_, err := limiter.unaryInterceptor(ctxA, nil, unaryInfo, unaryHandler)
+		require.NoError(err, "calls within the burst should be allowed")
+	}
+	_, err := limiter.unaryInterceptor(ctxA, nil, unaryInfo, unaryHandler)
+	require.Equal(codes.ResourceExhausted, status.Code(err), "calls exceeding the rate should be throttled")
+
+	// Different clients are limited independently, but streams are also counted.
+	ctxB := clientCtx("10.0.0.2")
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/oasis-core.Test/WatchPings"}
+	err = limiter.streamInterceptor(nil, &limitsTestStream{ctx: ctxB}, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+		// While this stream is active, another one should be rejected.
+		nestedErr := limiter.streamInterceptor(nil, stream, streamInfo, func(interface{}, grpc.ServerStream) error {
+			return nil
+		})
+		require.Equal(codes.ResourceExhausted, status.Code(nestedErr), "streams exceeding the limit should be throttled")
+		return nil
+	})
+	require.NoError(err, "stream within the limits should be allowed")
+	require.Empty(limiter.streams, "finished streams should be released")
+}
@@ -0,0 +1,177 @@
+// Package ratelimit implements token bucket rate limiters.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// pruneInterval is the minimum interval between pruning idle keyed limiters.
+const pruneInterval = time.Minute
+
+// Limiter is a token bucket rate limiter.
+//
+// The bucket holds up to burst tokens and is refilled at the given rate of
+// tokens per second. A limiter with a non-positive rate allows everything.
+type Limiter struct {
+	sync.Mutex
+
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Allow returns true iff a single event may happen now.
+func (l *Limiter) Allow() bool {
+	return l.AllowN(time.Now(), 1)
+}
+
+// AllowN returns true iff n events may happen at the given time. When allowed,
+// the corresponding tokens are consumed.
+func (l *Limiter) AllowN(now time.Time, n int) bool {
+	if l.rate <= 0 {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.refill(now)
+	if l.tokens < float64(n) {
+		return false
+	}
+	l.tokens -= float64(n)
+	return true
+}
+
+// ConsumeN unconditionally consumes n tokens at the given time. The bucket may
+// go into debt, in which case no further events are allowed until it is
+// refilled. This is useful when the cost of an event is only known after it
+// happened (e.g., the size of a response).
+//
+// Note that AllowN with n set to zero can be used to check whether the bucket
+// is in debt.
+func (l *Limiter) ConsumeN(now time.Time, n int) {
+	if l.rate <= 0 {
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.refill(now)
+	l.tokens -= float64(n)
+}
+
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens += elapsed * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// isFull returns true iff the bucket would be full at the given time. A full
+// bucket is indistinguishable from a freshly created one.
+func (l *Limiter) isFull(now time.Time) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	l.refill(now)
+	return l.tokens >= l.burst
+}
+
+// NewLimiter creates a new rate limiter allowing events at the given rate per
+// second with the given burst size. The bucket starts full.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// KeyedLimiter is a set of independent token bucket rate limiters, one for
+// each key (e.g., client address).
+type KeyedLimiter struct {
+	sync.Mutex
+
+	rate     float64
+	burst    int
+	limiters map[string]*Limiter
+
+	lastPrune time.Time
+}
+
+// Allow returns true iff a single event for the given key may happen now.
+func (k *KeyedLimiter) Allow(key string) bool {
+	return k.AllowN(key, time.Now(), 1)
+}
+
+// AllowN returns true iff n events for the given key may happen at the given
+// time. When allowed, the corresponding tokens are consumed.
+func (k *KeyedLimiter) AllowN(key string, now time.Time, n int) bool {
+	if k.rate <= 0 {
+		return true
+	}
+	return k.limiter(key, now).AllowN(now, n)
+}
+
+// ConsumeN unconditionally consumes n tokens for the given key at the given
+// time. See Limiter.ConsumeN for details.
+func (k *KeyedLimiter) ConsumeN(key string, now time.Time, n int) {
+	if k.rate <= 0 {
+		return
+	}
+	k.limiter(key, now).ConsumeN(now, n)
+}
+
+// Len returns the number of currently tracked keys.
+func (k *KeyedLimiter) Len() int {
+	k.Lock()
+	defer k.Unlock()
+
+	return len(k.limiters)
+}
+
+func (k *KeyedLimiter) limiter(key string, now time.Time) *Limiter {
+	k.Lock()
+	defer k.Unlock()
+
+	// Periodically drop limiters with full buckets as they would behave the
+	// same as newly created ones. This bounds memory used by idle keys.
+	if now.Sub(k.lastPrune) >= pruneInterval {
+		for key, l := range k.limiters {
+			if l.isFull(now) {
+				delete(k.limiters, key)
+			}
+		}
+		k.lastPrune = now
+	}
+
+	l, ok := k.limiters[key]
+	if !ok {
+		l = NewLimiter(k.rate, k.burst)
+		l.last = now
+		k.limiters[key] = l
+	}
+	return l
+}
+
+// NewKeyedLimiter creates a new keyed rate limiter where each key is allowed
+// events at the given rate per second with the given burst size.
+func NewKeyedLimiter(rate float64, burst int) *KeyedLimiter {
+	return &KeyedLimiter{
+		rate:      rate,
+		burst:     burst,
+		limiters:  make(map[string]*Limiter),
+		lastPrune: time.Now(),
+	}
+}
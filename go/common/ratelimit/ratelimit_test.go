@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	l := NewLimiter(2, 3)
+	l.last = now
+
+	// The bucket starts full.
+	for i := 0; i < 3; i++ {
+		require.True(l.AllowN(now, 1), "burst event %d should be allowed", i)
+	}
+	require.False(l.AllowN(now, 1), "event exceeding the burst should be throttled")
+
+	// Two tokens are refilled every second.
+	now = now.Add(500 * time.Millisecond)
+	require.True(l.AllowN(now, 1), "event after refill should be allowed")
+	require.False(l.AllowN(now, 1), "event exceeding the refill should be throttled")
+
+	// The bucket never holds more than the burst.
+	now = now.Add(time.Hour)
+	require.False(l.AllowN(now, 4), "events exceeding the burst should be throttled")
+	require.True(l.AllowN(now, 3), "events up to the burst should be allowed")
+
+	unlimited := NewLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		require.True(unlimited.Allow(), "unlimited limiter should allow everything")
+	}
+}
+
+func TestLimiterConsume(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	l := NewLimiter(100, 100)
+	l.last = now
+
+	// Consuming more than the burst puts the bucket into debt.
+	l.ConsumeN(now, 250)
+	require.False(l.AllowN(now, 0), "bucket in debt should throttle events")
+
+	// The debt is repaid at the refill rate.
+	now = now.Add(time.Second)
+	require.False(l.AllowN(now, 0), "bucket still in debt should throttle events")
+	now = now.Add(500 * time.Millisecond)
+	require.True(l.AllowN(now, 0), "bucket out of debt should allow events")
+}
+
+func TestKeyedLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	k := NewKeyedLimiter(1, 1)
+	k.lastPrune = now
+
+	require.True(k.AllowN("a", now, 1), "first event for a should be allowed")
+	require.False(k.AllowN("a", now, 1), "second event for a should be throttled")
+	require.True(k.AllowN("b", now, 1), "keys should be limited independently")
+	require.Equal(2, k.Len())
+
+	// Idle keys are pruned once their buckets are full again.
+	now = now.Add(2 * pruneInterval)
+	require.True(k.AllowN("c", now, 1), "first event for c should be allowed")
+	require.Equal(1, k.Len(), "idle keys should be pruned")
+}
@@ -378,6 +378,18 @@ type Backend interface {
 	RegisterP2PService(p2pAPI.Service) error
 }
 
+// P2PController is an optional interface implemented by consensus backends that support adjusting
+// consensus peering at runtime.
+type P2PController interface {
+	// DialPeers dials the given consensus peers, lifting any blocks placed via DisconnectPeer.
+	DialPeers(addrs []node.ConsensusAddress) error
+
+	// DisconnectPeer gracefully disconnects the given consensus peer, if connected, and rejects
+	// any further connections with it (including redials of persistent peers) until the peer is
+	// dialed again via DialPeers.
+	DisconnectPeer(id signature.PublicKey) error
+}
+
 // HaltHook is a function that gets called when consensus needs to halt for some reason.
 type HaltHook func(ctx context.Context, blockHeight int64, epoch beacon.EpochTime, err error)
 
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+// MethodBatch is the method name for atomically executing a batch of calls.
+//
+// Batch transactions are only accepted when enabled by the consensus parameters.
+var MethodBatch = transaction.NewMethodName(ModuleName, "Batch", Batch{})
+
+// MaxBatchCalls is the maximum number of calls in a single batch.
+const MaxBatchCalls = 16
+
+// Batch is a list of calls that are executed atomically in a single transaction.
+//
+// Either all calls succeed or the state changes of all calls are reverted. Fees are paid and
+// nonces are incremented once for the whole batch, all calls share the gas limit of the enclosing
+// transaction.
+type Batch struct {
+	// Calls are the calls to execute, in order.
+	Calls []BatchCall `json:"calls"`
+}
+
+// BatchCall is a single call in a batch.
+type BatchCall struct {
+	// Method is the method that should be called.
+	Method transaction.MethodName `json:"method"`
+	// Body is the method call body.
+	Body cbor.RawMessage `json:"body,omitempty"`
+}
+
+// ValidateBasic performs basic batch structure validation.
+func (b *Batch) ValidateBasic() error {
+	switch n := len(b.Calls); {
+	case n == 0:
+		return fmt.Errorf("empty batch")
+	case n > MaxBatchCalls:
+		return fmt.Errorf("too many calls in batch (%d > %d)", n, MaxBatchCalls)
+	}
+
+	for i, call := range b.Calls {
+		if err := call.Method.SanityCheck(); err != nil {
+			return fmt.Errorf("call %d: %w", i, err)
+		}
+		if call.Method == MethodBatch {
+			return fmt.Errorf("call %d: nested batches are not allowed", i)
+		}
+		if _, isSystem := SystemMethods[call.Method]; isSystem || call.Method.IsCritical() {
+			return fmt.Errorf("call %d: method %s not allowed in batch", i, call.Method)
+		}
+	}
+	return nil
+}
+
+// NewBatchCall creates a new batch call.
+func NewBatchCall(method transaction.MethodName, body interface{}) BatchCall {
+	var rawBody []byte
+	if body != nil {
+		rawBody = cbor.Marshal(body)
+	}
+
+	return BatchCall{
+		Method: method,
+		Body:   cbor.RawMessage(rawBody),
+	}
+}
+
+// NewBatchTx creates a new batch transaction.
+func NewBatchTx(nonce uint64, fee *transaction.Fee, calls []BatchCall) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodBatch, &Batch{Calls: calls})
+}
+
+// BatchCallEvent is the event emitted before any events emitted by a call in a batch. It is used
+// to attribute events to individual calls.
+type BatchCallEvent struct {
+	// Index is the index of the call in the batch.
+	Index uint32 `json:"index"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *BatchCallEvent) EventKind() string {
+	return "batch_call"
+}
@@ -0,0 +1,20 @@
+// Package proof defines proofs of consensus layer state.
+package proof
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// StateProof is a proof that a part of the consensus layer state is committed
+// to by a specific consensus layer block.
+type StateProof struct {
+	// Height is the consensus layer height of the proven state.
+	Height int64 `json:"height"`
+
+	// Proof is the MKVS proof of the queried state against the state root.
+	Proof syncer.Proof `json:"proof"`
+
+	// LightBlock is the consensus backend specific light block for the state
+	// as returned by GetLightBlockForState. It commits to the state root.
+	LightBlock []byte `json:"light_block"`
+}
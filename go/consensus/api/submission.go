@@ -20,6 +20,14 @@ import (
 const (
 	maxSubmissionRetryElapsedTime = 60 * time.Second
 	maxSubmissionRetryInterval    = 10 * time.Second
+
+	// txReplaceInterval is the interval after which a pending transaction with an estimated fee
+	// is replaced by one paying a higher fee.
+	txReplaceInterval = 30 * time.Second
+	// maxTxReplacements is the maximum number of times a pending transaction is replaced.
+	maxTxReplacements = 3
+	// txReplaceFeeBumpPercent is the minimum fee increase (in percent) of a replacement.
+	txReplaceFeeBumpPercent = 10
 )
 
 // PriceDiscovery is the consensus fee price discovery interface.
@@ -46,6 +54,42 @@ func (pd *staticPriceDiscovery) GasPrice(ctx context.Context) (*quantity.Quantit
 	return pd.price.Clone(), nil
 }
 
+// BaseFeeQuerier is the interface for querying the dynamic consensus base fee.
+type BaseFeeQuerier interface {
+	// BaseFee returns the dynamic base fee that applied in the given block and the projected base
+	// fee for the following block.
+	BaseFee(ctx context.Context, height int64) (*staking.BaseFee, error)
+}
+
+type baseFeePriceDiscovery struct {
+	querier  BaseFeeQuerier
+	minPrice quantity.Quantity
+}
+
+// NewBaseFeePriceDiscovery creates a price discovery mechanism which uses the projected base fee
+// for the next block as the gas price, but never less than the given minimum price (e.g., to
+// satisfy the minimum gas price configured by validators or when the base fee is disabled).
+func NewBaseFeePriceDiscovery(querier BaseFeeQuerier, minPrice uint64) (PriceDiscovery, error) {
+	pd := &baseFeePriceDiscovery{
+		querier: querier,
+	}
+	if err := pd.minPrice.FromUint64(minPrice); err != nil {
+		return nil, fmt.Errorf("submission: failed to convert gas price: %w", err)
+	}
+	return pd, nil
+}
+
+func (pd *baseFeePriceDiscovery) GasPrice(ctx context.Context) (*quantity.Quantity, error) {
+	baseFee, err := pd.querier.BaseFee(ctx, HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("submission: failed to query base fee: %w", err)
+	}
+	if baseFee.Next.Cmp(&pd.minPrice) > 0 {
+		return baseFee.Next.Clone(), nil
+	}
+	return pd.minPrice.Clone(), nil
+}
+
 type noOpPriceDiscovery struct{}
 
 func (pd *noOpPriceDiscovery) GasPrice(ctx context.Context) (*quantity.Quantity, error) {
@@ -63,7 +107,10 @@ type SubmissionManager interface {
 	// SignAndSubmitTx populates the nonce and fee fields in the transaction, signs the transaction
 	// with the passed signer and submits it to consensus backend.
 	//
-	// It also automatically handles retries in case the nonce was incorrectly estimated.
+	// It also automatically handles retries in case the nonce was incorrectly estimated. In case
+	// the transaction specifies an expiry and expires before being included in a block,
+	// transaction.ErrExpired is returned. In case the fee is estimated and the transaction remains
+	// pending for a while, it is replaced by a transaction paying a higher fee.
 	SignAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error
 
 	// SignAndSubmitTxWithProof populates the nonce and fee fields in the transaction, signs
@@ -79,6 +126,8 @@ type submissionManager struct {
 	priceDiscovery PriceDiscovery
 	maxFee         quantity.Quantity
 
+	replaceInterval time.Duration
+
 	noncesLock sync.Mutex
 	nonces     map[staking.Address]uint64
 
@@ -164,7 +213,7 @@ func (m *submissionManager) clearSignerNonce(signerAddr staking.Address) {
 	delete(m.nonces, signerAddr)
 }
 
-func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction, withProof bool) (*transaction.SignedTransaction, *transaction.Proof, error) {
+func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction, withProof, estimateFee bool) (*transaction.SignedTransaction, *transaction.Proof, error) {
 	// Update transaction nonce.
 	var err error
 	signerAddr := staking.NewAddress(signer.Public())
@@ -193,18 +242,27 @@ func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signatur
 		return nil, nil, backoff.Permanent(err)
 	}
 
-	var proof *transaction.Proof
-	if withProof {
-		proof, err = m.backend.SubmitTxWithProof(ctx, sigTx)
-	} else {
-		err = m.backend.SubmitTx(ctx, sigTx)
-	}
+	sigTx, proof, err := m.submitTx(ctx, signer, tx, sigTx, withProof, estimateFee)
 	if err != nil {
 		switch {
 		case errors.Is(err, transaction.ErrUpgradePending):
 			// Pending upgrade, retry submission.
 			m.logger.Debug("retrying transaction submission due to pending upgrade")
 			return nil, nil, err
+		case errors.Is(err, transaction.ErrGasPriceTooLow) && estimateFee:
+			// Gas price too low (e.g., due to an increased base fee), retry submission with
+			// a freshly estimated fee.
+			tx.Fee = nil
+			m.clearSignerNonce(signerAddr)
+			m.logger.Debug("retrying transaction submission due to gas price too low",
+				"account_address", signerAddr,
+			)
+			return nil, nil, err
+		case errors.Is(err, transaction.ErrExpired):
+			// Transaction expired before it could be included in a block, so its nonce has not
+			// been used. Do not retry as the expiry was requested by the caller.
+			m.clearSignerNonce(signerAddr)
+			return nil, nil, backoff.Permanent(err)
 		case errors.Is(err, transaction.ErrInvalidNonce):
 			// Invalid nonce, retry submission.
 			m.clearSignerNonce(signerAddr)
@@ -221,6 +279,149 @@ func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signatur
 	return sigTx, proof, nil
 }
 
+type submitResult struct {
+	sigTx *transaction.SignedTransaction
+	proof *transaction.Proof
+	err   error
+}
+
+// submitTx submits the signed transaction and waits for it to be included in a block.
+//
+// In case replace is set and the transaction remains pending, it is periodically replaced by
+// a transaction with the same nonce that pays a higher fee. The first transaction to be included
+// wins.
+func (m *submissionManager) submitTx(
+	ctx context.Context,
+	signer signature.Signer,
+	tx *transaction.Transaction,
+	sigTx *transaction.SignedTransaction,
+	withProof bool,
+	replace bool,
+) (*transaction.SignedTransaction, *transaction.Proof, error) {
+	submitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resultCh := make(chan *submitResult, maxTxReplacements+1)
+	submit := func(sigTx *transaction.SignedTransaction) {
+		go func() {
+			var (
+				proof *transaction.Proof
+				err   error
+			)
+			if withProof {
+				proof, err = m.backend.SubmitTxWithProof(submitCtx, sigTx)
+			} else {
+				err = m.backend.SubmitTx(submitCtx, sigTx)
+			}
+			resultCh <- &submitResult{sigTx, proof, err}
+		}()
+	}
+	submit(sigTx)
+
+	var replaceCh <-chan time.Time
+	if replace && m.replaceInterval > 0 && tx.Fee != nil {
+		ticker := time.NewTicker(m.replaceInterval)
+		defer ticker.Stop()
+		replaceCh = ticker.C
+	}
+
+	var (
+		pending      = 1
+		replacements int
+		firstErr     error
+	)
+	for {
+		select {
+		case result := <-resultCh:
+			pending--
+			if result.err == nil {
+				return result.sigTx, result.proof, nil
+			}
+			// Keep the error of the earliest failed transaction that was not replaced.
+			if firstErr == nil || errors.Is(firstErr, transaction.ErrReplaced) {
+				firstErr = result.err
+			}
+			if pending == 0 {
+				return nil, nil, firstErr
+			}
+		case <-replaceCh:
+			fee, err := m.bumpFee(ctx, tx.Fee)
+			if err != nil {
+				m.logger.Debug("not replacing pending transaction",
+					"err", err,
+				)
+				replaceCh = nil
+				continue
+			}
+
+			replTx := *tx
+			replTx.Fee = fee
+			replSigTx, err := transaction.Sign(signer, &replTx)
+			if err != nil {
+				return nil, nil, backoff.Permanent(err)
+			}
+
+			m.logger.Debug("replacing pending transaction",
+				"nonce", tx.Nonce,
+				"fee", fee.Amount,
+			)
+
+			tx.Fee = fee
+			submit(replSigTx)
+			pending++
+
+			replacements++
+			if replacements >= maxTxReplacements {
+				replaceCh = nil
+			}
+		}
+	}
+}
+
+// bumpFee returns a fee that implies a strictly higher gas price than the given fee, taking the
+// current gas price into account.
+func (m *submissionManager) bumpFee(ctx context.Context, fee *transaction.Fee) (*transaction.Fee, error) {
+	var gasQuantity quantity.Quantity
+	if err := gasQuantity.FromUint64(uint64(fee.Gas)); err != nil {
+		return nil, err
+	}
+
+	// Increase the fee by a fixed percentage, but at least by one unit of the gas price.
+	amount := fee.Amount.Clone()
+	if err := amount.Mul(quantity.NewFromUint64(100 + txReplaceFeeBumpPercent)); err != nil {
+		return nil, err
+	}
+	if err := amount.Quo(quantity.NewFromUint64(100)); err != nil {
+		return nil, err
+	}
+	minAmount := fee.Amount.Clone()
+	if err := minAmount.Add(&gasQuantity); err != nil {
+		return nil, err
+	}
+	if amount.Cmp(minAmount) < 0 {
+		amount = minAmount
+	}
+
+	// Use the current gas price in case it increased even more.
+	if price, err := m.priceDiscovery.GasPrice(ctx); err == nil {
+		if err = price.Mul(&gasQuantity); err == nil && price.Cmp(amount) > 0 {
+			amount = price
+		}
+	}
+
+	if !m.maxFee.IsZero() && amount.Cmp(&m.maxFee) == 1 {
+		return nil, fmt.Errorf("bumped fee exceeds configured maximum: %s (max: %s)",
+			amount,
+			m.maxFee,
+		)
+	}
+
+	return &transaction.Fee{
+		Gas:    fee.Gas,
+		Amount: *amount,
+	}, nil
+}
+
 func (m *submissionManager) signAndSubmitTxWithRetry(ctx context.Context, signer signature.Signer, tx *transaction.Transaction, withProof bool) (*transaction.SignedTransaction, *transaction.Proof, error) {
 	sched := cmnBackoff.NewExponentialBackOff()
 	sched.MaxInterval = maxSubmissionRetryInterval
@@ -231,9 +432,12 @@ func (m *submissionManager) signAndSubmitTxWithRetry(ctx context.Context, signer
 		proof *transaction.Proof
 	)
 
+	// Only re-estimate fees that were not explicitly set by the caller.
+	estimateFee := tx.Fee == nil
+
 	f := func() error {
 		var err error
-		sigTx, proof, err = m.signAndSubmitTx(ctx, signer, tx, withProof)
+		sigTx, proof, err = m.signAndSubmitTx(ctx, signer, tx, withProof, estimateFee)
 		return err
 	}
 
@@ -258,10 +462,11 @@ func (m *submissionManager) SignAndSubmitTxWithProof(ctx context.Context, signer
 // NewSubmissionManager creates a new transaction submission manager.
 func NewSubmissionManager(backend ClientBackend, priceDiscovery PriceDiscovery, maxFee uint64) SubmissionManager {
 	sm := &submissionManager{
-		backend:        backend,
-		priceDiscovery: priceDiscovery,
-		nonces:         make(map[staking.Address]uint64),
-		logger:         logging.GetLogger("consensus/submission"),
+		backend:         backend,
+		priceDiscovery:  priceDiscovery,
+		replaceInterval: txReplaceInterval,
+		nonces:          make(map[staking.Address]uint64),
+		logger:          logging.GetLogger("consensus/submission"),
 	}
 	_ = sm.maxFee.FromUint64(maxFee)
 
@@ -28,6 +28,11 @@ type Error struct {
 type Result struct {
 	Error  Error    `json:"error"`
 	Events []*Event `json:"events"`
+
+	// Calls are the per-call results in case of a successful batch transaction. Events of the
+	// transaction that are not attributed to any call (e.g., fee payments) are only included in
+	// the transaction's events.
+	Calls []*Result `json:"calls,omitempty"`
 }
 
 // IsSuccess returns true if transaction execution was successful.
@@ -30,9 +30,27 @@ var (
 	// ErrMethodNotSupported is the error returned if transaction method is not supported.
 	ErrMethodNotSupported = errors.New(moduleName, 5, "transaction: method not supported")
 
+	// ErrInvalidFeePayer is the error returned when the fee payer of a transaction is invalid.
+	ErrInvalidFeePayer = errors.New(moduleName, 6, "transaction: invalid fee payer")
+
+	// ErrExpired is the error returned when a transaction has expired and can no longer be
+	// included in a block.
+	ErrExpired = errors.New(moduleName, 7, "transaction: expired")
+
+	// ErrReplaced is the error returned when a pending transaction has been replaced by another
+	// transaction with the same signer and nonce, but a higher gas price.
+	ErrReplaced = errors.New(moduleName, 8, "transaction: replaced by a transaction with a higher gas price")
+
+	// ErrReplacementUnderpriced is the error returned when a transaction with the same signer and
+	// nonce as a pending transaction does not have a strictly higher gas price.
+	ErrReplacementUnderpriced = errors.New(moduleName, 9, "transaction: replacement gas price too low")
+
 	// SignatureContext is the context used for signing transactions.
 	SignatureContext = signature.NewContext("oasis-core/consensus: tx", signature.WithChainSeparation())
 
+	// FeePayerSignatureContext is the context used by fee payers for co-signing transactions.
+	FeePayerSignatureContext = signature.NewContext("oasis-core/consensus: tx fee payer", signature.WithChainSeparation())
+
 	registeredMethods sync.Map
 
 	_ prettyprint.PrettyPrinter = (*Transaction)(nil)
@@ -46,6 +64,16 @@ type Transaction struct {
 	// Fee is an optional fee that the sender commits to pay to execute this
 	// transaction.
 	Fee *Fee `json:"fee,omitempty"`
+	// FeePayer is an optional account that pays the transaction fee instead of the signer. In
+	// this case the transaction must also be signed by the fee payer.
+	//
+	// Transactions with a fee payer are only accepted when enabled by the consensus parameters.
+	FeePayer *FeePayer `json:"fee_payer,omitempty"`
+	// ValidUntil is an optional expiry after which the transaction can no longer be included in
+	// a block.
+	//
+	// Transactions with an expiry are only accepted when enabled by the consensus parameters.
+	ValidUntil *ValidUntil `json:"valid_until,omitempty"`
 
 	// Method is the method that should be called.
 	Method MethodName `json:"method"`
@@ -53,6 +81,76 @@ type Transaction struct {
 	Body cbor.RawMessage `json:"body,omitempty"`
 }
 
+// FeePayer is the account paying the fee of a transaction on behalf of its signer.
+type FeePayer struct {
+	// PublicKey is the public key of the fee payer.
+	PublicKey signature.PublicKey `json:"public_key"`
+	// Nonce is the fee payer's account nonce to prevent replay.
+	Nonce uint64 `json:"nonce"`
+	// UseAllowance specifies that the fee should be charged against the allowance that the fee
+	// payer has granted to the signer. The transaction is rejected in case the allowance does not
+	// cover the fee.
+	UseAllowance bool `json:"use_allowance,omitempty"`
+}
+
+// ValidUntil is the transaction expiry.
+//
+// In case both the height and the epoch are specified, the transaction expires as soon as any
+// of them is exceeded.
+type ValidUntil struct {
+	// Height is the last block height at which the transaction can be included.
+	Height int64 `json:"height,omitempty"`
+	// Epoch is the last epoch in which the transaction can be included.
+	Epoch uint64 `json:"epoch,omitempty"`
+}
+
+// SanityCheck performs a basic sanity check on the transaction expiry.
+func (vu *ValidUntil) SanityCheck() error {
+	if vu.Height < 0 {
+		return fmt.Errorf("transaction: invalid expiry height")
+	}
+	if vu.Height == 0 && vu.Epoch == 0 {
+		return fmt.Errorf("transaction: empty expiry")
+	}
+	return nil
+}
+
+// IsExpired returns true iff the transaction can no longer be included in a block at the given
+// height and epoch.
+func (vu *ValidUntil) IsExpired(height int64, epoch uint64) bool {
+	if vu.Height > 0 && height > vu.Height {
+		return true
+	}
+	if vu.Epoch > 0 && epoch > vu.Epoch {
+		return true
+	}
+	return false
+}
+
+// String returns a string representation of the transaction expiry.
+func (vu ValidUntil) String() string {
+	switch {
+	case vu.Height > 0 && vu.Epoch > 0:
+		return fmt.Sprintf("height %d, epoch %d", vu.Height, vu.Epoch)
+	case vu.Epoch > 0:
+		return fmt.Sprintf("epoch %d", vu.Epoch)
+	default:
+		return fmt.Sprintf("height %d", vu.Height)
+	}
+}
+
+// PrettyPrint writes a pretty-printed representation of the fee payer to the given writer.
+func (fp FeePayer) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sPublic key:    %s\n", prefix, fp.PublicKey)
+	fmt.Fprintf(w, "%sNonce:         %d\n", prefix, fp.Nonce)
+	fmt.Fprintf(w, "%sUse allowance: %t\n", prefix, fp.UseAllowance)
+}
+
+// PrettyType returns a representation of the type that can be used for pretty printing.
+func (fp FeePayer) PrettyType() (interface{}, error) {
+	return fp, nil
+}
+
 // PrettyPrintBody writes a pretty-printed representation of transaction's body
 // to the given writer.
 func (t Transaction) PrettyPrintBody(ctx context.Context, prefix string, w io.Writer) {
@@ -98,6 +196,13 @@ func (t Transaction) PrettyPrint(ctx context.Context, prefix string, w io.Writer
 	} else {
 		fmt.Fprintf(w, "%sFee:   none\n", prefix)
 	}
+	if t.FeePayer != nil {
+		fmt.Fprintf(w, "%sFee payer:\n", prefix)
+		t.FeePayer.PrettyPrint(ctx, prefix+"  ", w)
+	}
+	if t.ValidUntil != nil {
+		fmt.Fprintf(w, "%sValid until: %s\n", prefix, t.ValidUntil)
+	}
 	if genesisHash, ok := ctx.Value(prettyprint.ContextKeyGenesisHash).(hash.Hash); ok {
 		fmt.Println("Other info:")
 		fmt.Printf("  Genesis document's hash: %s\n", genesisHash)
@@ -126,15 +231,25 @@ func (t *Transaction) PrettyType() (interface{}, error) {
 	}
 
 	return &PrettyTransaction{
-		Nonce:  t.Nonce,
-		Fee:    t.Fee,
-		Method: t.Method,
-		Body:   body,
+		Nonce:      t.Nonce,
+		Fee:        t.Fee,
+		FeePayer:   t.FeePayer,
+		ValidUntil: t.ValidUntil,
+		Method:     t.Method,
+		Body:       body,
 	}, nil
 }
 
 // SanityCheck performs a basic sanity check on the transaction.
 func (t *Transaction) SanityCheck() error {
+	if t.FeePayer != nil && !t.FeePayer.PublicKey.IsValid() {
+		return ErrInvalidFeePayer
+	}
+	if t.ValidUntil != nil {
+		if err := t.ValidUntil.SanityCheck(); err != nil {
+			return err
+		}
+	}
 	return t.Method.SanityCheck()
 }
 
@@ -158,15 +273,21 @@ func NewTransaction(nonce uint64, fee *Fee, method MethodName, body interface{})
 //
 // It should only be used for pretty printing.
 type PrettyTransaction struct {
-	Nonce  uint64      `json:"nonce"`
-	Fee    *Fee        `json:"fee,omitempty"`
-	Method MethodName  `json:"method"`
-	Body   interface{} `json:"body,omitempty"`
+	Nonce      uint64      `json:"nonce"`
+	Fee        *Fee        `json:"fee,omitempty"`
+	FeePayer   *FeePayer   `json:"fee_payer,omitempty"`
+	ValidUntil *ValidUntil `json:"valid_until,omitempty"`
+	Method     MethodName  `json:"method"`
+	Body       interface{} `json:"body,omitempty"`
 }
 
 // SignedTransaction is a signed consensus transaction.
 type SignedTransaction struct {
 	signature.Signed
+
+	// FeePayerSignature is the fee payer's signature over the transaction blob. It must be present
+	// iff the transaction specifies a fee payer.
+	FeePayerSignature *signature.Signature `json:"fee_payer_signature,omitempty"`
 }
 
 // Hash returns the cryptographic hash of the encoded transaction.
@@ -186,6 +307,13 @@ func (s SignedTransaction) PrettyPrint(ctx context.Context, prefix string, w io.
 	if !s.Signature.Verify(SignatureContext, s.Blob) {
 		fmt.Fprintf(w, "%s        [INVALID SIGNATURE]\n", prefix)
 	}
+	if s.FeePayerSignature != nil {
+		fmt.Fprintf(w, "%sFee payer: %s\n", prefix, s.FeePayerSignature.PublicKey)
+		fmt.Fprintf(w, "%s           (signature: %s)\n", prefix, s.FeePayerSignature.Signature)
+		if !s.FeePayerSignature.Verify(FeePayerSignatureContext, s.Blob) {
+			fmt.Fprintf(w, "%s           [INVALID SIGNATURE]\n", prefix)
+		}
+	}
 
 	// Display the blob even if signature verification failed as it may
 	// be useful to look into it regardless.
@@ -209,12 +337,56 @@ func (s SignedTransaction) PrettyType() (interface{}, error) {
 	return signature.NewPrettySigned(s.Signed, tx)
 }
 
-// Open first verifies the blob signature and then unmarshals the blob.
+// Open first verifies the blob signature and then unmarshals the blob. In case the transaction
+// specifies a fee payer, the fee payer signature is verified as well.
 func (s *SignedTransaction) Open(tx *Transaction) error { // nolint: interfacer
-	return s.Signed.Open(SignatureContext, tx)
+	if err := s.Signed.Open(SignatureContext, tx); err != nil {
+		return err
+	}
+	return s.verifyFeePayer(tx)
+}
+
+// verifyFeePayer verifies the fee payer signature against the fee payer specified in the
+// already opened transaction.
+func (s *SignedTransaction) verifyFeePayer(tx *Transaction) error {
+	switch {
+	case tx.FeePayer == nil && s.FeePayerSignature == nil:
+		return nil
+	case tx.FeePayer == nil || s.FeePayerSignature == nil:
+		return ErrInvalidFeePayer
+	case !s.FeePayerSignature.PublicKey.Equal(tx.FeePayer.PublicKey):
+		return ErrInvalidFeePayer
+	case !s.FeePayerSignature.Verify(FeePayerSignatureContext, s.Blob):
+		return signature.ErrVerifyFailed
+	default:
+		return nil
+	}
+}
+
+// SignFeePayer co-signs the transaction as its fee payer.
+//
+// The transaction must specify the given signer as its fee payer.
+func (s *SignedTransaction) SignFeePayer(signer signature.Signer) error {
+	var tx Transaction
+	if err := cbor.Unmarshal(s.Blob, &tx); err != nil {
+		return fmt.Errorf("transaction: malformed signed blob: %w", err)
+	}
+	if tx.FeePayer == nil || !tx.FeePayer.PublicKey.Equal(signer.Public()) {
+		return ErrInvalidFeePayer
+	}
+
+	sig, err := signature.Sign(signer, FeePayerSignatureContext, s.Blob)
+	if err != nil {
+		return err
+	}
+	s.FeePayerSignature = sig
+	return nil
 }
 
 // Sign signs a transaction.
+//
+// In case the transaction specifies a fee payer, the returned transaction must also be co-signed
+// by the fee payer (see SignFeePayer).
 func Sign(signer signature.Signer, tx *Transaction) (*SignedTransaction, error) {
 	signed, err := signature.SignSigned(signer, SignatureContext, tx)
 	if err != nil {
@@ -265,6 +437,10 @@ func OpenRawTransactions(rawTxBytes [][]byte) ([]signature.PublicKey, []*Transac
 			errs[i] = err
 			continue
 		}
+		if err := signedTxes[i].verifyFeePayer(&tx); err != nil {
+			errs[i] = err
+			continue
+		}
 		txes[i] = &tx
 	}
 
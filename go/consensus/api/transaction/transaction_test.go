@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 )
 
 type testMethodBodyNormal struct{}
@@ -24,3 +28,77 @@ func TestMethodMetadata(t *testing.T) {
 	require.False(methodNormal.IsCritical())
 	require.True(methodCritical.IsCritical())
 }
+
+func TestFeePayer(t *testing.T) {
+	require := require.New(t)
+
+	signature.SetChainContext("test: oasis-core tests")
+
+	signer := memorySigner.NewTestSigner("consensus/transaction: tx signer")
+	payer := memorySigner.NewTestSigner("consensus/transaction: tx fee payer")
+	other := memorySigner.NewTestSigner("consensus/transaction: other signer")
+
+	methodNormal := NewMethodName("test", "FeePayer", testMethodBodyNormal{})
+
+	// Transaction without a fee payer must not carry a fee payer signature.
+	tx := NewTransaction(0, nil, methodNormal, nil)
+	sigTx, err := Sign(signer, tx)
+	require.NoError(err, "Sign")
+	require.ErrorIs(sigTx.SignFeePayer(payer), ErrInvalidFeePayer, "SignFeePayer should fail without fee payer")
+	var opened Transaction
+	require.NoError(sigTx.Open(&opened), "Open")
+
+	sigTx.FeePayerSignature = &signature.Signature{PublicKey: payer.Public()}
+	require.ErrorIs(sigTx.Open(&opened), ErrInvalidFeePayer, "Open should fail with unexpected fee payer signature")
+
+	// Transaction with a fee payer must be co-signed by the fee payer.
+	tx = NewTransaction(0, nil, methodNormal, nil)
+	tx.FeePayer = &FeePayer{
+		PublicKey: payer.Public(),
+		Nonce:     1,
+	}
+	sigTx, err = Sign(signer, tx)
+	require.NoError(err, "Sign")
+	require.ErrorIs(sigTx.Open(&opened), ErrInvalidFeePayer, "Open should fail without fee payer signature")
+
+	require.ErrorIs(sigTx.SignFeePayer(other), ErrInvalidFeePayer, "SignFeePayer should fail for other signer")
+	require.NoError(sigTx.SignFeePayer(payer), "SignFeePayer")
+	require.NoError(sigTx.Open(&opened), "Open")
+	require.EqualValues(tx.FeePayer, opened.FeePayer, "fee payer should be preserved")
+
+	// Fee payer signature must be made using the fee payer signature context.
+	sigTx.FeePayerSignature, err = signature.Sign(payer, SignatureContext, sigTx.Blob)
+	require.NoError(err, "Sign")
+	require.ErrorIs(sigTx.Open(&opened), signature.ErrVerifyFailed, "Open should fail with invalid fee payer signature")
+
+	// Raw transactions should be verified in the same way.
+	require.NoError(sigTx.SignFeePayer(payer), "SignFeePayer")
+	valid := *sigTx
+	missing := *sigTx
+	missing.FeePayerSignature = nil
+	_, txs, errs := OpenRawTransactions([][]byte{cbor.Marshal(valid), cbor.Marshal(missing)})
+	require.NoError(errs[0], "OpenRawTransactions should succeed with fee payer signature")
+	require.NotNil(txs[0])
+	require.ErrorIs(errs[1], ErrInvalidFeePayer, "OpenRawTransactions should fail without fee payer signature")
+	require.Nil(txs[1])
+}
+
+func TestValidUntil(t *testing.T) {
+	require := require.New(t)
+
+	methodNormal := NewMethodName("test", "ValidUntil", testMethodBodyNormal{})
+
+	tx := NewTransaction(0, nil, methodNormal, nil)
+	tx.ValidUntil = &ValidUntil{}
+	require.Error(tx.SanityCheck(), "empty expiry should be rejected")
+	tx.ValidUntil = &ValidUntil{Height: -1}
+	require.Error(tx.SanityCheck(), "negative expiry height should be rejected")
+	tx.ValidUntil = &ValidUntil{Height: 10, Epoch: 2}
+	require.NoError(tx.SanityCheck(), "valid expiry should be accepted")
+
+	require.False(tx.ValidUntil.IsExpired(10, 2))
+	require.True(tx.ValidUntil.IsExpired(11, 2), "transaction should expire after the height")
+	require.True(tx.ValidUntil.IsExpired(10, 3), "transaction should expire after the epoch")
+	require.False((&ValidUntil{Epoch: 2}).IsExpired(1_000, 2), "unset height should be ignored")
+	require.False((&ValidUntil{Height: 10}).IsExpired(10, 1_000), "unset epoch should be ignored")
+}
@@ -0,0 +1,136 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var (
+	methodTestBatchSet  = transaction.NewMethodName("testbatch", "Set", testBatchSet{})
+	methodTestBatchFail = transaction.NewMethodName("testbatch", "Fail", nil)
+)
+
+type testBatchSet struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type testBatchApp struct {
+	api.Application
+}
+
+func (app *testBatchApp) Name() string {
+	return "testbatch"
+}
+
+func (app *testBatchApp) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
+	switch tx.Method {
+	case methodTestBatchSet:
+		var set testBatchSet
+		if err := cbor.Unmarshal(tx.Body, &set); err != nil {
+			return err
+		}
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+			Amount: *quantity.NewFromUint64(uint64(len(set.Value))),
+		}))
+		return ctx.State().Insert(ctx, []byte(set.Key), []byte(set.Value))
+	case methodTestBatchFail:
+		return errTest
+	default:
+		return transaction.ErrMethodNotSupported
+	}
+}
+
+func TestBatch(t *testing.T) {
+	require := require.New(t)
+
+	doc := &genesis.Document{}
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{
+		Genesis: doc,
+	})
+	ctx := appState.NewContext(api.ContextDeliverTx)
+	defer ctx.Close()
+
+	app := &testBatchApp{}
+	mux := &abciMux{
+		appsByMethod: map[transaction.MethodName]api.Application{
+			methodTestBatchSet:  app,
+			methodTestBatchFail: app,
+		},
+	}
+
+	// Batches should be rejected while disabled.
+	_, err := mux.decodeBatch(ctx, consensus.NewBatchTx(0, nil, []consensus.BatchCall{
+		consensus.NewBatchCall(methodTestBatchSet, &testBatchSet{Key: "a", Value: "1"}),
+	}))
+	require.ErrorIs(err, transaction.ErrMethodNotSupported, "decodeBatch should fail while batches are disabled")
+
+	doc.Consensus.Parameters.EnableBatchTransactions = true
+
+	// Invalid batches should be rejected.
+	for _, calls := range [][]consensus.BatchCall{
+		nil,
+		{consensus.NewBatchCall(consensus.MethodBatch, &consensus.Batch{})},
+		{consensus.NewBatchCall(consensus.MethodMeta, &consensus.BlockMetadata{})},
+		{consensus.NewBatchCall(transaction.NewMethodName("testbatch", "Unknown", nil), nil)},
+		make([]consensus.BatchCall, consensus.MaxBatchCalls+1),
+	} {
+		_, err = mux.decodeBatch(ctx, consensus.NewBatchTx(0, nil, calls))
+		require.Error(err, "decodeBatch should fail for invalid batch")
+	}
+
+	// Successful batch should apply all calls.
+	tx := consensus.NewBatchTx(0, nil, []consensus.BatchCall{
+		consensus.NewBatchCall(methodTestBatchSet, &testBatchSet{Key: "a", Value: "1"}),
+		consensus.NewBatchCall(methodTestBatchSet, &testBatchSet{Key: "b", Value: "22"}),
+	})
+	batch, err := mux.decodeBatch(ctx, tx)
+	require.NoError(err, "decodeBatch")
+	err = mux.executeBatch(ctx, tx, batch)
+	require.NoError(err, "executeBatch")
+
+	value, err := ctx.State().Get(ctx, []byte("a"))
+	require.NoError(err, "Get")
+	require.EqualValues("1", value)
+	value, err = ctx.State().Get(ctx, []byte("b"))
+	require.NoError(err, "Get")
+	require.EqualValues("22", value)
+
+	evs := ctx.GetEvents()
+	require.Len(evs, 4, "each call should emit a marker event followed by its events")
+	for i, idx := range []int{0, 2} {
+		require.Equal(api.EventTypeForApp(consensus.ModuleName), evs[idx].Type)
+		require.Equal(api.EventTypeForApp(app.Name()), evs[idx+1].Type)
+		var ev consensus.BatchCallEvent
+		require.NoError(events.DecodeValue(evs[idx].Attributes[0].Value, &ev))
+		require.EqualValues(i, ev.Index)
+	}
+
+	// Failed batch should not apply any calls.
+	ctx = appState.NewContext(api.ContextDeliverTx)
+	defer ctx.Close()
+
+	tx = consensus.NewBatchTx(0, nil, []consensus.BatchCall{
+		consensus.NewBatchCall(methodTestBatchSet, &testBatchSet{Key: "c", Value: "3"}),
+		consensus.NewBatchCall(methodTestBatchFail, nil),
+	})
+	batch, err = mux.decodeBatch(ctx, tx)
+	require.NoError(err, "decodeBatch")
+	err = mux.executeBatch(ctx, tx, batch)
+	require.ErrorIs(err, errTest, "executeBatch should fail with the call error")
+
+	value, err = ctx.State().Get(ctx, []byte("c"))
+	require.NoError(err, "Get")
+	require.Nil(value, "state changes of a failed batch should be reverted")
+	require.Empty(ctx.GetEvents(), "events of a failed batch should be reverted")
+}
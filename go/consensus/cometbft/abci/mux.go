@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"runtime/debug"
 	"sort"
 	"sync"
@@ -225,6 +226,9 @@ type abciMux struct {
 	// waiting for that transaction to become invalid.
 	invalidatedTxs sync.Map
 
+	// pendingTxs tracks pending transactions that may be replaced.
+	pendingTxs *pendingTxs
+
 	md messageDispatcher
 }
 
@@ -385,10 +389,14 @@ func (mux *abciMux) PrepareProposal(req types.RequestPrepareProposal) types.Resp
 	// Make sure there will be enough space for any metadata transactions.
 	maxTxBytes := req.MaxTxBytes - consensus.BlockMetadataMaxSize
 
-	// Schedule an initial set of transactions.
+	// Schedule an initial set of transactions, skipping any replaced transactions that have not
+	// yet been evicted from the mempool.
 	txs := make([][]byte, 0, len(req.Txs))
 	var totalBytes int64
 	for _, tx := range req.Txs {
+		if mux.pendingTxs.isReplaced(hash.NewFromBytes(tx)) {
+			continue
+		}
 		totalBytes += int64(len(tx))
 		if totalBytes > maxTxBytes {
 			break
@@ -584,7 +592,8 @@ func (mux *abciMux) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginB
 	if params.MaxBlockGas > 0 {
 		blockCtx.GasAccountant = api.NewGasAccountant(params.MaxBlockGas)
 	} else {
-		blockCtx.GasAccountant = api.NewNopGasAccountant()
+		// Still keep track of the gas used in the block as it drives the dynamic base fee.
+		blockCtx.GasAccountant = api.NewGasAccountant(transaction.Gas(math.MaxUint64))
 	}
 	mux.state.blockCtx = blockCtx
 
@@ -662,7 +671,7 @@ func (mux *abciMux) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 	ctx := mux.state.NewContext(api.ContextCheckTx)
 	defer ctx.Close()
 
-	if err := mux.executeTx(ctx, req.Tx); err != nil {
+	if err := mux.executeTx(ctx, req.Tx, req.Type == types.CheckTxType_Recheck); err != nil {
 		module, code := errors.Code(err)
 
 		if req.Type == types.CheckTxType_Recheck {
@@ -709,7 +718,7 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 	ctx := mux.state.NewContext(api.ContextDeliverTx)
 	defer ctx.Close()
 
-	if err := mux.executeTx(ctx, req.Tx); err != nil {
+	if err := mux.executeTx(ctx, req.Tx, false); err != nil {
 		if api.IsUnavailableStateError(err) {
 			// Make sure to not commit any transactions which include results based on unavailable
 			// and/or corrupted state -- doing so can further corrupt state.
@@ -828,6 +837,9 @@ func (mux *abciMux) Commit() types.ResponseCommit {
 		"last_retained_version", lastRetainedVersion,
 	)
 
+	// Pending transactions will be re-checked against the new state.
+	mux.pendingTxs.commit(mux.state.BlockHeight())
+
 	// Check if there is an upgrade pending for the next consensus block. This is needed because
 	// validators will halt before proposing a block so there will be no "next block" until all of
 	// the validators upgrade, but we also want non-validator nodes to halt for upgrade.
@@ -960,6 +972,7 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 		state:        state,
 		appsByName:   make(map[string]api.Application),
 		appsByMethod: make(map[transaction.MethodName]api.Application),
+		pendingTxs:   newPendingTxs(),
 	}
 
 	mux.logger.Debug("ABCI multiplexer initialized",
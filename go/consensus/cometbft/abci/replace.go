@@ -0,0 +1,165 @@
+package abci
+
+import (
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+// pendingTxKey identifies a pending transaction by its signer and nonce.
+type pendingTxKey struct {
+	signer signature.PublicKey
+	nonce  uint64
+}
+
+// pendingTx is a transaction that passed CheckTx and is pending in the mempool.
+type pendingTx struct {
+	hash hash.Hash
+	tx   *transaction.Transaction
+}
+
+// pendingTxs tracks pending transactions in order to support replacing them with transactions
+// that pay a higher gas price (replace-by-fee).
+//
+// Replacement is a mempool-level best effort and is not enforced by consensus. The tracked
+// transactions are local to the node and replaced transactions are only remembered until they
+// have been re-checked after the next commit, so a replaced transaction can still be included in
+// a block in case it is proposed by a node that has not seen the replacement or re-submitted
+// after being pruned. In either case only one of the transactions can be executed as they share
+// the same nonce.
+type pendingTxs struct {
+	sync.Mutex
+
+	// byKey are the transactions that passed CheckTx since the last commit.
+	byKey map[pendingTxKey]*pendingTx
+	// replaced maps hashes of replaced transactions to the height at which they were replaced.
+	// Replaced transactions are rejected when re-checked which evicts them from the mempool.
+	replaced map[hash.Hash]int64
+}
+
+func newPendingTxs() *pendingTxs {
+	return &pendingTxs{
+		byKey:    make(map[pendingTxKey]*pendingTx),
+		replaced: make(map[hash.Hash]int64),
+	}
+}
+
+func (p *pendingTxs) get(signer signature.PublicKey, nonce uint64) *pendingTx {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.byKey[pendingTxKey{signer, nonce}]
+}
+
+func (p *pendingTxs) add(signer signature.PublicKey, txHash hash.Hash, tx *transaction.Transaction) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.byKey[pendingTxKey{signer, tx.Nonce}] = &pendingTx{txHash, tx}
+}
+
+func (p *pendingTxs) replace(signer signature.PublicKey, old *pendingTx, txHash hash.Hash, tx *transaction.Transaction, height int64) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.replaced[old.hash] = height
+	p.byKey[pendingTxKey{signer, tx.Nonce}] = &pendingTx{txHash, tx}
+}
+
+func (p *pendingTxs) isReplaced(txHash hash.Hash) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	_, replaced := p.replaced[txHash]
+	return replaced
+}
+
+// commit resets the pending transactions after a block has been committed at the given height
+// as the mempool re-checks all remaining transactions against the new state.
+func (p *pendingTxs) commit(height int64) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.byKey = make(map[pendingTxKey]*pendingTx)
+
+	// Forget replaced transactions once they have been through at least one re-check, which
+	// evicts them from the local mempool. Any later re-submissions are processed as usual.
+	for txHash, replacedAt := range p.replaced {
+		if replacedAt < height-1 {
+			delete(p.replaced, txHash)
+		}
+	}
+}
+
+// isHigherGasPrice returns true iff the gas price of the first fee is strictly higher than the
+// gas price of the second fee.
+func isHigherGasPrice(fee, other *transaction.Fee) bool {
+	if fee == nil {
+		return false
+	}
+	if other == nil {
+		other = &transaction.Fee{}
+	}
+	return fee.GasPrice().Cmp(other.GasPrice()) > 0
+}
+
+// processCheckTx processes a transaction in CheckTx, replacing any pending transaction with the
+// same signer and nonce in case the new transaction pays a strictly higher gas price.
+func (mux *abciMux) processCheckTx(ctx *api.Context, tx *transaction.Transaction, rawTx []byte, recheck bool) error {
+	txHash := hash.NewFromBytes(rawTx)
+	signer := ctx.TxSigner()
+
+	if mux.pendingTxs.isReplaced(txHash) {
+		return transaction.ErrReplaced
+	}
+
+	var old *pendingTx
+	if !recheck {
+		old = mux.pendingTxs.get(signer, tx.Nonce)
+	}
+	txAuthHandler := mux.state.txAuthHandler
+	if old == nil || old.hash.Equal(&txHash) || txAuthHandler == nil {
+		if err := mux.processTx(ctx, tx, len(rawTx)); err != nil {
+			return err
+		}
+		mux.pendingTxs.add(signer, txHash, tx)
+		return nil
+	}
+
+	if !isHigherGasPrice(tx.Fee, old.tx.Fee) {
+		return transaction.ErrReplacementUnderpriced
+	}
+
+	// Revert the pending transaction and process its replacement in a separate transaction
+	// context so that nothing changes in case the replacement fails.
+	txCtx := ctx.NewTransaction()
+	defer txCtx.Close()
+
+	err := func() error {
+		if err := txAuthHandler.RevertPostExecuteTx(txCtx, old.tx); err != nil {
+			return err
+		}
+		return mux.processTx(txCtx, tx, len(rawTx))
+	}()
+	ctx.SetGasAccountant(txCtx.Gas())
+	ctx.SetPriority(txCtx.GetPriority())
+	if err != nil {
+		return err
+	}
+	txCtx.Commit()
+
+	ctx.Logger().Debug("replaced pending transaction",
+		"tx_signer", signer,
+		"nonce", tx.Nonce,
+		"replaced_tx_hash", old.hash,
+		"tx_hash", txHash,
+	)
+
+	mux.pendingTxs.replace(signer, old, txHash, tx, ctx.BlockHeight())
+	mux.notifyInvalidatedCheckTx(old.hash, transaction.ErrReplaced)
+
+	return nil
+}
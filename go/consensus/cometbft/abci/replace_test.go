@@ -0,0 +1,94 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+func TestPendingTxs(t *testing.T) {
+	require := require.New(t)
+
+	signer := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	tx := &transaction.Transaction{Nonce: 1}
+	txHash := hash.NewFromBytes([]byte("tx"))
+	replTx := &transaction.Transaction{Nonce: 1}
+	replTxHash := hash.NewFromBytes([]byte("replacement tx"))
+
+	p := newPendingTxs()
+	require.Nil(p.get(signer, 1), "there should be no pending transaction")
+
+	p.add(signer, txHash, tx)
+	pending := p.get(signer, 1)
+	require.NotNil(pending, "transaction should be pending")
+	require.EqualValues(txHash, pending.hash)
+	require.Nil(p.get(signer, 2), "there should be no pending transaction for other nonces")
+
+	p.replace(signer, pending, replTxHash, replTx, 10)
+	require.EqualValues(replTxHash, p.get(signer, 1).hash, "replacement should be pending")
+	require.True(p.isReplaced(txHash), "transaction should be replaced")
+	require.False(p.isReplaced(replTxHash), "replacement should not be replaced")
+
+	// Pending transactions are reset on commit, replaced transactions are kept until re-checked.
+	p.commit(11)
+	require.Nil(p.get(signer, 1), "pending transactions should be reset on commit")
+	require.True(p.isReplaced(txHash), "transaction should remain replaced")
+	p.commit(12)
+	require.False(p.isReplaced(txHash), "replaced transactions should be pruned")
+}
+
+func TestIsHigherGasPrice(t *testing.T) {
+	require := require.New(t)
+
+	fee := func(gas transaction.Gas, amount uint64) *transaction.Fee {
+		return &transaction.Fee{Gas: gas, Amount: *quantity.NewFromUint64(amount)}
+	}
+
+	require.True(isHigherGasPrice(fee(10, 20), fee(10, 10)))
+	require.True(isHigherGasPrice(fee(10, 20), nil))
+	require.False(isHigherGasPrice(fee(10, 10), fee(10, 10)), "equal gas price should not be higher")
+	require.False(isHigherGasPrice(fee(10, 19), fee(10, 10)), "gas price is rounded down")
+	require.False(isHigherGasPrice(fee(20, 20), fee(10, 10)), "higher fee with the same gas price should not be higher")
+	require.False(isHigherGasPrice(nil, fee(10, 10)))
+}
+
+func TestPendingTxsPruneWindow(t *testing.T) {
+	require := require.New(t)
+
+	signer := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	txHash1 := hash.NewFromBytes([]byte("tx 1"))
+	txHash2 := hash.NewFromBytes([]byte("tx 2"))
+	replTxHash1 := hash.NewFromBytes([]byte("replacement tx 1"))
+	replTxHash2 := hash.NewFromBytes([]byte("replacement tx 2"))
+
+	p := newPendingTxs()
+
+	// Replace a transaction at height 10.
+	p.add(signer, txHash1, &transaction.Transaction{Nonce: 1})
+	p.replace(signer, p.get(signer, 1), replTxHash1, &transaction.Transaction{Nonce: 1}, 10)
+
+	// Replace another transaction at height 11.
+	p.commit(11)
+	p.add(signer, txHash2, &transaction.Transaction{Nonce: 2})
+	p.replace(signer, p.get(signer, 2), replTxHash2, &transaction.Transaction{Nonce: 2}, 11)
+	require.True(p.isReplaced(txHash1), "transaction replaced at height 10 should be kept after commit at height 11")
+	require.True(p.isReplaced(txHash2), "transaction replaced at height 11 should be replaced")
+
+	// Each replaced transaction is kept for exactly two commits.
+	p.commit(12)
+	require.False(p.isReplaced(txHash1), "transaction replaced at height 10 should be pruned after commit at height 12")
+	require.True(p.isReplaced(txHash2), "transaction replaced at height 11 should be kept after commit at height 12")
+
+	p.commit(13)
+	require.False(p.isReplaced(txHash2), "transaction replaced at height 11 should be pruned after commit at height 13")
+
+	// Once pruned, a replaced transaction is no longer known to be replaced and can be re-added.
+	p.add(signer, txHash1, &transaction.Transaction{Nonce: 1})
+	require.EqualValues(txHash1, p.get(signer, 1).hash, "pruned replaced transaction should be accepted again")
+	require.Empty(p.replaced, "all replaced transactions should be pruned")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -50,10 +51,25 @@ func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Trans
 		)
 		return nil, nil, err
 	}
+	if err := checkTxFeePayer(params, &tx, sigTx.FeePayerSignature); err != nil {
+		return nil, nil, err
+	}
 
 	return &tx, &sigTx, nil
 }
 
+// checkTxFeePayer makes sure that the transaction only specifies a fee payer in case fee payers
+// are enabled by the consensus parameters.
+func checkTxFeePayer(params *consensusGenesis.Parameters, tx *transaction.Transaction, feePayerSig *signature.Signature) error {
+	if params.EnableFeePayer {
+		return nil
+	}
+	if tx.FeePayer != nil || feePayerSig != nil {
+		return fmt.Errorf("%w: fee payers are disabled", transaction.ErrInvalidFeePayer)
+	}
+	return nil
+}
+
 func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txSize int) error {
 	// Handle special methods.
 	if _, isSystem := consensus.SystemMethods[tx.Method]; isSystem {
@@ -61,13 +77,25 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 	}
 
 	// Lookup method handler.
-	app := mux.appsByMethod[tx.Method]
-	if app == nil {
-		ctx.Logger().Debug("unknown method",
-			"tx", tx,
-			"method", tx.Method,
-		)
-		return fmt.Errorf("mux: unknown method: %s", tx.Method)
+	var (
+		app   api.Application
+		batch *consensus.Batch
+	)
+	switch tx.Method {
+	case consensus.MethodBatch:
+		var err error
+		if batch, err = mux.decodeBatch(ctx, tx); err != nil {
+			return err
+		}
+	default:
+		app = mux.appsByMethod[tx.Method]
+		if app == nil {
+			ctx.Logger().Debug("unknown method",
+				"tx", tx,
+				"method", tx.Method,
+			)
+			return fmt.Errorf("mux: unknown method: %s", tx.Method)
+		}
 	}
 
 	// Pass the transaction through the fee handler if configured.
@@ -93,13 +121,20 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 	}
 
 	// Route to correct handler.
-	ctx.Logger().Debug("dispatching",
-		"app", app.Name(),
-		"tx", tx,
-	)
+	switch batch {
+	case nil:
+		ctx.Logger().Debug("dispatching",
+			"app", app.Name(),
+			"tx", tx,
+		)
 
-	if err := app.ExecuteTx(ctx, tx); err != nil {
-		return err
+		if err := app.ExecuteTx(ctx, tx); err != nil {
+			return err
+		}
+	default:
+		if err := mux.executeBatch(ctx, tx, batch); err != nil {
+			return err
+		}
 	}
 
 	//  Pass the transaction through the PostExecuteTx handler if configured.
@@ -118,7 +153,105 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 	return nil
 }
 
-func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
+// checkTxExpiry makes sure that the transaction has not expired and can be included in the block
+// that is currently being executed (DeliverTx) or in the next block (CheckTx).
+//
+// Transactions with an expiry are rejected unless enabled by the consensus parameters.
+func (mux *abciMux) checkTxExpiry(ctx *api.Context, tx *transaction.Transaction) error {
+	if tx.ValidUntil == nil {
+		return nil
+	}
+	if !ctx.AppState().ConsensusParameters().EnableTransactionExpiry {
+		return fmt.Errorf("%w: transaction expiry is disabled", consensus.ErrInvalidArgument)
+	}
+
+	var epoch beacon.EpochTime
+	if tx.ValidUntil.Epoch > 0 {
+		var err error
+		if epoch, err = ctx.AppState().GetCurrentEpoch(ctx); err != nil {
+			return fmt.Errorf("failed to get current epoch: %w", err)
+		}
+	}
+
+	height := ctx.BlockHeight() + 1
+	if tx.ValidUntil.IsExpired(height, uint64(epoch)) {
+		ctx.Logger().Debug("expired transaction",
+			"valid_until", tx.ValidUntil,
+			"height", height,
+			"epoch", epoch,
+		)
+		return transaction.ErrExpired
+	}
+	return nil
+}
+
+// decodeBatch decodes and validates the body of a batch transaction.
+//
+// Batch transactions are rejected unless enabled by the consensus parameters.
+func (mux *abciMux) decodeBatch(ctx *api.Context, tx *transaction.Transaction) (*consensus.Batch, error) {
+	if !ctx.AppState().ConsensusParameters().EnableBatchTransactions {
+		return nil, fmt.Errorf("%w: batch transactions are disabled", transaction.ErrMethodNotSupported)
+	}
+
+	var batch consensus.Batch
+	if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+		ctx.Logger().Debug("malformed batch",
+			"tx", tx,
+			"err", err,
+		)
+		return nil, fmt.Errorf("mux: malformed batch: %w", err)
+	}
+	if err := batch.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("mux: invalid batch: %w", err)
+	}
+	for i, call := range batch.Calls {
+		if mux.appsByMethod[call.Method] == nil {
+			return nil, fmt.Errorf("mux: invalid batch: call %d: unknown method: %s", i, call.Method)
+		}
+	}
+	return &batch, nil
+}
+
+// executeBatch dispatches all calls of a batch transaction to their owning applications.
+//
+// All calls are executed in a single transaction context so that either all or none of their
+// state changes and events are applied.
+func (mux *abciMux) executeBatch(ctx *api.Context, tx *transaction.Transaction, batch *consensus.Batch) error {
+	txCtx := ctx.NewTransaction()
+	defer txCtx.Close()
+
+	for i, call := range batch.Calls {
+		app := mux.appsByMethod[call.Method]
+		callTx := &transaction.Transaction{
+			Nonce:    tx.Nonce,
+			Fee:      tx.Fee,
+			FeePayer: tx.FeePayer,
+			Method:   call.Method,
+			Body:     call.Body,
+		}
+
+		ctx.Logger().Debug("dispatching batch call",
+			"app", app.Name(),
+			"index", i,
+			"tx", callTx,
+		)
+
+		// Emit a marker event so that subsequent events can be attributed to this call.
+		txCtx.EmitEvent(api.NewEventBuilder(consensus.ModuleName).TypedAttribute(&consensus.BatchCallEvent{
+			Index: uint32(i),
+		}))
+
+		if err := app.ExecuteTx(txCtx, callTx); err != nil {
+			return fmt.Errorf("mux: batch call %d (%s) failed: %w", i, call.Method, err)
+		}
+	}
+
+	txCtx.Commit()
+
+	return nil
+}
+
+func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte, recheck bool) error {
 	tx, sigTx, err := mux.decodeTx(ctx, rawTx)
 	if err != nil {
 		return err
@@ -127,6 +260,12 @@ func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
 	// Set authenticated transaction signer.
 	ctx.SetTxSigner(sigTx.Signature.PublicKey)
 
+	// Reject expired transactions. In CheckTx this also causes expired transactions to be evicted
+	// from the mempool when they are re-checked after each block.
+	if err = mux.checkTxExpiry(ctx, tx); err != nil {
+		return err
+	}
+
 	// If we are in CheckTx mode and there is a pending upgrade in this block, make sure to reject
 	// any transactions before processing as they may potentially query incompatible state.
 	if upgrader := mux.state.Upgrader(); upgrader != nil && ctx.IsCheckOnly() {
@@ -139,6 +278,9 @@ func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
 		}
 	}
 
+	if ctx.IsCheckOnly() {
+		return mux.processCheckTx(ctx, tx, rawTx, recheck)
+	}
 	return mux.processTx(ctx, tx, len(rawTx))
 }
 
@@ -153,6 +295,10 @@ func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Tran
 		return 0, consensus.ErrNoCommittedBlocks
 	}
 
+	if err := checkTxFeePayer(mux.state.ConsensusParameters(), tx, nil); err != nil {
+		return 0, err
+	}
+
 	// As opposed to other transaction dispatch entry points (CheckTx/DeliverTx), this method can
 	// be called in parallel to the consensus layer and to other invocations.
 	ctx := mux.state.NewContext(api.ContextSimulateTx)
@@ -173,6 +319,9 @@ func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Tran
 			// Signature is fixed-size, so we can leave it as default.
 		},
 	}
+	if tx.FeePayer != nil {
+		mockSignedTx.FeePayerSignature = &signature.Signature{PublicKey: tx.FeePayer.PublicKey}
+	}
 	txSize := len(cbor.Marshal(mockSignedTx))
 
 	// Ignore any errors that occurred during simulation as we only need to estimate gas even if the
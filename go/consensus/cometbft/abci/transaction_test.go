@@ -0,0 +1,85 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+func TestCheckTxExpiry(t *testing.T) {
+	require := require.New(t)
+
+	doc := &genesis.Document{}
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{
+		BlockHeight:  10,
+		CurrentEpoch: 3,
+		Genesis:      doc,
+	})
+	mux := &abciMux{}
+
+	for _, kind := range []api.ContextMode{api.ContextCheckTx, api.ContextDeliverTx} {
+		ctx := appState.NewContext(kind)
+		defer ctx.Close()
+
+		// Transactions with an expiry should be rejected while disabled.
+		doc.Consensus.Parameters.EnableTransactionExpiry = false
+		err := mux.checkTxExpiry(ctx, &transaction.Transaction{})
+		require.NoError(err, "transaction without an expiry should be accepted")
+		err = mux.checkTxExpiry(ctx, &transaction.Transaction{ValidUntil: &transaction.ValidUntil{Height: 11}})
+		require.ErrorIs(err, consensus.ErrInvalidArgument, "transaction with an expiry should be rejected while disabled")
+
+		doc.Consensus.Parameters.EnableTransactionExpiry = true
+
+		for _, tc := range []struct {
+			validUntil *transaction.ValidUntil
+			expired    bool
+		}{
+			{nil, false},
+			{&transaction.ValidUntil{Height: 11}, false},
+			{&transaction.ValidUntil{Height: 10}, true},
+			{&transaction.ValidUntil{Epoch: 3}, false},
+			{&transaction.ValidUntil{Epoch: 2}, true},
+			{&transaction.ValidUntil{Height: 100, Epoch: 2}, true},
+			{&transaction.ValidUntil{Height: 10, Epoch: 100}, true},
+		} {
+			tx := &transaction.Transaction{ValidUntil: tc.validUntil}
+			err := mux.checkTxExpiry(ctx, tx)
+			switch tc.expired {
+			case true:
+				require.ErrorIs(err, transaction.ErrExpired, "transaction valid until %s should be expired", tc.validUntil)
+			case false:
+				require.NoError(err, "transaction valid until %s should not be expired", tc.validUntil)
+			}
+		}
+	}
+}
+
+func TestCheckTxFeePayer(t *testing.T) {
+	require := require.New(t)
+
+	var params consensusGenesis.Parameters
+	feePayer := &transaction.FeePayer{PublicKey: signature.NewPublicKey("47aadd91516ac548decdb436fde957992610facc09ba2f850da0fe1b2be96119")}
+	feePayerSig := &signature.Signature{PublicKey: feePayer.PublicKey}
+
+	// Transactions without a fee payer should always be accepted.
+	err := checkTxFeePayer(&params, &transaction.Transaction{}, nil)
+	require.NoError(err, "transaction without a fee payer should be accepted")
+
+	// Fee payers should be rejected while disabled.
+	err = checkTxFeePayer(&params, &transaction.Transaction{FeePayer: feePayer}, feePayerSig)
+	require.ErrorIs(err, transaction.ErrInvalidFeePayer, "fee payer should be rejected while disabled")
+	err = checkTxFeePayer(&params, &transaction.Transaction{}, feePayerSig)
+	require.ErrorIs(err, transaction.ErrInvalidFeePayer, "fee payer signature should be rejected while disabled")
+
+	// Fee payers should be accepted once enabled.
+	params.EnableFeePayer = true
+	err = checkTxFeePayer(&params, &transaction.Transaction{FeePayer: feePayer}, feePayerSig)
+	require.NoError(err, "fee payer should be accepted once enabled")
+}
@@ -265,6 +265,13 @@ type TransactionAuthHandler interface {
 	// PostExecuteTx is called after the transaction has been executed. It is
 	// only called in case the execution did not produce an error.
 	PostExecuteTx(ctx *Context, tx *transaction.Transaction) error
+
+	// RevertPostExecuteTx reverts the updates performed by PostExecuteTx
+	// during CheckTx for a pending transaction that is being replaced.
+	//
+	// It must reject the request in case the transaction is not the latest
+	// pending transaction of its signer (or fee payer).
+	RevertPostExecuteTx(ctx *Context, tx *transaction.Transaction) error
 }
 
 // ServiceEvent is a CometBFT-specific consensus.ServiceEvent.
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// NewStateProof generates a proof of the given keys and of all keys under the given key prefixes
+// in the consensus state as of executing the block at the given height.
+//
+// In case the height is consensus.HeightLatest, the most recent height for which the state is
+// committed to by a finalized block is used.
+func NewStateProof(
+	ctx context.Context,
+	backend consensus.ClientBackend,
+	state ApplicationQueryState,
+	height int64,
+	keys [][]byte,
+	prefixes [][]byte,
+) (*proof.StateProof, error) {
+	if state == nil {
+		return nil, ErrNoState
+	}
+	if height == consensus.HeightLatest {
+		// The state after the latest block is only committed to by the next block.
+		height = state.BlockHeight() - 1
+	}
+	if height <= 0 || height > state.BlockHeight() {
+		return nil, consensus.ErrVersionNotFound
+	}
+
+	lb, err := backend.GetLightBlockForState(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	ndb := state.Storage().NodeDB()
+	roots, err := ndb.GetRootsForVersion(ctx, uint64(height))
+	if err != nil {
+		return nil, err
+	}
+	switch len(roots) {
+	case 0:
+		// No roots for that state -- it may have been pruned.
+		return nil, consensus.ErrVersionNotFound
+	case 1:
+		// A single root.
+	default:
+		// Unexpected number of roots.
+		return nil, fmt.Errorf("state: incorrect number of roots (%d): %+v", height, roots)
+	}
+	tree := mkvs.NewWithRoot(nil, ndb, roots[0], mkvs.WithoutWriteLog())
+	defer tree.Close()
+
+	p, err := buildStateProof(ctx, tree, roots[0].Hash, keys, prefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proof.StateProof{
+		Height:     height,
+		Proof:      *p,
+		LightBlock: lb.Meta,
+	}, nil
+}
+
+// buildStateProof builds a proof of the given keys and of all keys under the given key prefixes.
+//
+// Proofs for keys also prove absence in case a key does not exist. Proofs for prefixes include
+// the first key following the prefix in order to prove that there are no further keys under it.
+func buildStateProof(
+	ctx context.Context,
+	tree mkvs.ImmutableKeyValueTree,
+	root hash.Hash,
+	keys [][]byte,
+	prefixes [][]byte,
+) (*syncer.Proof, error) {
+	it := tree.NewIterator(ctx, mkvs.WithProof(root))
+	defer it.Close()
+
+	for _, key := range keys {
+		it.Seek(key)
+	}
+	for _, prefix := range prefixes {
+		for it.Seek(prefix); it.Valid() && bytes.HasPrefix(it.Key(), prefix); it.Next() {
+		}
+	}
+	if it.Err() != nil {
+		return nil, UnavailableStateError(it.Err())
+	}
+
+	return it.GetProof()
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+func TestBuildStateProof(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	tree := mkvs.New(nil, nil, node.RootTypeState)
+	defer tree.Close()
+	for _, kv := range [][2]string{
+		{"a", "1"},
+		{"b/1", "2"},
+		{"b/2", "3"},
+		{"c", "4"},
+		{"d", "5"},
+	} {
+		err := tree.Insert(ctx, []byte(kv[0]), []byte(kv[1]))
+		require.NoError(err, "Insert")
+	}
+	for i := 0; i < 100; i++ {
+		err := tree.Insert(ctx, []byte(fmt.Sprintf("z/%03d", i)), []byte("6"))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, common.Namespace{}, 1)
+	require.NoError(err, "Commit")
+	root := node.Root{Version: 1, Type: node.RootTypeState, Hash: rootHash}
+
+	p, err := buildStateProof(ctx, tree, rootHash, [][]byte{[]byte("c"), []byte("x")}, [][]byte{[]byte("b/")})
+	require.NoError(err, "buildStateProof")
+
+	proven := mkvs.NewWithRoot(syncer.NewProofReadSyncer(p), nil, root)
+	defer proven.Close()
+
+	// Proven key.
+	value, err := proven.Get(ctx, []byte("c"))
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("4"), value)
+
+	// Proven absence of a key.
+	value, err = proven.Get(ctx, []byte("x"))
+	require.NoError(err, "Get")
+	require.Nil(value)
+
+	// All keys under the prefix.
+	it := proven.NewIterator(ctx)
+	defer it.Close()
+	var values []string
+	for it.Seek([]byte("b/")); it.Valid(); it.Next() {
+		if it.Key()[0] != 'b' {
+			break
+		}
+		values = append(values, string(it.Value()))
+	}
+	require.NoError(it.Err(), "iterator")
+	require.EqualValues([]string{"2", "3"}, values)
+
+	// Keys which are not part of the proof cannot be accessed.
+	proven2 := mkvs.NewWithRoot(syncer.NewProofReadSyncer(p), nil, root)
+	defer proven2.Close()
+	_, err = proven2.Get(ctx, []byte("z/050"))
+	require.Error(err, "Get should fail for keys not included in the proof")
+
+	// Proofs against a different root are rejected.
+	other := mkvs.NewWithRoot(syncer.NewProofReadSyncer(p), nil, node.Root{Version: 1, Type: node.RootTypeState})
+	defer other.Close()
+	_, err = other.Get(ctx, []byte("c"))
+	require.Error(err, "Get should fail for an invalid root")
+}
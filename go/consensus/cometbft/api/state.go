@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
@@ -214,7 +215,7 @@ func (ms *mockApplicationState) UpdateMockApplicationStateConfig(cfg *MockApplic
 	if cfg.MaxBlockGas > 0 {
 		ms.blockCtx.GasAccountant = NewGasAccountant(cfg.MaxBlockGas)
 	} else {
-		ms.blockCtx.GasAccountant = NewNopGasAccountant()
+		ms.blockCtx.GasAccountant = NewGasAccountant(transaction.Gas(math.MaxUint64))
 	}
 
 	if cfg.Genesis == nil {
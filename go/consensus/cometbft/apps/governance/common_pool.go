@@ -0,0 +1,123 @@
+package governance
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	stakingAPI "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// checkCommonPoolSpend ensures that the common pool has enough funds for the given proposal.
+func checkCommonPoolSpend(ctx *api.Context, spend *governance.CommonPoolSpendProposal) error {
+	total, err := spend.TotalAmount()
+	if err != nil {
+		return governance.ErrInvalidArgument
+	}
+
+	commonPool, err := stakingState.NewMutableState(ctx.State()).CommonPool(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query common pool: %w", err)
+	}
+	if commonPool.Cmp(total) < 0 {
+		ctx.Logger().Debug("governance: not enough funds in the common pool",
+			"common_pool", commonPool,
+			"total_amount", total,
+		)
+		return stakingAPI.ErrInsufficientBalance
+	}
+	return nil
+}
+
+// payCommonPoolSpend transfers the amounts of the given common pool spend proposal that are due by
+// the given epoch and have not yet been paid out from the common pool to the recipients, and
+// updates the paid amounts accordingly.
+//
+// In case the common pool doesn't hold enough funds, only the available funds are transferred and
+// the rest remains due. Returns true iff the proposal has been paid out in full.
+func payCommonPoolSpend(
+	ctx *api.Context,
+	proposal *governance.Proposal,
+	spend *governance.VestingCommonPoolSpend,
+	epoch beacon.EpochTime,
+) (bool, error) {
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	paidOut := true
+	for i, r := range proposal.Content.CommonPoolSpend.Recipients {
+		paid := &spend.Paid[i]
+
+		vested, err := r.Vested(proposal.ClosesAt, epoch)
+		if err != nil {
+			return false, fmt.Errorf("failed to compute vested amount: %w", err)
+		}
+		if paid.Cmp(vested) < 0 {
+			amount := vested.Clone()
+			if err = amount.Sub(paid); err != nil {
+				return false, fmt.Errorf("failed to compute due amount: %w", err)
+			}
+
+			commonPool, err := stakeState.CommonPool(ctx)
+			if err != nil {
+				return false, fmt.Errorf("failed to query common pool: %w", err)
+			}
+			if commonPool.Cmp(amount) < 0 {
+				ctx.Logger().Warn("common pool depleted, deferring part of common pool spend",
+					"proposal_id", proposal.ID,
+					"recipient", r.Address,
+					"amount", amount,
+					"common_pool", commonPool,
+				)
+				amount = commonPool.Clone()
+			}
+
+			if !amount.IsZero() {
+				if _, err = stakeState.TransferFromCommon(ctx, r.Address, amount, false); err != nil {
+					return false, fmt.Errorf("failed to transfer from common pool: %w", err)
+				}
+				if err = paid.Add(amount); err != nil {
+					return false, fmt.Errorf("failed to update paid amount: %w", err)
+				}
+			}
+		}
+
+		if paid.Cmp(&r.Amount) < 0 {
+			paidOut = false
+		}
+	}
+	return paidOut, nil
+}
+
+// payVestingCommonPoolSpends pays out the amounts of passed common pool spend proposals that are
+// due by the given epoch, including any amounts that could not be paid out before.
+func payVestingCommonPoolSpends(ctx *api.Context, state *governanceState.MutableState, epoch beacon.EpochTime) error {
+	spends, err := state.VestingCommonPoolSpends(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query vesting common pool spends: %w", err)
+	}
+
+	for _, spend := range spends {
+		proposal, err := state.Proposal(ctx, spend.ProposalID)
+		if err != nil {
+			return fmt.Errorf("failed to query common pool spend proposal: %w", err)
+		}
+
+		paidOut, err := payCommonPoolSpend(ctx, proposal, spend, epoch)
+		if err != nil {
+			return err
+		}
+		switch paidOut {
+		case true:
+			err = state.RemoveVestingCommonPoolSpend(ctx, spend.ProposalID)
+		case false:
+			err = state.SetVestingCommonPoolSpend(ctx, spend)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update vesting common pool spend: %w", err)
+		}
+	}
+	return nil
+}
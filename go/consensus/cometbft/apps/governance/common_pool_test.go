@@ -0,0 +1,273 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestCommonPoolSpend(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	state := governanceState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+	}
+
+	err = state.SetConsensusParameters(ctx, &governance.ConsensusParameters{
+		EnableCommonPoolSpendProposal: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = stakeState.SetCommonPool(ctx, quantity.NewFromUint64(1_000))
+	require.NoError(err, "SetCommonPool")
+
+	addr1 := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	balance := func(addr staking.Address) *quantity.Quantity {
+		acct, aerr := stakeState.Account(ctx, addr)
+		require.NoError(aerr, "Account")
+		return &acct.General.Balance
+	}
+	commonPool := func() *quantity.Quantity {
+		pool, perr := stakeState.CommonPool(ctx)
+		require.NoError(perr, "CommonPool")
+		return pool
+	}
+
+	// Spending should fail while common pool spend proposals are disabled.
+	err = state.SetConsensusParameters(ctx, &governance.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+	proposal := &governance.Proposal{
+		ID:       1,
+		ClosesAt: 10,
+		Content: governance.ProposalContent{CommonPoolSpend: &governance.CommonPoolSpendProposal{
+			Recipients: []governance.CommonPoolSpendRecipient{
+				{Address: addr1, Amount: *quantity.NewFromUint64(100)},
+			},
+		}},
+	}
+	err = app.executeProposal(ctx, state, proposal)
+	require.ErrorIs(err, governance.ErrInvalidArgument, "executing proposal should fail while disabled")
+	require.Equal(governance.StateFailed, proposal.State)
+	require.True(balance(addr1).IsZero(), "nothing should be paid out while disabled")
+	require.EqualValues(quantity.NewFromUint64(1_000), commonPool())
+
+	err = state.SetConsensusParameters(ctx, &governance.ConsensusParameters{
+		EnableCommonPoolSpendProposal: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Spending more than the common pool holds should fail.
+	proposal = &governance.Proposal{
+		ID:       1,
+		ClosesAt: 10,
+		Content: governance.ProposalContent{CommonPoolSpend: &governance.CommonPoolSpendProposal{
+			Recipients: []governance.CommonPoolSpendRecipient{
+				{Address: addr1, Amount: *quantity.NewFromUint64(1_001)},
+			},
+		}},
+	}
+	err = app.executeProposal(ctx, state, proposal)
+	require.ErrorIs(err, staking.ErrInsufficientBalance, "executing proposal should fail")
+	require.Equal(governance.StateFailed, proposal.State)
+
+	// Spending within the common pool should work.
+	proposal = &governance.Proposal{
+		ID:       2,
+		ClosesAt: 10,
+		Content: governance.ProposalContent{CommonPoolSpend: &governance.CommonPoolSpendProposal{
+			Recipients: []governance.CommonPoolSpendRecipient{
+				{Address: addr1, Amount: *quantity.NewFromUint64(100)},
+				{Address: addr2, Amount: *quantity.NewFromUint64(300), VestingEpochs: 3},
+			},
+		}},
+	}
+	err = app.executeProposal(ctx, state, proposal)
+	require.NoError(err, "executing proposal should work")
+	require.Equal(governance.StatePassed, proposal.State)
+	err = state.SetProposal(ctx, proposal)
+	require.NoError(err, "SetProposal")
+
+	require.EqualValues(quantity.NewFromUint64(100), balance(addr1), "non-vesting amount should be paid out")
+	require.True(balance(addr2).IsZero(), "vesting amount should not be paid out yet")
+	require.EqualValues(quantity.NewFromUint64(900), commonPool())
+
+	vesting, err := state.VestingCommonPoolSpends(ctx)
+	require.NoError(err, "VestingCommonPoolSpends")
+	require.Len(vesting, 1, "proposal should be vesting")
+
+	// Installments should be paid out in each of the following epochs.
+	for i, epoch := range []beacon.EpochTime{11, 12, 13} {
+		err = payVestingCommonPoolSpends(ctx, state, epoch)
+		require.NoError(err, "payVestingCommonPoolSpends")
+		require.EqualValues(quantity.NewFromUint64(uint64(i+1)*100), balance(addr2), "installment should be paid out")
+	}
+	require.EqualValues(quantity.NewFromUint64(100), balance(addr1), "non-vesting amount should only be paid out once")
+	require.EqualValues(quantity.NewFromUint64(600), commonPool())
+
+	vesting, err = state.VestingCommonPoolSpends(ctx)
+	require.NoError(err, "VestingCommonPoolSpends")
+	require.Empty(vesting, "proposal should no longer be vesting")
+}
+
+func TestCommonPoolSpendDeferred(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	state := governanceState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+	}
+
+	err = state.SetConsensusParameters(ctx, &governance.ConsensusParameters{
+		EnableCommonPoolSpendProposal: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = stakeState.SetCommonPool(ctx, quantity.NewFromUint64(400))
+	require.NoError(err, "SetCommonPool")
+
+	addr := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	balance := func() *quantity.Quantity {
+		acct, aerr := stakeState.Account(ctx, addr)
+		require.NoError(aerr, "Account")
+		return &acct.General.Balance
+	}
+	setCommonPool := func(amount uint64) {
+		serr := stakeState.SetCommonPool(ctx, quantity.NewFromUint64(amount))
+		require.NoError(serr, "SetCommonPool")
+	}
+	vesting := func() []*governance.VestingCommonPoolSpend {
+		spends, verr := state.VestingCommonPoolSpends(ctx)
+		require.NoError(verr, "VestingCommonPoolSpends")
+		return spends
+	}
+
+	proposal := &governance.Proposal{
+		ID:       1,
+		ClosesAt: 10,
+		Content: governance.ProposalContent{CommonPoolSpend: &governance.CommonPoolSpendProposal{
+			Recipients: []governance.CommonPoolSpendRecipient{
+				{Address: addr, Amount: *quantity.NewFromUint64(400), VestingEpochs: 4},
+			},
+		}},
+	}
+	err = app.executeProposal(ctx, state, proposal)
+	require.NoError(err, "executing proposal should work")
+	err = state.SetProposal(ctx, proposal)
+	require.NoError(err, "SetProposal")
+
+	// An installment that the common pool can't cover should only be paid out partially.
+	setCommonPool(60)
+	err = payVestingCommonPoolSpends(ctx, state, 11)
+	require.NoError(err, "payVestingCommonPoolSpends")
+	require.EqualValues(quantity.NewFromUint64(60), balance(), "available funds should be paid out")
+	require.Len(vesting(), 1, "proposal should still be vesting")
+
+	// Once the common pool is refilled, the unpaid amount should be paid out together with the
+	// installment for the current epoch.
+	setCommonPool(1_000)
+	err = payVestingCommonPoolSpends(ctx, state, 12)
+	require.NoError(err, "payVestingCommonPoolSpends")
+	require.EqualValues(quantity.NewFromUint64(200), balance(), "unpaid amount should be carried forward")
+
+	// Skipped epochs should be paid out in full, even past the end of the vesting period.
+	setCommonPool(0)
+	err = payVestingCommonPoolSpends(ctx, state, 14)
+	require.NoError(err, "payVestingCommonPoolSpends")
+	require.EqualValues(quantity.NewFromUint64(200), balance())
+	require.Len(vesting(), 1, "proposal should be vesting until paid out in full")
+
+	setCommonPool(1_000)
+	err = payVestingCommonPoolSpends(ctx, state, 20)
+	require.NoError(err, "payVestingCommonPoolSpends")
+	require.EqualValues(quantity.NewFromUint64(400), balance(), "whole amount should be paid out")
+	require.Empty(vesting(), "proposal should no longer be vesting")
+}
+
+func TestCommonPoolSpendSkippedEpochs(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	state := governanceState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+	}
+
+	err = state.SetConsensusParameters(ctx, &governance.ConsensusParameters{
+		EnableCommonPoolSpendProposal: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = stakeState.SetCommonPool(ctx, quantity.NewFromUint64(1_000))
+	require.NoError(err, "SetCommonPool")
+
+	addr := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	proposal := &governance.Proposal{
+		ID:       1,
+		ClosesAt: 10,
+		Content: governance.ProposalContent{CommonPoolSpend: &governance.CommonPoolSpendProposal{
+			Recipients: []governance.CommonPoolSpendRecipient{
+				{Address: addr, Amount: *quantity.NewFromUint64(500), VestingEpochs: 5},
+			},
+		}},
+	}
+	err = app.executeProposal(ctx, state, proposal)
+	require.NoError(err, "executing proposal should work")
+	err = state.SetProposal(ctx, proposal)
+	require.NoError(err, "SetProposal")
+
+	// Installments of all epochs skipped since the last payout should be paid out.
+	for _, tc := range []struct {
+		epoch   beacon.EpochTime
+		balance uint64
+	}{
+		{11, 100},
+		{14, 400},
+		{17, 500},
+	} {
+		err = payVestingCommonPoolSpends(ctx, state, tc.epoch)
+		require.NoError(err, "payVestingCommonPoolSpends")
+
+		acct, err := stakeState.Account(ctx, addr)
+		require.NoError(err, "Account")
+		require.EqualValues(quantity.NewFromUint64(tc.balance), &acct.General.Balance, "balance at epoch %d", tc.epoch)
+	}
+
+	vesting, err := state.VestingCommonPoolSpends(ctx)
+	require.NoError(err, "VestingCommonPoolSpends")
+	require.Empty(vesting, "proposal should no longer be vesting")
+
+	commonPool, err := stakeState.CommonPool(ctx)
+	require.NoError(err, "CommonPool")
+	require.EqualValues(quantity.NewFromUint64(500), commonPool)
+}
@@ -57,6 +57,13 @@ func (app *governanceApplication) InitChain(ctx *abciAPI.Context, request types.
 		}
 	}
 
+	// Insert vesting common pool spends.
+	for _, spend := range st.VestingCommonPoolSpends {
+		if err = state.SetVestingCommonPoolSpend(ctx, spend); err != nil {
+			return fmt.Errorf("cometbft/governance: failed to set vesting common pool spend: %w", err)
+		}
+	}
+
 	if err := state.SetNextProposalIdentifier(ctx, largestProposalID+1); err != nil {
 		return fmt.Errorf("cometbft/governance: failed to set next proposal identifier: %w", err)
 	}
@@ -86,9 +93,15 @@ func (gq *governanceQuerier) Genesis(ctx context.Context) (*governance.Genesis,
 		voteEntries[proposal.ID] = votes
 	}
 
+	vestingCommonPoolSpends, err := gq.state.VestingCommonPoolSpends(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &governance.Genesis{
-		Parameters:  *params,
-		Proposals:   proposals,
-		VoteEntries: voteEntries,
+		Parameters:              *params,
+		Proposals:               proposals,
+		VoteEntries:             voteEntries,
+		VestingCommonPoolSpends: vestingCommonPoolSpends,
 	}, nil
 }
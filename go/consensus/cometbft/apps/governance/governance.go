@@ -135,8 +135,14 @@ func (app *governanceApplication) BeginBlock(ctx *api.Context) error {
 		return nil
 	}
 
-	// Check if a pending upgrade is scheduled for current epoch.
 	state := governanceState.NewMutableState(ctx.State())
+
+	// Pay out any common pool spend installments due in the current epoch.
+	if err := payVestingCommonPoolSpends(ctx, state, epoch); err != nil {
+		return fmt.Errorf("cometbft/governance: failed to pay common pool spends: %w", err)
+	}
+
+	// Check if a pending upgrade is scheduled for current epoch.
 	pendingUpgrades, err := state.PendingUpgrades(ctx)
 	if err != nil {
 		return fmt.Errorf("cometbft/governance: couldn't get pending upgrades: %w", err)
@@ -303,6 +309,39 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 			ctx.Logger().Debug("governance: no module applied change parameters proposal")
 			return governance.ErrInvalidArgument
 		}
+	case proposal.Content.CommonPoolSpend != nil:
+		// Common pool spend proposals should be ignored in case they were disabled since the
+		// proposal was submitted.
+		params, err := state.ConsensusParameters(ctx)
+		if err != nil {
+			ctx.Logger().Error("failed to query consensus parameters",
+				"err", err,
+			)
+			return governance.ErrInvalidArgument
+		}
+		if !params.EnableCommonPoolSpendProposal {
+			ctx.Logger().Debug("common pool spend proposals are disabled")
+			return governance.ErrInvalidArgument
+		}
+
+		// The common pool may have changed since the proposal was submitted.
+		if err = checkCommonPoolSpend(ctx, proposal.Content.CommonPoolSpend); err != nil {
+			return err
+		}
+
+		// Pay out the non-vesting amounts and keep track of the amounts that remain to be paid.
+		spend := governance.NewVestingCommonPoolSpend(proposal)
+		paidOut, err := payCommonPoolSpend(ctx, proposal, spend, proposal.ClosesAt)
+		if err != nil {
+			return err
+		}
+		if !paidOut {
+			if err = state.SetVestingCommonPoolSpend(ctx, spend); err != nil {
+				return fmt.Errorf("failed to set vesting common pool spend: %w", err)
+			}
+		}
+	case proposal.Content.Text != nil:
+		// Text proposals are not executed, the voting results are recorded in the proposal.
 	default:
 		return governance.ErrInvalidArgument
 	}
@@ -473,8 +512,9 @@ func (app *governanceApplication) closeProposal(
 		"results", proposal.Results,
 		"invalid_votes", proposal.InvalidVotes,
 		"stake_threshold", params.StakeThreshold,
+		"veto_threshold", params.VetoThreshold,
 	)
-	if err := proposal.CloseProposal(totalVotingStake, params.StakeThreshold); err != nil {
+	if err := proposal.CloseProposal(totalVotingStake, params.StakeThreshold, params.VetoThreshold); err != nil {
 		return err
 	}
 
@@ -602,8 +642,9 @@ func (app *governanceApplication) EndBlock(ctx *api.Context) (types.ResponseEndB
 
 		// Emit Proposal finalized event.
 		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&governance.ProposalFinalizedEvent{
-			ID:    proposal.ID,
-			State: proposal.State,
+			ID:     proposal.ID,
+			State:  proposal.State,
+			Vetoed: proposal.Vetoed,
 		}))
 
 		switch proposal.State {
@@ -623,6 +664,24 @@ func (app *governanceApplication) EndBlock(ctx *api.Context) (types.ResponseEndB
 					fmt.Errorf("consensus/governance: failed to reclaim proposal deposit: %w", err)
 			}
 		case governance.StateRejected:
+			// Proposal vetoed, deposit is burned if configured.
+			if proposal.Vetoed {
+				var params *governance.ConsensusParameters
+				if params, err = state.ConsensusParameters(ctx); err != nil {
+					return types.ResponseEndBlock{}, fmt.Errorf("consensus/governance: failed to fetch consensus parameters: %w", err)
+				}
+				if params.BurnVetoedDeposits {
+					if err = stakingState.BurnGovernanceDeposit(
+						ctx,
+						&proposal.Deposit,
+					); err != nil {
+						return types.ResponseEndBlock{},
+							fmt.Errorf("consensus/governance: failed to burn proposal deposit: %w", err)
+					}
+					break
+				}
+			}
+
 			// Proposal rejected, deposit is transferred into the common pool.
 			if err = stakingState.DiscardGovernanceDeposit(
 				ctx,
@@ -599,6 +599,14 @@ func TestExecuteProposal(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"executing text proposal should work",
+			&governance.Proposal{
+				ID:      13,
+				Content: governance.ProposalContent{Text: &governance.TextProposal{Title: "title"}},
+			},
+			nil,
+		},
 	} {
 		err = app.executeProposal(ctx, state, tc.proposal)
 		if tc.err != nil {
@@ -1047,3 +1055,100 @@ func TestEndBlock(t *testing.T) {
 		tc.check()
 	}
 }
+
+func TestEndBlockVeto(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Prepare state.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+	state := governanceState.NewMutableState(ctx.State())
+
+	app := &governanceApplication{
+		state: appState,
+	}
+
+	registryState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	_, accounts, _ := initValidatorsEscrowState(t, stakeState, registryState, schedulerState)
+
+	err = stakeState.SetCommonPool(ctx, quantity.NewFromUint64(1000))
+	require.NoError(err, "SetCommonPool")
+	err = stakeState.SetGovernanceDeposits(ctx, quantity.NewFromUint64(300))
+	require.NoError(err, "SetGovernanceDeposits")
+	err = stakeState.SetTotalSupply(ctx, quantity.NewFromUint64(10_000))
+	require.NoError(err, "SetTotalSupply")
+
+	err = state.SetConsensusParameters(ctx, &governance.ConsensusParameters{
+		MinProposalDeposit:        *quantity.NewFromUint64(100),
+		StakeThreshold:            90,
+		VetoThreshold:             34,
+		BurnVetoedDeposits:        true,
+		UpgradeMinEpochDiff:       10,
+		UpgradeCancelMinEpochDiff: 10,
+	})
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	// Proposals that should be vetoed at epoch 11 and rejected without a veto at epochs 12
+	// and 13. The last one is only vetoed by a single validator, so the quorum is not reached.
+	for i, votes := range []struct {
+		vote   governance.Vote
+		voters int
+	}{
+		{governance.VoteNoWithVeto, numValidators},
+		{governance.VoteNo, numValidators},
+		{governance.VoteNoWithVeto, 1},
+	} {
+		p := &governance.Proposal{
+			ID:        uint64(i + 1),
+			Submitter: accounts[i],
+			Deposit:   *quantity.NewFromUint64(100),
+			Content:   governance.ProposalContent{Text: &governance.TextProposal{Title: "title"}},
+			ClosesAt:  beacon.EpochTime(11 + i),
+			State:     governance.StateActive,
+		}
+		err = state.SetActiveProposal(ctx, p)
+		require.NoError(err, "SetActiveProposal")
+		for _, valAddr := range accounts[:votes.voters] {
+			err = state.SetVote(ctx, p.ID, valAddr, votes.vote)
+			require.NoError(err, "Vote")
+		}
+	}
+
+	for _, tc := range []struct {
+		msg         string
+		epoch       beacon.EpochTime
+		vetoed      bool
+		commonPool  uint64
+		totalSupply uint64
+	}{
+		{"vetoed proposal deposit should be burned", 11, true, 1000, 9_900},
+		{"rejected proposal deposit should be discarded into the common pool", 12, false, 1100, 9_900},
+		{"proposal vetoed without quorum deposit should be discarded into the common pool", 13, false, 1200, 9_900},
+	} {
+		appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+			CurrentEpoch: tc.epoch,
+			EpochChanged: true,
+		})
+
+		_, err = app.EndBlock(ctx)
+		require.NoError(err, tc.msg)
+
+		var proposal *governance.Proposal
+		proposal, err = state.Proposal(ctx, uint64(tc.epoch-10))
+		require.NoError(err, "Proposal")
+		require.Equal(governance.StateRejected, proposal.State, tc.msg)
+		require.Equal(tc.vetoed, proposal.Vetoed, tc.msg)
+
+		var commonPool, totalSupply *quantity.Quantity
+		commonPool, err = stakeState.CommonPool(ctx)
+		require.NoError(err, "CommonPool")
+		require.EqualValues(quantity.NewFromUint64(tc.commonPool), commonPool, tc.msg)
+		totalSupply, err = stakeState.TotalSupply(ctx)
+		require.NoError(err, "TotalSupply")
+		require.EqualValues(quantity.NewFromUint64(tc.totalSupply), totalSupply, tc.msg)
+	}
+}
@@ -47,6 +47,13 @@ var (
 	// Key format is: 0x85.
 	// Value is CBOR-serialized governance.ConsensusParameters.
 	parametersKeyFmt = keyformat.New(0x85)
+
+	// vestingCommonPoolSpendsKeyFmt is the key format used for storing the payout status of passed
+	// common pool spend proposals that have not yet been paid out in full.
+	//
+	// Key format is: 0x86 <proposal-id (uint64)>.
+	// Value is a CBOR-serialized governance.VestingCommonPoolSpend.
+	vestingCommonPoolSpendsKeyFmt = keyformat.New(0x86, uint64(0))
 )
 
 // ImmutableState is the immutable consensus state wrapper.
@@ -232,6 +239,30 @@ func (s *ImmutableState) PendingUpgrades(ctx context.Context) ([]*upgrade.Descri
 	return pendingUpgrades, nil
 }
 
+// VestingCommonPoolSpends returns the payout statuses of all passed common pool spend proposals
+// that have not yet been paid out in full.
+func (s *ImmutableState) VestingCommonPoolSpends(ctx context.Context) ([]*governance.VestingCommonPoolSpend, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var spends []*governance.VestingCommonPoolSpend
+	for it.Seek(vestingCommonPoolSpendsKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !vestingCommonPoolSpendsKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var spend governance.VestingCommonPoolSpend
+		if err := cbor.Unmarshal(it.Value(), &spend); err != nil {
+			return nil, api.UnavailableStateError(err)
+		}
+		spends = append(spends, &spend)
+	}
+	if it.Err() != nil {
+		return nil, api.UnavailableStateError(it.Err())
+	}
+	return spends, nil
+}
+
 // ConsensusParameters returns the governance consensus parameters.
 func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*governance.ConsensusParameters, error) {
 	raw, err := s.is.Get(ctx, parametersKeyFmt.Encode())
@@ -338,6 +369,18 @@ func (s *MutableState) RemovePendingUpgradesForEpoch(ctx context.Context, epoch
 	return nil
 }
 
+// SetVestingCommonPoolSpend sets the payout status of a passed common pool spend proposal.
+func (s *MutableState) SetVestingCommonPoolSpend(ctx context.Context, spend *governance.VestingCommonPoolSpend) error {
+	err := s.ms.Insert(ctx, vestingCommonPoolSpendsKeyFmt.Encode(spend.ProposalID), cbor.Marshal(spend))
+	return api.UnavailableStateError(err)
+}
+
+// RemoveVestingCommonPoolSpend removes the payout status of a common pool spend proposal.
+func (s *MutableState) RemoveVestingCommonPoolSpend(ctx context.Context, proposalID uint64) error {
+	err := s.ms.Remove(ctx, vestingCommonPoolSpendsKeyFmt.Encode(proposalID))
+	return api.UnavailableStateError(err)
+}
+
 // SetVote sets a vote for a proposal.
 func (s *MutableState) SetVote(
 	ctx context.Context,
@@ -48,6 +48,12 @@ func (app *governanceApplication) submitProposal(
 	if proposalContent.ChangeParameters != nil && !params.EnableChangeParametersProposal {
 		return nil, governance.ErrInvalidArgument
 	}
+	if proposalContent.CommonPoolSpend != nil && !params.EnableCommonPoolSpendProposal {
+		return nil, governance.ErrInvalidArgument
+	}
+	if proposalContent.Text != nil && !params.EnableTextProposal {
+		return nil, governance.ErrInvalidArgument
+	}
 
 	// Charge gas for this transaction.
 	if err = ctx.Gas().UseGas(1, governance.GasOpSubmitProposal, params.GasCosts); err != nil {
@@ -156,6 +162,15 @@ func (app *governanceApplication) submitProposal(
 			ctx.Logger().Debug("governance: no module interested in change parameters proposal")
 			return nil, governance.ErrInvalidArgument
 		}
+
+	case proposalContent.CommonPoolSpend != nil:
+		// Ensure the common pool currently holds enough funds.
+		if err = checkCommonPoolSpend(ctx, proposalContent.CommonPoolSpend); err != nil {
+			return nil, err
+		}
+
+	case proposalContent.Text != nil:
+		// Text proposals are only used for signalling.
 	default:
 		return nil, governance.ErrInvalidArgument
 	}
@@ -329,6 +344,14 @@ func (app *governanceApplication) castVote(
 		)
 		return governance.ErrVotingIsClosed
 	}
+	// Ensure vote is valid, if enabled.
+	if params.RejectInvalidVotes && !proposalVote.Vote.IsValid() {
+		ctx.Logger().Debug("governance: invalid vote",
+			"proposal_id", proposalVote.ID,
+			"vote", proposalVote.Vote,
+		)
+		return governance.ErrInvalidArgument
+	}
 
 	// Save the vote.
 	if err := state.SetVote(ctx, proposal.ID, submitterAddr, proposalVote.Vote); err != nil {
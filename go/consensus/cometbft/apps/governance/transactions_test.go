@@ -58,6 +58,18 @@ func TestSubmitProposal(t *testing.T) {
 		VotingPeriod:              beacon.EpochTime(50),
 	}
 
+	textConsParams := *baseConsParams
+	textConsParams.EnableTextProposal = true
+	text := &governance.ProposalContent{Text: &governance.TextProposal{Title: "title"}}
+
+	commonPoolSpendConsParams := *baseConsParams
+	commonPoolSpendConsParams.EnableCommonPoolSpendProposal = true
+	commonPoolSpend := &governance.ProposalContent{CommonPoolSpend: &governance.CommonPoolSpendProposal{
+		Recipients: []governance.CommonPoolSpendRecipient{
+			{Address: addr1, Amount: *quantity.NewFromUint64(100)},
+		},
+	}}
+
 	for _, tc := range []struct {
 		msg             string
 		params          *governance.ConsensusParameters
@@ -223,6 +235,41 @@ func TestSubmitProposal(t *testing.T) {
 			},
 			governance.ErrUpgradeAlreadyPending,
 		},
+		{
+			"should fail with text proposal while disabled",
+			baseConsParams,
+			pk1,
+			text,
+			func() {},
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should work with text proposal when enabled",
+			&textConsParams,
+			pk1,
+			text,
+			func() {},
+			nil,
+		},
+		{
+			"should fail with common pool spend proposal while disabled",
+			baseConsParams,
+			pk1,
+			commonPoolSpend,
+			func() {
+				err = stakeState.SetCommonPool(ctx, quantity.NewFromUint64(1_000))
+				require.NoError(err, "SetCommonPool()")
+			},
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should work with common pool spend proposal when enabled",
+			&commonPoolSpendConsParams,
+			pk1,
+			commonPoolSpend,
+			func() {},
+			nil,
+		},
 	} {
 		err = state.SetConsensusParameters(ctx, tc.params)
 		require.NoError(err, "setting governance consensus parameters should not error")
@@ -240,6 +287,11 @@ func TestSubmitProposal(t *testing.T) {
 		_, err = app.submitProposal(txCtx, state, tc.proposalContent)
 		if tc.err != nil {
 			require.True(errors.Is(err, tc.err), tc.msg)
+
+			// If proposal failed, ensure no proposal deposit was made.
+			governanceDepositsAfter, err = stakeState.GovernanceDeposits(txCtx)
+			require.NoError(err, "GovernanceDeposits()")
+			require.EqualValues(governanceDepositsBefore, governanceDepositsAfter, tc.msg)
 			continue
 		}
 
@@ -444,6 +496,22 @@ func TestCastVote(t *testing.T) {
 				}
 			},
 		},
+		{
+			"invalid vote should be stored when invalid votes are not rejected",
+			signers[3].Public(),
+			&governance.ProposalVote{
+				ID:   p1.ID,
+				Vote: governance.Vote(99),
+			},
+			nil,
+			func() {
+				// Ensure vote exists.
+				var votes []*governance.VoteEntry
+				votes, err = state.Votes(ctx, p1.ID)
+				require.NoError(err, "Votes()")
+				require.Len(votes, 4, "four votes should exist")
+			},
+		},
 	} {
 		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
 		defer txCtx.Close()
@@ -454,4 +522,19 @@ func TestCastVote(t *testing.T) {
 
 		tc.check()
 	}
+
+	// Invalid votes should be rejected when enabled.
+	params.RejectInvalidVotes = true
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(signers[3].Public())
+
+	err = app.castVote(txCtx, state, &governance.ProposalVote{
+		ID:   p1.ID,
+		Vote: governance.Vote(99),
+	})
+	require.Equal(governance.ErrInvalidArgument, err, "invalid vote should be rejected")
 }
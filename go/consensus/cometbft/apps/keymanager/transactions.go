@@ -52,15 +52,21 @@ func (app *keymanagerApplication) updatePolicy(
 		return err
 	}
 
+	kmParams, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ensure that master secret recovery is allowed.
+	if sigPol.Policy.MasterSecretRecovery != nil && !kmParams.EnableMasterSecretRecovery {
+		return fmt.Errorf("%w: master secret recovery is disabled", api.ErrInvalidArgument)
+	}
+
 	if ctx.IsCheckOnly() {
 		return nil
 	}
 
 	// Charge gas for this operation.
-	kmParams, err := state.ConsensusParameters(ctx)
-	if err != nil {
-		return err
-	}
 	if err = ctx.Gas().UseGas(1, api.GasOpUpdatePolicy, kmParams.GasCosts); err != nil {
 		return err
 	}
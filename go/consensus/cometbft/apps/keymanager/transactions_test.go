@@ -257,3 +257,68 @@ func TestPublishEphemeralSecret(t *testing.T) {
 		require.EqualError(t, err, "keymanager: ephemeral secret can be proposed once per epoch")
 	})
 }
+
+func TestUpdatePolicy(t *testing.T) {
+	// Prepare key manager app.
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	app := keymanagerApplication{appState}
+
+	// Prepare abci contexts.
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+	txCtx := appState.NewContext(abciAPI.ContextCheckTx)
+	defer txCtx.Close()
+
+	// Prepare states.
+	kmState := keymanagerState.NewMutableState(ctx.State())
+	regState := registryState.NewMutableState(ctx.State())
+
+	// Register a key manager runtime.
+	entitySigner := memorySigner.NewTestSigner("entity signer")
+	var kmID common.Namespace
+	err := kmID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err, "failed to unmarshal keymanager id")
+	kmRt := registryAPI.Runtime{
+		ID:          kmID,
+		EntityID:    entitySigner.Public(),
+		Kind:        registryAPI.KindKeyManager,
+		TEEHardware: node.TEEHardwareIntelSGX,
+	}
+	err = regState.SetRuntime(ctx, &kmRt, false)
+	require.NoError(t, err, "registry.SetRuntime")
+
+	// Set transaction signer.
+	txCtx.SetTxSigner(entitySigner.Public())
+
+	// Prepare policy with a master secret recovery configuration.
+	custodian := x25519.PrivateKey(sha512.Sum512_256([]byte("custodian")))
+	sigPol := &api.SignedPolicySGX{
+		Policy: api.PolicySGX{
+			Serial: 1,
+			ID:     kmID,
+			MasterSecretRecovery: &api.MasterSecretRecoveryPolicy{
+				Threshold:  1,
+				Custodians: []x25519.PublicKey{*custodian.Public()},
+			},
+		},
+	}
+
+	t.Run("master secret recovery disabled", func(t *testing.T) {
+		err := kmState.SetConsensusParameters(ctx, &api.ConsensusParameters{})
+		require.NoError(t, err, "api.SetConsensusParameters")
+
+		err = app.updatePolicy(txCtx, kmState, sigPol)
+		require.ErrorIs(t, err, api.ErrInvalidArgument)
+	})
+
+	t.Run("master secret recovery enabled", func(t *testing.T) {
+		err := kmState.SetConsensusParameters(ctx, &api.ConsensusParameters{
+			EnableMasterSecretRecovery: true,
+		})
+		require.NoError(t, err, "api.SetConsensusParameters")
+
+		err = app.updatePolicy(txCtx, kmState, sigPol)
+		require.NoError(t, err, "updatePolicy")
+	})
+}
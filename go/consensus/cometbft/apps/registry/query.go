@@ -8,6 +8,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -41,6 +43,18 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 	return &registryQuerier{sf.state, state, height}, nil
 }
 
+// StateProofAt returns a proof of the given state keys and of all keys under the given key
+// prefixes at a specific height.
+func (sf *QueryFactory) StateProofAt(
+	ctx context.Context,
+	backend consensus.ClientBackend,
+	height int64,
+	keys [][]byte,
+	prefixes [][]byte,
+) (*proof.StateProof, error) {
+	return abciAPI.NewStateProof(ctx, backend, sf.state, height, keys, prefixes)
+}
+
 type registryQuerier struct {
 	queryState abciAPI.ApplicationQueryState
 	state      *registryState.ImmutableState
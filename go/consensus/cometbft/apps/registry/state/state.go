@@ -77,6 +77,16 @@ var (
 	runtimeByEntityKeyFmt = keyformat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
 )
 
+// EntityKey returns the state key of the signed entity descriptor for the given entity.
+func EntityKey(id signature.PublicKey) []byte {
+	return signedEntityKeyFmt.Encode(&id)
+}
+
+// NodeKey returns the state key of the signed node descriptor for the given node.
+func NodeKey(id signature.PublicKey) []byte {
+	return signedNodeKeyFmt.Encode(&id)
+}
+
 // ImmutableState is the immutable registry state wrapper.
 type ImmutableState struct {
 	is *abciAPI.ImmutableState
@@ -26,11 +26,6 @@ func (app *registryApplication) registerEntity(
 		return err
 	}
 
-	if ctx.IsCheckOnly() {
-		return nil
-	}
-
-	// Charge gas for this transaction.
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		ctx.Logger().Error("RegisterEntity: failed to fetch consensus parameters",
@@ -38,6 +33,16 @@ func (app *registryApplication) registerEntity(
 		)
 		return err
 	}
+
+	if ent.Metadata != nil && !params.EnableEntityMetadata {
+		return fmt.Errorf("%w: entity metadata is disabled", registry.ErrForbidden)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
 	if err = ctx.Gas().UseGas(1, registry.GasOpRegisterEntity, params.GasCosts); err != nil {
 		return err
 	}
@@ -22,6 +22,97 @@ import (
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
+func TestRegisterEntity(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		DebugBypassStake: true,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: entity signer: metadata")
+	register := func(ent *entity.Entity) error {
+		sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, ent)
+		require.NoError(err, "SignEntity")
+
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(entitySigner.Public())
+		return app.registerEntity(txCtx, state, sigEnt)
+	}
+
+	// Register an entity without metadata.
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	}
+	err = register(&ent)
+	require.NoError(err, "entity registration without metadata should succeed")
+
+	// Metadata should be rejected while disabled.
+	ent.Metadata = &entity.Metadata{
+		Name:    "Entity",
+		URL:     "https://example.com",
+		Email:   "entity@example.com",
+		Keybase: "entity",
+	}
+	err = register(&ent)
+	require.ErrorIs(err, registry.ErrForbidden, "entity registration with metadata should fail while disabled")
+
+	regEnt, err := state.Entity(ctx, ent.ID)
+	require.NoError(err, "Entity")
+	require.Nil(regEnt.Metadata, "metadata should not be registered while disabled")
+
+	err = state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		DebugBypassStake:     true,
+		EnableEntityMetadata: true,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	// Update the entity with metadata.
+	err = register(&ent)
+	require.NoError(err, "entity registration with metadata should succeed")
+
+	regEnt, err = state.Entity(ctx, ent.ID)
+	require.NoError(err, "Entity")
+	require.EqualValues(&ent, regEnt, "registered entity should include metadata")
+
+	// Invalid metadata should be rejected.
+	validMetadata := ent.Metadata
+	ent.Metadata = &entity.Metadata{
+		Name: "Entity",
+		URL:  "ftp://example.com",
+	}
+	err = register(&ent)
+	require.ErrorIs(err, registry.ErrInvalidArgument, "entity registration with invalid metadata should fail")
+
+	ent.Metadata = &entity.Metadata{}
+	err = register(&ent)
+	require.ErrorIs(err, registry.ErrInvalidArgument, "entity registration with empty metadata should fail")
+
+	regEnt, err = state.Entity(ctx, ent.ID)
+	require.NoError(err, "Entity")
+	require.EqualValues(validMetadata, regEnt.Metadata, "metadata should not change after failed updates")
+
+	// Metadata can be removed.
+	ent.Metadata = nil
+	err = register(&ent)
+	require.NoError(err, "entity registration without metadata should succeed")
+
+	regEnt, err = state.Entity(ctx, ent.ID)
+	require.NoError(err, "Entity")
+	require.Nil(regEnt.Metadata, "metadata should be removed")
+}
+
 func TestRegisterNode(t *testing.T) {
 	require := requirePkg.New(t)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
@@ -29,7 +30,7 @@ func (app *stakingApplication) GetSignerNonce(ctx context.Context, req *consensu
 
 // Implements api.TransactionAuthHandler.
 func (app *stakingApplication) AuthenticateTx(ctx *api.Context, tx *transaction.Transaction) error {
-	return stakingState.AuthenticateAndPayFees(ctx, ctx.TxSigner(), tx.Nonce, tx.Fee)
+	return stakingState.AuthenticateAndPayFees(ctx, ctx.TxSigner(), tx.Nonce, tx.Fee, tx.FeePayer)
 }
 
 // Implements api.TransactionAuthHandler.
@@ -56,16 +57,103 @@ func (app *stakingApplication) PostExecuteTx(ctx *api.Context, tx *transaction.T
 	if err != nil {
 		return fmt.Errorf("failed to fetch account state: %w", err)
 	}
+	account.General.Nonce++
+
+	// Fees are paid by the fee payer if one is specified, in which case its nonce is incremented
+	// as well.
+	payerAddr, payerAccount := addr, account
+	if tx.FeePayer != nil {
+		payerAddr = staking.NewAddress(tx.FeePayer.PublicKey)
+		if payerAccount, err = state.Account(ctx, payerAddr); err != nil {
+			return fmt.Errorf("failed to fetch fee payer account state: %w", err)
+		}
+		payerAccount.General.Nonce++
 
-	// Deduct fee and increment the nonce.
-	if err := account.General.Balance.Sub(&fee.Amount); err != nil {
+		if tx.FeePayer.UseAllowance {
+			if _, err = stakingState.ChargeFeeAllowance(payerAccount, addr, &fee.Amount); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Deduct fee.
+	if err = payerAccount.General.Balance.Sub(&fee.Amount); err != nil {
 		return transaction.ErrInsufficientFeeBalance
 	}
 
-	account.General.Nonce++
-	if err := state.SetAccount(ctx, addr, account); err != nil {
+	if err = state.SetAccount(ctx, addr, account); err != nil {
 		return fmt.Errorf("failed to set account: %w", err)
 	}
+	if tx.FeePayer != nil {
+		if err = state.SetAccount(ctx, payerAddr, payerAccount); err != nil {
+			return fmt.Errorf("failed to set fee payer account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Implements api.TransactionAuthHandler.
+func (app *stakingApplication) RevertPostExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
+	if !ctx.IsCheckOnly() {
+		return fmt.Errorf("staking: transactions can only be reverted in CheckTx")
+	}
+
+	state := stakingState.NewMutableState(ctx.State())
+
+	fee := tx.Fee
+	if fee == nil {
+		fee = &transaction.Fee{}
+	}
+
+	addr := staking.NewAddress(ctx.TxSigner())
+
+	account, err := state.Account(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account state: %w", err)
+	}
+	// Only the latest pending transaction can be reverted as otherwise nonces would have gaps.
+	if account.General.Nonce != tx.Nonce+1 {
+		return transaction.ErrInvalidNonce
+	}
+	account.General.Nonce--
+
+	payerAddr, payerAccount := addr, account
+	if tx.FeePayer != nil {
+		payerAddr = staking.NewAddress(tx.FeePayer.PublicKey)
+		if payerAccount, err = state.Account(ctx, payerAddr); err != nil {
+			return fmt.Errorf("failed to fetch fee payer account state: %w", err)
+		}
+		if payerAccount.General.Nonce != tx.FeePayer.Nonce+1 {
+			return transaction.ErrInvalidNonce
+		}
+		payerAccount.General.Nonce--
+
+		if tx.FeePayer.UseAllowance && !fee.Amount.IsZero() {
+			if payerAccount.General.Allowances == nil {
+				payerAccount.General.Allowances = make(map[staking.Address]quantity.Quantity)
+			}
+			allowance := payerAccount.General.Allowances[addr]
+			if err = allowance.Add(&fee.Amount); err != nil {
+				return fmt.Errorf("failed to refund allowance: %w", err)
+			}
+			payerAccount.General.Allowances[addr] = allowance
+		}
+	}
+
+	// Refund fee.
+	if err = payerAccount.General.Balance.Add(&fee.Amount); err != nil {
+		return fmt.Errorf("failed to refund fee: %w", err)
+	}
+
+	if err = state.SetAccount(ctx, addr, account); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+	if tx.FeePayer != nil {
+		if err = state.SetAccount(ctx, payerAddr, payerAccount); err != nil {
+			return fmt.Errorf("failed to set fee payer account: %w", err)
+		}
+	}
 
 	return nil
 }
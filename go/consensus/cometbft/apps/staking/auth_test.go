@@ -0,0 +1,95 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestRevertPostExecuteTx(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		MinGasPrice: quantity.NewFromUint64(1),
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr := staking.NewAddress(pk)
+	payerPk := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	payerAddr := staking.NewAddress(payerPk)
+	err = stakeState.SetAccount(ctx, addr, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(1_000),
+			Nonce:   5,
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetAccount(ctx, payerAddr, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(1_000),
+			Nonce:   7,
+			Allowances: map[staking.Address]quantity.Quantity{
+				addr: *quantity.NewFromUint64(500),
+			},
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	fee := &transaction.Fee{Gas: 10, Amount: *quantity.NewFromUint64(100)}
+	for _, tx := range []*transaction.Transaction{
+		{Nonce: 5, Fee: fee},
+		{Nonce: 5, Fee: fee, FeePayer: &transaction.FeePayer{PublicKey: payerPk, Nonce: 7, UseAllowance: true}},
+	} {
+		txCtx := appState.NewContext(abciAPI.ContextCheckTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk)
+
+		err = app.AuthenticateTx(txCtx, tx)
+		require.NoError(err, "AuthenticateTx")
+		err = app.PostExecuteTx(txCtx, tx)
+		require.NoError(err, "PostExecuteTx")
+
+		// Only the latest pending transaction can be reverted.
+		err = app.RevertPostExecuteTx(txCtx, &transaction.Transaction{Nonce: 4, Fee: fee})
+		require.ErrorIs(err, transaction.ErrInvalidNonce, "RevertPostExecuteTx should fail for older nonces")
+
+		err = app.RevertPostExecuteTx(txCtx, tx)
+		require.NoError(err, "RevertPostExecuteTx")
+
+		acct, err := stakeState.Account(txCtx, addr)
+		require.NoError(err, "Account")
+		require.EqualValues(5, acct.General.Nonce, "signer nonce should be reverted")
+		require.EqualValues(*quantity.NewFromUint64(1_000), acct.General.Balance, "fee should be refunded")
+
+		payer, err := stakeState.Account(txCtx, payerAddr)
+		require.NoError(err, "Account")
+		require.EqualValues(7, payer.General.Nonce, "fee payer nonce should be reverted")
+		require.EqualValues(*quantity.NewFromUint64(1_000), payer.General.Balance, "fee should be refunded")
+		require.EqualValues(*quantity.NewFromUint64(500), payer.General.Allowances[addr], "allowance should be refunded")
+	}
+
+	// Reverting outside CheckTx should fail.
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk)
+	err = app.RevertPostExecuteTx(txCtx, &transaction.Transaction{Nonce: 5, Fee: fee})
+	require.Error(err, "RevertPostExecuteTx should fail outside CheckTx")
+}
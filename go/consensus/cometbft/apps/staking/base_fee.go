@@ -0,0 +1,98 @@
+package staking
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// settleBaseFees burns the base fees collected in the current block or transfers them to the
+// common pool, depending on the consensus parameters.
+func (app *stakingApplication) settleBaseFees(
+	ctx *abciAPI.Context,
+	stakeState *stakingState.MutableState,
+	params *staking.ConsensusParameters,
+	baseFees *quantity.Quantity,
+) error {
+	if baseFees.IsZero() {
+		return nil
+	}
+
+	if params.BurnBaseFee {
+		totalSupply, err := stakeState.TotalSupply(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query total supply: %w", err)
+		}
+		if err = totalSupply.Sub(baseFees); err != nil {
+			return fmt.Errorf("burn base fees: %w", err)
+		}
+		if err = stakeState.SetTotalSupply(ctx, totalSupply); err != nil {
+			return fmt.Errorf("failed to set total supply: %w", err)
+		}
+
+		ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).TypedAttribute(&staking.BurnEvent{
+			Owner:  staking.FeeAccumulatorAddress,
+			Amount: *baseFees,
+		}))
+		return nil
+	}
+
+	commonPool, err := stakeState.CommonPool(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query common pool: %w", err)
+	}
+	if err = commonPool.Add(baseFees); err != nil {
+		return fmt.Errorf("add base fees: %w", err)
+	}
+	if err = stakeState.SetCommonPool(ctx, commonPool); err != nil {
+		return fmt.Errorf("failed to set common pool: %w", err)
+	}
+
+	ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+		From:   staking.FeeAccumulatorAddress,
+		To:     staking.CommonPoolAddress,
+		Amount: *baseFees,
+	}))
+	return nil
+}
+
+// updateBaseFee computes the base fee for the next block based on the amount of gas used in the
+// current block.
+func (app *stakingApplication) updateBaseFee(
+	ctx *abciAPI.Context,
+	stakeState *stakingState.MutableState,
+	params *staking.ConsensusParameters,
+) error {
+	baseFee, err := stakeState.BaseFee(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query base fee: %w", err)
+	}
+
+	if !params.BaseFeeEnabled() {
+		// Remove any leftover base fee in case the base fee has been disabled.
+		if baseFee.Next.IsZero() && baseFee.Current.IsZero() {
+			return nil
+		}
+		return stakeState.ClearBaseFee(ctx)
+	}
+
+	gasUsed := ctx.BlockContext().GasAccountant.GasUsed()
+	next, err := params.NextBaseFee(&baseFee.Next, gasUsed)
+	if err != nil {
+		return fmt.Errorf("failed to compute next base fee: %w", err)
+	}
+
+	ctx.Logger().Debug("updating base fee",
+		"gas_used", gasUsed,
+		"current", baseFee.Next,
+		"next", next,
+	)
+
+	return stakeState.SetBaseFee(ctx, &staking.BaseFee{
+		Current: baseFee.Next,
+		Next:    *next,
+	})
+}
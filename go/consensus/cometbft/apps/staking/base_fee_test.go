@@ -0,0 +1,107 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestBaseFee(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr := staking.NewAddress(pk)
+	err = stakeState.SetAccount(ctx, addr, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	params := &staking.ConsensusParameters{
+		BaseFeeTargetGas:            1_000,
+		BaseFeeMaxChangeDenominator: 8,
+		MinBaseFee:                  8,
+	}
+	err = stakeState.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+	err = stakeState.SetBaseFee(ctx, &staking.BaseFee{Next: *quantity.NewFromUint64(10)})
+	require.NoError(err, "SetBaseFee")
+
+	// Fees below the base fee should be rejected.
+	for _, kind := range []abciAPI.ContextMode{abciAPI.ContextCheckTx, abciAPI.ContextDeliverTx} {
+		txCtx := appState.NewContext(kind)
+		defer txCtx.Close()
+
+		err = stakingState.AuthenticateAndPayFees(txCtx, pk, 0, &transaction.Fee{
+			Gas:    100,
+			Amount: *quantity.NewFromUint64(999),
+		}, nil)
+		require.ErrorIs(err, transaction.ErrGasPriceTooLow, "fee below base fee should be rejected")
+	}
+
+	// Fees at or above the base fee should be accepted and the base fee portion set aside.
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	err = stakingState.AuthenticateAndPayFees(txCtx, pk, 0, &transaction.Fee{
+		Gas:    100,
+		Amount: *quantity.NewFromUint64(1_500),
+	}, nil)
+	require.NoError(err, "AuthenticateAndPayFees")
+
+	baseFees := stakingState.BlockBaseFees(ctx)
+	require.EqualValues(*quantity.NewFromUint64(1_000), baseFees, "base fees should be set aside")
+	fees := stakingState.BlockFees(ctx)
+	require.EqualValues(*quantity.NewFromUint64(500), fees, "remaining fees should go to validators")
+
+	// Base fees should be transferred to the common pool.
+	err = app.settleBaseFees(ctx, stakeState, params, &baseFees)
+	require.NoError(err, "settleBaseFees")
+	commonPool, err := stakeState.CommonPool(ctx)
+	require.NoError(err, "CommonPool")
+	require.EqualValues(quantity.NewFromUint64(1_000), commonPool, "base fees should go to the common pool")
+
+	// Base fees should be burned.
+	err = stakeState.SetTotalSupply(ctx, quantity.NewFromUint64(10_000))
+	require.NoError(err, "SetTotalSupply")
+	params.BurnBaseFee = true
+	err = app.settleBaseFees(ctx, stakeState, params, &baseFees)
+	require.NoError(err, "settleBaseFees")
+	totalSupply, err := stakeState.TotalSupply(ctx)
+	require.NoError(err, "TotalSupply")
+	require.EqualValues(quantity.NewFromUint64(9_000), totalSupply, "base fees should be burned")
+
+	// Base fee should decrease as the block did not use any gas.
+	err = app.updateBaseFee(ctx, stakeState, params)
+	require.NoError(err, "updateBaseFee")
+	baseFee, err := stakeState.BaseFee(ctx)
+	require.NoError(err, "BaseFee")
+	require.EqualValues(*quantity.NewFromUint64(10), baseFee.Current, "current base fee should be the applied one")
+	require.EqualValues(*quantity.NewFromUint64(9), baseFee.Next, "next base fee should decrease")
+
+	// Base fee should be removed when disabled.
+	params.BaseFeeTargetGas = 0
+	err = app.updateBaseFee(ctx, stakeState, params)
+	require.NoError(err, "updateBaseFee")
+	baseFee, err = stakeState.BaseFee(ctx)
+	require.NoError(err, "BaseFee")
+	require.True(baseFee.Next.IsZero(), "base fee should be zero when disabled")
+}
@@ -63,6 +63,26 @@ func (app *stakingApplication) initLastBlockFees(ctx *abciAPI.Context, st *staki
 	return nil
 }
 
+func (app *stakingApplication) initBaseFee(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis) error {
+	if !st.Parameters.BaseFeeEnabled() {
+		return nil
+	}
+
+	baseFee := quantity.NewFromUint64(st.Parameters.MinBaseFee)
+	if st.BaseFee != nil {
+		if !st.BaseFee.IsValid() {
+			return fmt.Errorf("cometbft/staking: invalid genesis state BaseFee")
+		}
+		if st.BaseFee.Cmp(baseFee) > 0 {
+			baseFee = st.BaseFee.Clone()
+		}
+	}
+	if err := state.SetBaseFee(ctx, &staking.BaseFee{Next: *baseFee}); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set base fee: %w", err)
+	}
+	return nil
+}
+
 func (app *stakingApplication) initGovernanceDeposits(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis, totalSupply *quantity.Quantity) error {
 	if !st.GovernanceDeposits.IsValid() {
 		return fmt.Errorf("cometbft/staking: invalid genesis state GovernanceDeposits")
@@ -310,6 +330,10 @@ func (app *stakingApplication) InitChain(ctx *abciAPI.Context, request types.Req
 		return err
 	}
 
+	if err := app.initBaseFee(ctx, state, st); err != nil {
+		return err
+	}
+
 	if err := app.initLedger(ctx, state, st, &totalSupply); err != nil {
 		return err
 	}
@@ -356,6 +380,11 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		return nil, err
 	}
 
+	baseFee, err := sq.state.BaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	addresses, err := sq.state.Addresses(ctx)
 	if err != nil {
 		return nil, err
@@ -397,5 +426,8 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		Delegations:          delegations,
 		DebondingDelegations: debondingDelegations,
 	}
+	if !baseFee.Next.IsZero() {
+		gen.BaseFee = &baseFee.Next
+	}
 	return &gen, nil
 }
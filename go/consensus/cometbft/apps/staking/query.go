@@ -5,6 +5,8 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -16,6 +18,7 @@ type Query interface {
 	CommonPool(context.Context) (*quantity.Quantity, error)
 	LastBlockFees(context.Context) (*quantity.Quantity, error)
 	GovernanceDeposits(context.Context) (*quantity.Quantity, error)
+	BaseFee(context.Context) (*staking.BaseFee, error)
 	Threshold(context.Context, staking.ThresholdKind) (*quantity.Quantity, error)
 	DebondingInterval(context.Context) (beacon.EpochTime, error)
 	Addresses(context.Context) ([]staking.Address, error)
@@ -45,6 +48,18 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 	return &stakingQuerier{state}, nil
 }
 
+// StateProofAt returns a proof of the given state keys and of all keys under the given key
+// prefixes at a specific height.
+func (sf *QueryFactory) StateProofAt(
+	ctx context.Context,
+	backend consensus.ClientBackend,
+	height int64,
+	keys [][]byte,
+	prefixes [][]byte,
+) (*proof.StateProof, error) {
+	return abciAPI.NewStateProof(ctx, backend, sf.state, height, keys, prefixes)
+}
+
 type stakingQuerier struct {
 	state *stakingState.ImmutableState
 }
@@ -65,6 +80,10 @@ func (sq *stakingQuerier) GovernanceDeposits(ctx context.Context) (*quantity.Qua
 	return sq.state.GovernanceDeposits(ctx)
 }
 
+func (sq *stakingQuerier) BaseFee(ctx context.Context) (*staking.BaseFee, error) {
+	return sq.state.BaseFee(ctx)
+}
+
 func (sq *stakingQuerier) Threshold(ctx context.Context, kind staking.ThresholdKind) (*quantity.Quantity, error) {
 	thresholds, err := sq.state.Thresholds(ctx)
 	if err != nil {
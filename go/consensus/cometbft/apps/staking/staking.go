@@ -213,8 +213,22 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 }
 
 func (app *stakingApplication) EndBlock(ctx *api.Context) (types.ResponseEndBlock, error) {
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	params, err := stakeState.ConsensusParameters(ctx)
+	if err != nil {
+		return types.ResponseEndBlock{}, fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	baseFees := stakingState.BlockBaseFees(ctx)
+	if err = app.settleBaseFees(ctx, stakeState, params, &baseFees); err != nil {
+		return types.ResponseEndBlock{}, fmt.Errorf("settle base fees: %w", err)
+	}
+	if err = app.updateBaseFee(ctx, stakeState, params); err != nil {
+		return types.ResponseEndBlock{}, fmt.Errorf("update base fee: %w", err)
+	}
+
 	fees := stakingState.BlockFees(ctx)
-	if err := app.disburseFeesP(ctx, stakingState.NewMutableState(ctx.State()), stakingState.BlockProposer(ctx), &fees); err != nil {
+	if err = app.disburseFeesP(ctx, stakeState, stakingState.BlockProposer(ctx), &fees); err != nil {
 		return types.ResponseEndBlock{}, fmt.Errorf("disburse fees proposer: %w", err)
 	}
 
@@ -21,12 +21,16 @@ func (fak feeAccumulatorKey) NewDefault() interface{} {
 // feeAccumulator is the per-block fee accumulator that gets all fees paid
 // in a block.
 type feeAccumulator struct {
-	balance quantity.Quantity
+	balance  quantity.Quantity
+	baseFees quantity.Quantity
 }
 
 // AuthenticateAndPayFees authenticates the message signer and makes sure that
 // any gas fees are paid.
 //
+// In case a fee payer is given, the fees are paid by the fee payer instead of
+// the signer and the nonces of both accounts are checked and incremented.
+//
 // This method transfers the fees to the per-block fee accumulator which is
 // persisted at the end of the block.
 func AuthenticateAndPayFees(
@@ -34,6 +38,7 @@ func AuthenticateAndPayFees(
 	signer signature.PublicKey,
 	nonce uint64,
 	fee *transaction.Fee,
+	feePayer *transaction.FeePayer,
 ) error {
 	state := NewMutableState(ctx.State())
 
@@ -52,17 +57,26 @@ func AuthenticateAndPayFees(
 	}
 
 	// Fetch account and make sure the nonce is correct.
-	account, err := state.Account(ctx, addr)
+	account, err := fetchAccountWithNonce(ctx, state, addr, nonce)
 	if err != nil {
-		return fmt.Errorf("failed to fetch account state: %w", err)
+		return err
 	}
-	if account.General.Nonce != nonce {
-		logger.Error("invalid account nonce",
-			"account_addr", addr,
-			"account_nonce", account.General.Nonce,
-			"nonce", nonce,
-		)
-		return transaction.ErrInvalidNonce
+
+	// Fetch the fee payer account and make sure the nonce is correct.
+	payerAddr, payerAccount := addr, account
+	if feePayer != nil {
+		payerAddr = staking.NewAddress(feePayer.PublicKey)
+		if payerAddr.IsReserved() {
+			return fmt.Errorf("using reserved account address %s is prohibited", payerAddr)
+		}
+		if payerAddr.Equal(addr) {
+			return transaction.ErrInvalidFeePayer
+		}
+
+		payerAccount, err = fetchAccountWithNonce(ctx, state, payerAddr, feePayer.Nonce)
+		if err != nil {
+			return err
+		}
 	}
 
 	if fee == nil {
@@ -80,16 +94,33 @@ func AuthenticateAndPayFees(
 	}
 
 	// Check against minimum balance plus fee.
-	if account.General.Balance.Cmp(needed) < 0 {
+	if payerAccount.General.Balance.Cmp(needed) < 0 {
 		logger.Error("account balance too low",
-			"account_addr", addr,
-			"account_balance", account.General.Balance,
+			"account_addr", payerAddr,
+			"account_balance", payerAccount.General.Balance,
 			"min_transact_balance", params.MinTransactBalance,
 			"fee_amount", fee.Amount,
 		)
 		return staking.ErrBalanceTooLow
 	}
 
+	// Charge the fee against the allowance granted by the fee payer if requested.
+	var allowance *quantity.Quantity
+	if feePayer != nil && feePayer.UseAllowance {
+		if allowance, err = ChargeFeeAllowance(payerAccount, addr, &fee.Amount); err != nil {
+			return err
+		}
+	}
+
+	// Check fee against the dynamic base fee.
+	baseFee, err := state.BaseFee(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch base fee: %w", err)
+	}
+	if fee.Gas > 0 && fee.GasPrice().Cmp(&baseFee.Next) < 0 {
+		return transaction.ErrGasPriceTooLow
+	}
+
 	if ctx.IsCheckOnly() {
 		// Configure gas accountant on the context so that we can report gas wanted.
 		ctx.SetGasAccountant(abciAPI.NewGasAccountant(fee.Gas))
@@ -109,19 +140,46 @@ func AuthenticateAndPayFees(
 
 	// Transfer fee to per-block fee accumulator.
 	feeAcc := ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator)
-	if err = quantity.Move(&feeAcc.balance, &account.General.Balance, &fee.Amount); err != nil {
+	if err = quantity.Move(&feeAcc.balance, &payerAccount.General.Balance, &fee.Amount); err != nil {
 		return fmt.Errorf("staking: failed to pay fees: %w", err)
 	}
+	// Set aside the base fee portion of the fee which is not paid to validators.
+	if !baseFee.Next.IsZero() {
+		baseFeeAmount := baseFee.Next.Clone()
+		if err = baseFeeAmount.Mul(quantity.NewFromUint64(uint64(fee.Gas))); err != nil {
+			return fmt.Errorf("staking: failed to compute base fee amount: %w", err)
+		}
+		if err = quantity.Move(&feeAcc.baseFees, &feeAcc.balance, baseFeeAmount); err != nil {
+			return fmt.Errorf("staking: failed to pay base fee: %w", err)
+		}
+	}
 
 	account.General.Nonce++
-	if err := state.SetAccount(ctx, addr, account); err != nil {
+	if err = state.SetAccount(ctx, addr, account); err != nil {
 		return fmt.Errorf("failed to set account: %w", err)
 	}
+	if feePayer != nil {
+		payerAccount.General.Nonce++
+		if err = state.SetAccount(ctx, payerAddr, payerAccount); err != nil {
+			return fmt.Errorf("failed to set fee payer account: %w", err)
+		}
+	}
+
+	// Emit allowance change event if the fee was charged against an allowance.
+	if allowance != nil && !fee.Amount.IsZero() {
+		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.AllowanceChangeEvent{
+			Owner:        payerAddr,
+			Beneficiary:  addr,
+			Allowance:    *allowance,
+			Negative:     true,
+			AmountChange: fee.Amount,
+		}))
+	}
 
 	// Emit transfer event if fee is non-zero.
 	if !fee.Amount.IsZero() {
 		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.TransferEvent{
-			From:   addr,
+			From:   payerAddr,
 			To:     staking.FeeAccumulatorAddress,
 			Amount: fee.Amount,
 		}))
@@ -136,12 +194,60 @@ func AuthenticateAndPayFees(
 	return nil
 }
 
-// BlockFees returns the accumulated fee balance for the current block.
+// fetchAccountWithNonce fetches the given account and makes sure that its nonce matches.
+func fetchAccountWithNonce(
+	ctx *abciAPI.Context,
+	state *MutableState,
+	addr staking.Address,
+	nonce uint64,
+) (*staking.Account, error) {
+	account, err := state.Account(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account state: %w", err)
+	}
+	if account.General.Nonce != nonce {
+		logger.Error("invalid account nonce",
+			"account_addr", addr,
+			"account_nonce", account.General.Nonce,
+			"nonce", nonce,
+		)
+		return nil, transaction.ErrInvalidNonce
+	}
+	return account, nil
+}
+
+// ChargeFeeAllowance deducts the given fee amount from the allowance that the fee payer account
+// has granted to the beneficiary and returns the remaining allowance. The allowance is removed
+// once it reaches zero.
+//
+// The fee payer account is only modified in memory, the caller is responsible for persisting it.
+func ChargeFeeAllowance(payer *staking.Account, beneficiary staking.Address, amount *quantity.Quantity) (*quantity.Quantity, error) {
+	allowance, ok := payer.General.Allowances[beneficiary]
+	if !ok {
+		return nil, staking.ErrForbidden
+	}
+	if err := allowance.Sub(amount); err != nil {
+		return nil, staking.ErrForbidden
+	}
+	if allowance.IsZero() {
+		delete(payer.General.Allowances, beneficiary)
+	} else {
+		payer.General.Allowances[beneficiary] = allowance
+	}
+	return &allowance, nil
+}
+
+// BlockFees returns the accumulated fee balance for the current block, excluding base fees.
 func BlockFees(ctx *abciAPI.Context) quantity.Quantity {
 	// Fetch accumulated fees in the current block.
 	return ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator).balance
 }
 
+// BlockBaseFees returns the accumulated base fee balance for the current block.
+func BlockBaseFees(ctx *abciAPI.Context) quantity.Quantity {
+	return ctx.BlockContext().Get(feeAccumulatorKey{}).(*feeAccumulator).baseFees
+}
+
 // proposerKey is the block context key.
 type proposerKey struct{}
 
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestAuthenticateAndPayFeesFeePayer(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+	err = s.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	signer := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	signerAddr := staking.NewAddress(signer)
+	payer := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	payerAddr := staking.NewAddress(payer)
+
+	err = s.SetAccount(ctx, signerAddr, &staking.Account{
+		General: staking.GeneralAccount{
+			Nonce: 5,
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = s.SetAccount(ctx, payerAddr, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(1_000),
+			Nonce:   10,
+			Allowances: map[staking.Address]quantity.Quantity{
+				signerAddr: *quantity.NewFromUint64(150),
+			},
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+
+	fee := &transaction.Fee{
+		Gas:    10,
+		Amount: *quantity.NewFromUint64(100),
+	}
+
+	// Signer cannot be its own fee payer.
+	err = AuthenticateAndPayFees(txCtx, signer, 5, fee, &transaction.FeePayer{PublicKey: signer, Nonce: 5})
+	require.ErrorIs(err, transaction.ErrInvalidFeePayer, "signer should not be its own fee payer")
+
+	// Fee payer nonce must be correct.
+	err = AuthenticateAndPayFees(txCtx, signer, 5, fee, &transaction.FeePayer{PublicKey: payer, Nonce: 9})
+	require.ErrorIs(err, transaction.ErrInvalidNonce, "invalid fee payer nonce should be rejected")
+
+	// Fee should be paid by the fee payer and charged against the allowance.
+	feePayer := &transaction.FeePayer{PublicKey: payer, Nonce: 10, UseAllowance: true}
+	err = AuthenticateAndPayFees(txCtx, signer, 5, fee, feePayer)
+	require.NoError(err, "AuthenticateAndPayFees")
+
+	signerAcct, err := s.Account(ctx, signerAddr)
+	require.NoError(err, "Account")
+	require.EqualValues(6, signerAcct.General.Nonce, "signer nonce should be incremented")
+	require.True(signerAcct.General.Balance.IsZero(), "signer balance should not change")
+
+	payerAcct, err := s.Account(ctx, payerAddr)
+	require.NoError(err, "Account")
+	require.EqualValues(11, payerAcct.General.Nonce, "fee payer nonce should be incremented")
+	require.EqualValues(*quantity.NewFromUint64(900), payerAcct.General.Balance, "fee payer should pay the fee")
+	require.EqualValues(*quantity.NewFromUint64(50), payerAcct.General.Allowances[signerAddr], "fee should be charged against the allowance")
+	require.EqualValues(*quantity.NewFromUint64(100), BlockFees(ctx), "fee should be accumulated")
+
+	// Fee exceeding the remaining allowance should be rejected.
+	feePayer.Nonce = 11
+	err = AuthenticateAndPayFees(txCtx, signer, 6, fee, feePayer)
+	require.ErrorIs(err, staking.ErrForbidden, "fee exceeding the allowance should be rejected")
+
+	// Without using the allowance, the fee payer balance is the only limit.
+	feePayer.UseAllowance = false
+	err = AuthenticateAndPayFees(txCtx, signer, 6, fee, feePayer)
+	require.NoError(err, "AuthenticateAndPayFees")
+
+	payerAcct, err = s.Account(ctx, payerAddr)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(800), payerAcct.General.Balance, "fee payer should pay the fee")
+	require.EqualValues(*quantity.NewFromUint64(50), payerAcct.General.Allowances[signerAddr], "allowance should not change")
+}
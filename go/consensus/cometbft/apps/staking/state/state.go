@@ -79,9 +79,25 @@ var (
 	// Value is empty.
 	commissionScheduleAddressesKeyFmt = keyformat.New(0x5B, &staking.Address{})
 
+	// baseFeeKeyFmt is the key format used for the dynamic base fee.
+	//
+	// Value is CBOR-serialized staking.BaseFee.
+	baseFeeKeyFmt = keyformat.New(0x5C)
+
 	logger = logging.GetLogger("cometbft/staking")
 )
 
+// AccountKey returns the state key of the staking account for the given address.
+func AccountKey(address staking.Address) []byte {
+	return accountKeyFmt.Encode(&address)
+}
+
+// DelegationsForKeyPrefix returns the state key prefix of all (outgoing) delegations
+// of the given delegator.
+func DelegationsForKeyPrefix(delegatorAddr staking.Address) []byte {
+	return delegationKeyReverseFmt.Encode(&delegatorAddr)
+}
+
 // ImmutableState is the immutable staking state wrapper.
 type ImmutableState struct {
 	is *abciAPI.ImmutableState
@@ -521,6 +537,24 @@ func (s *ImmutableState) GovernanceDeposits(ctx context.Context) (*quantity.Quan
 	return s.loadStoredBalance(ctx, governanceDepositsKeyFmt)
 }
 
+// BaseFee returns the dynamic base fee. If the base fee is disabled, both current and next base
+// fees are zero.
+func (s *ImmutableState) BaseFee(ctx context.Context) (*staking.BaseFee, error) {
+	value, err := s.is.Get(ctx, baseFeeKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return &staking.BaseFee{}, nil
+	}
+
+	var baseFee staking.BaseFee
+	if err = cbor.Unmarshal(value, &baseFee); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &baseFee, nil
+}
+
 type EpochSigning struct {
 	Total    uint64
 	ByEntity map[signature.PublicKey]uint64
@@ -727,6 +761,18 @@ func (s *MutableState) SetLastBlockFees(ctx context.Context, q *quantity.Quantit
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetBaseFee sets the dynamic base fee.
+func (s *MutableState) SetBaseFee(ctx context.Context, baseFee *staking.BaseFee) error {
+	err := s.ms.Insert(ctx, baseFeeKeyFmt.Encode(), cbor.Marshal(baseFee))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// ClearBaseFee removes the dynamic base fee.
+func (s *MutableState) ClearBaseFee(ctx context.Context) error {
+	err := s.ms.Remove(ctx, baseFeeKeyFmt.Encode())
+	return abciAPI.UnavailableStateError(err)
+}
+
 func (s *MutableState) SetEpochSigning(ctx context.Context, es *EpochSigning) error {
 	err := s.ms.Insert(ctx, epochSigningKeyFmt.Encode(), cbor.Marshal(es))
 	return abciAPI.UnavailableStateError(err)
@@ -1163,6 +1209,45 @@ func (s *MutableState) DiscardGovernanceDeposit(
 	return nil
 }
 
+// BurnGovernanceDeposit burns the amount from the governance deposits pool.
+func (s *MutableState) BurnGovernanceDeposit(
+	ctx *abciAPI.Context,
+	amount *quantity.Quantity,
+) error {
+	totalSupply, err := s.TotalSupply(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/staking: failed to query total supply: %w", err)
+	}
+
+	deposits, err := s.GovernanceDeposits(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/staking: failed to query governance deposit %w", err)
+	}
+
+	if err = deposits.Sub(amount); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to burn governance deposit: %w", err)
+	}
+	if err = totalSupply.Sub(amount); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to burn governance deposit: %w", err)
+	}
+
+	if err = s.SetGovernanceDeposits(ctx, deposits); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set governance deposits: %w", err)
+	}
+	if err = s.SetTotalSupply(ctx, totalSupply); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set total supply: %w", err)
+	}
+
+	if !ctx.IsCheckOnly() {
+		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.BurnEvent{
+			Owner:  staking.GovernanceDepositsAddress,
+			Amount: *amount,
+		}))
+	}
+
+	return nil
+}
+
 // AddRewards computes and transfers a staking reward to active escrow accounts.
 // If the common pool runs out, the rewards that are too big to be paid out are
 // skipped and no error is returned.
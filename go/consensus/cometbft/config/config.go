@@ -81,7 +81,8 @@ type P2PConfig struct {
 
 // SubmissionConfig is the transaction submission configuration.
 type SubmissionConfig struct {
-	// Gas price used when submitting consensus transactions.
+	// Gas price used when submitting consensus transactions. If the dynamic base fee is higher,
+	// the base fee is used instead.
 	GasPrice uint64 `yaml:"gas_price"`
 	// Max transaction fee when submitting consensus transactions.
 	MaxFee uint64 `yaml:"max_fee"`
@@ -137,6 +138,9 @@ type DebugConfig struct {
 
 	// Disable populating seed node address book with genesis validators.
 	DisableAddrBookFromGenesis bool `yaml:"disable_addr_book_from_genesis,omitempty"`
+
+	// Offset added to the timestamps of signed votes to simulate clock skew (UNSAFE).
+	ClockSkew time.Duration `yaml:"clock_skew,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -224,6 +228,7 @@ func DefaultConfig() Config {
 			P2PAllowDuplicateIP:             false,
 			UnsafeReplayRecoverCorruptedWAL: false,
 			DisableAddrBookFromGenesis:      false,
+			ClockSkew:                       0,
 		},
 	}
 }
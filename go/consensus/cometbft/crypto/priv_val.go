@@ -377,3 +377,27 @@ func LoadOrGeneratePrivVal(baseDir string, signer signature.Signer) (cmttypes.Pr
 
 	return pv, nil
 }
+
+type skewedPrivVal struct {
+	cmttypes.PrivValidator
+
+	skew time.Duration
+}
+
+func (pv *skewedPrivVal) SignVote(chainID string, vote *cmtproto.Vote) error {
+	// CometBFT uses the timestamp of the signed vote, so this affects the BFT time. Proposal
+	// timestamps are left unchanged as CometBFT only copies back their signature.
+	vote.Timestamp = vote.Timestamp.Add(pv.skew)
+	return pv.PrivValidator.SignVote(chainID, vote)
+}
+
+// NewSkewedPrivVal wraps a private validator so that the timestamps of all signed votes are
+// offset by the given duration, simulating a skewed local clock.
+//
+// This is only meant to be used for testing.
+func NewSkewedPrivVal(pv cmttypes.PrivValidator, skew time.Duration) cmttypes.PrivValidator {
+	return &skewedPrivVal{
+		PrivValidator: pv,
+		skew:          skew,
+	}
+}
@@ -0,0 +1,38 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestSkewedPrivVal(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("skewed priv val test")
+	pv, err := LoadOrGeneratePrivVal(t.TempDir(), signer)
+	require.NoError(err, "LoadOrGeneratePrivVal")
+
+	skew := 5 * time.Second
+	spv := NewSkewedPrivVal(pv, skew)
+
+	ts := time.Now().UTC()
+	vote := &cmtproto.Vote{
+		Type:      cmtproto.PrevoteType,
+		Height:    1,
+		Round:     0,
+		Timestamp: ts,
+	}
+	err = spv.SignVote("chain", vote)
+	require.NoError(err, "SignVote")
+	require.Equal(ts.Add(skew), vote.Timestamp, "vote timestamp should be skewed")
+
+	pk, err := spv.GetPubKey()
+	require.NoError(err, "GetPubKey")
+	require.True(pk.VerifySignature(cmttypes.VoteSignBytes("chain", vote), vote.Signature), "signature should cover the skewed timestamp")
+}
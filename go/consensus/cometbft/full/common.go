@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 
 	dbm "github.com/cometbft/cometbft-db"
+	cmtabcitypes "github.com/cometbft/cometbft/abci/types"
 	cmtmerkle "github.com/cometbft/cometbft/crypto/merkle"
 	cmtcore "github.com/cometbft/cometbft/rpc/core"
 	cmtcoretypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -28,6 +29,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
@@ -679,61 +681,98 @@ func (n *commonNode) GetTransactionsWithResults(ctx context.Context, height int6
 			},
 		}
 
-		// Transaction staking events.
-		stakingEvents, err := tmstaking.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
+		// Transaction events.
+		if result.Events, err = resultEventsFromCometBFT(txsWithResults.Transactions[txIdx], blk.Height, rs.Events); err != nil {
 			return nil, err
 		}
-		for _, e := range stakingEvents {
-			result.Events = append(result.Events, &results.Event{Staking: e})
-		}
 
-		// Transaction registry events.
-		registryEvents, _, err := tmregistry.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range registryEvents {
-			result.Events = append(result.Events, &results.Event{Registry: e})
+		// Per-call results in case of batch transactions.
+		for _, callEvents := range splitBatchCallEvents(rs.Events) {
+			callResult := &results.Result{}
+			if callResult.Events, err = resultEventsFromCometBFT(txsWithResults.Transactions[txIdx], blk.Height, callEvents); err != nil {
+				return nil, err
+			}
+			result.Calls = append(result.Calls, callResult)
 		}
 
-		// Transaction roothash events.
-		roothashEvents, err := tmroothash.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range roothashEvents {
-			result.Events = append(result.Events, &results.Event{RootHash: e})
-		}
+		txsWithResults.Results = append(txsWithResults.Results, result)
+	}
+	return &txsWithResults, nil
+}
 
-		// Transaction governance events.
-		governanceEvents, err := tmgovernance.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range governanceEvents {
-			result.Events = append(result.Events, &results.Event{Governance: e})
+// resultEventsFromCometBFT converts CometBFT events of a transaction into transaction result events.
+func resultEventsFromCometBFT(tx cmttypes.Tx, height int64, tmEvents []cmtabcitypes.Event) ([]*results.Event, error) {
+	var events []*results.Event
+
+	// Transaction staking events.
+	stakingEvents, err := tmstaking.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range stakingEvents {
+		events = append(events, &results.Event{Staking: e})
+	}
+
+	// Transaction registry events.
+	registryEvents, _, err := tmregistry.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range registryEvents {
+		events = append(events, &results.Event{Registry: e})
+	}
+
+	// Transaction roothash events.
+	roothashEvents, err := tmroothash.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range roothashEvents {
+		events = append(events, &results.Event{RootHash: e})
+	}
+
+	// Transaction governance events.
+	governanceEvents, err := tmgovernance.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range governanceEvents {
+		events = append(events, &results.Event{Governance: e})
+	}
+
+	return events, nil
+}
+
+// splitBatchCallEvents splits the CometBFT events of a batch transaction into per-call events
+// based on the batch call marker events. Events emitted before the first call (e.g., fee
+// payments) are not attributed to any call.
+//
+// Returns nil in case the events do not belong to a successful batch transaction.
+func splitBatchCallEvents(tmEvents []cmtabcitypes.Event) [][]cmtabcitypes.Event {
+	eventType := api.EventTypeForApp(consensusAPI.ModuleName)
+
+	var calls [][]cmtabcitypes.Event
+	for _, tmEv := range tmEvents {
+		if tmEv.GetType() == eventType {
+			var isMarker bool
+			for _, pair := range tmEv.GetAttributes() {
+				if eventsAPI.IsAttributeKind(pair.GetKey(), &consensusAPI.BatchCallEvent{}) {
+					isMarker = true
+					break
+				}
+			}
+			if isMarker {
+				calls = append(calls, nil)
+				continue
+			}
 		}
 
-		txsWithResults.Results = append(txsWithResults.Results, result)
+		if len(calls) == 0 {
+			continue
+		}
+		calls[len(calls)-1] = append(calls[len(calls)-1], tmEv)
 	}
-	return &txsWithResults, nil
+	return calls
 }
 
 // Implements consensusAPI.Backend.
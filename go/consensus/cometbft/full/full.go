@@ -32,9 +32,11 @@ import (
 	beaconAPI "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/random"
 	"github.com/oasisprotocol/oasis-core/go/config"
@@ -72,7 +74,8 @@ const (
 )
 
 var (
-	_ api.Backend = (*fullService)(nil)
+	_ api.Backend                = (*fullService)(nil)
+	_ consensusAPI.P2PController = (*fullService)(nil)
 
 	labelCometBFT = prometheus.Labels{"backend": "cometbft"}
 )
@@ -92,6 +95,8 @@ type fullService struct { // nolint: maligned
 
 	submissionMgr consensusAPI.SubmissionManager
 
+	blockedPeers *peerBlocklist
+
 	genesisProvider genesisAPI.Provider
 	syncedCh        chan struct{}
 	quitCh          chan struct{}
@@ -383,6 +388,46 @@ func (t *fullService) GetUnconfirmedTransactions(ctx context.Context) ([][]byte,
 	return txs, nil
 }
 
+// Implements consensusAPI.P2PController.
+func (t *fullService) DialPeers(addrs []node.ConsensusAddress) error {
+	if !t.started() {
+		return fmt.Errorf("cometbft: not yet started")
+	}
+
+	rawAddrs := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		t.blockedPeers.unblock(consensusPeerID(addr.ID))
+		rawAddrs = append(rawAddrs, addr.String())
+	}
+	tmAddrs, err := tmcommon.ConsensusAddressesToCometBFT(rawAddrs)
+	if err != nil {
+		return fmt.Errorf("cometbft: failed to convert peer addresses: %w", err)
+	}
+
+	if err = t.node.Switch().DialPeersAsync(tmAddrs); err != nil {
+		return fmt.Errorf("cometbft: failed to dial peers: %w", err)
+	}
+	return nil
+}
+
+// Implements consensusAPI.P2PController.
+func (t *fullService) DisconnectPeer(id signature.PublicKey) error {
+	if !t.started() {
+		return fmt.Errorf("cometbft: not yet started")
+	}
+
+	// Block the peer first so that the switch does not reconnect to it, even if it is
+	// a persistent peer.
+	peerID := consensusPeerID(id)
+	t.blockedPeers.block(peerID)
+
+	sw := t.node.Switch()
+	if peer := sw.Peers().Get(peerID); peer != nil {
+		sw.StopPeerGracefully(peer)
+	}
+	return nil
+}
+
 // Implements consensusAPI.Backend.
 func (t *fullService) GetStatus(ctx context.Context) (*consensusAPI.Status, error) {
 	status, err := t.commonNode.GetStatus(ctx)
@@ -639,6 +684,12 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 	if err != nil {
 		return err
 	}
+	if skew := config.GlobalConfig.Consensus.Debug.ClockSkew; skew != 0 && cmflags.DebugDontBlameOasis() {
+		t.Logger.Warn("simulating clock skew in signed votes",
+			"clock_skew", skew,
+		)
+		cometbftPV = crypto.NewSkewedPrivVal(cometbftPV, skew)
+	}
 
 	tmGenDoc, err := api.GetCometBFTGenesisDocument(t.genesisProvider)
 	if err != nil {
@@ -755,6 +806,10 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 			return fmt.Errorf("cometbft: internal error: state database not set")
 		}
 		t.client = cmtcli.New(t.node)
+
+		// Reject connections with peers blocked via DisconnectPeer. No other peer filters are
+		// configured as peer filtering via ABCI queries is disabled.
+		cmtp2p.SwitchPeerFilters(t.blockedPeers.filter)(t.node.Switch())
 		t.failMonitor = newFailMonitor(t.ctx, t.Logger, t.node.ConsensusState().Wait)
 
 		// Register a halt hook that handles upgrades gracefully.
@@ -921,6 +976,20 @@ func (t *fullService) metrics() {
 	}
 }
 
+// baseFeeQuerier queries the base fee via the staking backend which is only available after the
+// consensus services have been initialized.
+type baseFeeQuerier struct {
+	t *fullService
+}
+
+func (q *baseFeeQuerier) BaseFee(ctx context.Context, height int64) (*stakingAPI.BaseFee, error) {
+	backend := q.t.Staking()
+	if backend == nil {
+		return nil, consensusAPI.ErrNoCommittedBlocks
+	}
+	return backend.BaseFee(ctx, height)
+}
+
 // New creates a new CometBFT consensus backend.
 func New(
 	ctx context.Context,
@@ -938,6 +1007,7 @@ func New(
 		commonNode:      commonNode,
 		upgrader:        upgrader,
 		blockNotifier:   pubsub.NewBroker(false),
+		blockedPeers:    newPeerBlocklist(),
 		genesisProvider: genesisProvider,
 		syncedCh:        make(chan struct{}),
 		quitCh:          make(chan struct{}),
@@ -948,7 +1018,7 @@ func New(
 	t.Logger.Info("starting a full consensus node")
 
 	// Create the submission manager.
-	pd, err := consensusAPI.NewStaticPriceDiscovery(config.GlobalConfig.Consensus.Submission.GasPrice)
+	pd, err := consensusAPI.NewBaseFeePriceDiscovery(&baseFeeQuerier{t}, config.GlobalConfig.Consensus.Submission.GasPrice)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft: failed to create submission manager: %w", err)
 	}
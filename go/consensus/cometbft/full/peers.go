@@ -0,0 +1,64 @@
+package full
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	cmtp2p "github.com/cometbft/cometbft/p2p"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
+)
+
+// peerBlocklist is a set of consensus peers with which all connections are rejected.
+//
+// Blocking is enforced via a switch peer filter, so it also covers connections made by the
+// switch itself (e.g., redialing of persistent peers, inbound connections and peer exchange).
+type peerBlocklist struct {
+	sync.RWMutex
+
+	peers map[cmtp2p.ID]struct{}
+}
+
+func (b *peerBlocklist) block(id cmtp2p.ID) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.peers[id] = struct{}{}
+}
+
+func (b *peerBlocklist) unblock(id cmtp2p.ID) {
+	b.Lock()
+	defer b.Unlock()
+
+	delete(b.peers, id)
+}
+
+func (b *peerBlocklist) isBlocked(id cmtp2p.ID) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	_, blocked := b.peers[id]
+	return blocked
+}
+
+// filter is a switch peer filter which rejects blocked peers.
+func (b *peerBlocklist) filter(_ cmtp2p.IPeerSet, p cmtp2p.Peer) error {
+	if b.isBlocked(p.ID()) {
+		return fmt.Errorf("peer %s is blocked", p.ID())
+	}
+	return nil
+}
+
+func newPeerBlocklist() *peerBlocklist {
+	return &peerBlocklist{
+		peers: make(map[cmtp2p.ID]struct{}),
+	}
+}
+
+// consensusPeerID returns the CometBFT peer ID of the given consensus peer.
+func consensusPeerID(id signature.PublicKey) cmtp2p.ID {
+	// ID needs to be lowercase, see tmcommon.PublicKeysToCometBFT.
+	return cmtp2p.ID(strings.ToLower(crypto.PublicKeyToCometBFT(&id).Address().String()))
+}
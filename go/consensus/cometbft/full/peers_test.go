@@ -0,0 +1,39 @@
+package full
+
+import (
+	"net"
+	"testing"
+
+	cmtp2p "github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/mock"
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
+)
+
+func TestPeerBlocklist(t *testing.T) {
+	require := require.New(t)
+
+	unhealthy := mock.NewPeer(net.ParseIP("127.0.0.1"))
+	healthy := mock.NewPeer(net.ParseIP("127.0.0.2"))
+
+	b := newPeerBlocklist()
+	b.block(unhealthy.ID())
+
+	// A blocked peer should be rejected on every (re)connection attempt until unblocked.
+	for i := 0; i < 3; i++ {
+		require.Error(b.filter(nil, unhealthy), "blocked peer should be rejected")
+		require.NoError(b.filter(nil, healthy), "other peers should be accepted")
+	}
+
+	b.unblock(unhealthy.ID())
+	require.NoError(b.filter(nil, unhealthy), "unblocked peer should be accepted")
+}
+
+func TestConsensusPeerID(t *testing.T) {
+	signer := memorySigner.NewTestSigner("consensus peer ID test")
+	pk := signer.Public()
+
+	require.Equal(t, cmtp2p.PubKeyToID(crypto.PublicKeyToCometBFT(&pk)), consensusPeerID(pk))
+}
@@ -21,6 +21,7 @@ import (
 	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	"github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
@@ -63,6 +64,27 @@ func (sc *serviceClient) GetEntity(ctx context.Context, query *api.IDQuery) (*en
 	return q.Entity(ctx, query.ID)
 }
 
+func (sc *serviceClient) GetEntityWithProof(ctx context.Context, query *api.IDQuery) (*api.EntityWithProof, error) {
+	p, err := sc.querier.StateProofAt(ctx, sc.backend, query.Height, [][]byte{registryState.EntityKey(query.ID)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ent, err := sc.GetEntity(ctx, &api.IDQuery{Height: p.Height, ID: query.ID})
+	switch err {
+	case nil:
+	case api.ErrNoSuchEntity:
+		ent = nil
+	default:
+		return nil, err
+	}
+
+	return &api.EntityWithProof{
+		Entity: ent,
+		Proof:  p,
+	}, nil
+}
+
 func (sc *serviceClient) GetEntities(ctx context.Context, height int64) ([]*entity.Entity, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -89,6 +111,27 @@ func (sc *serviceClient) GetNode(ctx context.Context, query *api.IDQuery) (*node
 	return q.Node(ctx, query.ID)
 }
 
+func (sc *serviceClient) GetNodeWithProof(ctx context.Context, query *api.IDQuery) (*api.NodeWithProof, error) {
+	p, err := sc.querier.StateProofAt(ctx, sc.backend, query.Height, [][]byte{registryState.NodeKey(query.ID)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nd, err := sc.GetNode(ctx, &api.IDQuery{Height: p.Height, ID: query.ID})
+	switch err {
+	case nil:
+	case api.ErrNoSuchNode:
+		nd = nil
+	default:
+		return nil, err
+	}
+
+	return &api.NodeWithProof{
+		Node:  nd,
+		Proof: p,
+	}, nil
+}
+
 func (sc *serviceClient) GetNodeStatus(ctx context.Context, query *api.IDQuery) (*api.NodeStatus, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -18,6 +18,7 @@ import (
 	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
@@ -92,6 +93,15 @@ func (sc *serviceClient) GovernanceDeposits(ctx context.Context, height int64) (
 	return q.GovernanceDeposits(ctx)
 }
 
+func (sc *serviceClient) BaseFee(ctx context.Context, height int64) (*api.BaseFee, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.BaseFee(ctx)
+}
+
 func (sc *serviceClient) Threshold(ctx context.Context, query *api.ThresholdQuery) (*quantity.Quantity, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -137,6 +147,40 @@ func (sc *serviceClient) DelegationsFor(ctx context.Context, query *api.OwnerQue
 	return q.DelegationsFor(ctx, query.Owner)
 }
 
+func (sc *serviceClient) AccountWithProof(ctx context.Context, query *api.OwnerQuery) (*api.AccountWithProof, error) {
+	p, err := sc.querier.StateProofAt(ctx, sc.backend, query.Height, [][]byte{stakingState.AccountKey(query.Owner)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := sc.Account(ctx, &api.OwnerQuery{Height: p.Height, Owner: query.Owner})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.AccountWithProof{
+		Account: account,
+		Proof:   p,
+	}, nil
+}
+
+func (sc *serviceClient) DelegationsForWithProof(ctx context.Context, query *api.OwnerQuery) (*api.DelegationsWithProof, error) {
+	p, err := sc.querier.StateProofAt(ctx, sc.backend, query.Height, nil, [][]byte{stakingState.DelegationsForKeyPrefix(query.Owner)})
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, err := sc.DelegationsFor(ctx, &api.OwnerQuery{Height: p.Height, Owner: query.Owner})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DelegationsWithProof{
+		Delegations: delegations,
+		Proof:       p,
+	}, nil
+}
+
 func (sc *serviceClient) DelegationInfosFor(ctx context.Context, query *api.OwnerQuery) (map[api.Address]*api.DelegationInfo, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
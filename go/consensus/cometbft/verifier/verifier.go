@@ -0,0 +1,351 @@
+// Package verifier implements verification of CometBFT consensus state proofs.
+//
+// It can be used by light clients (e.g., wallets and bridges) to verify responses of queries
+// which return a proof of consensus state (e.g., staking.Backend.AccountWithProof) without
+// trusting the node serving them.
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cmtlight "github.com/cometbft/cometbft/light"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+const (
+	// DefaultMaxClockDrift is the default maximum allowed clock drift.
+	DefaultMaxClockDrift = 10 * time.Second
+)
+
+var (
+	// ErrInvalidLightBlock is the error returned when a light block fails verification.
+	ErrInvalidLightBlock = errors.New("verifier: invalid light block")
+	// ErrInvalidProof is the error returned when a state proof fails verification.
+	ErrInvalidProof = errors.New("verifier: invalid state proof")
+	// ErrValueMismatch is the error returned when a value returned together with a proof does not
+	// match the proven value.
+	ErrValueMismatch = errors.New("verifier: value does not match proof")
+)
+
+// Config is the state proof verifier configuration.
+type Config struct {
+	// ChainContext is the chain domain separation context.
+	ChainContext string
+
+	// TrustedLightBlock is the light block trusted by the caller (e.g., obtained from a trusted
+	// source or verified by a light client).
+	TrustedLightBlock *cmttypes.LightBlock
+
+	// TrustingPeriod is the period during which validators of a trusted light block can be
+	// trusted. It should be shorter than the debonding interval.
+	TrustingPeriod time.Duration
+
+	// MaxClockDrift is the maximum allowed clock drift. If zero, DefaultMaxClockDrift is used.
+	MaxClockDrift time.Duration
+}
+
+// Verifier is a consensus state proof verifier.
+//
+// Light blocks of proofs are always verified against the trusted light block, which only changes
+// when explicitly updated via UpdateTrustedLightBlock, so verification results do not depend on
+// the order of verified proofs. Callers following the chain should update the trusted light block
+// at least once per trusting period.
+type Verifier struct {
+	sync.RWMutex
+
+	chainID        string
+	trusted        *cmttypes.LightBlock
+	trustingPeriod time.Duration
+	maxClockDrift  time.Duration
+
+	now func() time.Time
+}
+
+// New creates a new state proof verifier.
+func New(cfg *Config) (*Verifier, error) {
+	if cfg.TrustedLightBlock == nil {
+		return nil, fmt.Errorf("verifier: missing trusted light block")
+	}
+	if cfg.TrustingPeriod <= 0 {
+		return nil, fmt.Errorf("verifier: invalid trusting period")
+	}
+	chainID := tmapi.CometBFTChainID(cfg.ChainContext)
+	if err := cfg.TrustedLightBlock.ValidateBasic(chainID); err != nil {
+		return nil, fmt.Errorf("verifier: invalid trusted light block: %w", err)
+	}
+
+	maxClockDrift := cfg.MaxClockDrift
+	if maxClockDrift == 0 {
+		maxClockDrift = DefaultMaxClockDrift
+	}
+
+	return &Verifier{
+		chainID:        chainID,
+		trusted:        cfg.TrustedLightBlock,
+		trustingPeriod: cfg.TrustingPeriod,
+		maxClockDrift:  maxClockDrift,
+		now:            time.Now,
+	}, nil
+}
+
+// TrustedLightBlock returns the currently trusted light block.
+func (v *Verifier) TrustedLightBlock() *cmttypes.LightBlock {
+	v.RLock()
+	defer v.RUnlock()
+
+	return v.trusted
+}
+
+// UpdateTrustedLightBlock verifies the given light block against the currently trusted light
+// block and, if valid, makes it the trusted light block.
+//
+// Light blocks older than the currently trusted light block are rejected.
+func (v *Verifier) UpdateTrustedLightBlock(lb *cmttypes.LightBlock) error {
+	v.Lock()
+	defer v.Unlock()
+
+	if err := v.verifyLightBlockLocked(lb); err != nil {
+		return err
+	}
+	v.trusted = lb
+
+	return nil
+}
+
+// DecodeLightBlock decodes a consensus backend specific light block.
+func DecodeLightBlock(meta []byte) (*cmttypes.LightBlock, error) {
+	var pb cmtproto.LightBlock
+	if err := pb.Unmarshal(meta); err != nil {
+		return nil, fmt.Errorf("%w: malformed light block: %s", ErrInvalidLightBlock, err)
+	}
+	lb, err := cmttypes.LightBlockFromProto(&pb)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed light block: %s", ErrInvalidLightBlock, err)
+	}
+	return lb, nil
+}
+
+// verifyLightBlock verifies the given untrusted light block against the trusted light block.
+func (v *Verifier) verifyLightBlock(lb *cmttypes.LightBlock) error {
+	v.RLock()
+	defer v.RUnlock()
+
+	return v.verifyLightBlockLocked(lb)
+}
+
+func (v *Verifier) verifyLightBlockLocked(lb *cmttypes.LightBlock) error {
+	if err := lb.ValidateBasic(v.chainID); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidLightBlock, err)
+	}
+
+	switch {
+	case lb.Height == v.trusted.Height:
+		if !bytes.Equal(lb.Hash(), v.trusted.Hash()) {
+			return fmt.Errorf("%w: hash mismatch with trusted light block", ErrInvalidLightBlock)
+		}
+		return nil
+	case lb.Height < v.trusted.Height:
+		return fmt.Errorf("%w: light block is older than the trusted light block (%d < %d)",
+			ErrInvalidLightBlock, lb.Height, v.trusted.Height,
+		)
+	default:
+	}
+
+	err := cmtlight.Verify(
+		v.trusted.SignedHeader,
+		v.trusted.ValidatorSet,
+		lb.SignedHeader,
+		lb.ValidatorSet,
+		v.trustingPeriod,
+		v.now(),
+		v.maxClockDrift,
+		cmtlight.DefaultTrustLevel,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidLightBlock, err)
+	}
+
+	return nil
+}
+
+// VerifyStateProof verifies the given state proof and returns a tree which can be used to read
+// the proven part of the consensus state. Accessing any state not covered by the proof fails.
+//
+// The caller is responsible for closing the returned tree.
+func (v *Verifier) VerifyStateProof(ctx context.Context, sp *proof.StateProof) (mkvs.Tree, error) {
+	if sp == nil {
+		return nil, fmt.Errorf("%w: missing proof", ErrInvalidProof)
+	}
+
+	lb, err := DecodeLightBlock(sp.LightBlock)
+	if err != nil {
+		return nil, err
+	}
+	// The state after executing a block is committed to by the next block.
+	if lb.Height != sp.Height+1 {
+		return nil, fmt.Errorf("%w: light block height mismatch (expected: %d got: %d)",
+			ErrInvalidProof, sp.Height+1, lb.Height,
+		)
+	}
+	if err = v.verifyLightBlock(lb); err != nil {
+		return nil, err
+	}
+
+	var stateRoot hash.Hash
+	if err = stateRoot.UnmarshalBinary(lb.AppHash); err != nil {
+		return nil, fmt.Errorf("%w: malformed state root: %s", ErrInvalidLightBlock, err)
+	}
+	if !sp.Proof.UntrustedRoot.Equal(&stateRoot) {
+		return nil, fmt.Errorf("%w: state root mismatch", ErrInvalidProof)
+	}
+
+	// Verify the proof eagerly so that malformed proofs are reported as such.
+	var pv syncer.ProofVerifier
+	if _, err = pv.VerifyProof(ctx, stateRoot, &sp.Proof); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+
+	root := mkvsNode.Root{
+		Version: uint64(sp.Height),
+		Type:    mkvsNode.RootTypeState,
+		Hash:    stateRoot,
+	}
+	return mkvs.NewWithRoot(syncer.NewProofReadSyncer(&sp.Proof), nil, root), nil
+}
+
+// VerifyAccount verifies the response of staking.Backend.AccountWithProof and returns the proven
+// account descriptor.
+func (v *Verifier) VerifyAccount(
+	ctx context.Context,
+	owner staking.Address,
+	rsp *staking.AccountWithProof,
+) (*staking.Account, error) {
+	tree, err := v.VerifyStateProof(ctx, rsp.Proof)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	account, err := stakingState.NewMutableState(tree).Account(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+	if err = checkValue(account, rsp.Account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// VerifyDelegationsFor verifies the response of staking.Backend.DelegationsForWithProof and
+// returns the proven (outgoing) delegations of the given delegator.
+func (v *Verifier) VerifyDelegationsFor(
+	ctx context.Context,
+	delegator staking.Address,
+	rsp *staking.DelegationsWithProof,
+) (map[staking.Address]*staking.Delegation, error) {
+	tree, err := v.VerifyStateProof(ctx, rsp.Proof)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	delegations, err := stakingState.NewMutableState(tree).DelegationsFor(ctx, delegator)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+	if err = checkValue(delegations, rsp.Delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// VerifyEntity verifies the response of registry.Backend.GetEntityWithProof and returns the
+// proven entity descriptor. In case the entity has been proven to not exist, it returns
+// registry.ErrNoSuchEntity.
+func (v *Verifier) VerifyEntity(
+	ctx context.Context,
+	id signature.PublicKey,
+	rsp *registry.EntityWithProof,
+) (*entity.Entity, error) {
+	tree, err := v.VerifyStateProof(ctx, rsp.Proof)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	ent, err := registryState.NewMutableState(tree).Entity(ctx, id)
+	switch {
+	case err == nil:
+	case errors.Is(err, registry.ErrNoSuchEntity):
+		if rsp.Entity != nil {
+			return nil, ErrValueMismatch
+		}
+		return nil, err
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+	if err = checkValue(ent, rsp.Entity); err != nil {
+		return nil, err
+	}
+	return ent, nil
+}
+
+// VerifyNode verifies the response of registry.Backend.GetNodeWithProof and returns the proven
+// node descriptor. In case the node has been proven to not exist, it returns
+// registry.ErrNoSuchNode.
+func (v *Verifier) VerifyNode(
+	ctx context.Context,
+	id signature.PublicKey,
+	rsp *registry.NodeWithProof,
+) (*node.Node, error) {
+	tree, err := v.VerifyStateProof(ctx, rsp.Proof)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	nd, err := registryState.NewMutableState(tree).Node(ctx, id)
+	switch {
+	case err == nil:
+	case errors.Is(err, registry.ErrNoSuchNode):
+		if rsp.Node != nil {
+			return nil, ErrValueMismatch
+		}
+		return nil, err
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+	if err = checkValue(nd, rsp.Node); err != nil {
+		return nil, err
+	}
+	return nd, nil
+}
+
+// checkValue checks that the returned value matches the proven value.
+func checkValue(proven, returned interface{}) error {
+	if !bytes.Equal(cbor.Marshal(proven), cbor.Marshal(returned)) {
+		return ErrValueMismatch
+	}
+	return nil
+}
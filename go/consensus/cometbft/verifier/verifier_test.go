@@ -0,0 +1,246 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+var testChainContext = hash.NewFromBytes([]byte("verifier test")).String()
+
+type testChain struct {
+	pv     cmttypes.MockPV
+	valSet *cmttypes.ValidatorSet
+	start  time.Time
+}
+
+func newTestChain() *testChain {
+	pv := cmttypes.NewMockPV()
+	return &testChain{
+		pv:     pv,
+		valSet: cmttypes.NewValidatorSet([]*cmttypes.Validator{pv.ExtractIntoValidator(10)}),
+		start:  time.Now().Add(-time.Hour),
+	}
+}
+
+func (tc *testChain) lightBlock(t *testing.T, height int64, appHash hash.Hash) *cmttypes.LightBlock {
+	chainID := tmapi.CometBFTChainID(testChainContext)
+	header := cmttypes.Header{
+		Version:            cmtversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            chainID,
+		Height:             height,
+		Time:               tc.start.Add(time.Duration(height) * time.Second),
+		ValidatorsHash:     tc.valSet.Hash(),
+		NextValidatorsHash: tc.valSet.Hash(),
+		AppHash:            appHash[:],
+		ProposerAddress:    tc.valSet.Proposer.Address,
+	}
+	blockID := cmttypes.BlockID{
+		Hash: header.Hash(),
+		PartSetHeader: cmttypes.PartSetHeader{
+			Total: 1,
+			Hash:  tmhash.Sum([]byte("parts")),
+		},
+	}
+	voteSet := cmttypes.NewVoteSet(chainID, height, 0, cmtproto.PrecommitType, tc.valSet)
+	commit, err := cmttypes.MakeCommit(blockID, height, 0, voteSet, []cmttypes.PrivValidator{tc.pv}, header.Time)
+	require.NoError(t, err, "MakeCommit")
+
+	return &cmttypes.LightBlock{
+		SignedHeader: &cmttypes.SignedHeader{
+			Header: &header,
+			Commit: commit,
+		},
+		ValidatorSet: tc.valSet,
+	}
+}
+
+func (tc *testChain) stateProof(
+	t *testing.T,
+	tree mkvs.Tree,
+	root hash.Hash,
+	height int64,
+	keys [][]byte,
+	prefixes [][]byte,
+) *proof.StateProof {
+	ctx := context.Background()
+
+	it := tree.NewIterator(ctx, mkvs.WithProof(root))
+	defer it.Close()
+	for _, key := range keys {
+		it.Seek(key)
+	}
+	for _, prefix := range prefixes {
+		for it.Seek(prefix); it.Valid() && bytes.HasPrefix(it.Key(), prefix); it.Next() {
+		}
+	}
+	require.NoError(t, it.Err(), "iterator")
+	p, err := it.GetProof()
+	require.NoError(t, err, "GetProof")
+
+	lb := tc.lightBlock(t, height+1, root)
+	lbPb, err := lb.ToProto()
+	require.NoError(t, err, "ToProto")
+	meta, err := lbPb.Marshal()
+	require.NoError(t, err, "Marshal")
+
+	return &proof.StateProof{
+		Height:     height,
+		Proof:      *p,
+		LightBlock: meta,
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Prepare consensus state.
+	addr1 := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001"))
+	addr2 := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002"))
+	addr3 := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003"))
+	account := &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+			Nonce:   5,
+		},
+	}
+	entSigner := memorySigner.NewTestSigner("verifier test entity")
+	ent := &entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entSigner.Public(),
+	}
+	sigEnt, err := entity.SignEntity(entSigner, registry.RegisterEntitySignatureContext, ent)
+	require.NoError(err, "SignEntity")
+
+	tree := mkvs.New(nil, nil, mkvsNode.RootTypeState)
+	defer tree.Close()
+	stakeState := stakingState.NewMutableState(tree)
+	err = stakeState.SetAccount(ctx, addr1, account)
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetAccount(ctx, addr2, &staking.Account{})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetDelegation(ctx, addr1, addr2, &staking.Delegation{Shares: *quantity.NewFromUint64(10)})
+	require.NoError(err, "SetDelegation")
+	err = stakeState.SetDelegation(ctx, addr1, addr3, &staking.Delegation{Shares: *quantity.NewFromUint64(20)})
+	require.NoError(err, "SetDelegation")
+	err = stakeState.SetDelegation(ctx, addr2, addr3, &staking.Delegation{Shares: *quantity.NewFromUint64(30)})
+	require.NoError(err, "SetDelegation")
+	err = registryState.NewMutableState(tree).SetEntity(ctx, ent, sigEnt)
+	require.NoError(err, "SetEntity")
+	_, root, err := tree.Commit(ctx, common.Namespace{}, 5)
+	require.NoError(err, "Commit")
+
+	tc := newTestChain()
+	v, err := New(&Config{
+		ChainContext:      testChainContext,
+		TrustedLightBlock: tc.lightBlock(t, 1, hash.Hash{}),
+		TrustingPeriod:    24 * time.Hour,
+	})
+	require.NoError(err, "New")
+
+	// Account.
+	sp := tc.stateProof(t, tree, root, 5, [][]byte{stakingState.AccountKey(addr1)}, nil)
+	proven, err := v.VerifyAccount(ctx, addr1, &staking.AccountWithProof{Account: account, Proof: sp})
+	require.NoError(err, "VerifyAccount")
+	require.EqualValues(account, proven)
+	require.EqualValues(1, v.TrustedLightBlock().Height, "verified light block should not become trusted")
+
+	_, err = v.VerifyAccount(ctx, addr1, &staking.AccountWithProof{Account: &staking.Account{}, Proof: sp})
+	require.ErrorIs(err, ErrValueMismatch, "VerifyAccount should fail for a mismatched account")
+
+	_, err = v.VerifyAccount(ctx, addr2, &staking.AccountWithProof{Account: &staking.Account{}, Proof: sp})
+	require.ErrorIs(err, ErrInvalidProof, "VerifyAccount should fail for an account not covered by the proof")
+
+	// Delegations.
+	sp = tc.stateProof(t, tree, root, 5, nil, [][]byte{stakingState.DelegationsForKeyPrefix(addr1)})
+	delegations, err := v.VerifyDelegationsFor(ctx, addr1, &staking.DelegationsWithProof{
+		Delegations: map[staking.Address]*staking.Delegation{
+			addr2: {Shares: *quantity.NewFromUint64(10)},
+			addr3: {Shares: *quantity.NewFromUint64(20)},
+		},
+		Proof: sp,
+	})
+	require.NoError(err, "VerifyDelegationsFor")
+	require.Len(delegations, 2)
+
+	_, err = v.VerifyDelegationsFor(ctx, addr1, &staking.DelegationsWithProof{
+		Delegations: map[staking.Address]*staking.Delegation{
+			addr2: {Shares: *quantity.NewFromUint64(10)},
+		},
+		Proof: sp,
+	})
+	require.ErrorIs(err, ErrValueMismatch, "VerifyDelegationsFor should fail for incomplete delegations")
+
+	// Entities.
+	sp = tc.stateProof(t, tree, root, 5, [][]byte{registryState.EntityKey(ent.ID)}, nil)
+	provenEnt, err := v.VerifyEntity(ctx, ent.ID, &registry.EntityWithProof{Entity: ent, Proof: sp})
+	require.NoError(err, "VerifyEntity")
+	require.EqualValues(ent, provenEnt)
+
+	missingID := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000004")
+	sp = tc.stateProof(t, tree, root, 5, [][]byte{registryState.EntityKey(missingID)}, nil)
+	_, err = v.VerifyEntity(ctx, missingID, &registry.EntityWithProof{Proof: sp})
+	require.ErrorIs(err, registry.ErrNoSuchEntity, "VerifyEntity should prove absence")
+	_, err = v.VerifyEntity(ctx, missingID, &registry.EntityWithProof{Entity: ent, Proof: sp})
+	require.ErrorIs(err, ErrValueMismatch, "VerifyEntity should fail for an entity proven to not exist")
+
+	// Invalid proofs.
+	sp = tc.stateProof(t, tree, root, 5, [][]byte{stakingState.AccountKey(addr1)}, nil)
+	sp.Height = 4
+	_, err = v.VerifyStateProof(ctx, sp)
+	require.ErrorIs(err, ErrInvalidProof, "VerifyStateProof should fail for a height mismatch")
+
+	sp = tc.stateProof(t, tree, root, 5, [][]byte{stakingState.AccountKey(addr1)}, nil)
+	sp.Proof.UntrustedRoot = hash.NewFromBytes([]byte("invalid root"))
+	_, err = v.VerifyStateProof(ctx, sp)
+	require.ErrorIs(err, ErrInvalidProof, "VerifyStateProof should fail for a state root mismatch")
+
+	other := newTestChain()
+	sp = other.stateProof(t, tree, root, 10, [][]byte{stakingState.AccountKey(addr1)}, nil)
+	_, err = v.VerifyStateProof(ctx, sp)
+	require.ErrorIs(err, ErrInvalidLightBlock, "VerifyStateProof should fail for untrusted validators")
+
+	// Verification should not depend on the order of queries.
+	sp = tc.stateProof(t, tree, root, 10, [][]byte{stakingState.AccountKey(addr1)}, nil)
+	_, err = v.VerifyAccount(ctx, addr1, &staking.AccountWithProof{Account: account, Proof: sp})
+	require.NoError(err, "VerifyAccount for a newer height")
+	sp = tc.stateProof(t, tree, root, 3, [][]byte{stakingState.AccountKey(addr1)}, nil)
+	_, err = v.VerifyAccount(ctx, addr1, &staking.AccountWithProof{Account: account, Proof: sp})
+	require.NoError(err, "VerifyAccount for an older height after a newer one")
+
+	// Trusted light block updates.
+	err = v.UpdateTrustedLightBlock(other.lightBlock(t, 6, root))
+	require.ErrorIs(err, ErrInvalidLightBlock, "UpdateTrustedLightBlock should fail for untrusted validators")
+	err = v.UpdateTrustedLightBlock(tc.lightBlock(t, 6, root))
+	require.NoError(err, "UpdateTrustedLightBlock")
+	require.EqualValues(6, v.TrustedLightBlock().Height, "updated light block should become trusted")
+
+	sp = tc.stateProof(t, tree, root, 3, [][]byte{stakingState.AccountKey(addr1)}, nil)
+	_, err = v.VerifyStateProof(ctx, sp)
+	require.ErrorIs(err, ErrInvalidLightBlock, "VerifyStateProof should fail for light blocks older than the trusted one")
+}
@@ -39,6 +39,13 @@ type Parameters struct { // nolint: maligned
 
 	// PublicKeyBlacklist is the network-wide public key blacklist.
 	PublicKeyBlacklist []signature.PublicKey `json:"public_key_blacklist,omitempty"`
+
+	// EnableFeePayer specifies whether transactions with a separate fee payer are accepted.
+	EnableFeePayer bool `json:"enable_fee_payer,omitempty"`
+	// EnableBatchTransactions specifies whether atomic batch transactions are accepted.
+	EnableBatchTransactions bool `json:"enable_batch_transactions,omitempty"`
+	// EnableTransactionExpiry specifies whether transactions with an expiry are accepted.
+	EnableTransactionExpiry bool `json:"enable_transaction_expiry,omitempty"`
 }
 
 const (
@@ -15,6 +15,8 @@ import (
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	block "github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
+	sentry "github.com/oasisprotocol/oasis-core/go/sentry/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
@@ -26,8 +28,17 @@ import (
 // ModuleName is the module name for the controller service.
 const ModuleName = "control"
 
-// ErrNotImplemented is the error raised when the node does not support the required functionality.
-var ErrNotImplemented = errors.New(ModuleName, 1, "control: not implemented")
+var (
+	// ErrNotImplemented is the error raised when the node does not support the required functionality.
+	ErrNotImplemented = errors.New(ModuleName, 1, "control: not implemented")
+
+	// ErrRuntimeNotFound is the error raised when the requested runtime is not supported by the node.
+	ErrRuntimeNotFound = errors.New(ModuleName, 2, "control: runtime not found")
+
+	// ErrTransactionNotFound is the error raised when the requested transaction is not queued in
+	// the transaction pool.
+	ErrTransactionNotFound = errors.New(ModuleName, 3, "control: transaction not found")
+)
 
 // NodeController is a node controller interface.
 type NodeController interface {
@@ -59,6 +70,55 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// GetTxPoolTransactions returns the transactions currently queued in the transaction pool of
+	// the given runtime.
+	GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*txpool.TransactionInfo, error)
+
+	// GetTxPoolTransaction returns the given transaction queued in the transaction pool of the
+	// given runtime.
+	GetTxPoolTransaction(ctx context.Context, query *TxPoolTransactionQuery) (*txpool.TransactionInfo, error)
+
+	// EvictTxPoolTransactions evicts the matching transactions from the transaction pool of the
+	// given runtime and returns the evicted transactions.
+	EvictTxPoolTransactions(ctx context.Context, req *EvictTxPoolTransactionsRequest) ([]*txpool.TransactionInfo, error)
+
+	// GetPeerReputations returns the current reputation scores of P2P peers.
+	GetPeerReputations(ctx context.Context) ([]*p2p.PeerScore, error)
+
+	// GetKeymanagerAuditLog returns the key manager access audit log records matching the given
+	// query, most recent first.
+	GetKeymanagerAuditLog(ctx context.Context, query *keymanagerWorker.AuditQuery) ([]*keymanagerWorker.AuditRecord, error)
+}
+
+// TxPoolTransactionQuery is a transaction pool transaction query.
+type TxPoolTransactionQuery struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// TxHash is the transaction hash.
+	TxHash hash.Hash `json:"tx_hash"`
+}
+
+// EvictTxPoolTransactionsRequest is a transaction pool eviction request.
+//
+// A transaction is evicted if it matches any of the given hashes or is older than the given age.
+type EvictTxPoolTransactionsRequest struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// TxHashes are the hashes of transactions to evict.
+	TxHashes []hash.Hash `json:"tx_hashes,omitempty"`
+	// OlderThan is the minimum age of transactions to evict (zero means no age-based eviction).
+	OlderThan time.Duration `json:"older_than,omitempty"`
+}
+
+// Matches returns true iff the given transaction should be evicted.
+func (r *EvictTxPoolTransactionsRequest) Matches(ti *txpool.TransactionInfo, now time.Time) bool {
+	for _, h := range r.TxHashes {
+		if ti.Hash.Equal(&h) {
+			return true
+		}
+	}
+	return r.OlderThan > 0 && ti.Age(now) >= r.OlderThan
 }
 
 // Status is the current status overview.
@@ -146,6 +206,9 @@ type RegistrationStatus struct {
 
 	// NodeStatus is the registry live status of the node.
 	NodeStatus *registry.NodeStatus `json:"node_status,omitempty"`
+
+	// Sentries is the health of the sentry nodes the node is configured to use, if any.
+	Sentries []*sentry.SentryHealth `json:"sentries,omitempty"`
 }
 
 // RuntimeStatus is the per-runtime status overview.
@@ -5,8 +5,12 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 )
 
 var (
@@ -29,6 +33,16 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodGetTxPoolTransactions is the GetTxPoolTransactions method.
+	methodGetTxPoolTransactions = serviceName.NewMethod("GetTxPoolTransactions", common.Namespace{})
+	// methodGetTxPoolTransaction is the GetTxPoolTransaction method.
+	methodGetTxPoolTransaction = serviceName.NewMethod("GetTxPoolTransaction", TxPoolTransactionQuery{})
+	// methodEvictTxPoolTransactions is the EvictTxPoolTransactions method.
+	methodEvictTxPoolTransactions = serviceName.NewMethod("EvictTxPoolTransactions", EvictTxPoolTransactionsRequest{})
+	// methodGetPeerReputations is the GetPeerReputations method.
+	methodGetPeerReputations = serviceName.NewMethod("GetPeerReputations", nil)
+	// methodGetKeymanagerAuditLog is the GetKeymanagerAuditLog method.
+	methodGetKeymanagerAuditLog = serviceName.NewMethod("GetKeymanagerAuditLog", keymanagerWorker.AuditQuery{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -67,6 +81,26 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetTxPoolTransactions.ShortName(),
+				Handler:    handlerGetTxPoolTransactions,
+			},
+			{
+				MethodName: methodGetTxPoolTransaction.ShortName(),
+				Handler:    handlerGetTxPoolTransaction,
+			},
+			{
+				MethodName: methodEvictTxPoolTransactions.ShortName(),
+				Handler:    handlerEvictTxPoolTransactions,
+			},
+			{
+				MethodName: methodGetPeerReputations.ShortName(),
+				Handler:    handlerGetPeerReputations,
+			},
+			{
+				MethodName: methodGetKeymanagerAuditLog.ShortName(),
+				Handler:    handlerGetKeymanagerAuditLog,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -236,6 +270,117 @@ func handlerGetStatus(
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetTxPoolTransactions(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetTxPoolTransactions(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxPoolTransactions.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetTxPoolTransactions(ctx, req.(common.Namespace))
+	}
+	return interceptor(ctx, runtimeID, info, handler)
+}
+
+func handlerGetTxPoolTransaction(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query TxPoolTransactionQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetTxPoolTransaction(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxPoolTransaction.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetTxPoolTransaction(ctx, req.(*TxPoolTransactionQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerEvictTxPoolTransactions(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req EvictTxPoolTransactionsRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).EvictTxPoolTransactions(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodEvictTxPoolTransactions.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).EvictTxPoolTransactions(ctx, req.(*EvictTxPoolTransactionsRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetPeerReputations(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetPeerReputations(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetPeerReputations.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetPeerReputations(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerGetKeymanagerAuditLog(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query keymanagerWorker.AuditQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetKeymanagerAuditLog(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetKeymanagerAuditLog.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetKeymanagerAuditLog(ctx, req.(*keymanagerWorker.AuditQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -289,6 +434,46 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*txpool.TransactionInfo, error) {
+	var rsp []*txpool.TransactionInfo
+	if err := c.conn.Invoke(ctx, methodGetTxPoolTransactions.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) GetTxPoolTransaction(ctx context.Context, query *TxPoolTransactionQuery) (*txpool.TransactionInfo, error) {
+	var rsp txpool.TransactionInfo
+	if err := c.conn.Invoke(ctx, methodGetTxPoolTransaction.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) EvictTxPoolTransactions(ctx context.Context, req *EvictTxPoolTransactionsRequest) ([]*txpool.TransactionInfo, error) {
+	var rsp []*txpool.TransactionInfo
+	if err := c.conn.Invoke(ctx, methodEvictTxPoolTransactions.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) GetPeerReputations(ctx context.Context) ([]*p2p.PeerScore, error) {
+	var rsp []*p2p.PeerScore
+	if err := c.conn.Invoke(ctx, methodGetPeerReputations.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) GetKeymanagerAuditLog(ctx context.Context, query *keymanagerWorker.AuditQuery) ([]*keymanagerWorker.AuditRecord, error) {
+	var rsp []*keymanagerWorker.AuditRecord
+	if err := c.conn.Invoke(ctx, methodGetKeymanagerAuditLog.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
@@ -6,7 +6,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"reflect"
+	"strings"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -17,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -27,6 +30,18 @@ const ModuleName = "governance"
 // ProposalContent.
 const ProposalContentInvalidText = "(invalid)"
 
+// MaxCommonPoolSpendRecipients is the maximum number of recipients of a common pool spend proposal.
+const MaxCommonPoolSpendRecipients = 64
+
+const (
+	// MaxTextProposalTitleLength is the maximum length of a text proposal title.
+	MaxTextProposalTitleLength = 128
+	// MaxTextProposalBodyLength is the maximum length of a text proposal body.
+	MaxTextProposalBodyLength = 4096
+	// MaxTextProposalURLLength is the maximum length of a text proposal URL.
+	MaxTextProposalURLLength = 256
+)
+
 var (
 	// ErrInvalidArgument is the error returned on malformed argument(s).
 	ErrInvalidArgument = errors.New(ModuleName, 1, "governance: invalid argument")
@@ -58,6 +73,8 @@ var (
 	_ prettyprint.PrettyPrinter = (*UpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*CancelUpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ChangeParametersProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*CommonPoolSpendProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*TextProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ProposalVote)(nil)
 )
 
@@ -66,6 +83,8 @@ type ProposalContent struct {
 	Upgrade          *UpgradeProposal          `json:"upgrade,omitempty"`
 	CancelUpgrade    *CancelUpgradeProposal    `json:"cancel_upgrade,omitempty"`
 	ChangeParameters *ChangeParametersProposal `json:"change_parameters,omitempty"`
+	CommonPoolSpend  *CommonPoolSpendProposal  `json:"common_pool_spend,omitempty"`
+	Text             *TextProposal             `json:"text,omitempty"`
 }
 
 // ValidateBasic performs basic proposal content validity checks.
@@ -91,6 +110,14 @@ func (p *ProposalContent) ValidateBasic() error {
 		if err := p.ChangeParameters.ValidateBasic(); err != nil {
 			return fmt.Errorf("change parameters proposal validation failed: %w", err)
 		}
+	case p.CommonPoolSpend != nil:
+		if err := p.CommonPoolSpend.ValidateBasic(); err != nil {
+			return fmt.Errorf("common pool spend proposal validation failed: %w", err)
+		}
+	case p.Text != nil:
+		if err := p.Text.ValidateBasic(); err != nil {
+			return fmt.Errorf("text proposal validation failed: %w", err)
+		}
 	default:
 		return fmt.Errorf("proposal content has no fields set")
 	}
@@ -118,6 +145,12 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 	if !p.ChangeParameters.Equals(other.ChangeParameters) {
 		return false
 	}
+	if !p.CommonPoolSpend.Equals(other.CommonPoolSpend) {
+		return false
+	}
+	if !p.Text.Equals(other.Text) {
+		return false
+	}
 	return true
 }
 
@@ -131,6 +164,12 @@ func (p ProposalContent) PrettyPrint(ctx context.Context, prefix string, w io.Wr
 	case p.CancelUpgrade != nil && p.Upgrade == nil:
 		fmt.Fprintf(w, "%sCancel Upgrade:\n", prefix)
 		p.CancelUpgrade.PrettyPrint(ctx, prefix+"  ", w)
+	case p.CommonPoolSpend != nil && p.Upgrade == nil && p.CancelUpgrade == nil:
+		fmt.Fprintf(w, "%sCommon Pool Spend:\n", prefix)
+		p.CommonPoolSpend.PrettyPrint(ctx, prefix+"  ", w)
+	case p.Text != nil && p.Upgrade == nil && p.CancelUpgrade == nil:
+		fmt.Fprintf(w, "%sText:\n", prefix)
+		p.Text.PrettyPrint(ctx, prefix+"  ", w)
 	default:
 		fmt.Fprintf(w, "%s%s\n", prefix, ProposalContentInvalidText)
 	}
@@ -254,6 +293,209 @@ func (p *ChangeParametersProposal) ValidateBasic() error {
 	return nil
 }
 
+// CommonPoolSpendProposal is a proposal to spend funds from the common pool.
+type CommonPoolSpendProposal struct {
+	// Recipients are the recipients of the funds.
+	Recipients []CommonPoolSpendRecipient `json:"recipients"`
+}
+
+// CommonPoolSpendRecipient is a recipient of funds from the common pool.
+type CommonPoolSpendRecipient struct {
+	// Address is the address of the recipient.
+	Address staking.Address `json:"address"`
+	// Amount is the total amount transferred to the recipient.
+	Amount quantity.Quantity `json:"amount"`
+	// VestingEpochs is the number of epochs over which the amount is paid out in equal
+	// installments, starting with the epoch following the one in which the proposal passed.
+	//
+	// If zero, the whole amount is paid out when the proposal passes.
+	VestingEpochs beacon.EpochTime `json:"vesting_epochs,omitempty"`
+}
+
+// Vested returns the total amount that should have been paid out to the recipient by the given
+// epoch in case the proposal passed at the passed epoch.
+func (r *CommonPoolSpendRecipient) Vested(passedAt, epoch beacon.EpochTime) (*quantity.Quantity, error) {
+	switch {
+	case epoch < passedAt:
+		return quantity.NewQuantity(), nil
+	case epoch >= passedAt+r.VestingEpochs:
+		return r.Amount.Clone(), nil
+	}
+
+	// Installments are equal with the last one also including any remainder.
+	var epochs, vestedEpochs quantity.Quantity
+	if err := epochs.FromUint64(uint64(r.VestingEpochs)); err != nil {
+		return nil, err
+	}
+	if err := vestedEpochs.FromUint64(uint64(epoch - passedAt)); err != nil {
+		return nil, err
+	}
+	vested := r.Amount.Clone()
+	if err := vested.Quo(&epochs); err != nil {
+		return nil, err
+	}
+	if err := vested.Mul(&vestedEpochs); err != nil {
+		return nil, err
+	}
+	return vested, nil
+}
+
+// TotalAmount returns the total amount spent by the proposal.
+func (p *CommonPoolSpendProposal) TotalAmount() (*quantity.Quantity, error) {
+	total := quantity.NewQuantity()
+	for _, r := range p.Recipients {
+		if err := total.Add(&r.Amount); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// VestingEpochs returns the largest number of vesting epochs of any recipient.
+func (p *CommonPoolSpendProposal) VestingEpochs() beacon.EpochTime {
+	var epochs beacon.EpochTime
+	for _, r := range p.Recipients {
+		if r.VestingEpochs > epochs {
+			epochs = r.VestingEpochs
+		}
+	}
+	return epochs
+}
+
+// ValidateBasic performs a basic validation on the common pool spend proposal.
+func (p *CommonPoolSpendProposal) ValidateBasic() error {
+	if len(p.Recipients) == 0 {
+		return fmt.Errorf("invalid recipients: recipients should not be empty")
+	}
+	if len(p.Recipients) > MaxCommonPoolSpendRecipients {
+		return fmt.Errorf("invalid recipients: too many recipients (max: %d)", MaxCommonPoolSpendRecipients)
+	}
+	seen := make(map[staking.Address]struct{})
+	for _, r := range p.Recipients {
+		if !r.Address.IsValid() {
+			return fmt.Errorf("invalid recipient address: %s", r.Address)
+		}
+		if r.Address.IsReserved() {
+			return fmt.Errorf("invalid recipient address: %s is reserved", r.Address)
+		}
+		if _, ok := seen[r.Address]; ok {
+			return fmt.Errorf("invalid recipients: duplicate recipient %s", r.Address)
+		}
+		seen[r.Address] = struct{}{}
+		if r.Amount.IsZero() {
+			return fmt.Errorf("invalid amount for recipient %s: amount should not be zero", r.Address)
+		}
+	}
+	return nil
+}
+
+// Equals checks if common pool spend proposals are equal.
+func (p *CommonPoolSpendProposal) Equals(other *CommonPoolSpendProposal) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	if len(p.Recipients) != len(other.Recipients) {
+		return false
+	}
+	for i := range p.Recipients {
+		r, or := &p.Recipients[i], &other.Recipients[i]
+		if !r.Address.Equal(or.Address) || r.Amount.Cmp(&or.Amount) != 0 || r.VestingEpochs != or.VestingEpochs {
+			return false
+		}
+	}
+	return true
+}
+
+// PrettyPrint writes a pretty-printed representation of CommonPoolSpendProposal to the given
+// writer.
+func (p CommonPoolSpendProposal) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sRecipients:\n", prefix)
+	for _, r := range p.Recipients {
+		fmt.Fprintf(w, "%s  - Address: %s\n", prefix, r.Address)
+		fmt.Fprintf(w, "%s    Amount:  ", prefix)
+		token.PrettyPrintAmount(ctx, r.Amount, w)
+		fmt.Fprintln(w)
+		if r.VestingEpochs > 0 {
+			fmt.Fprintf(w, "%s    Vesting: %d epochs\n", prefix, r.VestingEpochs)
+		}
+	}
+}
+
+// PrettyType returns a representation of CommonPoolSpendProposal that can be used for pretty
+// printing.
+func (p CommonPoolSpendProposal) PrettyType() (interface{}, error) {
+	return p, nil
+}
+
+// TextProposal is a non-executing proposal used for signalling.
+type TextProposal struct {
+	// Title is the title of the proposal.
+	Title string `json:"title"`
+	// Body is the text of the proposal.
+	Body string `json:"body,omitempty"`
+	// URL is an optional link to additional information about the proposal.
+	URL string `json:"url,omitempty"`
+}
+
+// ValidateBasic performs a basic validation on the text proposal.
+func (p *TextProposal) ValidateBasic() error {
+	if len(p.Title) == 0 {
+		return fmt.Errorf("invalid title: title should not be empty")
+	}
+	if len(p.Title) > MaxTextProposalTitleLength {
+		return fmt.Errorf("invalid title: title too long (max: %d)", MaxTextProposalTitleLength)
+	}
+	if len(p.Body) > MaxTextProposalBodyLength {
+		return fmt.Errorf("invalid body: body too long (max: %d)", MaxTextProposalBodyLength)
+	}
+	if len(p.URL) > MaxTextProposalURLLength {
+		return fmt.Errorf("invalid URL: URL too long (max: %d)", MaxTextProposalURLLength)
+	}
+	if len(p.URL) > 0 {
+		u, err := url.Parse(p.URL)
+		if err != nil {
+			return fmt.Errorf("invalid URL: %w", err)
+		}
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return fmt.Errorf("invalid URL: unsupported scheme '%s'", u.Scheme)
+		}
+	}
+	return nil
+}
+
+// Equals checks if text proposals are equal.
+func (p *TextProposal) Equals(other *TextProposal) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	return p.Title == other.Title && p.Body == other.Body && p.URL == other.URL
+}
+
+// PrettyPrint writes a pretty-printed representation of TextProposal to the given writer.
+func (p TextProposal) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sTitle: %s\n", prefix, p.Title)
+	if p.URL != "" {
+		fmt.Fprintf(w, "%sURL:   %s\n", prefix, p.URL)
+	}
+	if p.Body != "" {
+		fmt.Fprintf(w, "%sBody:\n", prefix)
+		for _, line := range strings.Split(p.Body, "\n") {
+			fmt.Fprintf(w, "%s  %s\n", prefix, line)
+		}
+	}
+}
+
+// PrettyType returns a representation of TextProposal that can be used for pretty printing.
+func (p TextProposal) PrettyType() (interface{}, error) {
+	return p, nil
+}
+
 // ProposalVote is a vote for a proposal.
 type ProposalVote struct {
 	// ID is the unique identifier of a proposal.
@@ -333,6 +575,10 @@ type Genesis struct {
 
 	// VoteEntries are the governance proposal vote entries.
 	VoteEntries map[uint64][]*VoteEntry `json:"vote_entries,omitempty"`
+
+	// VestingCommonPoolSpends are the payout statuses of passed common pool spend proposals that
+	// have not yet been paid out in full.
+	VestingCommonPoolSpends []*VestingCommonPoolSpend `json:"vesting_common_pool_spends,omitempty"`
 }
 
 // ConsensusParameters are the governance consensus parameters.
@@ -353,6 +599,21 @@ type ConsensusParameters struct {
 	// proposal to be accepted.  This value has a lower bound of 67.
 	StakeThreshold uint8 `json:"stake_threshold,omitempty"`
 
+	// VetoThreshold is the minimum percentage of VoteNoWithVeto votes in terms
+	// of voted stake when the proposal expires in order for a proposal to be
+	// vetoed. Vetoes only take effect in case the voted stake reaches the
+	// StakeThreshold percentage of total voting power. Vetoed proposals are
+	// rejected. Zero disables vetoes.
+	VetoThreshold uint8 `json:"veto_threshold,omitempty"`
+
+	// BurnVetoedDeposits is true iff deposits of vetoed proposals are burned
+	// instead of being transferred to the common pool.
+	BurnVetoedDeposits bool `json:"burn_vetoed_deposits,omitempty"`
+
+	// RejectInvalidVotes is true iff votes of an unknown kind are rejected
+	// instead of being counted as invalid votes.
+	RejectInvalidVotes bool `json:"reject_invalid_votes,omitempty"`
+
 	// UpgradeMinEpochDiff is the minimum number of epochs between the current
 	// epoch and the proposed upgrade epoch for the upgrade proposal to be valid.
 	// This is also the minimum number of epochs between two pending upgrades.
@@ -364,6 +625,12 @@ type ConsensusParameters struct {
 
 	// EnableChangeParametersProposal is true iff change parameters proposals are allowed.
 	EnableChangeParametersProposal bool `json:"enable_change_parameters_proposal,omitempty"`
+
+	// EnableCommonPoolSpendProposal is true iff common pool spend proposals are allowed.
+	EnableCommonPoolSpendProposal bool `json:"enable_common_pool_spend_proposal,omitempty"`
+
+	// EnableTextProposal is true iff text proposals are allowed.
+	EnableTextProposal bool `json:"enable_text_proposal,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed governance consensus parameter changes.
@@ -380,6 +647,15 @@ type ConsensusParameterChanges struct {
 	// StakeThreshold is the new stake threshold.
 	StakeThreshold *uint8 `json:"stake_threshold,omitempty"`
 
+	// VetoThreshold is the new veto threshold.
+	VetoThreshold *uint8 `json:"veto_threshold,omitempty"`
+
+	// BurnVetoedDeposits is the new burn vetoed deposits flag.
+	BurnVetoedDeposits *bool `json:"burn_vetoed_deposits,omitempty"`
+
+	// RejectInvalidVotes is the new reject invalid votes flag.
+	RejectInvalidVotes *bool `json:"reject_invalid_votes,omitempty"`
+
 	// UpgradeMinEpochDiff is the new minimal epoch difference between two pending upgrades.
 	UpgradeMinEpochDiff *beacon.EpochTime `json:"upgrade_min_epoch_diff,omitempty"`
 
@@ -389,6 +665,12 @@ type ConsensusParameterChanges struct {
 
 	// EnableChangeParametersProposal is the new enable change parameters proposal flag.
 	EnableChangeParametersProposal *bool `json:"enable_change_parameters_proposal,omitempty"`
+
+	// EnableCommonPoolSpendProposal is the new enable common pool spend proposal flag.
+	EnableCommonPoolSpendProposal *bool `json:"enable_common_pool_spend_proposal,omitempty"`
+
+	// EnableTextProposal is the new enable text proposal flag.
+	EnableTextProposal *bool `json:"enable_text_proposal,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -405,6 +687,15 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.StakeThreshold != nil {
 		params.StakeThreshold = *c.StakeThreshold
 	}
+	if c.VetoThreshold != nil {
+		params.VetoThreshold = *c.VetoThreshold
+	}
+	if c.BurnVetoedDeposits != nil {
+		params.BurnVetoedDeposits = *c.BurnVetoedDeposits
+	}
+	if c.RejectInvalidVotes != nil {
+		params.RejectInvalidVotes = *c.RejectInvalidVotes
+	}
 	if c.UpgradeMinEpochDiff != nil {
 		params.UpgradeMinEpochDiff = *c.UpgradeMinEpochDiff
 	}
@@ -414,6 +705,12 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.EnableChangeParametersProposal != nil {
 		params.EnableChangeParametersProposal = *c.EnableChangeParametersProposal
 	}
+	if c.EnableCommonPoolSpendProposal != nil {
+		params.EnableCommonPoolSpendProposal = *c.EnableCommonPoolSpendProposal
+	}
+	if c.EnableTextProposal != nil {
+		params.EnableTextProposal = *c.EnableTextProposal
+	}
 	return nil
 }
 
@@ -458,6 +755,8 @@ type ProposalFinalizedEvent struct {
 	ID uint64 `json:"id"`
 	// State is the new proposal state.
 	State ProposalState `json:"state"`
+	// Vetoed is true iff the proposal has been vetoed.
+	Vetoed bool `json:"vetoed,omitempty"`
 }
 
 // EventKind returns a string representation of this event's kind.
@@ -4,16 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
+var testAddr = staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
 func TestValidateBasic(t *testing.T) {
 	for _, tc := range []struct {
 		msg       string
@@ -61,6 +67,91 @@ func TestValidateBasic(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			msg: "common pool spend without recipients should fail",
+			p: &ProposalContent{
+				CommonPoolSpend: &CommonPoolSpendProposal{},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "common pool spend with zero amount should fail",
+			p: &ProposalContent{
+				CommonPoolSpend: &CommonPoolSpendProposal{
+					Recipients: []CommonPoolSpendRecipient{{Address: testAddr}},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "common pool spend with reserved recipient should fail",
+			p: &ProposalContent{
+				CommonPoolSpend: &CommonPoolSpendProposal{
+					Recipients: []CommonPoolSpendRecipient{
+						{Address: staking.CommonPoolAddress, Amount: *quantity.NewFromUint64(10)},
+					},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "common pool spend with duplicate recipients should fail",
+			p: &ProposalContent{
+				CommonPoolSpend: &CommonPoolSpendProposal{
+					Recipients: []CommonPoolSpendRecipient{
+						{Address: testAddr, Amount: *quantity.NewFromUint64(10)},
+						{Address: testAddr, Amount: *quantity.NewFromUint64(20), VestingEpochs: 2},
+					},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "text proposal without title should fail",
+			p: &ProposalContent{
+				Text: &TextProposal{Body: "body"},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "text proposal with too long title should fail",
+			p: &ProposalContent{
+				Text: &TextProposal{Title: strings.Repeat("a", MaxTextProposalTitleLength+1)},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "text proposal with too long body should fail",
+			p: &ProposalContent{
+				Text: &TextProposal{Title: "title", Body: strings.Repeat("a", MaxTextProposalBodyLength+1)},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "text proposal with invalid URL should fail",
+			p: &ProposalContent{
+				Text: &TextProposal{Title: "title", URL: "ftp://example.com"},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "valid text proposal should not fail",
+			p: &ProposalContent{
+				Text: &TextProposal{Title: "title", Body: "body", URL: "https://example.com/proposal"},
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "valid common pool spend should not fail",
+			p: &ProposalContent{
+				CommonPoolSpend: &CommonPoolSpendProposal{
+					Recipients: []CommonPoolSpendRecipient{
+						{Address: testAddr, Amount: *quantity.NewFromUint64(10), VestingEpochs: 2},
+					},
+				},
+			},
+			shouldErr: false,
+		},
 	} {
 		err := tc.p.ValidateBasic()
 		if tc.shouldErr {
@@ -71,6 +162,41 @@ func TestValidateBasic(t *testing.T) {
 	}
 }
 
+func TestCommonPoolSpendVested(t *testing.T) {
+	require := require.New(t)
+
+	r := CommonPoolSpendRecipient{Address: testAddr, Amount: *quantity.NewFromUint64(100)}
+	for _, tc := range []struct {
+		epoch  beacon.EpochTime
+		amount uint64
+	}{
+		{9, 0},
+		{10, 100},
+		{11, 100},
+	} {
+		amount, err := r.Vested(10, tc.epoch)
+		require.NoError(err, "Vested")
+		require.Zero(amount.Cmp(quantity.NewFromUint64(tc.amount)), "vested amount at epoch %d", tc.epoch)
+	}
+
+	r.VestingEpochs = 3
+	for _, tc := range []struct {
+		epoch  beacon.EpochTime
+		amount uint64
+	}{
+		{9, 0},
+		{10, 0},
+		{11, 33},
+		{12, 66},
+		{13, 100},
+		{14, 100},
+	} {
+		amount, err := r.Vested(10, tc.epoch)
+		require.NoError(err, "Vested")
+		require.Zero(amount.Cmp(quantity.NewFromUint64(tc.amount)), "vested amount at epoch %d", tc.epoch)
+	}
+}
+
 func TestProposalContentEquals(t *testing.T) {
 	for _, tc := range []struct {
 		msg    string
@@ -100,6 +100,8 @@ type Proposal struct {
 	Results map[Vote]quantity.Quantity `json:"results,omitempty"`
 	// InvalidVotes is the number of invalid votes after tallying.
 	InvalidVotes uint64 `json:"invalid_votes,omitempty"`
+	// Vetoed is true iff the proposal has been rejected due to reaching the veto threshold.
+	Vetoed bool `json:"vetoed,omitempty"`
 }
 
 // VotedSum returns the sum of all votes.
@@ -116,10 +118,13 @@ func (p *Proposal) VotedSum() (*quantity.Quantity, error) {
 // CloseProposal closes an active proposal based on the vote results and
 // specified voting parameters.
 //
-// The proposal is accepted iff the percentage of yes votes relative to
-// total voting power is at least `stakeThreshold`.  Otherwise the proposal
-// is rejected.
-func (p *Proposal) CloseProposal(totalVotingStake quantity.Quantity, stakeThreshold uint8) error {
+// The proposal is vetoed and rejected in case `vetoThreshold` is non-zero, the
+// percentage of voted stake relative to total voting power is at least
+// `stakeThreshold` and the percentage of no with veto votes relative to the
+// voted stake is at least `vetoThreshold`. Otherwise, the proposal is accepted iff the percentage of
+// yes votes relative to total voting power is at least `stakeThreshold`.
+// Otherwise the proposal is rejected.
+func (p *Proposal) CloseProposal(totalVotingStake quantity.Quantity, stakeThreshold uint8, vetoThreshold uint8) error {
 	if p.State != StateActive {
 		return fmt.Errorf("%w: expected: %v, got: %v", errInvalidProposalState, StateActive, p.State)
 	}
@@ -139,6 +144,36 @@ func (p *Proposal) CloseProposal(totalVotingStake quantity.Quantity, stakeThresh
 		return fmt.Errorf("%w: voted stake (%v) greater than total possbile voting stake (%v)", errInvalidProposalState, votedStake, totalVotingStake)
 	}
 
+	// In case the percentage of no with veto votes (by stake) relative to the
+	// voted stake reaches the veto threshold, the proposal is vetoed. Vetoes
+	// only take effect in case the percentage of voted stake relative to the
+	// total voting power reaches the stake threshold quorum.
+	votedVetoStake := p.Results[VoteNoWithVeto]
+	if vetoThreshold > 0 && !votedVetoStake.IsZero() {
+		votedPercentage := votedStake.Clone()
+		if err = votedPercentage.Mul(quantity.NewFromUint64(100)); err != nil {
+			return fmt.Errorf("failed to multiply votedPercentage: %w", err)
+		}
+		if err = votedPercentage.Quo(&totalVotingStake); err != nil {
+			return fmt.Errorf("failed to divide votedPercentage: %w", err)
+		}
+
+		votedVetoPercentage := votedVetoStake.Clone()
+		if err = votedVetoPercentage.Mul(quantity.NewFromUint64(100)); err != nil {
+			return fmt.Errorf("failed to multiply votedVetoPercentage: %w", err)
+		}
+		if err = votedVetoPercentage.Quo(votedStake); err != nil {
+			return fmt.Errorf("failed to divide votedVetoPercentage: %w", err)
+		}
+
+		if votedPercentage.Cmp(quantity.NewFromUint64(uint64(stakeThreshold))) >= 0 &&
+			votedVetoPercentage.Cmp(quantity.NewFromUint64(uint64(vetoThreshold))) >= 0 {
+			p.State = StateRejected
+			p.Vetoed = true
+			return nil
+		}
+	}
+
 	votedYesStake := p.Results[VoteYes]
 	if votedYesStake.IsZero() {
 		// If there's no yes votes, we can early reject the vote.
@@ -173,15 +208,27 @@ type Vote uint8
 
 // Vote kinds.
 const (
-	VoteYes     Vote = 1
-	VoteNo      Vote = 2
-	VoteAbstain Vote = 3
+	VoteYes        Vote = 1
+	VoteNo         Vote = 2
+	VoteAbstain    Vote = 3
+	VoteNoWithVeto Vote = 4
 
-	VoteYesName     = "yes"
-	VoteNoName      = "no"
-	VoteAbstainName = "abstain"
+	VoteYesName        = "yes"
+	VoteNoName         = "no"
+	VoteAbstainName    = "abstain"
+	VoteNoWithVetoName = "no_with_veto"
 )
 
+// IsValid checks whether the vote is a valid vote kind.
+func (v Vote) IsValid() bool {
+	switch v {
+	case VoteYes, VoteNo, VoteAbstain, VoteNoWithVeto:
+		return true
+	default:
+		return false
+	}
+}
+
 // String returns a string representation of a Vote.
 func (v Vote) String() string {
 	switch v {
@@ -191,6 +238,8 @@ func (v Vote) String() string {
 		return VoteNoName
 	case VoteAbstain:
 		return VoteAbstainName
+	case VoteNoWithVeto:
+		return VoteNoWithVetoName
 	default:
 		return fmt.Sprintf("[unknown vote: %d]", v)
 	}
@@ -205,6 +254,8 @@ func (v Vote) MarshalText() ([]byte, error) {
 		return []byte(VoteNoName), nil
 	case VoteAbstain:
 		return []byte(VoteAbstainName), nil
+	case VoteNoWithVeto:
+		return []byte(VoteNoWithVetoName), nil
 	default:
 		return nil, fmt.Errorf("invalid vote: %d", v)
 	}
@@ -219,6 +270,8 @@ func (v *Vote) UnmarshalText(text []byte) error {
 		*v = VoteNo
 	case VoteAbstainName:
 		*v = VoteAbstain
+	case VoteNoWithVetoName:
+		*v = VoteNoWithVeto
 	default:
 		return fmt.Errorf("invalid vote: %s", string(text))
 	}
@@ -276,3 +329,21 @@ func PendingUpgradesFromProposals(proposals []*Proposal, epoch beacon.EpochTime)
 	}
 	return pendingUpgrades, proposalIDs
 }
+
+// VestingCommonPoolSpend is the payout status of a passed common pool spend proposal that has not
+// yet been paid out in full.
+type VestingCommonPoolSpend struct {
+	// ProposalID is the identifier of the common pool spend proposal.
+	ProposalID uint64 `json:"proposal_id"`
+	// Paid are the amounts already paid out to the proposal's recipients, in the same order.
+	Paid []quantity.Quantity `json:"paid"`
+}
+
+// NewVestingCommonPoolSpend creates the payout status of a common pool spend proposal with
+// nothing paid out yet.
+func NewVestingCommonPoolSpend(proposal *Proposal) *VestingCommonPoolSpend {
+	return &VestingCommonPoolSpend{
+		ProposalID: proposal.ID,
+		Paid:       make([]quantity.Quantity, len(proposal.Content.CommonPoolSpend.Recipients)),
+	}
+}
@@ -53,7 +53,9 @@ func TestVote(t *testing.T) {
 		VoteYes,
 		VoteNo,
 		VoteAbstain,
+		VoteNoWithVeto,
 	} {
+		require.True(v.IsValid(), "vote should be valid")
 		enc, err := v.MarshalText()
 		require.NoError(err, "MarshalText")
 
@@ -69,6 +71,7 @@ func TestVote(t *testing.T) {
 	_, err := v.MarshalText()
 	require.Error(err, "MarshalText on invalid vote")
 	require.Contains(v.String(), "unknown vote", "String() on invalid vote")
+	require.False(v.IsValid(), "IsValid() on invalid vote")
 
 	var vt Vote
 	err = vt.UnmarshalText([]byte{})
@@ -112,9 +115,11 @@ func TestCloseProposal(t *testing.T) {
 		p                *Proposal
 		totalVotingStake *quantity.Quantity
 		stakeThreshold   uint8
+		vetoThreshold    uint8
 
-		expectedState ProposalState
-		expectedErr   error
+		expectedState  ProposalState
+		expectedVetoed bool
+		expectedErr    error
 	}{
 		{
 			msg: "proposal in invalid state",
@@ -217,8 +222,69 @@ func TestCloseProposal(t *testing.T) {
 			stakeThreshold:   90,
 			expectedState:    StatePassed,
 		},
+		{
+			msg: "proposal should be vetoed",
+			p: &Proposal{
+				State: StateActive,
+				// Veto threshold reached (34/100: 34% of voted stake).
+				Results: map[Vote]quantity.Quantity{
+					VoteYes:        *quantity.NewFromUint64(60),
+					VoteNoWithVeto: *quantity.NewFromUint64(34),
+					VoteAbstain:    *quantity.NewFromUint64(6),
+				},
+			},
+			totalVotingStake: totalVotingStake,
+			stakeThreshold:   50,
+			vetoThreshold:    34,
+			expectedState:    StateRejected,
+			expectedVetoed:   true,
+		},
+		{
+			msg: "proposal veto threshold barely not reached",
+			p: &Proposal{
+				State: StateActive,
+				// Veto threshold barely not reached (33/100: 33% of voted stake).
+				Results: map[Vote]quantity.Quantity{
+					VoteYes:        *quantity.NewFromUint64(60),
+					VoteNoWithVeto: *quantity.NewFromUint64(33),
+					VoteAbstain:    *quantity.NewFromUint64(7),
+				},
+			},
+			totalVotingStake: totalVotingStake,
+			stakeThreshold:   50,
+			vetoThreshold:    34,
+			expectedState:    StatePassed,
+		},
+		{
+			msg: "proposal should not be vetoed without quorum",
+			p: &Proposal{
+				State: StateActive,
+				// Veto threshold reached (1/1: 100% of voted stake), but quorum
+				// not reached (1/100: 1% of total voting stake).
+				Results: map[Vote]quantity.Quantity{
+					VoteNoWithVeto: *quantity.NewFromUint64(1),
+				},
+			},
+			totalVotingStake: totalVotingStake,
+			stakeThreshold:   50,
+			vetoThreshold:    34,
+			expectedState:    StateRejected,
+		},
+		{
+			msg: "proposal should not be vetoed when vetoes are disabled",
+			p: &Proposal{
+				State: StateActive,
+				Results: map[Vote]quantity.Quantity{
+					VoteYes:        *quantity.NewFromUint64(60),
+					VoteNoWithVeto: *quantity.NewFromUint64(40),
+				},
+			},
+			totalVotingStake: totalVotingStake,
+			stakeThreshold:   50,
+			expectedState:    StatePassed,
+		},
 	} {
-		err := tc.p.CloseProposal(*tc.totalVotingStake, tc.stakeThreshold)
+		err := tc.p.CloseProposal(*tc.totalVotingStake, tc.stakeThreshold, tc.vetoThreshold)
 		if tc.expectedErr != nil {
 			require.True(t, errors.Is(err, tc.expectedErr),
 				fmt.Sprintf("expected error: %v, got: %v: for case: %s", tc.expectedErr, err, tc.msg))
@@ -226,6 +292,7 @@ func TestCloseProposal(t *testing.T) {
 		}
 		require.NoError(t, err, tc.msg)
 		require.Equal(t, tc.expectedState, tc.p.State, tc.msg)
+		require.Equal(t, tc.expectedVetoed, tc.p.Vetoed, tc.msg)
 	}
 }
 
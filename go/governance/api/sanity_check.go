@@ -22,6 +22,10 @@ func (p *ConsensusParameters) SanityCheck() error {
 	if int64(p.StakeThreshold) <= 66 {
 		return fmt.Errorf("stake threshold must be greater than 66")
 	}
+	// VetoThreshold must be less than or equal to 100.
+	if int64(p.VetoThreshold) > 100 {
+		return fmt.Errorf("veto threshold must be less than or equal to 100")
+	}
 	// Voting_period must be less than upgrade_min_epoch_diff.
 	if p.VotingPeriod >= p.UpgradeMinEpochDiff {
 		return fmt.Errorf("voting_period should be less than upgrade_min_epoch_diff")
@@ -39,9 +43,14 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.MinProposalDeposit == nil &&
 		c.VotingPeriod == nil &&
 		c.StakeThreshold == nil &&
+		c.VetoThreshold == nil &&
+		c.BurnVetoedDeposits == nil &&
+		c.RejectInvalidVotes == nil &&
 		c.UpgradeMinEpochDiff == nil &&
 		c.UpgradeCancelMinEpochDiff == nil &&
-		c.EnableChangeParametersProposal == nil {
+		c.EnableChangeParametersProposal == nil &&
+		c.EnableCommonPoolSpendProposal == nil &&
+		c.EnableTextProposal == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
@@ -109,6 +118,39 @@ func SanityCheckVotes(proposal *Proposal, votes []*VoteEntry) error {
 	return nil
 }
 
+// SanityCheckVestingCommonPoolSpends sanity checks vesting common pool spends.
+func SanityCheckVestingCommonPoolSpends(proposals []*Proposal, spends []*VestingCommonPoolSpend) error {
+	proposalsByID := make(map[uint64]*Proposal)
+	for _, p := range proposals {
+		proposalsByID[p.ID] = p
+	}
+
+	seen := make(map[uint64]struct{})
+	for _, spend := range spends {
+		if _, ok := seen[spend.ProposalID]; ok {
+			return fmt.Errorf("vesting common pool spend %v: duplicate proposal", spend.ProposalID)
+		}
+		seen[spend.ProposalID] = struct{}{}
+
+		p, ok := proposalsByID[spend.ProposalID]
+		if !ok {
+			return fmt.Errorf("vesting common pool spend %v: missing proposal", spend.ProposalID)
+		}
+		if p.State != StatePassed || p.Content.CommonPoolSpend == nil {
+			return fmt.Errorf("vesting common pool spend %v: not a passed common pool spend proposal", spend.ProposalID)
+		}
+		if len(spend.Paid) != len(p.Content.CommonPoolSpend.Recipients) {
+			return fmt.Errorf("vesting common pool spend %v: paid amounts don't match recipients", spend.ProposalID)
+		}
+		for i, r := range p.Content.CommonPoolSpend.Recipients {
+			if spend.Paid[i].Cmp(&r.Amount) > 0 {
+				return fmt.Errorf("vesting common pool spend %v: paid more than the amount to %s", spend.ProposalID, r.Address)
+			}
+		}
+	}
+	return nil
+}
+
 // SanityCheckPendingUpgrades sanity checks pending upgrades.
 func SanityCheckPendingUpgrades(upgrades []*upgrade.Descriptor, epoch beacon.EpochTime, params *ConsensusParameters) error {
 	var upgradeEpochs []beacon.EpochTime
@@ -154,6 +196,9 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime, governanceDeposits *quantity
 			return fmt.Errorf("governance: votes sanity check failed: %w", err)
 		}
 	}
+	if err := SanityCheckVestingCommonPoolSpends(g.Proposals, g.VestingCommonPoolSpends); err != nil {
+		return fmt.Errorf("governance: vesting common pool spends sanity check failed: %w", err)
+	}
 	upgrades, _ := PendingUpgradesFromProposals(g.Proposals, now)
 	if err := SanityCheckPendingUpgrades(upgrades, now, &g.Parameters); err != nil {
 		return fmt.Errorf("governance: pending upgrades sanity check failed: %w", err)
@@ -79,6 +79,12 @@ var (
 	// RPCMethodInit is the name of the `init` method.
 	RPCMethodInit = "init"
 
+	// RPCMethodGetOrCreateKeys is the name of the `get_or_create_keys` method.
+	RPCMethodGetOrCreateKeys = "get_or_create_keys"
+
+	// RPCMethodGetOrCreateEphemeralKeys is the name of the `get_or_create_ephemeral_keys` method.
+	RPCMethodGetOrCreateEphemeralKeys = "get_or_create_ephemeral_keys"
+
 	// RPCMethodGetPublicKey is the name of the `get_public_key` method.
 	RPCMethodGetPublicKey = "get_public_key"
 
@@ -97,6 +103,12 @@ var (
 	// RPCMethodLoadEphemeralSecret is the name of the `load_ephemeral_secret` RPC method.
 	RPCMethodLoadEphemeralSecret = "load_ephemeral_secret"
 
+	// RPCMethodExportMasterSecrets is the name of the `export_master_secrets` RPC method.
+	RPCMethodExportMasterSecrets = "export_master_secrets"
+
+	// RPCMethodImportMasterSecrets is the name of the `import_master_secrets` RPC method.
+	RPCMethodImportMasterSecrets = "import_master_secrets"
+
 	// initResponseSignatureContext is the context used to sign key manager init responses.
 	initResponseSignatureContext = signature.NewContext("oasis-core/keymanager: init response")
 )
@@ -398,12 +410,19 @@ type Genesis struct {
 // ConsensusParameters are the key manager consensus parameters.
 type ConsensusParameters struct {
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+
+	// EnableMasterSecretRecovery is true iff policies with a master secret recovery
+	// configuration are allowed.
+	EnableMasterSecretRecovery bool `json:"enable_master_secret_recovery,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed key manager consensus parameter changes.
 type ConsensusParameterChanges struct {
 	// GasCosts are the new gas costs.
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+
+	// EnableMasterSecretRecovery is the new master secret recovery enablement flag.
+	EnableMasterSecretRecovery *bool `json:"enable_master_secret_recovery,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -411,6 +430,9 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.GasCosts != nil {
 		params.GasCosts = c.GasCosts
 	}
+	if c.EnableMasterSecretRecovery != nil {
+		params.EnableMasterSecretRecovery = *c.EnableMasterSecretRecovery
+	}
 	return nil
 }
 
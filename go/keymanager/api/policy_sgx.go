@@ -31,6 +31,10 @@ type PolicySGX struct {
 
 	// MaxEphemeralSecretAge is the maximum age of an ephemeral secret in the number of epochs.
 	MaxEphemeralSecretAge beacon.EpochTime `json:"max_ephemeral_secret_age,omitempty"`
+
+	// MasterSecretRecovery is the master secret disaster recovery policy. If not set, master
+	// secrets cannot be exported from key manager enclaves.
+	MasterSecretRecovery *MasterSecretRecoveryPolicy `json:"master_secret_recovery,omitempty"`
 }
 
 // EnclavePolicySGX is the per-SGX key manager enclave ID access control policy.
@@ -67,6 +71,12 @@ func SanityCheckSignedPolicySGX(currentSigPol, newSigPol *SignedPolicySGX) error
 		}
 	}
 
+	if recovery := newSigPol.Policy.MasterSecretRecovery; recovery != nil {
+		if err := recovery.SanityCheck(); err != nil {
+			return err
+		}
+	}
+
 	// If a prior version of the policy is not provided, then there is nothing
 	// more to check.  Even with a prior version of the document, since policy
 	// updates can happen independently of a new version of the enclave, it's
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+	"github.com/oasisprotocol/deoxysii"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	mrae "github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/deoxysii"
+)
+
+// MaxRecoveryCustodians is the maximum number of master secret recovery custodians.
+//
+// Shares are evaluated at non-zero points of GF(2^8), which limits the number of shares.
+const MaxRecoveryCustodians = 255
+
+// MasterSecretRecoveryPolicy is the master secret disaster recovery policy.
+//
+// When set, key manager enclaves are allowed to export all master secret generations split
+// into Shamir shares, each encrypted to one of the recovery custodians, so that any threshold
+// of custodians can restore the master secrets to a fresh key manager committee.
+type MasterSecretRecoveryPolicy struct {
+	// Threshold is the number of custodians needed to recover the master secrets.
+	Threshold uint8 `json:"threshold"`
+
+	// Custodians are the public keys of the recovery custodians.
+	Custodians []x25519.PublicKey `json:"custodians"`
+}
+
+// SanityCheck performs a sanity check on the master secret recovery policy.
+func (p *MasterSecretRecoveryPolicy) SanityCheck() error {
+	if len(p.Custodians) == 0 {
+		return fmt.Errorf("keymanager: sanity check failed: recovery policy has no custodians")
+	}
+	if len(p.Custodians) > MaxRecoveryCustodians {
+		return fmt.Errorf("keymanager: sanity check failed: recovery policy has too many custodians (max: %d)", MaxRecoveryCustodians)
+	}
+	if p.Threshold == 0 || int(p.Threshold) > len(p.Custodians) {
+		return fmt.Errorf("keymanager: sanity check failed: recovery policy threshold must be between 1 and %d", len(p.Custodians))
+	}
+
+	custodians := make(map[x25519.PublicKey]struct{}, len(p.Custodians))
+	for _, pk := range p.Custodians {
+		if _, ok := custodians[pk]; ok {
+			return fmt.Errorf("keymanager: sanity check failed: recovery policy has duplicate custodians")
+		}
+		custodians[pk] = struct{}{}
+	}
+
+	return nil
+}
+
+// MasterSecretBackup is a backup of all master secret generations, split into Shamir shares
+// encrypted to the recovery custodians.
+type MasterSecretBackup struct {
+	// ID is the runtime ID of the key manager.
+	ID common.Namespace `json:"runtime_id"`
+
+	// Generation is the generation of the latest master secret in the backup. The backup
+	// contains all generations up to and including this one.
+	Generation uint64 `json:"generation"`
+
+	// Checksum is the checksum of the latest master secret in the backup.
+	Checksum []byte `json:"checksum"`
+
+	// Threshold is the number of shares needed to recover the master secrets.
+	Threshold uint8 `json:"threshold"`
+
+	// PubKey is the public key used to derive the symmetric keys for decryption.
+	PubKey x25519.PublicKey `json:"pub_key"`
+
+	// Shares is the map of custodian encrypted shares.
+	Shares map[x25519.PublicKey][]byte `json:"shares"`
+}
+
+// OpenShare decrypts the share of the given custodian.
+func (b *MasterSecretBackup) OpenShare(sk *x25519.PrivateKey) ([]byte, error) {
+	ciphertext, ok := b.Shares[*sk.Public()]
+	if !ok {
+		return nil, fmt.Errorf("keymanager: backup contains no share for custodian")
+	}
+
+	share, err := openMasterSecretShare(ciphertext, b.ID, b.Generation, &b.PubKey, sk)
+	if err != nil {
+		return nil, fmt.Errorf("keymanager: failed to decrypt share: %w", err)
+	}
+	return share, nil
+}
+
+// EncryptedMasterSecretShare is a master secret share encrypted to the REK of a key manager
+// enclave which is importing the master secrets.
+type EncryptedMasterSecretShare struct {
+	// PubKey is the public key used to derive the symmetric key for decryption.
+	PubKey x25519.PublicKey `json:"pub_key"`
+
+	// Ciphertext is the REK encrypted share.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// SealMasterSecretShare encrypts a decrypted master secret share to the given key manager
+// enclave REK.
+func SealMasterSecretShare(share []byte, runtimeID common.Namespace, generation uint64, rek *x25519.PublicKey) (*EncryptedMasterSecretShare, error) {
+	var sk x25519.PrivateKey
+	if _, err := rand.Read(sk[:]); err != nil {
+		return nil, fmt.Errorf("keymanager: failed to generate ephemeral key: %w", err)
+	}
+
+	var nonce [deoxysii.NonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("keymanager: failed to generate nonce: %w", err)
+	}
+
+	additionalData := packRuntimeIDGeneration(runtimeID, generation)
+	ciphertext := mrae.Box.Seal(nil, nonce[:], share, additionalData, rek, &sk)
+	ciphertext = append(ciphertext, nonce[:]...)
+
+	return &EncryptedMasterSecretShare{
+		PubKey:     *sk.Public(),
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+// ExportMasterSecretsRequest is the export master secrets RPC request, sent to the key manager
+// enclave.
+type ExportMasterSecretsRequest struct {
+	// Generation is the generation of the latest master secret which should be exported.
+	Generation uint64 `json:"generation"`
+}
+
+// ExportMasterSecretsResponse is the RPC response, returned as part of
+// an ExportMasterSecretsRequest from the key manager enclave.
+type ExportMasterSecretsResponse struct {
+	Backup MasterSecretBackup `json:"backup"`
+}
+
+// ImportMasterSecretsRequest is the import master secrets RPC request, sent to the key manager
+// enclave.
+//
+// The request also serves as the recovery file format, assembled from the shares of enough
+// custodians.
+type ImportMasterSecretsRequest struct {
+	// ID is the runtime ID of the key manager.
+	ID common.Namespace `json:"runtime_id"`
+
+	// Generation is the generation of the latest master secret in the backup.
+	Generation uint64 `json:"generation"`
+
+	// Shares are the master secret shares encrypted to the importing enclave.
+	Shares []EncryptedMasterSecretShare `json:"shares"`
+}
+
+func openMasterSecretShare(ciphertext []byte, runtimeID common.Namespace, generation uint64, pk *x25519.PublicKey, sk *x25519.PrivateKey) ([]byte, error) {
+	if len(ciphertext) < deoxysii.NonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonceOffset := len(ciphertext) - deoxysii.NonceSize
+	nonce, ciphertext := ciphertext[nonceOffset:], ciphertext[:nonceOffset]
+
+	additionalData := packRuntimeIDGeneration(runtimeID, generation)
+	return mrae.Box.Open(nil, nonce, ciphertext, additionalData, pk, sk)
+}
+
+// packRuntimeIDGeneration concatenates runtime ID and generation (runtime_id || generation)
+// using little-endian byte order.
+func packRuntimeIDGeneration(runtimeID common.Namespace, generation uint64) []byte {
+	data := make([]byte, 0, len(runtimeID)+8)
+	data = append(data, runtimeID[:]...)
+	return binary.LittleEndian.AppendUint64(data, generation)
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+	"github.com/oasisprotocol/deoxysii"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	mrae "github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/deoxysii"
+)
+
+func generateTestCustodians(n int) ([]x25519.PrivateKey, []x25519.PublicKey) {
+	sks := make([]x25519.PrivateKey, 0, n)
+	pks := make([]x25519.PublicKey, 0, n)
+	for i := 0; i < n; i++ {
+		sk := x25519.PrivateKey(sha512.Sum512_256([]byte(fmt.Sprintf("custodian %d", i))))
+		sks = append(sks, sk)
+		pks = append(pks, *sk.Public())
+	}
+	return sks, pks
+}
+
+func TestMasterSecretRecoveryPolicy(t *testing.T) {
+	require := require.New(t)
+
+	_, custodians := generateTestCustodians(3)
+
+	policy := MasterSecretRecoveryPolicy{
+		Threshold:  2,
+		Custodians: custodians,
+	}
+	require.NoError(policy.SanityCheck())
+
+	policy.Threshold = 0
+	require.Error(policy.SanityCheck(), "zero threshold should be rejected")
+
+	policy.Threshold = 4
+	require.Error(policy.SanityCheck(), "threshold above the number of custodians should be rejected")
+
+	policy.Threshold = 2
+	policy.Custodians = append(policy.Custodians, custodians[0])
+	require.Error(policy.SanityCheck(), "duplicate custodians should be rejected")
+
+	policy.Custodians = nil
+	require.Error(policy.SanityCheck(), "missing custodians should be rejected")
+}
+
+func TestMasterSecretShare(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+
+	sks, custodians := generateTestCustodians(2)
+	ephemeral := x25519.PrivateKey(sha512.Sum512_256([]byte("ephemeral key")))
+	rek := x25519.PrivateKey(sha512.Sum512_256([]byte("rek")))
+
+	// Encrypt shares to custodians the same way as the key manager enclave does.
+	backup := MasterSecretBackup{
+		ID:         runtimeID,
+		Generation: 3,
+		Threshold:  2,
+		PubKey:     *ephemeral.Public(),
+		Shares:     make(map[x25519.PublicKey][]byte),
+	}
+	for i, pk := range custodians {
+		var nonce [deoxysii.NonceSize]byte
+		nonce[0] = byte(i)
+		share := []byte{byte(i + 1), 1, 2, 3}
+		ciphertext := mrae.Box.Seal(nil, nonce[:], share, packRuntimeIDGeneration(runtimeID, 3), &pk, &ephemeral)
+		backup.Shares[pk] = append(ciphertext, nonce[:]...)
+	}
+
+	// Custodians should be able to decrypt only their own shares.
+	share, err := backup.OpenShare(&sks[1])
+	require.NoError(err, "OpenShare")
+	require.Equal([]byte{2, 1, 2, 3}, share)
+
+	unknown := x25519.PrivateKey(sha512.Sum512_256([]byte("unknown")))
+	_, err = backup.OpenShare(&unknown)
+	require.Error(err, "OpenShare should fail for unknown custodians")
+
+	// Shares re-encrypted to an enclave should be bound to the runtime and generation.
+	encShare, err := SealMasterSecretShare(share, runtimeID, 3, rek.Public())
+	require.NoError(err, "SealMasterSecretShare")
+
+	opened, err := openMasterSecretShare(encShare.Ciphertext, runtimeID, 3, &encShare.PubKey, &rek)
+	require.NoError(err, "openMasterSecretShare")
+	require.Equal(share, opened)
+
+	_, err = openMasterSecretShare(encShare.Ciphertext, runtimeID, 4, &encShare.PubKey, &rek)
+	require.Error(err, "share should not open for a different generation")
+}
@@ -49,7 +49,7 @@ func (p *ConsensusParameters) SanityCheck() error {
 
 // SanityCheck performs a sanity check on the consensus parameter changes.
 func (c *ConsensusParameterChanges) SanityCheck() error {
-	if c.GasCosts == nil {
+	if c.GasCosts == nil && c.EnableMasterSecretRecovery == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/fault"
+)
+
+const (
+	cfgFaultSocket = "fault.socket"
+
+	cfgFaultDown      = "down"
+	cfgFaultLatency   = "latency"
+	cfgFaultJitter    = "jitter"
+	cfgFaultLoss      = "loss"
+	cfgFaultLeaveFree = "leave_free"
+
+	faultRequestTimeout = 5 * time.Minute
+)
+
+var (
+	faultFlags     = flag.NewFlagSet("", flag.ContinueOnError)
+	faultLinkFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	faultDiskFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	faultCmd = &cobra.Command{
+		Use:   "fault",
+		Short: "inject faults into a running network",
+	}
+
+	faultPartitionCmd = &cobra.Command{
+		Use:   "partition <node,node,...> <node,node,...> [...]",
+		Short: "partition the network into groups of nodes",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var req faultRequest
+			for _, group := range args {
+				req.Groups = append(req.Groups, strings.Split(group, ","))
+			}
+			return doFaultRequest("partition", &req)
+		},
+	}
+
+	faultLinkCmd = &cobra.Command{
+		Use:   "link <node> <node>",
+		Short: "set faults on the link between two nodes (use * to match all nodes)",
+		Args:  cobra.ExactArgs(2),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			_ = viper.BindPFlags(faultLinkFlags)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("link", &faultRequest{
+				A: args[0],
+				B: args[1],
+				Fault: fault.LinkFault{
+					Down:    viper.GetBool(cfgFaultDown),
+					Latency: viper.GetDuration(cfgFaultLatency),
+					Jitter:  viper.GetDuration(cfgFaultJitter),
+					Loss:    viper.GetFloat64(cfgFaultLoss),
+				},
+			})
+		},
+	}
+
+	faultHealCmd = &cobra.Command{
+		Use:   "heal",
+		Short: "remove all link faults",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("heal", &faultRequest{})
+		},
+	}
+
+	faultPauseCmd = &cobra.Command{
+		Use:   "pause <node>",
+		Short: "pause a node process (SIGSTOP)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("pause", &faultRequest{Node: args[0]})
+		},
+	}
+
+	faultResumeCmd = &cobra.Command{
+		Use:   "resume <node>",
+		Short: "resume a paused node process (SIGCONT)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("resume", &faultRequest{Node: args[0]})
+		},
+	}
+
+	faultFillDiskCmd = &cobra.Command{
+		Use:   "fill-disk <node>",
+		Short: "fill the disk hosting the node's data directory",
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			_ = viper.BindPFlags(faultDiskFlags)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("fill-disk", &faultRequest{
+				Node:      args[0],
+				LeaveFree: uint64(config.ParseSizeInBytes(viper.GetString(cfgFaultLeaveFree))),
+			})
+		},
+	}
+
+	faultFreeDiskCmd = &cobra.Command{
+		Use:   "free-disk <node>",
+		Short: "free the disk space allocated by fill-disk",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("free-disk", &faultRequest{Node: args[0]})
+		},
+	}
+
+	faultSkewClockCmd = &cobra.Command{
+		Use:   "skew-clock <node> <skew>",
+		Short: "skew the clock of a validator node (restarts the node)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			skew, err := time.ParseDuration(args[1])
+			if err != nil {
+				return fmt.Errorf("malformed clock skew: %w", err)
+			}
+			return doFaultRequest("skew-clock", &faultRequest{Node: args[0], ClockSkew: skew})
+		},
+	}
+
+	faultClearCmd = &cobra.Command{
+		Use:   "clear",
+		Short: "remove all link faults, resume paused nodes and free filled disks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("clear", &faultRequest{})
+		},
+	}
+
+	faultStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "show the injected faults",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doFaultRequest("status", &faultRequest{})
+		},
+	}
+)
+
+// faultRequest is a request sent to the fault control socket.
+type faultRequest struct {
+	Groups    [][]string      `json:"groups,omitempty"`
+	A         string          `json:"a,omitempty"`
+	B         string          `json:"b,omitempty"`
+	Fault     fault.LinkFault `json:"fault"`
+	Node      string          `json:"node,omitempty"`
+	LeaveFree uint64          `json:"leave_free,omitempty"`
+	ClockSkew time.Duration   `json:"clock_skew,omitempty"`
+}
+
+func newFaultHandler(fi *oasis.FaultInjector) http.Handler {
+	handlers := map[string]func(context.Context, *faultRequest) error{
+		"partition": func(_ context.Context, req *faultRequest) error {
+			return fi.Partition(req.Groups...)
+		},
+		"link": func(_ context.Context, req *faultRequest) error {
+			return fi.SetLinkFault(req.A, req.B, req.Fault)
+		},
+		"heal": func(context.Context, *faultRequest) error {
+			fi.Heal()
+			return nil
+		},
+		"pause": func(_ context.Context, req *faultRequest) error {
+			return fi.Pause(req.Node)
+		},
+		"resume": func(_ context.Context, req *faultRequest) error {
+			return fi.Resume(req.Node)
+		},
+		"fill-disk": func(_ context.Context, req *faultRequest) error {
+			return fi.FillDisk(req.Node, req.LeaveFree)
+		},
+		"free-disk": func(_ context.Context, req *faultRequest) error {
+			return fi.FreeDisk(req.Node)
+		},
+		"skew-clock": func(ctx context.Context, req *faultRequest) error {
+			return fi.SkewClock(ctx, req.Node, req.ClockSkew)
+		},
+		"clear": func(context.Context, *faultRequest) error {
+			return fi.Clear()
+		},
+		"status": func(context.Context, *faultRequest) error {
+			return nil
+		},
+	}
+
+	mux := http.NewServeMux()
+	for name, handler := range handlers {
+		handler := handler
+		mux.HandleFunc("/"+name, func(w http.ResponseWriter, r *http.Request) {
+			var req faultRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, fmt.Sprintf("malformed request: %s", err), http.StatusBadRequest)
+				return
+			}
+			if err := handler(r.Context(), &req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(fi.Status())
+		})
+	}
+	return mux
+}
+
+// serveFaultControl serves the fault control API on the given UNIX socket.
+func serveFaultControl(fi *oasis.FaultInjector, path string) (func(), error) {
+	_ = os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on fault control socket: %w", err)
+	}
+
+	srv := &http.Server{
+		Handler:           newFaultHandler(fi),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	return func() {
+		_ = srv.Close()
+		_ = os.Remove(path)
+	}, nil
+}
+
+func doFaultRequest(name string, req *faultRequest) error {
+	path := viper.GetString(cfgFaultSocket)
+	if path == "" {
+		return fmt.Errorf("fault control socket not configured (use --%s)", cfgFaultSocket)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+		Timeout: faultRequestTimeout,
+	}
+	rsp, err := client.Post("http://fault/"+name, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to send fault request: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read fault response: %w", err)
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return errors.New(strings.TrimSpace(string(data)))
+	}
+
+	// Pretty-print the resulting fault status.
+	var out bytes.Buffer
+	if err = json.Indent(&out, data, "", "  "); err != nil {
+		return fmt.Errorf("malformed fault response: %w", err)
+	}
+	fmt.Print(out.String())
+	return nil
+}
+
+func init() {
+	faultFlags.String(cfgFaultSocket, "", "path to the fault control socket (disabled if not set)")
+	_ = viper.BindPFlags(faultFlags)
+
+	faultLinkFlags.Bool(cfgFaultDown, false, "drop all traffic on the link")
+	faultLinkFlags.Duration(cfgFaultLatency, 0, "latency added to the traffic on the link")
+	faultLinkFlags.Duration(cfgFaultJitter, 0, "maximum random delay added on top of the latency")
+	faultLinkFlags.Float64(cfgFaultLoss, 0, "packet loss probability (0-1)")
+	faultLinkCmd.Flags().AddFlagSet(faultLinkFlags)
+
+	faultDiskFlags.String(cfgFaultLeaveFree, "0", "amount of disk space to leave available (e.g., 10mb)")
+	faultFillDiskCmd.Flags().AddFlagSet(faultDiskFlags)
+
+	for _, cmd := range []*cobra.Command{
+		faultPartitionCmd,
+		faultLinkCmd,
+		faultHealCmd,
+		faultPauseCmd,
+		faultResumeCmd,
+		faultFillDiskCmd,
+		faultFreeDiskCmd,
+		faultSkewClockCmd,
+		faultClearCmd,
+		faultStatusCmd,
+	} {
+		faultCmd.AddCommand(cmd)
+	}
+}
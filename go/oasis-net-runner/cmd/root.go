@@ -147,6 +147,19 @@ func runRoot(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("root: failed to start network: %w", err)
 	}
 
+	// Serve the fault control API if configured.
+	if path := viper.GetString(cfgFaultSocket); path != "" {
+		closeFn, err := serveFaultControl(net.Faults(), path)
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+
+		logger.Info("fault control socket available",
+			"path", path,
+		)
+	}
+
 	// Display information about where the client node socket is.
 	if len(net.Clients()) > 0 {
 		logger.Info("client node socket available",
@@ -194,11 +207,14 @@ func init() {
 
 	rootCmd.PersistentFlags().AddFlagSet(rootFlags)
 	rootCmd.PersistentFlags().AddFlagSet(env.Flags)
+	rootCmd.PersistentFlags().AddFlagSet(faultFlags)
 	rootCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
 	rootCmd.Flags().AddFlagSet(fixtures.FileFixtureFlags)
 
 	dumpFixtureCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
 	rootCmd.AddCommand(dumpFixtureCmd)
+	rootCmd.AddCommand(faultCmd)
+	rootCmd.AddCommand(soakCmd)
 
 	cobra.OnInitialize(func() {
 		if cfgFile != "" {
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/oasis-net-runner/fixtures"
+	"github.com/oasisprotocol/oasis-core/go/oasis-net-runner/soak"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource/workload"
+)
+
+const (
+	cfgSoakSeed             = "soak.seed"
+	cfgSoakDuration         = "soak.duration"
+	cfgSoakActionInterval   = "soak.action_interval"
+	cfgSoakActions          = "soak.actions"
+	cfgSoakLivenessInterval = "soak.liveness_interval"
+	cfgSoakSanityInterval   = "soak.sanity_interval"
+	cfgSoakWorkloads        = "soak.workloads"
+	cfgSoakUpgradeBinary    = "soak.upgrade_binary"
+	cfgSoakBundleDir        = "soak.bundle_dir"
+)
+
+var (
+	soakFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	soakCmd = &cobra.Command{
+		Use:   "soak",
+		Short: "run the network under randomized chaos and check invariants",
+		RunE:  runSoak,
+	}
+)
+
+func runSoak(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	seed := viper.GetInt64(cfgSoakSeed)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	actions, err := soak.ParseActions(viper.GetStringSlice(cfgSoakActions))
+	if err != nil {
+		return err
+	}
+	cfg := &soak.Config{
+		Seed:             seed,
+		Duration:         viper.GetDuration(cfgSoakDuration),
+		ActionInterval:   viper.GetDuration(cfgSoakActionInterval),
+		Actions:          actions,
+		LivenessInterval: viper.GetDuration(cfgSoakLivenessInterval),
+		SanityInterval:   viper.GetDuration(cfgSoakSanityInterval),
+		Workloads:        viper.GetStringSlice(cfgSoakWorkloads),
+		UpgradeBinary:    viper.GetString(cfgSoakUpgradeBinary),
+		BundleDir:        viper.GetString(cfgSoakBundleDir),
+	}
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+
+	// Initialize the base dir, logging, etc.
+	rootEnv, err := initRootEnv(cmd)
+	if err != nil {
+		return err
+	}
+	defer rootEnv.Cleanup()
+	logger := logging.GetLogger("net-runner")
+
+	childEnv, err := rootEnv.NewChild("net-runner", nil)
+	if err != nil {
+		return fmt.Errorf("soak: failed to setup child environment: %w", err)
+	}
+
+	fixture, err := fixtures.GetFixture()
+	if err != nil {
+		return err
+	}
+	soak.PrepareFixture(fixture)
+
+	net, err := fixture.Create(childEnv)
+	if err != nil {
+		return fmt.Errorf("soak: failed to instantiate fixture: %w", err)
+	}
+
+	runner, err := soak.New(cfg, childEnv, net)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("starting soak",
+		"seed", seed,
+	)
+	return runner.Run(ctx)
+}
+
+func init() {
+	soakFlags.Int64(cfgSoakSeed, 0, "chaos schedule seed (random if 0)")
+	soakFlags.Duration(cfgSoakDuration, 1*time.Hour, "duration of the soak run")
+	soakFlags.Duration(cfgSoakActionInterval, 1*time.Minute, "interval between chaos actions")
+	soakFlags.StringSlice(cfgSoakActions, []string{
+		string(soak.ActionRestart),
+		string(soak.ActionResync),
+	}, "enabled chaos actions (restart, upgrade, resync)")
+	soakFlags.Duration(cfgSoakLivenessInterval, 2*time.Minute, "interval in which the consensus height must advance")
+	soakFlags.Duration(cfgSoakSanityInterval, 5*time.Minute, "interval between consensus state sanity checks")
+	soakFlags.StringSlice(cfgSoakWorkloads, []string{
+		workload.NameTransfer,
+		workload.NameDelegation,
+		workload.NameQueries,
+	}, "transaction workloads to run")
+	soakFlags.String(cfgSoakUpgradeBinary, "", "node binary used by upgrade actions")
+	soakFlags.String(cfgSoakBundleDir, ".", "directory where the log bundle is written on violation")
+	_ = viper.BindPFlags(soakFlags)
+
+	soakCmd.Flags().AddFlagSet(soakFlags)
+	soakCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
+	soakCmd.Flags().AddFlagSet(fixtures.FileFixtureFlags)
+}
@@ -33,6 +33,7 @@ const (
 	cfgDeterministicIdentities = "fixture.default.deterministic_entities"
 	cfgFundEntities            = "fixture.default.fund_entities"
 	cfgEpochtimeMock           = "fixture.default.epochtime_mock"
+	cfgFaultInjection          = "fixture.default.fault_injection"
 	cfgHaltEpoch               = "fixture.default.halt_epoch"
 	cfgKeymanagerBinary        = "fixture.default.keymanager.binary"
 	cfgNodeBinary              = "fixture.default.node.binary"
@@ -104,6 +105,7 @@ func newDefaultFixture() (*oasis.NetworkFixture, error) {
 			},
 			DeterministicIdentities: viper.GetBool(cfgDeterministicIdentities),
 			FundEntities:            viper.GetBool(cfgFundEntities),
+			FaultInjection:          viper.GetBool(cfgFaultInjection),
 			StakingGenesis:          &stakingGenesis,
 		},
 		Entities: []oasis.EntityCfg{
@@ -314,6 +316,7 @@ func init() {
 	DefaultFixtureFlags.Bool(cfgDeterministicIdentities, false, "generate nodes with deterministic identities")
 	DefaultFixtureFlags.Bool(cfgFundEntities, false, "fund all entities in genesis")
 	DefaultFixtureFlags.Bool(cfgEpochtimeMock, false, "use mock epochtime")
+	DefaultFixtureFlags.Bool(cfgFaultInjection, false, "enable fault-injecting proxies in front of node P2P ports")
 	DefaultFixtureFlags.Bool(cfgSetupRuntimes, true, "initialize the network with runtimes and runtime nodes")
 	DefaultFixtureFlags.Int(cfgNumEntities, 1, "number of (non debug) entities in genesis")
 	DefaultFixtureFlags.String(cfgKeymanagerBinary, "simple-keymanager", "path to the keymanager runtime")
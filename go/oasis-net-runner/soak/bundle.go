@@ -0,0 +1,138 @@
+package soak
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const reportFile = "soak-report.json"
+
+// Report is the report of a soak run.
+type Report struct {
+	// Seed is the seed of the chaos schedule.
+	Seed int64 `json:"seed"`
+	// Start is the time the soak run started.
+	Start time.Time `json:"start"`
+	// End is the time the soak run ended.
+	End time.Time `json:"end"`
+	// Violation is the invariant violation that stopped the run (if any).
+	Violation string `json:"violation,omitempty"`
+	// Journal are the executed chaos actions.
+	Journal []*JournalEntry `json:"journal"`
+}
+
+// JournalEntry is an executed chaos action.
+type JournalEntry struct {
+	Action
+
+	// Time is the time the action was started.
+	Time time.Time `json:"time"`
+	// Node is the name of the target node.
+	Node string `json:"node"`
+	// Error is the error that occurred while executing the action (if any).
+	Error string `json:"error,omitempty"`
+}
+
+// isBundled returns true iff the given file should be included in the log bundle.
+func isBundled(name string) bool {
+	switch filepath.Ext(name) {
+	case ".log", ".json", ".yaml":
+		return true
+	default:
+		return false
+	}
+}
+
+// writeBundle writes a gzipped tarball with the report and all logs, configuration files and
+// summaries found under the given directory.
+func writeBundle(path, dir string, report *Report) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("soak: failed to create bundle: %w", err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	rawReport, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("soak: failed to marshal report: %w", err)
+	}
+	if err = tw.WriteHeader(&tar.Header{
+		Name:    reportFile,
+		Mode:    0o600,
+		Size:    int64(len(rawReport)),
+		ModTime: report.End,
+	}); err != nil {
+		return fmt.Errorf("soak: failed to write bundle: %w", err)
+	}
+	if _, err = tw.Write(rawReport); err != nil {
+		return fmt.Errorf("soak: failed to write bundle: %w", err)
+	}
+
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// Skip databases.
+			if strings.HasSuffix(entry.Name(), ".db") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !isBundled(entry.Name()) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		return addBundleFile(tw, path, rel)
+	})
+	if err != nil {
+		return fmt.Errorf("soak: failed to write bundle: %w", err)
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("soak: failed to write bundle: %w", err)
+	}
+	if err = gw.Close(); err != nil {
+		return fmt.Errorf("soak: failed to write bundle: %w", err)
+	}
+	return f.Sync()
+}
+
+func addBundleFile(tw *tar.Writer, path, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(name)
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	// Files may still be written to, so only copy the size recorded in the header.
+	_, err = io.CopyN(tw, f, hdr.Size)
+	return err
+}
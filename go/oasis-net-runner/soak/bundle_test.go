@@ -0,0 +1,74 @@
+package soak
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBundle(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"validator-0/node.log":             "node log",
+		"validator-0/config.yaml":          "config",
+		"genesis.json":                     "{}",
+		"validator-0/consensus.db/000.log": "database",
+		"validator-0/identity.pem":         "secret",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	report := &Report{
+		Seed:      42,
+		Start:     time.Now(),
+		End:       time.Now(),
+		Violation: "consensus is dead",
+		Journal: []*JournalEntry{
+			{Action: Action{Step: 1, Kind: ActionRestart}, Node: "compute-0"},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(writeBundle(path, dir, report), "writeBundle")
+
+	f, err := os.Open(path)
+	require.NoError(err, "Open")
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(err, "gzip.NewReader")
+	tr := tar.NewReader(gr)
+
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err, "Next")
+		data, err := io.ReadAll(tr)
+		require.NoError(err, "ReadAll")
+		files[hdr.Name] = string(data)
+	}
+
+	require.Len(files, 4)
+	require.Equal("node log", files["validator-0/node.log"])
+	require.Equal("config", files["validator-0/config.yaml"])
+	require.Equal("{}", files["genesis.json"])
+
+	var decoded Report
+	require.NoError(json.Unmarshal([]byte(files[reportFile]), &decoded), "report should be valid JSON")
+	require.EqualValues(42, decoded.Seed)
+	require.Equal(report.Violation, decoded.Violation)
+	require.Len(decoded.Journal, 1)
+	require.Equal("compute-0", decoded.Journal[0].Node)
+}
@@ -0,0 +1,81 @@
+package soak
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// ActionKind is the kind of a chaos action.
+type ActionKind string
+
+const (
+	// ActionRestart kills a node and starts it again.
+	ActionRestart ActionKind = "restart"
+	// ActionUpgrade restarts a node using the upgrade binary.
+	ActionUpgrade ActionKind = "upgrade"
+	// ActionResync stops a node, wipes its state and starts it again so that it needs to resync.
+	ActionResync ActionKind = "resync"
+)
+
+// AllActions are all supported chaos actions.
+var AllActions = []ActionKind{ActionRestart, ActionUpgrade, ActionResync}
+
+// ParseActions parses a list of chaos action kinds.
+func ParseActions(raw []string) ([]ActionKind, error) {
+	var actions []ActionKind
+	for _, r := range raw {
+		kind := ActionKind(strings.TrimSpace(r))
+		switch kind {
+		case ActionRestart, ActionUpgrade, ActionResync:
+		default:
+			return nil, fmt.Errorf("soak: unsupported action '%s'", r)
+		}
+		actions = append(actions, kind)
+	}
+	return actions, nil
+}
+
+// Action is a scheduled chaos action.
+type Action struct {
+	// Step is the sequence number of the action.
+	Step uint64 `json:"step"`
+	// Kind is the kind of the action.
+	Kind ActionKind `json:"kind"`
+	// Target is the index of the target node among the chaos targets.
+	Target int `json:"target"`
+}
+
+// Schedule is a deterministic schedule of chaos actions derived from a seed.
+type Schedule struct {
+	rng        *rand.Rand
+	actions    []ActionKind
+	numTargets int
+	step       uint64
+}
+
+// Next returns the next scheduled action.
+func (s *Schedule) Next() Action {
+	s.step++
+	return Action{
+		Step:   s.step,
+		Kind:   s.actions[s.rng.Intn(len(s.actions))],
+		Target: s.rng.Intn(s.numTargets),
+	}
+}
+
+// NewSchedule creates a new schedule of the given actions applied to the given number of
+// targets. The same seed always results in the same sequence of actions.
+func NewSchedule(seed int64, actions []ActionKind, numTargets int) (*Schedule, error) {
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("soak: no actions enabled")
+	}
+	if numTargets <= 0 {
+		return nil, fmt.Errorf("soak: no chaos targets")
+	}
+	return &Schedule{
+		rng:        rand.New(rand.NewSource(seed)), // nolint: gosec
+		actions:    actions,
+		numTargets: numTargets,
+	}, nil
+}
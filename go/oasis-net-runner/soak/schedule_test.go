@@ -0,0 +1,47 @@
+package soak
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewSchedule(42, nil, 1)
+	require.Error(err, "NewSchedule should fail without actions")
+	_, err = NewSchedule(42, AllActions, 0)
+	require.Error(err, "NewSchedule should fail without targets")
+
+	s1, err := NewSchedule(42, AllActions, 5)
+	require.NoError(err, "NewSchedule")
+	s2, err := NewSchedule(42, AllActions, 5)
+	require.NoError(err, "NewSchedule")
+	s3, err := NewSchedule(43, AllActions, 5)
+	require.NoError(err, "NewSchedule")
+
+	var differs bool
+	kinds := make(map[ActionKind]bool)
+	for i := 0; i < 100; i++ {
+		a1, a2, a3 := s1.Next(), s2.Next(), s3.Next()
+		require.Equal(a1, a2, "same seed should result in the same schedule")
+		require.EqualValues(i+1, a1.Step)
+		require.True(a1.Target >= 0 && a1.Target < 5, "target should be in range")
+		differs = differs || a1 != a3
+		kinds[a1.Kind] = true
+	}
+	require.True(differs, "different seeds should result in different schedules")
+	require.Len(kinds, len(AllActions), "all actions should be scheduled")
+}
+
+func TestParseActions(t *testing.T) {
+	require := require.New(t)
+
+	actions, err := ParseActions([]string{"restart", " resync"})
+	require.NoError(err, "ParseActions")
+	require.Equal([]ActionKind{ActionRestart, ActionResync}, actions)
+
+	_, err = ParseActions([]string{"explode"})
+	require.Error(err, "ParseActions should fail for unsupported actions")
+}
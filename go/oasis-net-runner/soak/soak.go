@@ -0,0 +1,416 @@
+// Package soak implements a chaos soak mode for networks started by the network runner.
+//
+// A soak run keeps a network running under a continuous transaction workload while nodes are
+// randomly restarted, upgraded or resynced according to a seeded schedule. Network invariants
+// are checked periodically and the first violation stops the run and produces a log bundle
+// together with the seed needed to replay the same chaos schedule.
+package soak
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource/workload"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// Config is the soak run configuration.
+type Config struct {
+	// Seed is the seed of the chaos schedule.
+	Seed int64
+	// Duration is the duration of the soak run.
+	Duration time.Duration
+	// ActionInterval is the interval between chaos actions.
+	ActionInterval time.Duration
+	// Actions are the enabled chaos actions.
+	Actions []ActionKind
+	// LivenessInterval is the interval in which the consensus height must advance.
+	LivenessInterval time.Duration
+	// SanityInterval is the interval between consensus state sanity checks.
+	SanityInterval time.Duration
+	// Workloads are the transaction workloads to run during the soak.
+	Workloads []string
+	// UpgradeBinary is the path to the node binary used by upgrade actions.
+	UpgradeBinary string
+	// BundleDir is the directory where the log bundle is written on violation.
+	BundleDir string
+}
+
+// Validate validates the soak run configuration.
+func (cfg *Config) Validate() error {
+	if cfg.Duration <= 0 {
+		return fmt.Errorf("soak: duration must be positive")
+	}
+	if cfg.ActionInterval <= 0 {
+		return fmt.Errorf("soak: action interval must be positive")
+	}
+	if cfg.LivenessInterval <= 0 {
+		return fmt.Errorf("soak: liveness interval must be positive")
+	}
+	if cfg.SanityInterval <= 0 {
+		return fmt.Errorf("soak: sanity interval must be positive")
+	}
+	if len(cfg.Actions) == 0 {
+		return fmt.Errorf("soak: no actions enabled")
+	}
+	for _, kind := range cfg.Actions {
+		if kind == ActionUpgrade && cfg.UpgradeBinary == "" {
+			return fmt.Errorf("soak: upgrade action requires an upgrade binary")
+		}
+	}
+	if cfg.UpgradeBinary != "" {
+		if _, err := os.Stat(cfg.UpgradeBinary); err != nil {
+			return fmt.Errorf("soak: bad upgrade binary: %w", err)
+		}
+	}
+	return nil
+}
+
+// PrepareFixture prepares the network fixture for a soak run by making sure that the
+// supplementary sanity checker is enabled on at least one validator.
+func PrepareFixture(f *oasis.NetworkFixture) {
+	if len(f.Validators) == 0 {
+		return
+	}
+	for _, v := range f.Validators {
+		if v.Consensus.SupplementarySanityInterval > 0 {
+			return
+		}
+	}
+	f.Validators[0].Consensus.SupplementarySanityInterval = 1
+}
+
+// Runner is a soak runner.
+type Runner struct {
+	cfg    *Config
+	env    *env.Env
+	net    *oasis.Network
+	logger *logging.Logger
+
+	report   Report
+	upgraded map[string]bool
+}
+
+// Run starts the network and runs the soak until either the configured duration elapses or
+// an invariant is violated.
+func (r *Runner) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r.report.Seed = r.cfg.Seed
+	r.report.Start = time.Now()
+
+	r.logger.Info("starting soak run",
+		"seed", r.cfg.Seed,
+		"duration", r.cfg.Duration,
+		"actions", r.cfg.Actions,
+	)
+
+	if err := r.net.Start(); err != nil {
+		return fmt.Errorf("soak: failed to start network: %w", err)
+	}
+	ctrl := r.net.Controller()
+	if err := ctrl.WaitNodesRegistered(ctx, r.net.NumRegisterNodes()); err != nil {
+		return fmt.Errorf("soak: failed to wait for nodes to register: %w", err)
+	}
+
+	targets := r.chaosTargets()
+	schedule, err := NewSchedule(r.cfg.Seed, r.cfg.Actions, len(targets))
+	if err != nil {
+		return err
+	}
+
+	workloadErrCh := make(chan error, len(r.cfg.Workloads))
+	for _, name := range r.cfg.Workloads {
+		if err = r.startWorkload(workloadErrCh, name); err != nil {
+			return fmt.Errorf("soak: failed to start workload %s: %w", name, err)
+		}
+	}
+
+	deadline := time.After(r.cfg.Duration)
+	actionTicker := time.NewTicker(r.cfg.ActionInterval)
+	defer actionTicker.Stop()
+	livenessTicker := time.NewTicker(r.cfg.LivenessInterval)
+	defer livenessTicker.Stop()
+	sanityTicker := time.NewTicker(r.cfg.SanityInterval)
+	defer sanityTicker.Stop()
+
+	var lastHeight int64
+	for {
+		var violation error
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			if err = r.net.CheckLogWatchers(); err != nil {
+				violation = fmt.Errorf("log watcher: %w", err)
+				break
+			}
+			r.report.End = time.Now()
+			r.logger.Info("soak run finished",
+				"seed", r.cfg.Seed,
+				"actions", len(r.report.Journal),
+			)
+			return nil
+		case err = <-r.net.Errors():
+			violation = fmt.Errorf("network error: %w", err)
+		case err = <-workloadErrCh:
+			if err != nil {
+				violation = fmt.Errorf("workload failed: %w", err)
+			}
+		case <-actionTicker.C:
+			action := schedule.Next()
+			if err = r.execute(ctx, action, targets[action.Target]); err != nil {
+				violation = fmt.Errorf("action %d (%s) failed: %w", action.Step, action.Kind, err)
+			}
+		case <-livenessTicker.C:
+			var status *consensus.Status
+			status, err = ctrl.Consensus.GetStatus(ctx)
+			if err != nil {
+				violation = fmt.Errorf("liveness: failed to query status: %w", err)
+				break
+			}
+			if status.LatestHeight <= lastHeight {
+				violation = fmt.Errorf("liveness: consensus height stuck at %d", status.LatestHeight)
+				break
+			}
+			lastHeight = status.LatestHeight
+		case <-sanityTicker.C:
+			violation = r.checkSanity(ctx, ctrl)
+		}
+		if violation != nil {
+			cancel()
+			return r.fail(violation)
+		}
+	}
+}
+
+func (r *Runner) chaosTargets() []*oasis.Node {
+	// The first validator and the first client are never touched as they are used for
+	// invariant checks and for running the workloads.
+	var targets []*oasis.Node
+	for _, v := range tail(r.net.Validators()) {
+		targets = append(targets, v.Node)
+	}
+	for _, c := range tail(r.net.ComputeWorkers()) {
+		targets = append(targets, c.Node)
+	}
+	for _, k := range tail(r.net.Keymanagers()) {
+		targets = append(targets, k.Node)
+	}
+	for _, c := range tail(r.net.Clients()) {
+		targets = append(targets, c.Node)
+	}
+	return targets
+}
+
+func tail[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	return s[1:]
+}
+
+func (r *Runner) execute(ctx context.Context, action Action, node *oasis.Node) error {
+	kind := action.Kind
+	switch {
+	case kind == ActionUpgrade && r.upgraded[node.Name]:
+		kind = ActionRestart
+	case kind == ActionResync && r.isValidator(node):
+		// Wiping validator state could stall consensus in small networks.
+		kind = ActionRestart
+	}
+
+	entry := &JournalEntry{
+		Action: Action{
+			Step:   action.Step,
+			Kind:   kind,
+			Target: action.Target,
+		},
+		Time: time.Now(),
+		Node: node.Name,
+	}
+	r.report.Journal = append(r.report.Journal, entry)
+
+	r.logger.Info("executing chaos action",
+		"step", action.Step,
+		"kind", kind,
+		"node", node.Name,
+	)
+
+	var err error
+	switch kind {
+	case ActionRestart:
+		err = node.Restart(ctx)
+	case ActionUpgrade:
+		node.SetBinary(r.cfg.UpgradeBinary)
+		if err = node.Restart(ctx); err == nil {
+			r.upgraded[node.Name] = true
+		}
+	case ActionResync:
+		if err = node.Stop(); err != nil {
+			break
+		}
+		if err = cli.New(r.env, r.net, r.logger).UnsafeReset(node.DataDir(), false, true, false); err != nil {
+			break
+		}
+		err = node.Start()
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return err
+}
+
+func (r *Runner) isValidator(node *oasis.Node) bool {
+	for _, v := range r.net.Validators() {
+		if v.Node == node {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Runner) checkSanity(ctx context.Context, ctrl *oasis.Controller) error {
+	doc, err := ctrl.Consensus.StateToGenesis(ctx, consensus.HeightLatest)
+	if err != nil {
+		// Queries may fail transiently (e.g., while the state is being pruned).
+		r.logger.Warn("failed to dump state for sanity check",
+			"err", err,
+		)
+		return nil
+	}
+	if err = doc.SanityCheck(); err != nil {
+		return fmt.Errorf("sanity check: %w", err)
+	}
+	return nil
+}
+
+func (r *Runner) fail(violation error) error {
+	r.report.End = time.Now()
+	r.report.Violation = violation.Error()
+
+	r.logger.Error("invariant violated",
+		"seed", r.cfg.Seed,
+		"err", violation,
+	)
+
+	bundleDir := r.cfg.BundleDir
+	if bundleDir == "" {
+		bundleDir = r.env.Dir()
+	}
+	path := filepath.Join(bundleDir, fmt.Sprintf("soak-%d.tar.gz", r.cfg.Seed))
+	if err := writeBundle(path, r.env.Dir(), &r.report); err != nil {
+		r.logger.Error("failed to write log bundle",
+			"err", err,
+		)
+		return fmt.Errorf("soak: seed %d: %w", r.cfg.Seed, violation)
+	}
+
+	return fmt.Errorf("soak: seed %d (bundle: %s): %w", r.cfg.Seed, path, violation)
+}
+
+func (r *Runner) startWorkload(errCh chan<- error, name string) error {
+	node := r.net.Clients()[0].Node
+
+	r.logger.Info("starting workload",
+		"name", name,
+		"node", node.Name,
+	)
+
+	d, err := r.env.NewSubDir(fmt.Sprintf("workload-%s", name))
+	if err != nil {
+		return err
+	}
+	w, err := d.NewLogWriter(fmt.Sprintf("workload-%s.log", name))
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"debug", "txsource",
+		"--address", "unix:" + node.SocketPath(),
+		"--" + common.CfgDebugAllowTestKeys,
+		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugTestEntity,
+		"--" + flags.CfgGenesisFile, r.net.GenesisPath(),
+		"--" + txsource.CfgWorkload, name,
+		"--" + txsource.CfgTimeLimit, r.cfg.Duration.String(),
+		"--" + txsource.CfgSeed, strconv.FormatInt(r.cfg.Seed, 10),
+		"--" + txsource.CfgSummary, filepath.Join(d.String(), fmt.Sprintf("workload-%s-summary.json", name)),
+	}
+	for _, ent := range tail(r.net.Entities()) {
+		args = append(args, "--"+txsource.CfgValidatorEntity, ent.EntityKeyPath())
+	}
+	var haveRuntime bool
+	for _, rt := range r.net.Runtimes() {
+		if rt.Kind() == registry.KindCompute {
+			args = append(args, "--"+workload.CfgRuntimeID, rt.ID().String())
+			haveRuntime = true
+			break
+		}
+	}
+	if !haveRuntime {
+		args = append(args, "--"+workload.CfgQueriesRuntimeEnabled+"=false")
+	}
+
+	cmd := exec.Command(r.net.Config().NodeBinary, args...)
+	cmd.SysProcAttr = env.CmdAttrs
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	r.logger.Info("launching workload binary",
+		"args", strings.Join(args, " "),
+	)
+
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	doneCh := r.env.AddTermOnCleanup(cmd)
+
+	go func() {
+		waitErr := <-doneCh
+		r.logger.Info("workload finished",
+			"name", name,
+			"err", waitErr,
+		)
+		if waitErr != nil {
+			waitErr = fmt.Errorf("%s: %w", name, waitErr)
+		}
+		errCh <- waitErr
+	}()
+
+	return nil
+}
+
+// New creates a new soak runner for the given (not yet started) network.
+func New(cfg *Config, childEnv *env.Env, net *oasis.Network) (*Runner, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(net.Validators()) == 0 || len(net.Clients()) == 0 {
+		return nil, fmt.Errorf("soak: network needs at least one validator and one client")
+	}
+
+	return &Runner{
+		cfg:      cfg,
+		env:      childEnv,
+		net:      net,
+		logger:   logging.GetLogger("net-runner/soak"),
+		upgraded: make(map[string]bool),
+	}, nil
+}
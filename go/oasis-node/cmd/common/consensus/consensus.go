@@ -3,6 +3,7 @@ package consensus
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -32,6 +33,22 @@ const (
 	// CfgTxFeeGas configures the maximum gas limit.
 	CfgTxFeeGas = "transaction.fee.gas"
 
+	// CfgTxFeePayer configures the public key of the fee payer.
+	CfgTxFeePayer = "transaction.fee_payer.public_key"
+
+	// CfgTxFeePayerNonce configures the nonce of the fee payer.
+	CfgTxFeePayerNonce = "transaction.fee_payer.nonce"
+
+	// CfgTxFeePayerUseAllowance configures whether the fee is charged against the allowance
+	// granted by the fee payer.
+	CfgTxFeePayerUseAllowance = "transaction.fee_payer.use_allowance"
+
+	// CfgTxValidUntilHeight configures the last block height at which the transaction is valid.
+	CfgTxValidUntilHeight = "transaction.valid_until.height"
+
+	// CfgTxValidUntilEpoch configures the last epoch in which the transaction is valid.
+	CfgTxValidUntilEpoch = "transaction.valid_until.epoch"
+
 	// CfgTxFile configures the filename for the transaction.
 	CfgTxFile = "transaction.file"
 
@@ -92,7 +109,52 @@ func GetTxNonceAndFee() (uint64, *transaction.Fee) {
 	return nonce, &fee
 }
 
+// GetTxFeePayer returns the configured transaction fee payer, if any.
+func GetTxFeePayer() *transaction.FeePayer {
+	pk := viper.GetString(CfgTxFeePayer)
+	if pk == "" {
+		return nil
+	}
+
+	feePayer := transaction.FeePayer{
+		Nonce:        viper.GetUint64(CfgTxFeePayerNonce),
+		UseAllowance: viper.GetBool(CfgTxFeePayerUseAllowance),
+	}
+	if err := feePayer.PublicKey.UnmarshalText([]byte(pk)); err != nil {
+		logger.Error("failed to parse fee payer public key",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return &feePayer
+}
+
+// GetTxValidUntil returns the configured transaction expiry, if any.
+func GetTxValidUntil() *transaction.ValidUntil {
+	validUntil := transaction.ValidUntil{
+		Height: viper.GetInt64(CfgTxValidUntilHeight),
+		Epoch:  viper.GetUint64(CfgTxValidUntilEpoch),
+	}
+	if validUntil.Height == 0 && validUntil.Epoch == 0 {
+		return nil
+	}
+	if err := validUntil.SanityCheck(); err != nil {
+		logger.Error("invalid transaction expiry",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return &validUntil
+}
+
 func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer signature.Signer) {
+	if tx.FeePayer == nil {
+		tx.FeePayer = GetTxFeePayer()
+	}
+	if tx.ValidUntil == nil {
+		tx.ValidUntil = GetTxValidUntil()
+	}
+
 	if viper.GetBool(CfgTxUnsigned) {
 		rawUnsignedTx := cbor.Marshal(tx)
 		if err := os.WriteFile(viper.GetString(CfgTxFile), rawUnsignedTx, 0o600); err != nil {
@@ -140,6 +202,36 @@ func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer sign
 		os.Exit(1)
 	}
 
+	SaveSignedTx(sigTx)
+
+	if tx.FeePayer != nil {
+		fmt.Printf("\nThe transaction must be co-signed by the fee payer before it can be submitted.\n")
+	}
+}
+
+// LoadSignedTx loads a signed transaction from the configured transaction file.
+func LoadSignedTx() *transaction.SignedTransaction {
+	rawTx, err := os.ReadFile(viper.GetString(CfgTxFile))
+	if err != nil {
+		logger.Error("failed to read raw serialized transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var tx transaction.SignedTransaction
+	if err = json.Unmarshal(rawTx, &tx); err != nil {
+		logger.Error("failed to parse serialized transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	return &tx
+}
+
+// SaveSignedTx saves the signed transaction to the configured transaction file.
+func SaveSignedTx(sigTx *transaction.SignedTransaction) {
 	prettySigTx, err := cmdCommon.PrettyJSONMarshal(sigTx)
 	if err != nil {
 		logger.Error("failed to get pretty JSON of signed transaction",
@@ -155,6 +247,59 @@ func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer sign
 	}
 }
 
+// SignFeePayerAndSaveTx co-signs the signed transaction stored in the configured transaction file
+// as its fee payer and saves it back.
+func SignFeePayerAndSaveTx(ctx context.Context) {
+	sigTx := LoadSignedTx()
+
+	// Only verify the signer's signature as the fee payer signature is not yet available.
+	var tx transaction.Transaction
+	if err := sigTx.Signed.Open(transaction.SignatureContext, &tx); err != nil {
+		logger.Error("failed to open signed transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if tx.FeePayer == nil {
+		logger.Error("transaction does not specify a fee payer")
+		os.Exit(1)
+	}
+
+	_, signer, err := cmdCommon.LoadEntitySigner()
+	if err != nil {
+		logger.Error("failed to load signer",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer signer.Reset()
+
+	fmt.Printf("You are about to pay the fee for the following transaction signed by %s:\n", sigTx.Signature.PublicKey)
+	tx.PrettyPrint(ctx, "  ", os.Stdout)
+
+	switch cmdSigner.Backend() {
+	case signerFile.SignerName:
+		if !cmdFlags.AssumeYes() {
+			if !cmdCommon.GetUserConfirmation("\nAre you sure you want to continue? (y)es/(n)o: ") {
+				os.Exit(1)
+			}
+		}
+	case signerPlugin.SignerName:
+		if cmdCommon.Isatty(os.Stdin.Fd()) {
+			fmt.Println("\nYou may need to review the transaction on your device if you use a hardware-based signer plugin...")
+		}
+	}
+
+	if err = sigTx.SignFeePayer(signer); err != nil {
+		logger.Error("failed to co-sign transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	SaveSignedTx(sigTx)
+}
+
 func init() {
 	TxFileFlags.String(CfgTxFile, "", "path to the transaction")
 	_ = viper.BindPFlags(TxFileFlags)
@@ -163,6 +308,11 @@ func init() {
 	TxFlags.Uint64(CfgTxFeeAmount, 0, "transaction fee in base units")
 	TxFlags.String(CfgTxFeeGas, "0", "maximum transaction gas limit")
 	TxFlags.Bool(CfgTxUnsigned, false, "generate an unsigned transaction")
+	TxFlags.Int64(CfgTxValidUntilHeight, 0, "last block height at which the transaction is valid (0 for no expiry)")
+	TxFlags.Uint64(CfgTxValidUntilEpoch, 0, "last epoch in which the transaction is valid (0 for no expiry)")
+	TxFlags.String(CfgTxFeePayer, "", "public key of the account paying the transaction fee, in base64")
+	TxFlags.Uint64(CfgTxFeePayerNonce, 0, "nonce of the fee payer account")
+	TxFlags.Bool(CfgTxFeePayerUseAllowance, false, "charge the fee against the allowance granted by the fee payer")
 	_ = viper.BindPFlags(TxFlags)
 	TxFlags.AddFlagSet(TxFileFlags)
 	TxFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
@@ -0,0 +1,91 @@
+// Package config implements global gRPC configuration options.
+package config
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/bytesize"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
+)
+
+// Config is the gRPC configuration structure.
+type Config struct {
+	// Internal socket configuration.
+	Internal InternalConfig `yaml:"internal,omitempty"`
+	// Public read-only listener configuration.
+	Public PublicConfig `yaml:"public,omitempty"`
+}
+
+// InternalConfig is the internal gRPC socket configuration structure.
+type InternalConfig struct {
+	// Per-method authorization policy for clients of the internal socket.
+	//
+	// If no rules are configured, any client able to connect to the socket
+	// can call all methods.
+	Policy auth.Policy `yaml:"policy,omitempty"`
+}
+
+// PublicConfig is the public read-only gRPC listener configuration structure.
+type PublicConfig struct {
+	// Enable the public read-only gRPC listener.
+	Enabled bool `yaml:"enabled"`
+	// Port of the public read-only gRPC listener.
+	Port uint16 `yaml:"port"`
+	// Maximum number of requests per second per client IP address (0 = unlimited).
+	RequestRate float64 `yaml:"request_rate"`
+	// Maximum number of requests in a burst per client IP address.
+	RequestBurst int `yaml:"request_burst"`
+	// Maximum number of concurrent streams per client IP address (0 = unlimited).
+	MaxConcurrentStreams int `yaml:"max_concurrent_streams"`
+	// Maximum size of a request (e.g., 1mb).
+	MaxRequestSize string `yaml:"max_request_size"`
+}
+
+// Validate validates the configuration settings.
+func (c *Config) Validate() error {
+	if err := c.Internal.Policy.Validate(); err != nil {
+		return fmt.Errorf("internal.policy: %w", err)
+	}
+	if c.Public.Enabled {
+		if c.Public.Port == 0 {
+			return fmt.Errorf("public.port must be set when the public listener is enabled")
+		}
+		if c.Public.RequestRate < 0 {
+			return fmt.Errorf("public.request_rate must not be negative")
+		}
+		if c.Public.RequestRate > 0 && c.Public.RequestBurst < 1 {
+			return fmt.Errorf("public.request_burst must be at least 1 when rate limiting is enabled")
+		}
+		if c.Public.MaxConcurrentStreams < 0 {
+			return fmt.Errorf("public.max_concurrent_streams must not be negative")
+		}
+		if c.Public.MaxRequestSize == "" {
+			return fmt.Errorf("public.max_request_size must be set when the public listener is enabled")
+		}
+		size, err := bytesize.Parse(c.Public.MaxRequestSize)
+		if err != nil {
+			return fmt.Errorf("public.max_request_size: %w", err)
+		}
+		if size == 0 {
+			return fmt.Errorf("public.max_request_size must be positive")
+		}
+	}
+	return nil
+}
+
+// DefaultConfig returns the default configuration settings.
+func DefaultConfig() Config {
+	return Config{
+		Internal: InternalConfig{
+			Policy: auth.Policy{},
+		},
+		Public: PublicConfig{
+			Enabled:              false,
+			Port:                 9002,
+			RequestRate:          50,
+			RequestBurst:         100,
+			MaxConcurrentStreams: 16,
+			MaxRequestSize:       "1mb",
+		},
+	}
+}
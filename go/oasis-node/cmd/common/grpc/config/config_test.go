@@ -0,0 +1,22 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicMaxRequestSizeValidate(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Public.Enabled = true
+	require.NoError(cfg.Validate(), "default public listener configuration should be valid")
+
+	for _, size := range []string{"", "0", "0kb", "1 mib", "abc"} {
+		cfg = DefaultConfig()
+		cfg.Public.Enabled = true
+		cfg.Public.MaxRequestSize = size
+		require.Error(cfg.Validate(), "max request size '%s' should be rejected", size)
+	}
+}
@@ -17,8 +17,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 )
@@ -82,13 +84,22 @@ func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
 		path = viper.GetString(CfgDebugGrpcInternalSocketPath)
 	}
 
-	config := &cmnGrpc.ServerConfig{
+	cfg := &cmnGrpc.ServerConfig{
 		Name:           "internal",
 		Path:           path,
 		InstallWrapper: installWrapper,
 	}
 
-	return cmnGrpc.NewServer(config)
+	// Enforce the internal socket authorization policy, if configured.
+	if policy := &config.GlobalConfig.GRPC.Internal.Policy; !policy.IsEmpty() {
+		logger.Info("enforcing internal socket authorization policy",
+			"num_rules", len(policy.Rules),
+		)
+		cfg.AuthFunc = policy.AuthFunc
+		cfg.CustomOptions = append(cfg.CustomOptions, grpc.Creds(auth.NewPeerCredentials()))
+	}
+
+	return cmnGrpc.NewServer(cfg)
 }
 
 func NewClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
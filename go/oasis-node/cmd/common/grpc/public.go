@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
+	"github.com/oasisprotocol/oasis-core/go/config"
+)
+
+// PublicReadOnlyPolicy is the authorization policy of the public gRPC listener. It only allows
+// read-only queries and watches of the consensus, staking, registry, roothash and runtime client
+// services. State dumps, state sync and transaction submission are not allowed.
+var PublicReadOnlyPolicy = auth.Policy{
+	Rules: []auth.PolicyRule{
+		{
+			Name:       "read-only",
+			Identities: []string{auth.IdentityAny},
+			Methods: []string{
+				"/oasis-core.Consensus/EstimateGas",
+				"/oasis-core.Consensus/Get*",
+				"/oasis-core.Consensus/Watch*",
+
+				"/oasis-core.Staking/TokenSymbol",
+				"/oasis-core.Staking/TokenValueExponent",
+				"/oasis-core.Staking/TotalSupply",
+				"/oasis-core.Staking/CommonPool",
+				"/oasis-core.Staking/LastBlockFees",
+				"/oasis-core.Staking/GovernanceDeposits",
+				"/oasis-core.Staking/BaseFee",
+				"/oasis-core.Staking/Threshold",
+				"/oasis-core.Staking/Addresses",
+				"/oasis-core.Staking/CommissionScheduleAddresses",
+				"/oasis-core.Staking/Account",
+				"/oasis-core.Staking/AccountWithProof",
+				"/oasis-core.Staking/Delegation*",
+				"/oasis-core.Staking/DebondingDelegation*",
+				"/oasis-core.Staking/Allowance",
+				"/oasis-core.Staking/ConsensusParameters",
+				"/oasis-core.Staking/GetEvents",
+				"/oasis-core.Staking/Watch*",
+
+				"/oasis-core.Registry/Get*",
+				"/oasis-core.Registry/ConsensusParameters",
+				"/oasis-core.Registry/Watch*",
+
+				"/oasis-core.RootHash/Get*",
+				"/oasis-core.RootHash/ConsensusParameters",
+				"/oasis-core.RootHash/Watch*",
+
+				"/oasis-core.RuntimeClient/Get*",
+				"/oasis-core.RuntimeClient/Query",
+				"/oasis-core.RuntimeClient/Watch*",
+			},
+		},
+	},
+}
+
+// NewServerPublic constructs a new public read-only gRPC server listening on the configured
+// TCP port, or returns nil if the public listener is disabled.
+//
+// Services registered on the server are restricted by PublicReadOnlyPolicy and clients are
+// subject to the configured per-IP limits.
+//
+// This internally takes a snapshot of the current global tracer, so
+// make sure you initialize the global tracer before calling this.
+func NewServerPublic() (*cmnGrpc.Server, error) {
+	cfg := config.GlobalConfig.GRPC.Public
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	return cmnGrpc.NewServer(&cmnGrpc.ServerConfig{
+		Name:     "public",
+		Port:     cfg.Port,
+		AuthFunc: PublicReadOnlyPolicy.AuthFunc,
+		ClientLimits: &cmnGrpc.ClientLimits{
+			RequestRate:          cfg.RequestRate,
+			RequestBurst:         cfg.RequestBurst,
+			MaxConcurrentStreams: cfg.MaxConcurrentStreams,
+		},
+		MaxRequestSize: int(config.ParseSizeInBytes(cfg.MaxRequestSize)),
+	})
+}
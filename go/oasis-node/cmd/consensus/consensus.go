@@ -3,7 +3,6 @@ package consensus
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 
@@ -20,6 +19,7 @@ import (
 	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
 )
 
 const (
@@ -47,6 +47,12 @@ var (
 		Run:   doShowTx,
 	}
 
+	signFeePayerCmd = &cobra.Command{
+		Use:   "sign_fee_payer",
+		Short: "Co-sign a pre-signed transaction as its fee payer",
+		Run:   doSignFeePayer,
+	}
+
 	estimateGasCmd = &cobra.Command{
 		Use:   "estimate_gas",
 		Short: "Estimate how much gas a transaction will use",
@@ -74,26 +80,6 @@ func doConnect(cmd *cobra.Command) (*grpc.ClientConn, consensus.ClientBackend) {
 	return conn, client
 }
 
-func loadTx() *transaction.SignedTransaction {
-	rawTx, err := os.ReadFile(viper.GetString(cmdConsensus.CfgTxFile))
-	if err != nil {
-		logger.Error("failed to read raw serialized transaction",
-			"err", err,
-		)
-		os.Exit(1)
-	}
-
-	var tx transaction.SignedTransaction
-	if err = json.Unmarshal(rawTx, &tx); err != nil {
-		logger.Error("failed to parse serialized transaction",
-			"err", err,
-		)
-		os.Exit(1)
-	}
-
-	return &tx
-}
-
 func loadUnsignedTx() *transaction.Transaction {
 	rawUnsignedTx, err := os.ReadFile(viper.GetString(cmdConsensus.CfgTxFile))
 	if err != nil {
@@ -122,7 +108,7 @@ func doSubmitTx(cmd *cobra.Command, args []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
-	tx := loadTx()
+	tx := cmdConsensus.LoadSignedTx()
 
 	if err := client.SubmitTx(context.Background(), tx); err != nil {
 		logger.Error("failed to submit transaction",
@@ -144,10 +130,25 @@ func doShowTx(cmd *cobra.Command, args []string) {
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, genesis.Staking.TokenValueExponent)
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyGenesisHash, genesis.Hash())
 
-	sigTx := loadTx()
+	sigTx := cmdConsensus.LoadSignedTx()
 	sigTx.PrettyPrint(ctx, "", os.Stdout)
 }
 
+func doSignFeePayer(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	genesis := cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, genesis.Staking.TokenSymbol)
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, genesis.Staking.TokenValueExponent)
+
+	cmdConsensus.SignFeePayerAndSaveTx(ctx)
+}
+
 func doEstimateGas(cmd *cobra.Command, args []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -207,6 +208,7 @@ func Register(parentCmd *cobra.Command) {
 	for _, v := range []*cobra.Command{
 		submitTxCmd,
 		showTxCmd,
+		signFeePayerCmd,
 		estimateGasCmd,
 		nextBlockStateCmd,
 	} {
@@ -219,6 +221,13 @@ func Register(parentCmd *cobra.Command) {
 	showTxCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
 	showTxCmd.Flags().AddFlagSet(cmdFlags.GenesisFileFlags)
 
+	signFeePayerCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
+	signFeePayerCmd.Flags().AddFlagSet(cmdFlags.DebugTestEntityFlags)
+	signFeePayerCmd.Flags().AddFlagSet(cmdFlags.AssumeYesFlag)
+	signFeePayerCmd.Flags().AddFlagSet(cmdSigner.Flags)
+	signFeePayerCmd.Flags().AddFlagSet(cmdSigner.CLIFlags)
+	signFeePayerCmd.Flags().AddFlagSet(cmdFlags.GenesisFileFlags)
+
 	estimateGasCmd.Flags().StringVar(&signerPub, CfgSignerPub, "", "public key of the signer, in base64")
 	estimateGasCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
 	estimateGasCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
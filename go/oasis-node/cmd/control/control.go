@@ -305,5 +305,8 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
 	controlCmd.AddCommand(controlStatusCmd)
 	controlCmd.AddCommand(controlRuntimeStatsCmd)
+	registerTxPoolCmd(controlCmd)
+	registerP2PCmd(controlCmd)
+	registerKeymanagerCmd(controlCmd)
 	parentCmd.AddCommand(controlCmd)
 }
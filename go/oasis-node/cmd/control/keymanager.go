@@ -0,0 +1,124 @@
+package control
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+)
+
+const (
+	cfgAuditRuntimeID = "audit.runtime_id"
+	cfgAuditFrom      = "audit.from"
+	cfgAuditTo        = "audit.to"
+	cfgAuditLimit     = "audit.limit"
+	cfgAuditJSON      = "audit.json"
+)
+
+var (
+	controlKeymanagerCmd = &cobra.Command{
+		Use:   "keymanager",
+		Short: "key manager inspection",
+	}
+
+	controlKeymanagerAuditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "show key manager access audit log records",
+		Run:   doKeymanagerAudit,
+	}
+
+	keymanagerAuditFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+func parseTime(raw string) time.Time {
+	if raw == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		logger.Error("malformed time",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return t
+}
+
+func doKeymanagerAudit(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	query := &keymanagerWorker.AuditQuery{
+		From:  parseTime(viper.GetString(cfgAuditFrom)),
+		To:    parseTime(viper.GetString(cfgAuditTo)),
+		Limit: viper.GetInt(cfgAuditLimit),
+	}
+	if raw := viper.GetString(cfgAuditRuntimeID); raw != "" {
+		runtimeID := parseRuntimeID(raw)
+		query.RuntimeID = &runtimeID
+	}
+
+	records, err := client.GetKeymanagerAuditLog(context.Background(), query)
+	if err != nil {
+		logger.Error("failed to query key manager audit log",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if viper.GetBool(cfgAuditJSON) {
+		printPrettyJSON(records)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetHeader([]string{"Time", "Peer ID", "Node ID", "Runtime ID", "Method", "Key Pair ID", "Allowed"})
+	for _, rec := range records {
+		var nodeID, runtimeID, keyPairID string
+		if rec.NodeID != nil {
+			nodeID = rec.NodeID.String()
+		}
+		if rec.RuntimeID != nil {
+			runtimeID = rec.RuntimeID.String()
+		}
+		if rec.KeyPairID != nil {
+			keyPairID = hex.EncodeToString(rec.KeyPairID[:])
+		}
+		table.Append([]string{
+			rec.Time().UTC().Format(time.RFC3339),
+			rec.PeerID.String(),
+			nodeID,
+			runtimeID,
+			rec.Method,
+			keyPairID,
+			strconv.FormatBool(rec.Allowed),
+		})
+	}
+	table.Render()
+}
+
+func registerKeymanagerCmd(parentCmd *cobra.Command) {
+	controlKeymanagerAuditCmd.Flags().AddFlagSet(keymanagerAuditFlags)
+
+	controlKeymanagerCmd.AddCommand(controlKeymanagerAuditCmd)
+	parentCmd.AddCommand(controlKeymanagerCmd)
+}
+
+func init() {
+	keymanagerAuditFlags.String(cfgAuditRuntimeID, "", "only show requests made for the given runtime")
+	keymanagerAuditFlags.String(cfgAuditFrom, "", "only show requests received at or after the given time (RFC 3339)")
+	keymanagerAuditFlags.String(cfgAuditTo, "", "only show requests received before the given time (RFC 3339)")
+	keymanagerAuditFlags.Int(cfgAuditLimit, 100, "maximum number of records to show (0 means no limit)")
+	keymanagerAuditFlags.Bool(cfgAuditJSON, false, "output audit log records as JSON")
+	_ = viper.BindPFlags(keymanagerAuditFlags)
+}
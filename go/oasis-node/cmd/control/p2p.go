@@ -0,0 +1,87 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const cfgP2PReputationJSON = "reputation.json"
+
+var (
+	controlP2PCmd = &cobra.Command{
+		Use:   "p2p",
+		Short: "P2P peer inspection",
+	}
+
+	controlP2PReputationCmd = &cobra.Command{
+		Use:   "reputation",
+		Short: "show reputation scores of P2P peers",
+		Run:   doP2PReputation,
+	}
+
+	p2pReputationFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+func doP2PReputation(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	scores, err := client.GetPeerReputations(context.Background())
+	if err != nil {
+		logger.Error("failed to query peer reputations",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if viper.GetBool(cfgP2PReputationJSON) {
+		printPrettyJSON(scores)
+		return
+	}
+
+	// Show the worst peers first.
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score < scores[j].Score
+	})
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetHeader([]string{"Peer ID", "Score", "Blocked", "Protocols"})
+	for _, ps := range scores {
+		protocols := make([]string, 0, len(ps.Protocols))
+		for p, score := range ps.Protocols {
+			protocols = append(protocols, fmt.Sprintf("%s=%.2f", p, score))
+		}
+		sort.Strings(protocols)
+
+		table.Append([]string{
+			ps.PeerID.String(),
+			strconv.FormatFloat(ps.Score, 'f', 2, 64),
+			strconv.FormatBool(ps.Blocked),
+			strings.Join(protocols, "\n"),
+		})
+	}
+	table.Render()
+}
+
+func registerP2PCmd(parentCmd *cobra.Command) {
+	controlP2PReputationCmd.Flags().AddFlagSet(p2pReputationFlags)
+
+	controlP2PCmd.AddCommand(controlP2PReputationCmd)
+	parentCmd.AddCommand(controlP2PCmd)
+}
+
+func init() {
+	p2pReputationFlags.Bool(cfgP2PReputationJSON, false, "output peer reputations as JSON")
+	_ = viper.BindPFlags(p2pReputationFlags)
+}
@@ -0,0 +1,207 @@
+package control
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
+)
+
+const (
+	cfgTxPoolJSON     = "json"
+	cfgEvictTxHash    = "tx_hash"
+	cfgEvictOlderThan = "older_than"
+)
+
+var (
+	controlTxPoolCmd = &cobra.Command{
+		Use:   "txpool",
+		Short: "runtime transaction pool inspection and eviction",
+	}
+
+	controlTxPoolListCmd = &cobra.Command{
+		Use:   "list <runtime-id>",
+		Short: "list transactions queued in the runtime transaction pool",
+		Args:  cobra.ExactArgs(1),
+		Run:   doTxPoolList,
+	}
+
+	controlTxPoolGetCmd = &cobra.Command{
+		Use:   "get <runtime-id> <tx-hash>",
+		Short: "show a transaction queued in the runtime transaction pool",
+		Args:  cobra.ExactArgs(2),
+		Run:   doTxPoolGet,
+	}
+
+	controlTxPoolEvictCmd = &cobra.Command{
+		Use:   "evict <runtime-id>",
+		Short: "evict transactions from the runtime transaction pool by hash or age",
+		Args:  cobra.ExactArgs(1),
+		Run:   doTxPoolEvict,
+	}
+
+	txPoolListFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+	txPoolEvictFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+func parseRuntimeID(raw string) common.Namespace {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalText([]byte(raw)); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return runtimeID
+}
+
+func parseTxHash(raw string) hash.Hash {
+	var h hash.Hash
+	if err := h.UnmarshalHex(raw); err != nil {
+		logger.Error("malformed transaction hash",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return h
+}
+
+func printTxPoolTransactions(txs []*txpool.TransactionInfo) {
+	if viper.GetBool(cfgTxPoolJSON) {
+		printPrettyJSON(txs)
+		return
+	}
+
+	// Show the oldest transactions first.
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].FirstSeen.Before(txs[j].FirstSeen)
+	})
+
+	now := time.Now()
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetHeader([]string{"Hash", "Queue", "Size", "Priority", "Sender", "Sender Seq", "Age"})
+	for _, ti := range txs {
+		age := "unknown"
+		if !ti.FirstSeen.IsZero() {
+			age = ti.Age(now).Truncate(time.Second).String()
+		}
+		table.Append([]string{
+			ti.Hash.String(),
+			ti.Queue,
+			strconv.Itoa(ti.Size),
+			strconv.FormatUint(ti.Priority, 10),
+			hex.EncodeToString(ti.Sender),
+			strconv.FormatUint(ti.SenderSeq, 10),
+			age,
+		})
+	}
+	table.Render()
+}
+
+func printPrettyJSON(v interface{}) {
+	pretty, err := cmdCommon.PrettyJSONMarshal(v)
+	if err != nil {
+		logger.Error("failed to get pretty JSON",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(pretty))
+}
+
+func doTxPoolList(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	runtimeID := parseRuntimeID(args[0])
+
+	txs, err := client.GetTxPoolTransactions(context.Background(), runtimeID)
+	if err != nil {
+		logger.Error("failed to query transaction pool",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	printTxPoolTransactions(txs)
+}
+
+func doTxPoolGet(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	query := &control.TxPoolTransactionQuery{
+		RuntimeID: parseRuntimeID(args[0]),
+		TxHash:    parseTxHash(args[1]),
+	}
+
+	ti, err := client.GetTxPoolTransaction(context.Background(), query)
+	if err != nil {
+		logger.Error("failed to query transaction pool",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	printPrettyJSON(ti)
+}
+
+func doTxPoolEvict(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	req := &control.EvictTxPoolTransactionsRequest{
+		RuntimeID: parseRuntimeID(args[0]),
+		OlderThan: viper.GetDuration(cfgEvictOlderThan),
+	}
+	for _, raw := range viper.GetStringSlice(cfgEvictTxHash) {
+		req.TxHashes = append(req.TxHashes, parseTxHash(raw))
+	}
+	if len(req.TxHashes) == 0 && req.OlderThan <= 0 {
+		logger.Error("at least one of --tx_hash or --older_than must be specified")
+		os.Exit(1)
+	}
+
+	evicted, err := client.EvictTxPoolTransactions(context.Background(), req)
+	if err != nil {
+		logger.Error("failed to evict transactions",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	printTxPoolTransactions(evicted)
+}
+
+func registerTxPoolCmd(parentCmd *cobra.Command) {
+	controlTxPoolListCmd.Flags().AddFlagSet(txPoolListFlags)
+	controlTxPoolEvictCmd.Flags().AddFlagSet(txPoolListFlags)
+	controlTxPoolEvictCmd.Flags().AddFlagSet(txPoolEvictFlags)
+
+	controlTxPoolCmd.AddCommand(controlTxPoolListCmd)
+	controlTxPoolCmd.AddCommand(controlTxPoolGetCmd)
+	controlTxPoolCmd.AddCommand(controlTxPoolEvictCmd)
+	parentCmd.AddCommand(controlTxPoolCmd)
+}
+
+func init() {
+	txPoolListFlags.Bool(cfgTxPoolJSON, false, "output transactions as JSON")
+	_ = viper.BindPFlags(txPoolListFlags)
+
+	txPoolEvictFlags.StringSlice(cfgEvictTxHash, nil, "hash of a transaction to evict (can be repeated)")
+	txPoolEvictFlags.Duration(cfgEvictOlderThan, 0, "evict transactions older than the given age")
+	_ = viper.BindPFlags(txPoolEvictFlags)
+}
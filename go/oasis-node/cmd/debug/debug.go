@@ -10,6 +10,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/fixgenesis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/localstorage"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/p2p"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
 )
@@ -29,6 +31,8 @@ func Register(parentCmd *cobra.Command) {
 	dumpdb.Register(debugCmd)
 	beacon.Register(debugCmd)
 	bundle.Register(debugCmd)
+	localstorage.Register(debugCmd)
+	p2p.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
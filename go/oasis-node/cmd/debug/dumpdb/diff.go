@@ -0,0 +1,332 @@
+package dumpdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/diff"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+const (
+	cfgDiffFrom        = "dump.diff.from"
+	cfgDiffTo          = "dump.diff.to"
+	cfgDiffFromVersion = "dump.diff.from_version"
+	cfgDiffToVersion   = "dump.diff.to_version"
+	cfgDiffFormat      = "dump.diff.format"
+	cfgDiffOutput      = "dump.diff.output"
+
+	diffFormatText = "text"
+	diffFormatJSON = "json"
+)
+
+var (
+	dumpDBDiffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "compare two consensus state dumps, or two versions of the on-disk consensus DB",
+		Run:   doDiff,
+	}
+
+	dumpDBDiffFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+// ModuleDiff is the structured diff of a single consensus module section.
+type ModuleDiff struct {
+	// Module is the name of the module section.
+	Module string `json:"module"`
+	// Changes are the changes in the module section.
+	Changes []diff.Change `json:"changes"`
+}
+
+// StateDiff is the structured diff between two consensus state dumps.
+type StateDiff struct {
+	// FromHeight is the height of the old state dump.
+	FromHeight int64 `json:"from_height"`
+	// ToHeight is the height of the new state dump.
+	ToHeight int64 `json:"to_height"`
+	// Modules are the per-module diffs. Modules without changes are omitted.
+	Modules []ModuleDiff `json:"modules"`
+}
+
+type stateSection struct {
+	name  string
+	value interface{}
+}
+
+// stateSections returns the per-module sections of the given state dump in a
+// form suitable for structured diffing, with collections keyed by identifier.
+func stateSections(doc *genesis.Document) ([]stateSection, error) {
+	entities := make(map[string]*entity.Entity)
+	for _, sigEnt := range doc.Registry.Entities {
+		var ent entity.Entity
+		if err := cbor.Unmarshal(sigEnt.Blob, &ent); err != nil {
+			return nil, fmt.Errorf("dumpdb: malformed registry entity: %w", err)
+		}
+		entities[ent.ID.String()] = &ent
+	}
+
+	nodes := make(map[string]*node.Node)
+	for _, sigNode := range doc.Registry.Nodes {
+		var n node.Node
+		if err := cbor.Unmarshal(sigNode.Blob, &n); err != nil {
+			return nil, fmt.Errorf("dumpdb: malformed registry node: %w", err)
+		}
+		nodes[n.ID.String()] = &n
+	}
+
+	runtimesByID := func(runtimes []*registry.Runtime) map[string]*registry.Runtime {
+		m := make(map[string]*registry.Runtime)
+		for _, rt := range runtimes {
+			m[rt.ID.String()] = rt
+		}
+		return m
+	}
+
+	proposals := make(map[string]interface{})
+	for _, p := range doc.Governance.Proposals {
+		proposals[strconv.FormatUint(p.ID, 10)] = p
+	}
+
+	keyManagers := make(map[string]interface{})
+	for _, st := range doc.KeyManager.Statuses {
+		keyManagers[st.ID.String()] = st
+	}
+
+	return []stateSection{
+		{"staking/parameters", doc.Staking.Parameters},
+		{"staking/supply", map[string]interface{}{
+			"total_supply":        doc.Staking.TotalSupply,
+			"common_pool":         doc.Staking.CommonPool,
+			"last_block_fees":     doc.Staking.LastBlockFees,
+			"governance_deposits": doc.Staking.GovernanceDeposits,
+		}},
+		{"staking/accounts", doc.Staking.Ledger},
+		{"staking/delegations", doc.Staking.Delegations},
+		{"staking/debonding_delegations", doc.Staking.DebondingDelegations},
+		{"registry/parameters", doc.Registry.Parameters},
+		{"registry/entities", entities},
+		{"registry/nodes", nodes},
+		{"registry/node_statuses", doc.Registry.NodeStatuses},
+		{"registry/runtimes", runtimesByID(doc.Registry.Runtimes)},
+		{"registry/suspended_runtimes", runtimesByID(doc.Registry.SuspendedRuntimes)},
+		{"roothash/parameters", doc.RootHash.Parameters},
+		{"roothash/runtime_states", doc.RootHash.RuntimeStates},
+		{"governance/parameters", doc.Governance.Parameters},
+		{"governance/proposals", proposals},
+		{"governance/vote_entries", doc.Governance.VoteEntries},
+		{"keymanager/parameters", doc.KeyManager.Parameters},
+		{"keymanager/statuses", keyManagers},
+		{"scheduler/parameters", doc.Scheduler.Parameters},
+		{"beacon", doc.Beacon},
+		{"consensus/parameters", doc.Consensus.Parameters},
+	}, nil
+}
+
+// DiffStates computes the per-module structured diff between two consensus
+// state dumps.
+func DiffStates(from, to *genesis.Document) (*StateDiff, error) {
+	fromSections, err := stateSections(from)
+	if err != nil {
+		return nil, err
+	}
+	toSections, err := stateSections(to)
+	if err != nil {
+		return nil, err
+	}
+
+	sd := &StateDiff{
+		FromHeight: from.Height,
+		ToHeight:   to.Height,
+		Modules:    []ModuleDiff{},
+	}
+	for i := range fromSections {
+		changes, err := diff.Structured(fromSections[i].value, toSections[i].value)
+		if err != nil {
+			return nil, fmt.Errorf("dumpdb: failed to diff %s: %w", fromSections[i].name, err)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		sd.Modules = append(sd.Modules, ModuleDiff{
+			Module:  fromSections[i].name,
+			Changes: changes,
+		})
+	}
+	return sd, nil
+}
+
+// PrettyPrint writes a human-readable representation of the state diff.
+func (sd *StateDiff) PrettyPrint(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "State diff between heights %d and %d:\n", sd.FromHeight, sd.ToHeight); err != nil {
+		return err
+	}
+	if len(sd.Modules) == 0 {
+		_, err := fmt.Fprintln(w, "No differences.")
+		return err
+	}
+	for _, md := range sd.Modules {
+		if _, err := fmt.Fprintf(w, "\n== %s (%d changes)\n", md.Module, len(md.Changes)); err != nil {
+			return err
+		}
+		for _, c := range md.Changes {
+			if _, err := fmt.Fprintln(w, c.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func loadDumpFile(fn string) (*genesis.Document, error) {
+	raw, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't sanity check the document as state dumps are not guaranteed to
+	// be valid genesis documents.
+	var doc genesis.Document
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("malformed state dump: %w", err)
+	}
+	return &doc, nil
+}
+
+func doDiff(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	format := viper.GetString(cfgDiffFormat)
+	switch format {
+	case diffFormatText, diffFormatJSON:
+	default:
+		logger.Error("unsupported diff output format",
+			"format", format,
+		)
+		return
+	}
+
+	fromFn, toFn := viper.GetString(cfgDiffFrom), viper.GetString(cfgDiffTo)
+
+	// Open the on-disk consensus DB only when at least one of the sides
+	// is not given as a state dump file.
+	ctx := context.Background()
+	var (
+		ldb           storage.LocalBackend
+		oldDoc        *genesis.Document
+		latestVersion int64
+	)
+	if fromFn == "" || toFn == "" {
+		dataDir := cmdCommon.DataDir()
+		if dataDir == "" {
+			logger.Error("data directory must be set when not comparing two state dump files")
+			return
+		}
+
+		var err error
+		if oldDoc, err = loadGenesisDocument(); err != nil {
+			logger.Error("failed to load existing genesis document",
+				"err", err,
+			)
+			return
+		}
+		if ldb, latestVersion, err = openStateStorage(ctx, dataDir, oldDoc); err != nil {
+			logger.Error("failed to initialize ABCI storage backend",
+				"err", err,
+			)
+			return
+		}
+		defer ldb.Cleanup()
+	}
+
+	load := func(fn, versionCfg string) (*genesis.Document, error) {
+		if fn != "" {
+			return loadDumpFile(fn)
+		}
+		return dumpState(ctx, ldb, oldDoc, viper.GetInt64(versionCfg), latestVersion)
+	}
+
+	fromDoc, err := load(fromFn, cfgDiffFromVersion)
+	if err != nil {
+		logger.Error("failed to load old state",
+			"err", err,
+		)
+		return
+	}
+	toDoc, err := load(toFn, cfgDiffToVersion)
+	if err != nil {
+		logger.Error("failed to load new state",
+			"err", err,
+		)
+		return
+	}
+
+	sd, err := DiffStates(fromDoc, toDoc)
+	if err != nil {
+		logger.Error("failed to compute state diff",
+			"err", err,
+		)
+		return
+	}
+
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgDiffOutput)
+	if err != nil {
+		logger.Error("failed to get output writer for state diff",
+			"err", err,
+		)
+		return
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+
+	switch format {
+	case diffFormatJSON:
+		var data []byte
+		if data, err = cmdCommon.PrettyJSONMarshal(sd); err == nil {
+			_, err = w.Write(data)
+		}
+	default:
+		err = sd.PrettyPrint(w)
+	}
+	if err != nil {
+		logger.Error("failed to write state diff",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+func init() {
+	dumpDBDiffFlags.String(cfgDiffFrom, "", "path to the old state dump (default: use the on-disk consensus DB)")
+	dumpDBDiffFlags.String(cfgDiffTo, "", "path to the new state dump (default: use the on-disk consensus DB)")
+	dumpDBDiffFlags.Int64(cfgDiffFromVersion, 0, "old ABCI state version when using the on-disk consensus DB (0 = most recent)")
+	dumpDBDiffFlags.Int64(cfgDiffToVersion, 0, "new ABCI state version when using the on-disk consensus DB (0 = most recent)")
+	dumpDBDiffFlags.String(cfgDiffFormat, diffFormatText, "output format (text, json)")
+	dumpDBDiffFlags.String(cfgDiffOutput, "", "path to the state diff output (default: stdout)")
+	_ = viper.BindPFlags(dumpDBDiffFlags)
+}
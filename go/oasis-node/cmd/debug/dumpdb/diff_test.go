@@ -0,0 +1,75 @@
+package dumpdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/diff"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestDiffStates(t *testing.T) {
+	require := require.New(t)
+
+	addr := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001"))
+	account := func(balance uint64) *staking.Account {
+		return &staking.Account{
+			General: staking.GeneralAccount{
+				Balance: *quantity.NewFromUint64(balance),
+			},
+		}
+	}
+
+	from := &genesis.Document{
+		Height: 10,
+		Staking: staking.Genesis{
+			Ledger: map[staking.Address]*staking.Account{
+				addr: account(100),
+			},
+		},
+	}
+	to := &genesis.Document{
+		Height: 20,
+		Staking: staking.Genesis{
+			Ledger: map[staking.Address]*staking.Account{
+				addr: account(90),
+			},
+		},
+		Governance: governance.Genesis{
+			Proposals: []*governance.Proposal{
+				{ID: 1, State: governance.StateActive},
+			},
+		},
+	}
+
+	sd, err := DiffStates(from, to)
+	require.NoError(err, "DiffStates")
+	require.EqualValues(10, sd.FromHeight)
+	require.EqualValues(20, sd.ToHeight)
+	require.Len(sd.Modules, 2)
+
+	require.Equal("staking/accounts", sd.Modules[0].Module)
+	require.Len(sd.Modules[0].Changes, 1)
+	require.Equal("/"+addr.String()+"/general/balance", sd.Modules[0].Changes[0].Path)
+	require.Equal(diff.ChangeModified, sd.Modules[0].Changes[0].Kind)
+
+	require.Equal("governance/proposals", sd.Modules[1].Module)
+	require.Len(sd.Modules[1].Changes, 1)
+	require.Equal("/1", sd.Modules[1].Changes[0].Path)
+	require.Equal(diff.ChangeAdded, sd.Modules[1].Changes[0].Kind)
+
+	var buf bytes.Buffer
+	err = sd.PrettyPrint(&buf)
+	require.NoError(err, "PrettyPrint")
+	require.Contains(buf.String(), "== staking/accounts (1 changes)")
+
+	sd, err = DiffStates(from, from)
+	require.NoError(err, "DiffStates")
+	require.Empty(sd.Modules, "identical states should have no changes")
+}
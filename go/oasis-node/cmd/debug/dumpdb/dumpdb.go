@@ -79,29 +79,83 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 
 	// Load the old genesis document, required for filling in parameters
 	// that are not persisted to ABCI state.
-	fp, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	oldDoc, err := loadGenesisDocument()
 	if err != nil {
 		logger.Error("failed to load existing genesis document",
 			"err", err,
 		)
 		return
 	}
-	oldDoc, err := fp.GetGenesisDocument()
+
+	ctx := context.Background()
+	ldb, latestVersion, err := openStateStorage(ctx, dataDir, oldDoc)
 	if err != nil {
-		logger.Error("failed to get existing genesis document",
+		logger.Error("failed to initialize ABCI storage backend",
 			"err", err,
 		)
 		return
 	}
+	defer ldb.Cleanup()
 
-	// Initialize the ABCI state storage for access.
-	//
+	dumpVersion := viper.GetInt64(cfgDumpVersion)
+	doc, err := dumpState(ctx, ldb, oldDoc, dumpVersion, latestVersion)
+	if err != nil {
+		logger.Error("failed to dump state",
+			"err", err,
+			"dump_version", dumpVersion,
+			"latest_version", latestVersion,
+		)
+		return
+	}
+
+	logger.Info("writing state dump",
+		"output", viper.GetString(cfgDumpOutput),
+	)
+
+	// Write out the document.
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgDumpOutput)
+	if err != nil {
+		logger.Error("failed to get output writer for state dump",
+			"err", err,
+		)
+		return
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	prettyDoc, err := cmdCommon.PrettyJSONMarshal(doc)
+	if err != nil {
+		logger.Error("failed to marshal state dump into JSON",
+			"err", err,
+		)
+		return
+	}
+	if _, err := w.Write(prettyDoc); err != nil {
+		logger.Error("failed to write state dump file",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+func loadGenesisDocument() (*genesis.Document, error) {
+	fp, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	return fp.GetGenesisDocument()
+}
+
+// openStateStorage initializes the ABCI state storage for access and returns
+// it together with the latest state version.
+func openStateStorage(ctx context.Context, dataDir string, oldDoc *genesis.Document) (storage.LocalBackend, int64, error) {
 	// Note: While it would be great to always use read-only DB access,
 	// badger will refuse to open a DB that isn't closed properly in
 	// read-only mode because it needs to truncate the value log.
 	//
 	// Hope you have backups if you ever run into this.
-	ctx := context.Background()
 	ldb, _, stateRoot, err := abci.InitStateStorage(
 		ctx,
 		&abci.ApplicationConfig{
@@ -114,33 +168,33 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 		},
 	)
 	if err != nil {
-		logger.Error("failed to initialize ABCI storage backend",
-			"err", err,
-		)
+		return nil, 0, err
 	}
-	defer ldb.Cleanup()
+	return ldb, int64(stateRoot.Version), nil
+}
 
-	latestVersion := int64(stateRoot.Version)
-	dumpVersion := viper.GetInt64(cfgDumpVersion)
+// dumpState generates the state dump at the given version (0 meaning the
+// latest version) by querying all of the relevant backends, and extracting
+// the immutable parameters from the current genesis document.
+//
+// WARNING: The state is not guaranteed to be usable as a genesis
+// document without manual intervention, and only the state that
+// would be exported by the normal dump process will be present
+// in the dump.
+func dumpState(
+	ctx context.Context,
+	ldb storage.LocalBackend,
+	oldDoc *genesis.Document,
+	dumpVersion int64,
+	latestVersion int64,
+) (*genesis.Document, error) {
 	if dumpVersion == 0 {
 		dumpVersion = latestVersion
 	}
 	if dumpVersion <= 0 || dumpVersion > latestVersion {
-		logger.Error("dump requested for version that does not exist",
-			"dump_version", dumpVersion,
-			"latest_version", latestVersion,
-		)
-		return
+		return nil, fmt.Errorf("dumpdb: dump requested for version that does not exist: %d (latest: %d)", dumpVersion, latestVersion)
 	}
 
-	// Generate the dump by querying all of the relevant backends, and
-	// extracting the immutable parameters from the current genesis
-	// document.
-	//
-	// WARNING: The state is not guaranteed to be usable as a genesis
-	// document without manual intervention, and only the state that
-	// would be exported by the normal dump process will be present
-	// in the dump.
 	qs := &dumpQueryState{
 		ldb:    ldb,
 		height: dumpVersion,
@@ -159,29 +213,21 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	// Registry
 	registrySt, err := dumpRegistry(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump registry state",
-			"err", err,
-		)
+		return nil, err
 	}
 	doc.Registry = *registrySt
 
 	// RootHash
 	rootHashSt, err := dumpRootHash(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump root hash state",
-			"err", err,
-		)
-		return
+		return nil, err
 	}
 	doc.RootHash = *rootHashSt
 
 	// Staking
 	stakingSt, err := dumpStaking(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump staking state",
-			"err", err,
-		)
-		return
+		return nil, err
 	}
 	// Add static values to the staking genesis state.
 	stakingSt.TokenSymbol = oldDoc.Staking.TokenSymbol
@@ -191,83 +237,39 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	// KeyManager
 	keyManagerSt, err := dumpKeyManager(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump key manager state",
-			"err", err,
-		)
-		return
+		return nil, err
 	}
 	doc.KeyManager = *keyManagerSt
 
 	// Scheduler
 	schedulerSt, err := dumpScheduler(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump scheduler state",
-			"err", err,
-		)
-		return
+		return nil, err
 	}
 	doc.Scheduler = *schedulerSt
 
 	// Governance
 	governanceSt, err := dumpGovernance(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump governance state",
-			"err", err,
-		)
-		return
+		return nil, err
 	}
 	doc.Governance = *governanceSt
 
 	// Beacon
 	beaconSt, err := dumpBeacon(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump beacon state",
-			"err", err,
-		)
-		return
+		return nil, err
 	}
 	doc.Beacon = *beaconSt
 
 	// Consensus
 	consensusSt, err := dumpConsensus(ctx, qs)
 	if err != nil {
-		logger.Error("failed to dump consensus state",
-			"err", err,
-		)
-		return
+		return nil, err
 	}
 	doc.Consensus = *consensusSt
 
-	logger.Info("writing state dump",
-		"output", viper.GetString(cfgDumpOutput),
-	)
-
-	// Write out the document.
-	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgDumpOutput)
-	if err != nil {
-		logger.Error("failed to get output writer for state dump",
-			"err", err,
-		)
-		return
-	}
-	if shouldClose {
-		defer w.Close()
-	}
-	prettyDoc, err := cmdCommon.PrettyJSONMarshal(doc)
-	if err != nil {
-		logger.Error("failed to marshal state dump into JSON",
-			"err", err,
-		)
-		return
-	}
-	if _, err := w.Write(prettyDoc); err != nil {
-		logger.Error("failed to write state dump file",
-			"err", err,
-		)
-		return
-	}
-
-	ok = true
+	return doc, nil
 }
 
 func dumpRegistry(ctx context.Context, qs *dumpQueryState) (*registry.Genesis, error) {
@@ -410,6 +412,12 @@ func (qs *dumpQueryState) LastRetainedVersion() (int64, error) {
 func Register(parentCmd *cobra.Command) {
 	dumpDBCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
 	dumpDBCmd.Flags().AddFlagSet(dumpDBFlags)
+
+	dumpDBDiffCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
+	dumpDBDiffCmd.Flags().AddFlag(dumpDBFlags.Lookup(cfgDumpReadOnlyDB))
+	dumpDBDiffCmd.Flags().AddFlagSet(dumpDBDiffFlags)
+	dumpDBCmd.AddCommand(dumpDBDiffCmd)
+
 	parentCmd.AddCommand(dumpDBCmd)
 }
 
@@ -0,0 +1,304 @@
+// Package localstorage implements the runtime local storage debug sub-commands.
+package localstorage
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+)
+
+const cfgExportOutput = "localstorage.export.output"
+
+var (
+	localStorageCmd = &cobra.Command{
+		Use:   "localstorage",
+		Short: "runtime local storage utilities (node must be stopped)",
+	}
+
+	localStorageListCmd = &cobra.Command{
+		Use:   "list runtime-id (hex)",
+		Short: "list keys in the runtime local storage",
+		Args:  validateRuntimeIDArg,
+		Run:   doList,
+	}
+
+	localStorageDumpCmd = &cobra.Command{
+		Use:   "dump runtime-id (hex)",
+		Short: "dump keys and values in the runtime local storage",
+		Args:  validateRuntimeIDArg,
+		Run:   doDump,
+	}
+
+	localStorageExportCmd = &cobra.Command{
+		Use:   "export runtime-id (hex)",
+		Short: "export the runtime local storage to a JSON document",
+		Args:  validateRuntimeIDArg,
+		Run:   doExport,
+	}
+
+	localStorageWipeCmd = &cobra.Command{
+		Use:   "wipe runtime-id (hex)",
+		Short: "remove all keys from the runtime local storage (UNSAFE)",
+		Args:  validateRuntimeIDArg,
+		Run:   doWipe,
+	}
+
+	localStorageExportFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/localstorage")
+)
+
+// Entry is an exported local storage key/value pair.
+type Entry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Export is an exported runtime local storage.
+type Export struct {
+	RuntimeID common.Namespace   `json:"runtime_id"`
+	Usage     localstorage.Usage `json:"usage"`
+	Entries   []Entry            `json:"entries"`
+}
+
+func validateRuntimeIDArg(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+		return err
+	}
+
+	var id common.Namespace
+	if err := id.UnmarshalHex(args[0]); err != nil {
+		return fmt.Errorf("malformed runtime id '%v': %w", args[0], err)
+	}
+	return nil
+}
+
+// openLocalStorage opens the local storage of the given runtime in the node's
+// data directory. The node must not be running as the database is locked.
+func openLocalStorage(args []string) (common.Namespace, localstorage.LocalStorage, error) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var id common.Namespace
+	if err := id.UnmarshalHex(args[0]); err != nil {
+		return id, nil, err
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		return id, nil, fmt.Errorf("data directory must be set")
+	}
+
+	rtDataDir := runtimeRegistry.GetRuntimeStateDir(dataDir, id)
+	if _, err := os.Stat(filepath.Join(rtDataDir, runtimeRegistry.LocalStorageFile)); err != nil {
+		return id, nil, fmt.Errorf("failed to find runtime local storage: %w", err)
+	}
+
+	ls, err := localstorage.New(rtDataDir, runtimeRegistry.LocalStorageFile, id, localstorage.Quota{})
+	if err != nil {
+		return id, nil, err
+	}
+	return id, ls, nil
+}
+
+func doList(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	_, ls, err := openLocalStorage(args)
+	if err != nil {
+		logger.Error("failed to open runtime local storage",
+			"err", err,
+		)
+		return
+	}
+	defer ls.Stop()
+
+	if err = ls.Iterate(func(key, value []byte) error {
+		fmt.Printf("%s %d\n", hex.EncodeToString(key), len(value))
+		return nil
+	}); err != nil {
+		logger.Error("failed to iterate over runtime local storage",
+			"err", err,
+		)
+		return
+	}
+
+	usage := ls.Usage()
+	fmt.Printf("Total: %d keys, %d bytes\n", usage.Keys, usage.Size)
+
+	ok = true
+}
+
+func doDump(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	_, ls, err := openLocalStorage(args)
+	if err != nil {
+		logger.Error("failed to open runtime local storage",
+			"err", err,
+		)
+		return
+	}
+	defer ls.Stop()
+
+	if err = ls.Iterate(func(key, value []byte) error {
+		fmt.Printf("%s: %s\n", hex.EncodeToString(key), hex.EncodeToString(value))
+		return nil
+	}); err != nil {
+		logger.Error("failed to iterate over runtime local storage",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+func doExport(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	id, ls, err := openLocalStorage(args)
+	if err != nil {
+		logger.Error("failed to open runtime local storage",
+			"err", err,
+		)
+		return
+	}
+	defer ls.Stop()
+
+	export := Export{
+		RuntimeID: id,
+		Usage:     ls.Usage(),
+		Entries:   []Entry{},
+	}
+	if err = ls.Iterate(func(key, value []byte) error {
+		export.Entries = append(export.Entries, Entry{Key: key, Value: value})
+		return nil
+	}); err != nil {
+		logger.Error("failed to iterate over runtime local storage",
+			"err", err,
+		)
+		return
+	}
+
+	data, err := cmdCommon.PrettyJSONMarshal(export)
+	if err != nil {
+		logger.Error("failed to marshal runtime local storage export",
+			"err", err,
+		)
+		return
+	}
+
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgExportOutput)
+	if err != nil {
+		logger.Error("failed to get writer for export output",
+			"err", err,
+		)
+		return
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+
+	if _, err = w.Write(data); err != nil {
+		logger.Error("failed to write runtime local storage export",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+func doWipe(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	id, ls, err := openLocalStorage(args)
+	if err != nil {
+		logger.Error("failed to open runtime local storage",
+			"err", err,
+		)
+		return
+	}
+	defer ls.Stop()
+
+	usage := ls.Usage()
+	if cmdFlags.DryRun() {
+		logger.Info("dry run, would wipe runtime local storage",
+			"runtime_id", id,
+			"keys", usage.Keys,
+			"size", usage.Size,
+		)
+		ok = true
+		return
+	}
+
+	if err = ls.Wipe(); err != nil {
+		logger.Error("failed to wipe runtime local storage",
+			"err", err,
+		)
+		return
+	}
+
+	logger.Info("wiped runtime local storage",
+		"runtime_id", id,
+		"keys", usage.Keys,
+		"size", usage.Size,
+	)
+
+	ok = true
+}
+
+// Register registers the localstorage sub-command and all of its children.
+func Register(parentCmd *cobra.Command) {
+	localStorageExportCmd.Flags().AddFlagSet(localStorageExportFlags)
+	localStorageWipeCmd.Flags().AddFlagSet(cmdFlags.DryRunFlag)
+
+	for _, v := range []*cobra.Command{
+		localStorageListCmd,
+		localStorageDumpCmd,
+		localStorageExportCmd,
+		localStorageWipeCmd,
+	} {
+		localStorageCmd.AddCommand(v)
+	}
+	parentCmd.AddCommand(localStorageCmd)
+}
+
+func init() {
+	localStorageExportFlags.String(cfgExportOutput, "", "path to the exported local storage (default: stdout)")
+	_ = viper.BindPFlags(localStorageExportFlags)
+}
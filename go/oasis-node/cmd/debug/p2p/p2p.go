@@ -0,0 +1,324 @@
+// Package p2p implements the P2P message capture debug sub-commands.
+package p2p
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/p2p/capture"
+	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
+)
+
+const (
+	cfgTopics = "capture.topic"
+	cfgPeers  = "capture.peer"
+	cfgJSON   = "capture.json"
+)
+
+var (
+	p2pCmd = &cobra.Command{
+		Use:   "p2p",
+		Short: "P2P debug utilities",
+	}
+
+	p2pDumpCmd = &cobra.Command{
+		Use:   "dump capture-path...",
+		Short: "decode and print captured P2P messages",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   doDump,
+	}
+
+	p2pStatsCmd = &cobra.Command{
+		Use:   "stats capture-path...",
+		Short: "show aggregate statistics of captured P2P messages",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   doStats,
+	}
+
+	filterFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	outputFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/p2p")
+)
+
+// DecodedRecord is a captured P2P message together with its decoded gossip payload.
+type DecodedRecord struct {
+	*capture.Record
+
+	// Message is the decoded gossip message, if it could be decoded.
+	Message interface{} `json:"message,omitempty"`
+	// DecodeError is the error that occurred while decoding the gossip message.
+	DecodeError string `json:"decode_error,omitempty"`
+}
+
+// decodeRecord decodes the gossip payload of the given record using the decoder of the topic kind.
+func decodeRecord(rec *capture.Record) *DecodedRecord {
+	dr := &DecodedRecord{Record: rec}
+	if rec.Kind != capture.KindGossip {
+		return dr
+	}
+
+	kind, err := protocol.TopicKindFromID(rec.Topic)
+	if err == nil {
+		dr.Message, err = p2pAPI.DecodeTopicMessage(kind, rec.Data)
+	}
+	if err != nil {
+		dr.DecodeError = err.Error()
+	}
+	return dr
+}
+
+// forEachRecord iterates over all records in the capture files of the given capture paths that
+// pass the configured filters.
+func forEachRecord(paths []string, fn func(*capture.Record)) error {
+	var peers []core.PeerID
+	for _, rawPeerID := range viper.GetStringSlice(cfgPeers) {
+		peerID, err := peer.Decode(rawPeerID)
+		if err != nil {
+			return fmt.Errorf("malformed peer ID '%s': %w", rawPeerID, err)
+		}
+		peers = append(peers, peerID)
+	}
+	filter := capture.NewFilter(viper.GetStringSlice(cfgTopics), peers)
+
+	for _, path := range paths {
+		files := capture.Files(path)
+		if len(files) == 0 {
+			return fmt.Errorf("no capture files found at '%s'", path)
+		}
+
+		for _, file := range files {
+			if err := forEachFileRecord(file, filter, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func forEachFileRecord(file string, filter *capture.Filter, fn func(*capture.Record)) error {
+	r, err := capture.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for {
+		rec, err := r.Next()
+		switch {
+		case err == nil:
+		case errors.Is(err, io.EOF):
+			return nil
+		default:
+			return fmt.Errorf("failed to read capture file '%s': %w", file, err)
+		}
+
+		topic := rec.Topic
+		if rec.Kind == capture.KindRPC {
+			topic = rec.Protocol
+		}
+		peerIDs := make([]core.PeerID, 0, 2)
+		for _, rawPeerID := range []string{rec.PeerID, rec.From} {
+			if peerID, err := peer.Decode(rawPeerID); err == nil {
+				peerIDs = append(peerIDs, peerID)
+			}
+		}
+		if !filter.Matches(topic, peerIDs...) {
+			continue
+		}
+
+		fn(rec)
+	}
+}
+
+func doDump(cmd *cobra.Command, args []string) {
+	asJSON := viper.GetBool(cfgJSON)
+	enc := json.NewEncoder(os.Stdout)
+
+	if err := forEachRecord(args, func(rec *capture.Record) {
+		dr := decodeRecord(rec)
+		if asJSON {
+			_ = enc.Encode(dr)
+			return
+		}
+		fmt.Println(formatRecord(dr))
+	}); err != nil {
+		logger.Error("failed to dump captured messages",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func formatRecord(dr *DecodedRecord) string {
+	fields := []string{
+		dr.Time().UTC().Format("2006-01-02T15:04:05.000000Z"),
+		dr.Kind.String(),
+		dr.Direction.String(),
+		"peer=" + dr.PeerID,
+	}
+
+	switch dr.Kind {
+	case capture.KindGossip:
+		fields = append(fields,
+			"topic="+dr.Topic,
+			"from="+dr.From,
+			"size="+strconv.Itoa(len(dr.Data)),
+		)
+		switch {
+		case dr.DecodeError != "":
+			fields = append(fields, "decode_error="+strconv.Quote(dr.DecodeError))
+		default:
+			msg, _ := json.Marshal(dr.Message)
+			fields = append(fields, "message="+string(msg))
+		}
+	case capture.KindRPC:
+		fields = append(fields,
+			"protocol="+dr.Protocol,
+			"method="+dr.Method,
+			"request_size="+strconv.Itoa(dr.RequestSize),
+			"response_size="+strconv.Itoa(dr.ResponseSize),
+			"duration="+dr.Duration.String(),
+		)
+	}
+	if dr.Error != "" {
+		fields = append(fields, "err="+strconv.Quote(dr.Error))
+	}
+	return strings.Join(fields, " ")
+}
+
+// Stats are aggregate statistics of a group of captured messages.
+type Stats struct {
+	Messages uint64 `json:"messages"`
+	Bytes    uint64 `json:"bytes"`
+	Errors   uint64 `json:"errors"`
+}
+
+func (s *Stats) add(rec *capture.Record) {
+	s.Messages++
+	s.Bytes += uint64(rec.Size())
+	if rec.Error != "" {
+		s.Errors++
+	}
+}
+
+// Aggregate are statistics of captured messages grouped by topic and by peer.
+type Aggregate struct {
+	// Topics are statistics grouped by direction, topic (or protocol and method).
+	Topics map[string]*Stats `json:"topics"`
+	// Peers are statistics grouped by direction and peer.
+	Peers map[string]*Stats `json:"peers"`
+}
+
+func (a *Aggregate) add(rec *capture.Record) {
+	topic := rec.Topic
+	if rec.Kind == capture.KindRPC {
+		topic = rec.Protocol + " " + rec.Method
+	}
+	group := func(m map[string]*Stats, key string) {
+		key = rec.Direction.String() + " " + key
+		s, ok := m[key]
+		if !ok {
+			s = &Stats{}
+			m[key] = s
+		}
+		s.add(rec)
+	}
+
+	group(a.Topics, topic)
+	group(a.Peers, rec.PeerID)
+}
+
+func newAggregate() *Aggregate {
+	return &Aggregate{
+		Topics: make(map[string]*Stats),
+		Peers:  make(map[string]*Stats),
+	}
+}
+
+func doStats(cmd *cobra.Command, args []string) {
+	agg := newAggregate()
+	if err := forEachRecord(args, agg.add); err != nil {
+		logger.Error("failed to aggregate captured messages",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if viper.GetBool(cfgJSON) {
+		pp, _ := json.MarshalIndent(agg, "", "  ")
+		fmt.Println(string(pp))
+		return
+	}
+
+	printStats("Topic", agg.Topics)
+	fmt.Println()
+	printStats("Peer", agg.Peers)
+}
+
+func printStats(name string, stats map[string]*Stats) {
+	// Show the groups with the most traffic first.
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if stats[keys[i]].Bytes != stats[keys[j]].Bytes {
+			return stats[keys[i]].Bytes > stats[keys[j]].Bytes
+		}
+		return keys[i] < keys[j]
+	})
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetHeader([]string{"Direction", name, "Messages", "Bytes", "Errors"})
+	for _, key := range keys {
+		dir, group, _ := strings.Cut(key, " ")
+		s := stats[key]
+		table.Append([]string{
+			dir,
+			group,
+			strconv.FormatUint(s.Messages, 10),
+			strconv.FormatUint(s.Bytes, 10),
+			strconv.FormatUint(s.Errors, 10),
+		})
+	}
+	table.Render()
+}
+
+// Register registers the p2p sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	p2pDumpCmd.Flags().AddFlagSet(filterFlags)
+	p2pDumpCmd.Flags().AddFlagSet(outputFlags)
+	p2pStatsCmd.Flags().AddFlagSet(filterFlags)
+	p2pStatsCmd.Flags().AddFlagSet(outputFlags)
+
+	p2pCmd.AddCommand(p2pDumpCmd)
+	p2pCmd.AddCommand(p2pStatsCmd)
+	parentCmd.AddCommand(p2pCmd)
+}
+
+func init() {
+	filterFlags.StringSlice(cfgTopics, nil, "only include topics or protocols containing the given substring")
+	filterFlags.StringSlice(cfgPeers, nil, "only include messages exchanged with the given peer ID")
+	_ = viper.BindPFlags(filterFlags)
+
+	outputFlags.Bool(cfgJSON, false, "output as JSON")
+	_ = viper.BindPFlags(outputFlags)
+}
@@ -0,0 +1,72 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/p2p/capture"
+)
+
+func TestDecodeRecord(t *testing.T) {
+	require := require.New(t)
+
+	committeeMsg := &p2pAPI.CommitteeMessage{Epoch: 42}
+	dr := decodeRecord(&capture.Record{
+		Kind:  capture.KindGossip,
+		Topic: "oasis/chain/committee/rt/1.0.0",
+		Data:  cbor.Marshal(committeeMsg),
+	})
+	require.Empty(dr.DecodeError)
+	require.Equal(committeeMsg, dr.Message)
+
+	dr = decodeRecord(&capture.Record{
+		Kind:  capture.KindGossip,
+		Topic: "oasis/chain/tx/rt/1.0.0",
+		Data:  cbor.Marshal([]byte("tx")),
+	})
+	require.Empty(dr.DecodeError)
+	require.Equal([]byte("tx"), dr.Message)
+
+	dr = decodeRecord(&capture.Record{
+		Kind:  capture.KindGossip,
+		Topic: "oasis/chain/unknown/rt/1.0.0",
+		Data:  cbor.Marshal([]byte("tx")),
+	})
+	require.NotEmpty(dr.DecodeError, "unknown topic kinds should not be decoded")
+	require.Nil(dr.Message)
+
+	dr = decodeRecord(&capture.Record{
+		Kind:     capture.KindRPC,
+		Protocol: "/oasis/chain/storagesync/rt/1.0.0",
+	})
+	require.Empty(dr.DecodeError)
+	require.Nil(dr.Message)
+}
+
+func TestAggregate(t *testing.T) {
+	require := require.New(t)
+
+	agg := newAggregate()
+	for _, rec := range []*capture.Record{
+		{Kind: capture.KindGossip, Direction: capture.DirectionInbound, Topic: "tx", PeerID: "a", Data: []byte("12")},
+		{Kind: capture.KindGossip, Direction: capture.DirectionInbound, Topic: "tx", PeerID: "b", Data: []byte("1"), Error: "bad"},
+		{Kind: capture.KindGossip, Direction: capture.DirectionOutbound, Topic: "tx", PeerID: "c", Data: []byte("1")},
+		{Kind: capture.KindRPC, Direction: capture.DirectionInbound, Protocol: "sync", Method: "Get", PeerID: "a", RequestSize: 1, ResponseSize: 10},
+	} {
+		agg.add(rec)
+	}
+
+	require.Equal(map[string]*Stats{
+		"inbound tx":       {Messages: 2, Bytes: 3, Errors: 1},
+		"outbound tx":      {Messages: 1, Bytes: 1},
+		"inbound sync Get": {Messages: 1, Bytes: 11},
+	}, agg.Topics)
+	require.Equal(map[string]*Stats{
+		"inbound a":  {Messages: 2, Bytes: 13},
+		"inbound b":  {Messages: 1, Bytes: 1, Errors: 1},
+		"outbound c": {Messages: 1, Bytes: 1},
+	}, agg.Peers)
+}
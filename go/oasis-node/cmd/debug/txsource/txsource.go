@@ -5,6 +5,7 @@ import (
 	"crypto"
 	"fmt"
 	"math/rand"
+	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
@@ -33,6 +34,11 @@ const (
 	CfgTimeLimit       = "time_limit"
 	CfgGasPrice        = "gas_price"
 	CfgValidatorEntity = "validator_entity"
+	CfgTargetTPS       = "target_tps"
+	CfgRampUp          = "ramp_up"
+	CfgRampProfile     = "ramp_profile"
+	CfgRampSteps       = "ramp_steps"
+	CfgSummary         = "summary"
 )
 
 var (
@@ -83,6 +89,17 @@ func doRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("workload %s not found", name)
 	}
 
+	// Set up the workload scheduler.
+	scheduler, err := workload.NewScheduler(workload.ScheduleConfig{
+		TargetTPS:   viper.GetFloat64(CfgTargetTPS),
+		RampUp:      viper.GetDuration(CfgRampUp),
+		RampProfile: viper.GetString(CfgRampProfile),
+		RampSteps:   viper.GetUint64(CfgRampSteps),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid workload schedule: %w", err)
+	}
+
 	// Set up the deterministic random source.
 	hash := crypto.SHA512
 	seed := []byte(viper.GetString(CfgSeed))
@@ -107,6 +124,7 @@ func doRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create submission manager: %w", err)
 	}
 	sm := consensus.NewSubmissionManager(cnsc, pd, 0)
+	scheduledSm := scheduler.SubmissionManager(sm)
 
 	// Wait for sync before transferring control to the workload.
 	ncc := api.NewNodeControllerClient(conn)
@@ -149,15 +167,52 @@ func doRun(cmd *cobra.Command, args []string) error {
 	}
 
 	logger.Debug("entering workload", "name", name)
-	if err = w.Run(ctx, rng, conn, cnsc, sm, fundingAccount, validatorEntities); err != nil {
-		logger.Error("workload error", "err", err)
-		return fmt.Errorf("workload %s: %w", name, err)
+	runErr := w.Run(ctx, rng, conn, cnsc, scheduledSm, fundingAccount, validatorEntities)
+
+	// Write the summary even if the workload failed as it may help with debugging.
+	summary := scheduler.Summary()
+	summary.Workload = name
+	summary.Seed = viper.GetString(CfgSeed)
+	logger.Info("workload summary",
+		"name", name,
+		"submitted", summary.Submitted,
+		"failed", summary.Failed,
+		"achieved_tps", summary.AchievedTPS,
+		"latency_p50", summary.Latency.P50,
+		"latency_p99", summary.Latency.P99,
+	)
+	if err = writeSummary(summary); err != nil {
+		logger.Error("failed to write workload summary", "err", err)
+		if runErr == nil {
+			return err
+		}
+	}
+
+	if runErr != nil {
+		logger.Error("workload error", "err", runErr)
+		return fmt.Errorf("workload %s: %w", name, runErr)
 	}
 	logger.Debug("workload returned", "name", name)
 
 	return nil
 }
 
+func writeSummary(summary *workload.Summary) error {
+	fn := viper.GetString(CfgSummary)
+	if fn == "" {
+		return nil
+	}
+
+	data, err := common.PrettyJSONMarshal(summary)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(fn, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	return nil
+}
+
 // Register registers the txsource sub-command.
 func Register(parentCmd *cobra.Command) {
 	parentCmd.AddCommand(txsourceCmd)
@@ -170,6 +225,11 @@ func init() {
 	fs.Duration(CfgTimeLimit, 0, "Exit successfully after this long, or 0 to run forever")
 	fs.Uint64(CfgGasPrice, 0, "Gas price to use for consensus transactions")
 	fs.StringSlice(CfgValidatorEntity, nil, "Paths to validator entities")
+	fs.Float64(CfgTargetTPS, 0, "Target number of submitted transactions per second, or 0 for no limit")
+	fs.Duration(CfgRampUp, 0, "Duration over which the submission rate is ramped up to the target rate")
+	fs.String(CfgRampProfile, workload.RampProfileLinear, "Ramp-up profile (none, linear, step)")
+	fs.Uint64(CfgRampSteps, 5, "Number of steps used by the step ramp-up profile")
+	fs.String(CfgSummary, "", "Path to write the machine-readable (JSON) workload summary to")
 	_ = viper.BindPFlags(fs)
 	txsourceCmd.Flags().AddFlagSet(fs)
 
@@ -112,19 +112,23 @@ func (o *oversized) Run(
 			"payload_size", len(xfer.Data),
 		)
 
-		// Wait for a maximum of 5 seconds.
-		submitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-		err = cnsc.SubmitTx(submitCtx, signedTx)
-		cancel()
-		switch err {
-		case nil:
-			// This should never happen.
-			return fmt.Errorf("successfully submitted an oversized transaction")
-		case consensus.ErrOversizedTx:
-			// Submitting an oversized transaction is an error, so we expect this to fail.
-			o.Logger.Info("transaction rejected due to ErrOversizedTx")
-		default:
-			return fmt.Errorf("failed to submit oversized transaction: %w", err)
+		if err = o.SubmitScheduled(ctx, func() error {
+			// Wait for a maximum of 5 seconds.
+			submitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			switch serr := cnsc.SubmitTx(submitCtx, signedTx); serr {
+			case nil:
+				// This should never happen.
+				return fmt.Errorf("successfully submitted an oversized transaction")
+			case consensus.ErrOversizedTx:
+				// Submitting an oversized transaction is an error, so we expect this to fail.
+				o.Logger.Info("transaction rejected due to ErrOversizedTx")
+				return nil
+			default:
+				return fmt.Errorf("failed to submit oversized transaction: %w", serr)
+			}
+		}); err != nil {
+			return err
 		}
 
 		select {
@@ -136,7 +136,9 @@ func (p *parallel) Run(
 				parallelLogger.Debug("submitting self transfer",
 					"account", addr,
 				)
-				if err = cnsc.SubmitTx(ctx, signedTx); err != nil {
+				if err = p.SubmitScheduled(ctx, func() error {
+					return cnsc.SubmitTx(ctx, signedTx)
+				}); err != nil {
 					parallelLogger.Error("SubmitTx error", "err", err)
 					errCh <- fmt.Errorf("cnsc.SubmitTx: %w", err)
 					return
@@ -210,7 +210,11 @@ func (r *runtime) submitRuntimeRequest(ctx context.Context, rtc runtimeClient.Ru
 	// forever.
 	submitCtx, cancel := context.WithTimeout(ctx, runtimeRequestTimeout)
 	// Start the watch timeout now, so that the request time is included in the timeout.
-	out, err := rtc.SubmitTxMeta(submitCtx, rtx)
+	var out *runtimeClient.SubmitTxMetaResponse
+	err := r.SubmitScheduled(submitCtx, func() (err error) {
+		out, err = rtc.SubmitTxMeta(submitCtx, rtx)
+		return
+	})
 	cancel()
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to submit runtime transaction: %w", err)
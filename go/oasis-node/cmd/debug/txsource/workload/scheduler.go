@@ -0,0 +1,327 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+const (
+	// RampProfileNone starts submitting transactions at the target rate immediately.
+	RampProfileNone = "none"
+	// RampProfileLinear linearly increases the submission rate up to the target rate.
+	RampProfileLinear = "linear"
+	// RampProfileStep increases the submission rate up to the target rate in equal steps.
+	RampProfileStep = "step"
+)
+
+// latencyBuckets are the upper bounds of the submission-to-inclusion latency histogram buckets.
+var latencyBuckets = []time.Duration{
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	60 * time.Second,
+	120 * time.Second,
+}
+
+// ScheduleConfig is the workload scheduler configuration.
+type ScheduleConfig struct {
+	// TargetTPS is the target number of submitted transactions per second. Zero means that
+	// transactions are submitted as fast as the workload is able to.
+	TargetTPS float64
+
+	// RampUp is the duration over which the submission rate is increased up to the target rate.
+	RampUp time.Duration
+
+	// RampProfile is the shape of the ramp-up (none, linear or step).
+	RampProfile string
+
+	// RampSteps is the number of steps used by the step ramp-up profile.
+	RampSteps uint64
+}
+
+// Validate validates the scheduler configuration.
+func (cfg *ScheduleConfig) Validate() error {
+	if cfg.TargetTPS < 0 || math.IsNaN(cfg.TargetTPS) || math.IsInf(cfg.TargetTPS, 0) {
+		return fmt.Errorf("invalid target TPS: %f", cfg.TargetTPS)
+	}
+	if cfg.RampUp < 0 {
+		return fmt.Errorf("invalid ramp-up duration: %s", cfg.RampUp)
+	}
+
+	switch cfg.RampProfile {
+	case RampProfileNone, RampProfileLinear:
+	case RampProfileStep:
+		if cfg.RampSteps == 0 {
+			return fmt.Errorf("step ramp-up profile requires at least one step")
+		}
+	default:
+		return fmt.Errorf("unknown ramp-up profile: %s", cfg.RampProfile)
+	}
+	return nil
+}
+
+// LatencySummary is the summary of submission-to-inclusion latencies.
+type LatencySummary struct {
+	Count uint64        `json:"count"`
+	Min   time.Duration `json:"min"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+
+	// Buckets maps the latency histogram bucket upper bounds to the number of samples that fall
+	// into the given bucket. Samples above the largest bound are counted under "+Inf".
+	Buckets map[string]uint64 `json:"buckets"`
+}
+
+// Summary is the machine-readable summary of a workload run.
+type Summary struct {
+	Workload    string        `json:"workload"`
+	Seed        string        `json:"seed"`
+	TargetTPS   float64       `json:"target_tps"`
+	RampUp      time.Duration `json:"ramp_up"`
+	RampProfile string        `json:"ramp_profile"`
+
+	Duration    time.Duration `json:"duration"`
+	Submitted   uint64        `json:"submitted"`
+	Failed      uint64        `json:"failed"`
+	AchievedTPS float64       `json:"achieved_tps"`
+
+	Latency LatencySummary `json:"latency"`
+}
+
+// Scheduler paces transaction submissions of a workload according to a target rate and a ramp-up
+// profile and records submission-to-inclusion latencies.
+type Scheduler struct {
+	sync.Mutex
+
+	cfg ScheduleConfig
+
+	// start is the time of the first scheduled submission. It is only set once the workload
+	// submits its first transaction so that setup (e.g., node sync and account funding) is not
+	// included in the achieved rate.
+	start  time.Time
+	nextAt time.Time
+
+	submitted uint64
+	failed    uint64
+	latencies []time.Duration
+}
+
+// NewScheduler creates a new workload scheduler.
+func NewScheduler(cfg ScheduleConfig) (*Scheduler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Scheduler{
+		cfg: cfg,
+	}, nil
+}
+
+// rateAt returns the target submission rate at the given elapsed time since start.
+func (s *Scheduler) rateAt(elapsed time.Duration) float64 {
+	if s.cfg.RampUp == 0 || elapsed >= s.cfg.RampUp {
+		return s.cfg.TargetTPS
+	}
+
+	progress := float64(elapsed) / float64(s.cfg.RampUp)
+	switch s.cfg.RampProfile {
+	case RampProfileLinear:
+		return s.cfg.TargetTPS * progress
+	case RampProfileStep:
+		steps := float64(s.cfg.RampSteps)
+		return s.cfg.TargetTPS * (math.Floor(progress*steps) + 1) / steps
+	default:
+		return s.cfg.TargetTPS
+	}
+}
+
+// Wait blocks until the next transaction may be submitted according to the schedule.
+func (s *Scheduler) Wait(ctx context.Context) error {
+	s.Lock()
+	now := time.Now()
+	if s.start.IsZero() {
+		s.start = now
+	}
+	if s.cfg.TargetTPS == 0 {
+		s.Unlock()
+		return nil
+	}
+	if s.nextAt.Before(now) {
+		s.nextAt = now
+	}
+	at := s.nextAt
+
+	// While ramping up with a linear profile the rate may be arbitrarily close to zero, so make
+	// sure that at least a minimal rate is used.
+	rate := math.Max(s.rateAt(at.Sub(s.start)), s.cfg.TargetTPS/100)
+	s.nextAt = at.Add(time.Duration(float64(time.Second) / rate))
+	s.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Observe records the outcome of a transaction submission that started at the given time.
+func (s *Scheduler) Observe(start time.Time, err error) {
+	latency := time.Since(start)
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.submitted++
+	if err != nil {
+		s.failed++
+		return
+	}
+	s.latencies = append(s.latencies, latency)
+}
+
+// Submit waits for the schedule to allow a new transaction, calls the given submission function
+// which should only return once the transaction has been included and records the outcome.
+func (s *Scheduler) Submit(ctx context.Context, fn func() error) error {
+	if err := s.Wait(ctx); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err := fn()
+	s.Observe(start, err)
+	return err
+}
+
+// Summary returns the summary of all submissions recorded so far.
+func (s *Scheduler) Summary() *Summary {
+	s.Lock()
+	defer s.Unlock()
+
+	var duration time.Duration
+	if !s.start.IsZero() {
+		duration = time.Since(s.start)
+	}
+	summary := &Summary{
+		TargetTPS:   s.cfg.TargetTPS,
+		RampUp:      s.cfg.RampUp,
+		RampProfile: s.cfg.RampProfile,
+		Duration:    duration,
+		Submitted:   s.submitted,
+		Failed:      s.failed,
+		Latency:     summarizeLatencies(s.latencies),
+	}
+	if duration > 0 {
+		summary.AchievedTPS = float64(s.submitted-s.failed) / duration.Seconds()
+	}
+	return summary
+}
+
+func summarizeLatencies(latencies []time.Duration) LatencySummary {
+	summary := LatencySummary{
+		Count:   uint64(len(latencies)),
+		Buckets: make(map[string]uint64),
+	}
+	if len(latencies) == 0 {
+		return summary
+	}
+
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) time.Duration {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return sorted[idx]
+	}
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+
+		bucket := "+Inf"
+		for _, b := range latencyBuckets {
+			if l <= b {
+				bucket = b.String()
+				break
+			}
+		}
+		summary.Buckets[bucket]++
+	}
+
+	summary.Min = sorted[0]
+	summary.Max = sorted[len(sorted)-1]
+	summary.Mean = total / time.Duration(len(sorted))
+	summary.P50 = percentile(0.50)
+	summary.P90 = percentile(0.90)
+	summary.P95 = percentile(0.95)
+	summary.P99 = percentile(0.99)
+
+	return summary
+}
+
+// SubmissionManager returns a submission manager that paces all submissions through the
+// scheduler.
+func (s *Scheduler) SubmissionManager(sm consensus.SubmissionManager) consensus.SubmissionManager {
+	return &scheduledSubmissionManager{
+		SubmissionManager: sm,
+		scheduler:         s,
+	}
+}
+
+type scheduledSubmissionManager struct {
+	consensus.SubmissionManager
+
+	scheduler *Scheduler
+}
+
+// Implements consensus.SubmissionManager.
+func (m *scheduledSubmissionManager) SignAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+	return m.scheduler.Submit(ctx, func() error {
+		return m.SubmissionManager.SignAndSubmitTx(ctx, signer, tx)
+	})
+}
+
+// Implements consensus.SubmissionManager.
+func (m *scheduledSubmissionManager) SignAndSubmitTxWithProof(
+	ctx context.Context,
+	signer signature.Signer,
+	tx *transaction.Transaction,
+) (*transaction.SignedTransaction, *transaction.Proof, error) {
+	var (
+		sigTx *transaction.SignedTransaction
+		proof *transaction.Proof
+	)
+	err := m.scheduler.Submit(ctx, func() (err error) {
+		sigTx, proof, err = m.SubmissionManager.SignAndSubmitTxWithProof(ctx, signer, tx)
+		return
+	})
+	return sigTx, proof, err
+}
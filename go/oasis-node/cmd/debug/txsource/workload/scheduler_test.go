@@ -0,0 +1,92 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerRamp(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		profile  string
+		elapsed  time.Duration
+		expected float64
+	}{
+		{RampProfileNone, 0, 100},
+		{RampProfileNone, 5 * time.Second, 100},
+		{RampProfileLinear, 0, 0},
+		{RampProfileLinear, 5 * time.Second, 50},
+		{RampProfileLinear, 10 * time.Second, 100},
+		{RampProfileLinear, 20 * time.Second, 100},
+		{RampProfileStep, 0, 25},
+		{RampProfileStep, 5 * time.Second, 75},
+		{RampProfileStep, 9 * time.Second, 100},
+		{RampProfileStep, 20 * time.Second, 100},
+	} {
+		s, err := NewScheduler(ScheduleConfig{
+			TargetTPS:   100,
+			RampUp:      10 * time.Second,
+			RampProfile: tc.profile,
+			RampSteps:   4,
+		})
+		require.NoError(err, "NewScheduler")
+		require.InDelta(tc.expected, s.rateAt(tc.elapsed), 0.001, "rate for profile %s at %s", tc.profile, tc.elapsed)
+	}
+
+	_, err := NewScheduler(ScheduleConfig{TargetTPS: -1, RampProfile: RampProfileNone})
+	require.Error(err, "negative target TPS should be rejected")
+	_, err = NewScheduler(ScheduleConfig{TargetTPS: 1, RampProfile: "bogus"})
+	require.Error(err, "unknown ramp profile should be rejected")
+	_, err = NewScheduler(ScheduleConfig{TargetTPS: 1, RampProfile: RampProfileStep})
+	require.Error(err, "step ramp profile without steps should be rejected")
+}
+
+func TestSchedulerSummary(t *testing.T) {
+	require := require.New(t)
+
+	s, err := NewScheduler(ScheduleConfig{RampProfile: RampProfileNone})
+	require.NoError(err, "NewScheduler")
+
+	// Nothing has been submitted yet, so the clock should not be running.
+	time.Sleep(10 * time.Millisecond)
+	summary := s.Summary()
+	require.EqualValues(0, summary.Submitted)
+	require.EqualValues(0, summary.Latency.Count)
+	require.Zero(summary.Duration, "duration should only be measured from the first submission")
+	require.Zero(summary.AchievedTPS)
+
+	err = s.Submit(context.Background(), func() error { return nil })
+	require.NoError(err, "Submit")
+	err = s.Submit(context.Background(), func() error { return fmt.Errorf("failed") })
+	require.Error(err, "Submit should propagate submission errors")
+
+	now := time.Now()
+	for _, latency := range []time.Duration{
+		400 * time.Millisecond,
+		100 * time.Millisecond,
+		300 * time.Millisecond,
+		3 * time.Minute,
+	} {
+		s.Observe(now.Add(-latency), nil)
+	}
+
+	summary = s.Summary()
+	require.EqualValues(6, summary.Submitted)
+	require.EqualValues(1, summary.Failed)
+	require.EqualValues(5, summary.Latency.Count)
+	require.Less(summary.Latency.Min, 100*time.Millisecond)
+	require.InDelta(300*time.Millisecond, summary.Latency.P50, float64(50*time.Millisecond))
+	require.InDelta(3*time.Minute, summary.Latency.P99, float64(time.Second))
+	require.InDelta(3*time.Minute, summary.Latency.Max, float64(time.Second))
+	require.EqualValues(1, summary.Latency.Buckets["100ms"])
+	require.EqualValues(1, summary.Latency.Buckets["250ms"])
+	require.EqualValues(2, summary.Latency.Buckets["500ms"])
+	require.EqualValues(1, summary.Latency.Buckets["+Inf"])
+	require.Greater(summary.Duration, time.Duration(0))
+	require.Less(summary.Duration, time.Second, "duration should not include the idle time before the first submission")
+}
@@ -68,6 +68,12 @@ type BaseWorkload struct {
 	cc consensus.ClientBackend
 	sm consensus.SubmissionManager
 
+	// fundingSm is the submission manager used for funding transfers which are not paced by the
+	// workload scheduler and are not included in its statistics.
+	fundingSm consensus.SubmissionManager
+
+	scheduler *Scheduler
+
 	fundingAccount signature.Signer
 }
 
@@ -79,7 +85,23 @@ func (bw *BaseWorkload) Init(
 ) {
 	bw.cc = cc
 	bw.sm = sm
+	bw.fundingSm = sm
 	bw.fundingAccount = fundingAccount
+
+	if ssm, ok := sm.(*scheduledSubmissionManager); ok {
+		bw.scheduler = ssm.scheduler
+		bw.fundingSm = ssm.SubmissionManager
+	}
+}
+
+// SubmitScheduled paces a transaction submission that bypasses the submission manager (e.g., a
+// runtime transaction) through the workload scheduler, if one is configured. The passed function
+// should only return once the transaction has been included.
+func (bw *BaseWorkload) SubmitScheduled(ctx context.Context, fn func() error) error {
+	if bw.scheduler == nil {
+		return fn()
+	}
+	return bw.scheduler.Submit(ctx, fn)
 }
 
 // Consensus returns the consensus client backend.
@@ -132,6 +154,8 @@ func (bw *BaseWorkload) TransferFunds(ctx context.Context, from signature.Signer
 }
 
 // TransferFundsQty transfers funds from one account to the other, taking a Quantity amount.
+//
+// Funding transfers bypass the workload scheduler.
 func (bw *BaseWorkload) TransferFundsQty(ctx context.Context, from signature.Signer, to staking.Address, amount *quantity.Quantity) error {
 	tx := staking.NewTransferTx(0, nil, &staking.Transfer{
 		To:     to,
@@ -140,7 +164,7 @@ func (bw *BaseWorkload) TransferFundsQty(ctx context.Context, from signature.Sig
 
 	submitCtx, cancel := context.WithTimeout(ctx, maxSubmissionRetryElapsedTime)
 	defer cancel()
-	if err := bw.sm.SignAndSubmitTx(submitCtx, from, tx); err != nil {
+	if err := bw.fundingSm.SignAndSubmitTx(submitCtx, from, tx); err != nil {
 		bw.Logger.Error("failed to submit transaction",
 			"err", err,
 			"tx", tx,
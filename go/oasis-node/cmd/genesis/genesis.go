@@ -64,6 +64,7 @@ const (
 	CfgRegistryTEEFeaturesSGXPCS                = "registry.tee_features.sgx.pcs"
 	CfgRegistryTEEFeaturesSGXSignedAttestations = "registry.tee_features.sgx.signed_attestations"
 	CfgRegistryTEEFeaturesFreshnessProofs       = "registry.tee_features.freshness_proofs"
+	CfgRegistryEnableEntityMetadata             = "registry.enable_entity_metadata"
 
 	// Scheduler config flags.
 	cfgSchedulerMinValidators          = "scheduler.min_validators"
@@ -76,10 +77,18 @@ const (
 	// Governance config flags.
 	CfgGovernanceMinProposalDeposit             = "governance.min_proposal_deposit"
 	CfgGovernanceStakeThreshold                 = "governance.stake_threshold"
+	CfgGovernanceVetoThreshold                  = "governance.veto_threshold"
+	CfgGovernanceBurnVetoedDeposits             = "governance.burn_vetoed_deposits"
+	CfgGovernanceRejectInvalidVotes             = "governance.reject_invalid_votes"
 	CfgGovernanceUpgradeCancelMinEpochDiff      = "governance.upgrade_cancel_min_epoch_diff"
 	CfgGovernanceUpgradeMinEpochDiff            = "governance.upgrade_min_epoch_diff"
 	CfgGovernanceVotingPeriod                   = "governance.voting_period"
 	CfgGovernanceEnableChangeParametersProposal = "governance.enable_change_parameters_proposal"
+	CfgGovernanceEnableCommonPoolSpendProposal  = "governance.enable_common_pool_spend_proposal"
+	CfgGovernanceEnableTextProposal             = "governance.enable_text_proposal"
+
+	// Key manager config flags.
+	CfgKeyManagerEnableMasterSecretRecovery = "keymanager.enable_master_secret_recovery"
 
 	// Beacon config flags.
 	CfgBeaconBackend                  = "beacon.backend"
@@ -112,6 +121,9 @@ const (
 	CfgConsensusStateCheckpointChunkSize = "consensus.state_checkpoint.chunk_size"
 	CfgConsensusGasCostsTxByte           = "consensus.gas_costs.tx_byte"
 	cfgConsensusBlacklistPublicKey       = "consensus.blacklist_public_key"
+	CfgConsensusEnableFeePayer           = "consensus.enable_fee_payer"
+	CfgConsensusEnableBatchTransactions  = "consensus.enable_batch_transactions"
+	CfgConsensusEnableTransactionExpiry  = "consensus.enable_transaction_expiry"
 
 	// Consensus backend config flag.
 	cfgConsensusBackend = "consensus.backend"
@@ -241,10 +253,15 @@ func doInitGenesis(cmd *cobra.Command, args []string) {
 			GasCosts:                       governance.DefaultGasCosts, // TODO: configurable.
 			MinProposalDeposit:             *quantity.NewFromUint64(viper.GetUint64(CfgGovernanceMinProposalDeposit)),
 			StakeThreshold:                 uint8(viper.GetInt(CfgGovernanceStakeThreshold)),
+			VetoThreshold:                  uint8(viper.GetInt(CfgGovernanceVetoThreshold)),
+			BurnVetoedDeposits:             viper.GetBool(CfgGovernanceBurnVetoedDeposits),
+			RejectInvalidVotes:             viper.GetBool(CfgGovernanceRejectInvalidVotes),
 			UpgradeCancelMinEpochDiff:      beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeCancelMinEpochDiff)),
 			UpgradeMinEpochDiff:            beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeMinEpochDiff)),
 			VotingPeriod:                   beacon.EpochTime(viper.GetUint64(CfgGovernanceVotingPeriod)),
 			EnableChangeParametersProposal: viper.GetBool(CfgGovernanceEnableChangeParametersProposal),
+			EnableCommonPoolSpendProposal:  viper.GetBool(CfgGovernanceEnableCommonPoolSpendProposal),
+			EnableTextProposal:             viper.GetBool(CfgGovernanceEnableTextProposal),
 		},
 	}
 
@@ -297,7 +314,10 @@ func doInitGenesis(cmd *cobra.Command, args []string) {
 			GasCosts: transaction.Costs{
 				consensusGenesis.GasOpTxByte: transaction.Gas(viper.GetUint64(CfgConsensusGasCostsTxByte)),
 			},
-			PublicKeyBlacklist: pkBlacklist,
+			PublicKeyBlacklist:      pkBlacklist,
+			EnableFeePayer:          viper.GetBool(CfgConsensusEnableFeePayer),
+			EnableBatchTransactions: viper.GetBool(CfgConsensusEnableBatchTransactions),
+			EnableTransactionExpiry: viper.GetBool(CfgConsensusEnableTransactionExpiry),
 		},
 	}
 
@@ -338,6 +358,7 @@ func AppendRegistryState(doc *genesis.Document, entities, runtimes, nodes []stri
 			MaxNodeExpiration:             viper.GetUint64(CfgRegistryMaxNodeExpiration),
 			DisableRuntimeRegistration:    viper.GetBool(CfgRegistryDisableRuntimeRegistration),
 			EnableRuntimeGovernanceModels: make(map[registry.RuntimeGovernanceModel]bool),
+			EnableEntityMetadata:          viper.GetBool(CfgRegistryEnableEntityMetadata),
 		},
 		Entities: make([]*entity.SignedEntity, 0, len(entities)),
 		Runtimes: make([]*registry.Runtime, 0, len(runtimes)),
@@ -555,7 +576,8 @@ func AppendRootHashState(doc *genesis.Document, exports []string, l *logging.Log
 func AppendKeyManagerState(doc *genesis.Document, statuses []string, l *logging.Logger) error {
 	kmSt := keymanager.Genesis{
 		Parameters: keymanager.ConsensusParameters{
-			GasCosts: keymanager.DefaultGasCosts, // TODO: Make these configurable.
+			GasCosts:                   keymanager.DefaultGasCosts, // TODO: Make these configurable.
+			EnableMasterSecretRecovery: viper.GetBool(CfgKeyManagerEnableMasterSecretRecovery),
 		},
 	}
 
@@ -794,6 +816,7 @@ func init() {
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesSGXPCS, true, "enable PCS support for SGX TEEs")
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesSGXSignedAttestations, true, "enable SGX RAK-signed attestations")
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesFreshnessProofs, true, "enable freshness proofs")
+	initGenesisFlags.Bool(CfgRegistryEnableEntityMetadata, false, "enable entity descriptor metadata")
 	_ = initGenesisFlags.MarkHidden(cfgRegistryDebugAllowUnroutableAddresses)
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowTestRuntimes)
 	_ = initGenesisFlags.MarkHidden(cfgRegistryDebugBypassStake)
@@ -812,10 +835,18 @@ func init() {
 	// Governance config flags.
 	initGenesisFlags.Uint64(CfgGovernanceMinProposalDeposit, 100, "proposal deposit for governance proposals")
 	initGenesisFlags.Uint8(CfgGovernanceStakeThreshold, 90, "required stake threshold for governance proposals to be accepted")
+	initGenesisFlags.Uint8(CfgGovernanceVetoThreshold, 0, "required veto threshold for governance proposals to be vetoed (0 disables vetoes)")
+	initGenesisFlags.Bool(CfgGovernanceBurnVetoedDeposits, false, "burn deposits of vetoed governance proposals")
+	initGenesisFlags.Bool(CfgGovernanceRejectInvalidVotes, false, "reject governance votes of an unknown kind")
 	initGenesisFlags.Uint64(CfgGovernanceUpgradeCancelMinEpochDiff, 300, "minimum number of epochs in advance for canceling proposals")
 	initGenesisFlags.Uint64(CfgGovernanceUpgradeMinEpochDiff, 300, "minimum number of epochs the upgrade needs to be scheduled in advance")
 	initGenesisFlags.Uint64(CfgGovernanceVotingPeriod, 100, "voting period (in epochs)")
 	initGenesisFlags.Bool(CfgGovernanceEnableChangeParametersProposal, true, "enable change parameters proposals")
+	initGenesisFlags.Bool(CfgGovernanceEnableCommonPoolSpendProposal, false, "enable common pool spend proposals")
+	initGenesisFlags.Bool(CfgGovernanceEnableTextProposal, false, "enable text proposals")
+
+	// Key manager config flags.
+	initGenesisFlags.Bool(CfgKeyManagerEnableMasterSecretRecovery, false, "enable key manager master secret recovery")
 
 	// Beacon config flags.
 	initGenesisFlags.String(CfgBeaconBackend, "insecure", "beacon backend")
@@ -851,6 +882,9 @@ func init() {
 	initGenesisFlags.String(CfgConsensusStateCheckpointChunkSize, "8mb", "consensus state checkpoint chunk size (in bytes)")
 	initGenesisFlags.Uint64(CfgConsensusGasCostsTxByte, 1, "consensus gas costs: each transaction byte")
 	initGenesisFlags.StringSlice(cfgConsensusBlacklistPublicKey, nil, "blacklist public key")
+	initGenesisFlags.Bool(CfgConsensusEnableFeePayer, false, "enable transactions with a separate fee payer")
+	initGenesisFlags.Bool(CfgConsensusEnableBatchTransactions, false, "enable atomic batch transactions")
+	initGenesisFlags.Bool(CfgConsensusEnableTransactionExpiry, false, "enable transactions with an expiry")
 
 	// Consensus backend flag.
 	initGenesisFlags.String(cfgConsensusBackend, cmt.BackendName, "consensus backend")
@@ -28,6 +28,10 @@ import (
 const (
 	cfgProposalCancelUpgradeID   = "proposal.cancel_upgrade.id"
 	cfgProposalUpgradeDescriptor = "proposal.upgrade.descriptor"
+	cfgProposalCommonPoolSpend   = "proposal.common_pool_spend"
+	cfgProposalTextTitle         = "proposal.text.title"
+	cfgProposalTextBody          = "proposal.text.body"
+	cfgProposalTextURL           = "proposal.text.url"
 
 	cfgVote           = "vote"
 	cfgVoteProposalID = "vote.proposal.id"
@@ -142,9 +146,52 @@ func doGenSubmitProposal(cmd *cobra.Command, args []string) {
 				ProposalID: viper.GetUint64(cfgProposalCancelUpgradeID),
 			},
 		})
+	case viper.GetString(cfgProposalCommonPoolSpend) != "":
+		spendBytes, err := os.ReadFile(viper.GetString(cfgProposalCommonPoolSpend))
+		if err != nil {
+			logger.Error("failed to read common pool spend proposal",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		var spend governance.CommonPoolSpendProposal
+		if err = json.Unmarshal(spendBytes, &spend); err != nil {
+			logger.Error("can't parse common pool spend proposal",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		if err = spend.ValidateBasic(); err != nil {
+			logger.Error("submitted common pool spend proposal is not valid",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		tx = governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+			CommonPoolSpend: &spend,
+		})
+	case viper.GetString(cfgProposalTextTitle) != "":
+		text := governance.TextProposal{
+			Title: viper.GetString(cfgProposalTextTitle),
+			Body:  viper.GetString(cfgProposalTextBody),
+			URL:   viper.GetString(cfgProposalTextURL),
+		}
+		if err := text.ValidateBasic(); err != nil {
+			logger.Error("submitted text proposal is not valid",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		tx = governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+			Text: &text,
+		})
 	default:
-		logger.Error(fmt.Sprintf("missing required arguments: either '%v' or '%v' required",
-			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID,
+		logger.Error(fmt.Sprintf("missing required arguments: one of '%v', '%v', '%v' or '%v' required",
+			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID, cfgProposalCommonPoolSpend, cfgProposalTextTitle,
 		))
 		os.Exit(1)
 	}
@@ -311,11 +358,15 @@ func init() {
 
 	submitProposalFlags.String(cfgProposalUpgradeDescriptor, "", "Path to the proposal upgrade descriptor")
 	submitProposalFlags.Uint64(cfgProposalCancelUpgradeID, 0, "Cancel upgrade proposal ID")
+	submitProposalFlags.String(cfgProposalCommonPoolSpend, "", "Path to the common pool spend proposal")
+	submitProposalFlags.String(cfgProposalTextTitle, "", "Text proposal title")
+	submitProposalFlags.String(cfgProposalTextBody, "", "Text proposal body")
+	submitProposalFlags.String(cfgProposalTextURL, "", "Text proposal URL")
 	_ = viper.BindPFlags(submitProposalFlags)
 	submitProposalFlags.AddFlagSet(cmdConsensus.TxFlags)
 	submitProposalFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
 
-	castVoteFlags.String(cfgVote, "", "Vote to be cast (yes, no, abstain)")
+	castVoteFlags.String(cfgVote, "", "Vote to be cast (yes, no, abstain, no_with_veto)")
 	castVoteFlags.Uint64(cfgVoteProposalID, 0, "Cast vote proposal ID")
 	_ = viper.BindPFlags(castVoteFlags)
 	castVoteFlags.AddFlagSet(cmdConsensus.TxFlags)
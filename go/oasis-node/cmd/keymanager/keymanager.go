@@ -39,6 +39,8 @@ const (
 	CfgPolicySigFile                      = "keymanager.policy.signature.file"
 	CfgPolicyIgnoreSig                    = "keymanager.policy.ignore.signature"
 	CfgPolicyMasterSecretRotationInterval = "keymanager.policy.master_secret_rotation_interval"
+	CfgPolicyRecoveryThreshold            = "keymanager.policy.recovery.threshold"
+	CfgPolicyRecoveryCustodian            = "keymanager.policy.recovery.custodian"
 
 	CfgStatusFile        = "keymanager.status.file"
 	CfgStatusID          = "keymanager.status.id"
@@ -205,14 +207,51 @@ func policyFromFlags() (*kmApi.PolicySGX, error) {
 
 	rotationInterval := api.EpochTime(viper.GetUint64(CfgPolicyMasterSecretRotationInterval))
 
+	recovery, err := recoveryPolicyFromFlags()
+	if err != nil {
+		return nil, err
+	}
+
 	return &kmApi.PolicySGX{
 		Serial:                       serial,
 		ID:                           id,
 		Enclaves:                     enclaves,
 		MasterSecretRotationInterval: rotationInterval,
+		MasterSecretRecovery:         recovery,
 	}, nil
 }
 
+func recoveryPolicyFromFlags() (*kmApi.MasterSecretRecoveryPolicy, error) {
+	custodians := viper.GetStringSlice(CfgPolicyRecoveryCustodian)
+	if len(custodians) == 0 {
+		return nil, nil
+	}
+
+	policy := kmApi.MasterSecretRecoveryPolicy{
+		Threshold: uint8(viper.GetUint(CfgPolicyRecoveryThreshold)),
+	}
+	for _, b64pk := range custodians {
+		pk, err := unmarshalX25519PublicKey(b64pk)
+		if err != nil {
+			logger.Error("failed to parse recovery custodian public key",
+				"err", err,
+				"given_custodian", b64pk,
+			)
+			return nil, err
+		}
+		policy.Custodians = append(policy.Custodians, *pk)
+	}
+
+	if err := policy.SanityCheck(); err != nil {
+		logger.Error("invalid master secret recovery policy",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
 func doSignPolicy(cmd *cobra.Command, args []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -554,6 +593,8 @@ func registerKMInitPolicyFlags(cmd *cobra.Command) {
 		cmd.Flags().StringSlice(CfgPolicyMayReplicate, []string{}, "enclave_id1,enclave_id2... list of new enclaves which are allowed to access the master secret. Requires "+CfgPolicyEnclaveID)
 		cmd.Flags().StringToString(CfgPolicyMayQuery, map[string]string{}, "runtime_id=enclave_id1,enclave_id2... sets enclave query permission for runtime_id. Requires "+CfgPolicyEnclaveID)
 		cmd.Flags().Uint64(CfgPolicyMasterSecretRotationInterval, 0, "master secret rotation interval")
+		cmd.Flags().Uint8(CfgPolicyRecoveryThreshold, 0, "number of recovery custodians needed to recover master secrets. Requires "+CfgPolicyRecoveryCustodian)
+		cmd.Flags().StringSlice(CfgPolicyRecoveryCustodian, []string{}, "base64-encoded X25519 public key(s) of master secret recovery custodians")
 	}
 
 	cmd.Flags().AddFlagSet(policyFileFlag)
@@ -572,6 +613,8 @@ func registerKMInitPolicyFlags(cmd *cobra.Command) {
 		CfgPolicyMayReplicate,
 		CfgPolicyMayQuery,
 		CfgPolicyMasterSecretRotationInterval,
+		CfgPolicyRecoveryThreshold,
+		CfgPolicyRecoveryCustodian,
 	} {
 		_ = viper.BindPFlag(v, cmd.Flags().Lookup(v))
 	}
@@ -661,6 +704,8 @@ func Register(parentCmd *cobra.Command) {
 		keyManagerCmd.AddCommand(v)
 	}
 
+	registerRecoveryCmds(keyManagerCmd)
+
 	registerKMInitPolicyFlags(initPolicyCmd)
 	registerKMSignPolicyFlags(signPolicyCmd)
 	registerKMVerifyPolicyFlags(verifyPolicyCmd)
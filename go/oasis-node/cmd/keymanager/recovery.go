@@ -0,0 +1,243 @@
+package keymanager
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/pem"
+	kmApi "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+)
+
+const (
+	CfgRecoveryCustodianKeyFile = "keymanager.recovery.custodian.key_file"
+	CfgRecoveryBackupFile       = "keymanager.recovery.backup.file"
+	CfgRecoveryREK              = "keymanager.recovery.rek"
+	CfgRecoveryShareFile        = "keymanager.recovery.share.file"
+	CfgRecoveryFile             = "keymanager.recovery.file"
+
+	custodianKeyPemType = "X25519 PRIVATE KEY"
+)
+
+var (
+	recoveryInitCustodianCmd = &cobra.Command{
+		Use:   "recovery_init_custodian",
+		Short: "generate master secret recovery custodian key",
+		Run:   doRecoveryInitCustodian,
+	}
+
+	recoveryShareCmd = &cobra.Command{
+		Use:   "recovery_share",
+		Short: "decrypt custodian's share of a master secret backup and encrypt it to a key manager enclave",
+		Run:   doRecoveryShare,
+	}
+
+	recoveryBundleCmd = &cobra.Command{
+		Use:   "recovery_bundle",
+		Short: "combine encrypted master secret shares into a recovery file",
+		Run:   doRecoveryBundle,
+	}
+)
+
+func doRecoveryInitCustodian(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var sk x25519.PrivateKey
+	if _, err := rand.Read(sk[:]); err != nil {
+		logger.Error("failed to generate custodian key",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	data, err := pem.Marshal(custodianKeyPemType, sk[:])
+	if err != nil {
+		logger.Error("failed to encode custodian key",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fn := viper.GetString(CfgRecoveryCustodianKeyFile)
+	if err = os.WriteFile(fn, data, 0o600); err != nil {
+		logger.Error("failed to write custodian key file",
+			"err", err,
+			"CfgRecoveryCustodianKeyFile", fn,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Println(base64.StdEncoding.EncodeToString(sk.Public()[:]))
+}
+
+func doRecoveryShare(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	if err := recoveryShareFromFlags(); err != nil {
+		logger.Error("failed to generate master secret share",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func recoveryShareFromFlags() error {
+	backup, err := loadMasterSecretBackup(viper.GetString(CfgRecoveryBackupFile))
+	if err != nil {
+		return err
+	}
+
+	sk, err := loadCustodianKey(viper.GetString(CfgRecoveryCustodianKeyFile))
+	if err != nil {
+		return err
+	}
+
+	rek, err := unmarshalX25519PublicKey(viper.GetString(CfgRecoveryREK))
+	if err != nil {
+		return fmt.Errorf("malformed enclave REK: %w", err)
+	}
+
+	share, err := backup.OpenShare(sk)
+	if err != nil {
+		return err
+	}
+
+	encShare, err := kmApi.SealMasterSecretShare(share, backup.ID, backup.Generation, rek)
+	if err != nil {
+		return err
+	}
+
+	fn := viper.GetStringSlice(CfgRecoveryShareFile)
+	if len(fn) != 1 {
+		return fmt.Errorf("exactly one share file must be given")
+	}
+	if err = os.WriteFile(fn[0], cbor.Marshal(encShare), 0o600); err != nil {
+		return fmt.Errorf("failed to write share file: %w", err)
+	}
+
+	return nil
+}
+
+func doRecoveryBundle(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	if err := recoveryBundleFromFlags(); err != nil {
+		logger.Error("failed to generate master secret recovery file",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func recoveryBundleFromFlags() error {
+	backup, err := loadMasterSecretBackup(viper.GetString(CfgRecoveryBackupFile))
+	if err != nil {
+		return err
+	}
+
+	req := kmApi.ImportMasterSecretsRequest{
+		ID:         backup.ID,
+		Generation: backup.Generation,
+	}
+	for _, fn := range viper.GetStringSlice(CfgRecoveryShareFile) {
+		data, err := os.ReadFile(fn)
+		if err != nil {
+			return fmt.Errorf("failed to read share file: %w", err)
+		}
+		var share kmApi.EncryptedMasterSecretShare
+		if err = cbor.Unmarshal(data, &share); err != nil {
+			return fmt.Errorf("malformed share file '%s': %w", fn, err)
+		}
+		req.Shares = append(req.Shares, share)
+	}
+	if len(req.Shares) < int(backup.Threshold) {
+		return fmt.Errorf("not enough shares (expected at least: %d got: %d)", backup.Threshold, len(req.Shares))
+	}
+
+	fn := viper.GetString(CfgRecoveryFile)
+	if err = os.WriteFile(fn, cbor.Marshal(req), 0o600); err != nil {
+		return fmt.Errorf("failed to write recovery file: %w", err)
+	}
+
+	return nil
+}
+
+func loadMasterSecretBackup(fn string) (*kmApi.MasterSecretBackup, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup file: %w", err)
+	}
+	var backup kmApi.MasterSecretBackup
+	if err = cbor.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("malformed backup file: %w", err)
+	}
+	return &backup, nil
+}
+
+func loadCustodianKey(fn string) (*x25519.PrivateKey, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custodian key file: %w", err)
+	}
+	raw, err := pem.Unmarshal(custodianKeyPemType, data)
+	if err != nil {
+		return nil, fmt.Errorf("malformed custodian key file: %w", err)
+	}
+	var sk x25519.PrivateKey
+	if len(raw) != len(sk) {
+		return nil, fmt.Errorf("malformed custodian key file: invalid key size")
+	}
+	copy(sk[:], raw)
+	return &sk, nil
+}
+
+func unmarshalX25519PublicKey(b64pk string) (*x25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(b64pk)
+	if err != nil {
+		return nil, err
+	}
+	var pk x25519.PublicKey
+	if len(raw) != len(pk) {
+		return nil, fmt.Errorf("invalid public key size")
+	}
+	copy(pk[:], raw)
+	return &pk, nil
+}
+
+func registerRecoveryCmds(parentCmd *cobra.Command) {
+	recoveryInitCustodianCmd.Flags().String(CfgRecoveryCustodianKeyFile, "", "custodian private key file (PEM)")
+
+	recoveryShareCmd.Flags().String(CfgRecoveryCustodianKeyFile, "", "custodian private key file (PEM)")
+	recoveryShareCmd.Flags().String(CfgRecoveryBackupFile, "", "master secret backup file exported by a key manager node")
+	recoveryShareCmd.Flags().String(CfgRecoveryREK, "", "base64-encoded runtime encryption key of the enclave importing the master secrets")
+	recoveryShareCmd.Flags().StringSlice(CfgRecoveryShareFile, []string{}, "output file name of the encrypted share")
+
+	recoveryBundleCmd.Flags().String(CfgRecoveryBackupFile, "", "master secret backup file exported by a key manager node")
+	recoveryBundleCmd.Flags().StringSlice(CfgRecoveryShareFile, []string{}, "file name(s) of encrypted shares")
+	recoveryBundleCmd.Flags().String(CfgRecoveryFile, "", "output file name of the recovery file")
+
+	for _, cmd := range []*cobra.Command{
+		recoveryInitCustodianCmd,
+		recoveryShareCmd,
+		recoveryBundleCmd,
+	} {
+		cmd.PreRun = func(cmd *cobra.Command, args []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		}
+		parentCmd.AddCommand(cmd)
+	}
+}
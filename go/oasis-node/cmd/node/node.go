@@ -59,6 +59,7 @@ const exportsSubDir = "exports"
 type Node struct {
 	svcMgr       *background.ServiceManager
 	grpcInternal *grpc.Server
+	grpcPublic   *grpc.Server
 
 	stopOnce sync.Once
 
@@ -168,6 +169,14 @@ func (n *Node) startRuntimeServices() error {
 	roothashAPI.RegisterService(grpcSrv, n.Consensus.RootHash())
 	governanceAPI.RegisterService(grpcSrv, n.Consensus.Governance())
 
+	// Register the read-only services with the public gRPC server, if enabled.
+	if n.grpcPublic != nil {
+		grpcSrv = n.grpcPublic.Server()
+		registryAPI.RegisterService(grpcSrv, n.Consensus.Registry())
+		stakingAPI.RegisterService(grpcSrv, n.Consensus.Staking())
+		roothashAPI.RegisterService(grpcSrv, n.Consensus.RootHash())
+	}
+
 	// Register dump genesis halt hook.
 	n.Consensus.RegisterHaltHook(func(ctx context.Context, blockHeight int64, epoch beacon.EpochTime, _ error) {
 		n.logger.Info("Consensus halt hook: dumping genesis",
@@ -329,6 +338,9 @@ func (n *Node) initRuntimeWorkers() error {
 	if err != nil {
 		return err
 	}
+	if n.grpcPublic != nil {
+		n.ClientWorker.RegisterService(n.grpcPublic)
+	}
 	n.svcMgr.Register(n.ClientWorker)
 
 	// Commit storage settings to the registered runtimes.
@@ -512,6 +524,18 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 	// Register the node as a node controller.
 	controlAPI.RegisterService(node.grpcInternal.Server(), node)
 
+	// Initialize the public read-only gRPC server, if enabled.
+	node.grpcPublic, err = cmdGrpc.NewServerPublic()
+	if err != nil {
+		logger.Error("failed to initialize public gRPC server",
+			"err", err,
+		)
+		return nil, err
+	}
+	if node.grpcPublic != nil {
+		node.svcMgr.Register(node.grpcPublic)
+	}
+
 	// Open the common node store.
 	node.commonStore, err = persistent.NewCommonStore(node.dataDir)
 	if err != nil {
@@ -550,6 +574,9 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 	}
 	node.svcMgr.Register(node.Consensus)
 	consensusAPI.RegisterService(node.grpcInternal.Server(), node.Consensus)
+	if node.grpcPublic != nil {
+		consensusAPI.RegisterService(node.grpcPublic.Server(), node.Consensus)
+	}
 
 	// Initialize P2P network. Since libp2p host starts listening immediately when created, make
 	// sure that we don't start it if it is not needed.
@@ -613,6 +640,16 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	// Start the public gRPC server, if enabled.
+	if node.grpcPublic != nil {
+		if err = node.grpcPublic.Start(); err != nil {
+			logger.Error("failed to start public gRPC server",
+				"err", err,
+			)
+			return nil, err
+		}
+	}
+
 	// Start the consensus backend service.
 	if err = node.Consensus.Start(); err != nil {
 		logger.Error("failed to start consensus backend service",
@@ -13,6 +13,7 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
@@ -113,6 +114,76 @@ func (n *Node) CancelUpgrade(ctx context.Context, descriptor *upgrade.Descriptor
 	return n.Upgrader.CancelUpgrade(ctx, descriptor)
 }
 
+// GetTxPoolTransactions implements control.NodeController.
+func (n *Node) GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*txpool.TransactionInfo, error) {
+	txPool, err := n.getTxPool(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	return txPool.GetTransactions(), nil
+}
+
+// GetTxPoolTransaction implements control.NodeController.
+func (n *Node) GetTxPoolTransaction(ctx context.Context, query *control.TxPoolTransactionQuery) (*txpool.TransactionInfo, error) {
+	txPool, err := n.getTxPool(query.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	ti := txPool.GetTransaction(query.TxHash)
+	if ti == nil {
+		return nil, control.ErrTransactionNotFound
+	}
+	return ti, nil
+}
+
+// EvictTxPoolTransactions implements control.NodeController.
+func (n *Node) EvictTxPoolTransactions(ctx context.Context, req *control.EvictTxPoolTransactionsRequest) ([]*txpool.TransactionInfo, error) {
+	txPool, err := n.getTxPool(req.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	evicted := txPool.EvictTransactions(func(ti *txpool.TransactionInfo) bool {
+		return req.Matches(ti, now)
+	})
+
+	n.logger.Info("evicted transactions from the transaction pool",
+		"runtime_id", req.RuntimeID,
+		"num_evicted", len(evicted),
+	)
+
+	return evicted, nil
+}
+
+// GetPeerReputations implements control.NodeController.
+func (n *Node) GetPeerReputations(ctx context.Context) ([]*p2p.PeerScore, error) {
+	pm := n.P2P.PeerManager()
+	if pm == nil {
+		return nil, control.ErrNotImplemented
+	}
+	return pm.PeerReputation().Scores(), nil
+}
+
+// GetKeymanagerAuditLog implements control.NodeController.
+func (n *Node) GetKeymanagerAuditLog(ctx context.Context, query *keymanagerWorker.AuditQuery) ([]*keymanagerWorker.AuditRecord, error) {
+	if n.KeymanagerWorker == nil || !n.KeymanagerWorker.Enabled() {
+		return nil, control.ErrNotImplemented
+	}
+	return n.KeymanagerWorker.GetAuditLog(ctx, query)
+}
+
+func (n *Node) getTxPool(runtimeID common.Namespace) (txpool.TransactionPool, error) {
+	if n.CommonWorker == nil {
+		return nil, control.ErrRuntimeNotFound
+	}
+	rtNode := n.CommonWorker.GetRuntime(runtimeID)
+	if rtNode == nil || rtNode.TxPool == nil {
+		return nil, control.ErrRuntimeNotFound
+	}
+	return rtNode.TxPool, nil
+}
+
 // GetStatus implements control.NodeController.
 func (n *Node) GetStatus(ctx context.Context) (*control.Status, error) {
 	cs, err := n.getConsensusStatus(ctx)
@@ -3,9 +3,13 @@ package node
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 )
 
 // Assert that the seed node implements NodeController interface.
@@ -47,6 +51,31 @@ func (n *SeedNode) CancelUpgrade(ctx context.Context, descriptor *upgrade.Descri
 	return control.ErrNotImplemented
 }
 
+// GetTxPoolTransactions implements control.NodeController.
+func (n *SeedNode) GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*txpool.TransactionInfo, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// GetTxPoolTransaction implements control.NodeController.
+func (n *SeedNode) GetTxPoolTransaction(ctx context.Context, query *control.TxPoolTransactionQuery) (*txpool.TransactionInfo, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// EvictTxPoolTransactions implements control.NodeController.
+func (n *SeedNode) EvictTxPoolTransactions(ctx context.Context, req *control.EvictTxPoolTransactionsRequest) ([]*txpool.TransactionInfo, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// GetPeerReputations implements control.NodeController.
+func (n *SeedNode) GetPeerReputations(ctx context.Context) ([]*p2p.PeerScore, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// GetKeymanagerAuditLog implements control.NodeController.
+func (n *SeedNode) GetKeymanagerAuditLog(ctx context.Context, query *keymanagerWorker.AuditQuery) ([]*keymanagerWorker.AuditRecord, error) {
+	return nil, control.ErrNotImplemented
+}
+
 // GetStatus implements control.NodeController.
 func (n *SeedNode) GetStatus(ctx context.Context) (*control.Status, error) {
 	tmAddresses, err := n.cometbftSeed.GetAddresses()
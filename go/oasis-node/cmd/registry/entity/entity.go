@@ -34,6 +34,11 @@ const (
 	CfgNodeDescriptor = "entity.node.descriptor"
 	CfgReuseSigner    = "entity.reuse_signer"
 
+	CfgMetadataName    = "entity.metadata.name"
+	CfgMetadataURL     = "entity.metadata.url"
+	CfgMetadataEmail   = "entity.metadata.email"
+	CfgMetadataKeybase = "entity.metadata.keybase"
+
 	entityGenesisFilename = "entity_genesis.json"
 )
 
@@ -234,6 +239,38 @@ func doUpdate(cmd *cobra.Command, args []string) {
 		ent.Nodes = append(ent.Nodes, k)
 	}
 
+	// Update the entity's metadata. Only explicitly specified fields are changed
+	// and setting a field to an empty string removes it.
+	md := ent.Metadata
+	if md == nil {
+		md = &entity.Metadata{}
+	}
+	for _, v := range []struct {
+		flag  string
+		field *string
+	}{
+		{CfgMetadataName, &md.Name},
+		{CfgMetadataURL, &md.URL},
+		{CfgMetadataEmail, &md.Email},
+		{CfgMetadataKeybase, &md.Keybase},
+	} {
+		if cmd.Flags().Changed(v.flag) {
+			*v.field = viper.GetString(v.flag)
+		}
+	}
+	switch md.IsEmpty() {
+	case true:
+		ent.Metadata = nil
+	case false:
+		if err = md.ValidateBasic(); err != nil {
+			logger.Error("invalid entity metadata",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		ent.Metadata = md
+	}
+
 	// Save the entity descriptor.
 	if err = ent.Save(dataDir); err != nil {
 		logger.Error("failed to persist entity descriptor",
@@ -436,6 +473,10 @@ func init() {
 
 	updateFlags.StringSlice(CfgNodeID, nil, "ID(s) of nodes associated with this entity")
 	updateFlags.StringSlice(CfgNodeDescriptor, nil, "Node genesis descriptor(s) of nodes associated with this entity")
+	updateFlags.String(CfgMetadataName, "", "Entity name (empty to remove)")
+	updateFlags.String(CfgMetadataURL, "", "Entity website URL (empty to remove)")
+	updateFlags.String(CfgMetadataEmail, "", "Entity contact e-mail address (empty to remove)")
+	updateFlags.String(CfgMetadataKeybase, "", "Entity Keybase handle (empty to remove)")
 	_ = viper.BindPFlags(updateFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
@@ -234,6 +234,15 @@ func doInfo(cmd *cobra.Command, args []string) {
 	token.PrettyPrintAmount(ctx, *governanceDeposits, os.Stdout)
 	fmt.Println()
 
+	baseFee, err := client.BaseFee(ctx, height)
+	if err != nil {
+		logger.Error("failed to query base fee",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Printf("Base fee (gas price): %s (next block: %s)\n", baseFee.Current, baseFee.Next)
+
 	thresholdsToQuery := []api.ThresholdKind{
 		api.KindEntity,
 		api.KindNodeValidator,
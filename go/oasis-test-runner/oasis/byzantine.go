@@ -3,7 +3,6 @@ package oasis
 import (
 	"fmt"
 	"path/filepath"
-	"strconv"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -63,13 +62,16 @@ func (worker *Byzantine) AddArgs(args *argBuilder) error {
 }
 
 func (worker *Byzantine) ModifyConfig() error {
-	worker.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(worker.consensusPort))
-	worker.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(worker.consensusPort))
+	if err := worker.setConsensusPort(worker.consensusPort); err != nil {
+		return err
+	}
 
 	worker.Config.Consensus.Debug.P2PAllowDuplicateIP = true
 	worker.Config.Consensus.Debug.P2PAddrBookLenient = true
 
-	worker.Config.P2P.Port = worker.p2pPort
+	if err := worker.setP2PPort(nodePortP2P, worker.p2pPort); err != nil {
+		return err
+	}
 
 	worker.AddSeedNodesToConfig()
 
@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
@@ -21,7 +24,7 @@ type KeymanagerHelpers struct {
 }
 
 // InitPolicy generates the KM policy file.
-func (k *KeymanagerHelpers) InitPolicy(runtimeID common.Namespace, serial uint32, rotationInterval beacon.EpochTime, policies map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX, polPath string) error {
+func (k *KeymanagerHelpers) InitPolicy(runtimeID common.Namespace, serial uint32, rotationInterval beacon.EpochTime, policies map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX, recovery *keymanager.MasterSecretRecoveryPolicy, polPath string) error {
 	k.logger.Info("initing KM policy",
 		"policy_path", polPath,
 		"serial", serial,
@@ -58,6 +61,12 @@ func (k *KeymanagerHelpers) InitPolicy(runtimeID common.Namespace, serial uint32
 			args = append(args, strings.Join(encIDstrs, ","))
 		}
 	}
+	if recovery != nil {
+		args = append(args, "--"+cmdKM.CfgPolicyRecoveryThreshold, strconv.FormatUint(uint64(recovery.Threshold), 10))
+		for _, pk := range recovery.Custodians {
+			args = append(args, "--"+cmdKM.CfgPolicyRecoveryCustodian, base64.StdEncoding.EncodeToString(pk[:]))
+		}
+	}
 	if err := k.runSubCommand("keymanager-init_policy", args); err != nil {
 		return fmt.Errorf("failed to init KM policy: %w", err)
 	}
@@ -114,3 +123,73 @@ func (k *KeymanagerHelpers) GenUpdate(nonce uint64, polPath string, polSigPaths
 	}
 	return nil
 }
+
+// RecoveryInitCustodian generates a master secret recovery custodian key and returns
+// its public key.
+func (k *KeymanagerHelpers) RecoveryInitCustodian(keyPath string) (*x25519.PublicKey, error) {
+	k.logger.Info("generating KM recovery custodian key",
+		"key_path", keyPath,
+	)
+
+	args := []string{
+		"keymanager", "recovery_init_custodian",
+		"--" + cmdKM.CfgRecoveryCustodianKeyFile, keyPath,
+	}
+	out, err := k.runSubCommandWithOutput("keymanager-recovery_init_custodian", args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate KM recovery custodian key: error: %w output: %s", err, out.String())
+	}
+
+	var pk x25519.PublicKey
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(out.String()))
+	if err != nil || len(raw) != len(pk) {
+		return nil, fmt.Errorf("failed to parse output: output: %s", out.String())
+	}
+	copy(pk[:], raw)
+
+	return &pk, nil
+}
+
+// RecoveryShare decrypts the custodian's share of the master secret backup and encrypts it
+// to the given key manager enclave REK.
+func (k *KeymanagerHelpers) RecoveryShare(backupPath, keyPath string, rek x25519.PublicKey, sharePath string) error {
+	k.logger.Info("generating KM recovery share",
+		"backup_path", backupPath,
+		"key_path", keyPath,
+		"share_path", sharePath,
+	)
+
+	args := []string{
+		"keymanager", "recovery_share",
+		"--" + cmdKM.CfgRecoveryBackupFile, backupPath,
+		"--" + cmdKM.CfgRecoveryCustodianKeyFile, keyPath,
+		"--" + cmdKM.CfgRecoveryREK, base64.StdEncoding.EncodeToString(rek[:]),
+		"--" + cmdKM.CfgRecoveryShareFile, sharePath,
+	}
+	if err := k.runSubCommand("keymanager-recovery_share", args); err != nil {
+		return fmt.Errorf("failed to generate KM recovery share: %w", err)
+	}
+	return nil
+}
+
+// RecoveryBundle combines the encrypted master secret shares into a recovery file.
+func (k *KeymanagerHelpers) RecoveryBundle(backupPath string, sharePaths []string, recoveryPath string) error {
+	k.logger.Info("generating KM recovery file",
+		"backup_path", backupPath,
+		"share_paths", sharePaths,
+		"recovery_path", recoveryPath,
+	)
+
+	args := []string{
+		"keymanager", "recovery_bundle",
+		"--" + cmdKM.CfgRecoveryBackupFile, backupPath,
+		"--" + cmdKM.CfgRecoveryFile, recoveryPath,
+	}
+	for _, sharePath := range sharePaths {
+		args = append(args, "--"+cmdKM.CfgRecoveryShareFile, sharePath)
+	}
+	if err := k.runSubCommand("keymanager-recovery_bundle", args); err != nil {
+		return fmt.Errorf("failed to generate KM recovery file: %w", err)
+	}
+	return nil
+}
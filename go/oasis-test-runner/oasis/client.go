@@ -3,7 +3,6 @@ package oasis
 import (
 	"fmt"
 	"os"
-	"strconv"
 
 	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
@@ -48,15 +47,18 @@ func (client *Client) AddArgs(args *argBuilder) error {
 }
 
 func (client *Client) ModifyConfig() error {
-	client.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(client.consensusPort))
-	client.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(client.consensusPort))
+	if err := client.setConsensusPort(client.consensusPort); err != nil {
+		return err
+	}
 
 	if client.supplementarySanityInterval > 0 {
 		client.Config.Consensus.SupplementarySanity.Enabled = true
 		client.Config.Consensus.SupplementarySanity.Interval = client.supplementarySanityInterval
 	}
 
-	client.Config.P2P.Port = client.p2pPort
+	if err := client.setP2PPort(nodePortP2P, client.p2pPort); err != nil {
+		return err
+	}
 
 	if len(client.runtimes) > 0 {
 		client.Config.Mode = config.ModeStatelessClient
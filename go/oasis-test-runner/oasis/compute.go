@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"sync"
 	"time"
 
@@ -150,15 +149,18 @@ func (worker *Compute) ModifyConfig() error {
 	worker.RLock()
 	defer worker.RUnlock()
 
-	worker.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(worker.consensusPort))
-	worker.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(worker.consensusPort))
+	if err := worker.setConsensusPort(worker.consensusPort); err != nil {
+		return err
+	}
 
 	if worker.supplementarySanityInterval > 0 {
 		worker.Config.Consensus.SupplementarySanity.Enabled = true
 		worker.Config.Consensus.SupplementarySanity.Interval = worker.supplementarySanityInterval
 	}
 
-	worker.Config.P2P.Port = worker.p2pPort
+	if err := worker.setP2PPort(nodePortP2P, worker.p2pPort); err != nil {
+		return err
+	}
 
 	if !worker.entity.isDebugTestEntity {
 		dir := worker.entity.dir.String()
@@ -0,0 +1,61 @@
+package fault
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// DiskFillFile is the name of the file used to fill the disk.
+const DiskFillFile = "fault-disk-fill"
+
+// FillDisk fills the disk hosting the given directory so that only the given number of bytes
+// remain available, returning the number of bytes allocated.
+//
+// Note that this affects all users of the same file system.
+func FillDisk(dir string, leaveFree uint64) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return 0, fmt.Errorf("fault: failed to stat file system: %w", err)
+	}
+	avail := uint64(st.Bavail) * uint64(st.Bsize) // nolint: unconvert
+	if avail <= leaveFree {
+		return 0, nil
+	}
+	size := avail - leaveFree
+
+	f, err := os.OpenFile(filepath.Join(dir, DiskFillFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("fault: failed to create fill file: %w", err)
+	}
+	defer f.Close()
+
+	if err = allocate(f, int64(size)); err != nil {
+		return 0, fmt.Errorf("fault: failed to fill disk: %w", err)
+	}
+	return size, nil
+}
+
+// FreeDisk frees the space allocated by FillDisk in the given directory.
+func FreeDisk(dir string) error {
+	if err := os.Remove(filepath.Join(dir, DiskFillFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("fault: failed to remove fill file: %w", err)
+	}
+	return nil
+}
+
+func writeZeros(f *os.File, size int64) error {
+	buf := make([]byte, 4*1024*1024)
+	for size > 0 {
+		n := int64(len(buf))
+		if n > size {
+			n = size
+		}
+		if _, err := f.Write(buf[:n]); err != nil {
+			return err
+		}
+		size -= n
+	}
+	return nil
+}
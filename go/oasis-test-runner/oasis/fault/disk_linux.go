@@ -0,0 +1,18 @@
+package fault
+
+import (
+	"os"
+	"syscall"
+)
+
+func allocate(f *os.File, size int64) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	err = syscall.Fallocate(int(f.Fd()), 0, fi.Size(), size)
+	if err == syscall.EOPNOTSUPP {
+		return writeZeros(f, size)
+	}
+	return err
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package fault
+
+import "os"
+
+func allocate(f *os.File, size int64) error {
+	return writeZeros(f, size)
+}
@@ -0,0 +1,272 @@
+// Package fault implements network fault injection for local test networks.
+//
+// Faults are injected by TCP proxies placed in front of the P2P ports of the nodes. Since all
+// nodes run on the same host, the proxy identifies the node that initiated a connection by
+// looking up the process owning the other end of the connection.
+package fault
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	// AnyNode is the wildcard node name matching all nodes.
+	AnyNode = "*"
+
+	// lossPenalty is the delay used to emulate the retransmission of lost data.
+	lossPenalty = 200 * time.Millisecond
+)
+
+// LinkFault describes the faults injected into the traffic on a link between two nodes.
+type LinkFault struct {
+	// Down drops all traffic on the link (i.e. the nodes are partitioned).
+	Down bool `json:"down,omitempty"`
+	// Latency is the delay added to all data sent over the link.
+	Latency time.Duration `json:"latency,omitempty"`
+	// Jitter is the maximum random delay added on top of the latency.
+	Jitter time.Duration `json:"jitter,omitempty"`
+	// Loss is the probability that a packet is lost.
+	//
+	// As the proxies operate on TCP streams, loss is emulated by delaying the affected data
+	// by a retransmission timeout.
+	Loss float64 `json:"loss,omitempty"`
+}
+
+// Validate validates the link fault.
+func (f *LinkFault) Validate() error {
+	if f.Latency < 0 || f.Jitter < 0 {
+		return fmt.Errorf("fault: latency and jitter must be >= 0")
+	}
+	if f.Loss < 0 || f.Loss > 1 {
+		return fmt.Errorf("fault: loss must be between 0 and 1")
+	}
+	return nil
+}
+
+// IsZero returns true iff no faults are injected.
+func (f *LinkFault) IsZero() bool {
+	return *f == LinkFault{}
+}
+
+func (f *LinkFault) delay(rng *rand.Rand) time.Duration {
+	delay := f.Latency
+	if f.Jitter > 0 {
+		delay += time.Duration(rng.Int63n(int64(f.Jitter) + 1))
+	}
+	if f.Loss > 0 && rng.Float64() < f.Loss {
+		delay += lossPenalty
+	}
+	return delay
+}
+
+// Link is an (undirected) link between two nodes.
+type Link struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+func newLink(a, b string) Link {
+	if a > b {
+		a, b = b, a
+	}
+	return Link{A: a, B: b}
+}
+
+func (l Link) String() string {
+	return l.A + "<->" + l.B
+}
+
+// LinkStatus is the status of a faulty link.
+type LinkStatus struct {
+	Link
+	Fault LinkFault `json:"fault"`
+}
+
+// IdentifyFunc returns the name of the node owning the given (remote) end of a connection or
+// an empty string if the owner is not known.
+type IdentifyFunc func(remoteAddr string) string
+
+// Network is a set of fault-injecting proxies in front of the nodes of a local network.
+type Network struct {
+	sync.RWMutex
+
+	identify IdentifyFunc
+	links    map[Link]LinkFault
+	proxies  []*Proxy
+	conns    map[*proxyConn]struct{}
+
+	logger *logging.Logger
+}
+
+// SetLinkFault sets the faults injected into the traffic between the given nodes, replacing
+// any existing faults. Either of the nodes may be AnyNode to match all nodes.
+//
+// Existing connections between the nodes are closed when the link goes down.
+func (n *Network) SetLinkFault(a, b string, f LinkFault) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	if a == "" || b == "" {
+		return fmt.Errorf("fault: node names must not be empty")
+	}
+
+	n.Lock()
+	defer n.Unlock()
+
+	link := newLink(a, b)
+	if f.IsZero() {
+		delete(n.links, link)
+	} else {
+		n.links[link] = f
+	}
+
+	n.logger.Info("link fault updated",
+		"link", link,
+		"fault", f,
+	)
+
+	n.closeDownConnsLocked()
+	return nil
+}
+
+// Partition partitions the network into the given groups of nodes, so that nodes in different
+// groups are unable to communicate. Nodes not in any of the groups are not affected.
+func (n *Network) Partition(groups ...[]string) error {
+	for i, g := range groups {
+		for _, name := range g {
+			if name == "" || name == AnyNode {
+				return fmt.Errorf("fault: invalid node name in partition group %d: '%s'", i, name)
+			}
+		}
+	}
+
+	n.Lock()
+	defer n.Unlock()
+
+	for i := range groups {
+		for j := i + 1; j < len(groups); j++ {
+			for _, a := range groups[i] {
+				for _, b := range groups[j] {
+					link := newLink(a, b)
+					f := n.links[link]
+					f.Down = true
+					n.links[link] = f
+				}
+			}
+		}
+	}
+
+	n.logger.Info("network partitioned",
+		"groups", groups,
+	)
+
+	n.closeDownConnsLocked()
+	return nil
+}
+
+// Heal removes all link faults.
+func (n *Network) Heal() {
+	n.Lock()
+	defer n.Unlock()
+
+	n.links = make(map[Link]LinkFault)
+
+	n.logger.Info("network healed")
+}
+
+// Links returns the status of all faulty links.
+func (n *Network) Links() []LinkStatus {
+	n.RLock()
+	defer n.RUnlock()
+
+	links := make([]LinkStatus, 0, len(n.links))
+	for link, f := range n.links {
+		links = append(links, LinkStatus{Link: link, Fault: f})
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Link.String() < links[j].Link.String()
+	})
+	return links
+}
+
+// LinkFault returns the faults injected into the traffic between the given nodes.
+//
+// Faults set for the exact link take precedence over the ones set using wildcards. If the
+// source node is not known, only faults set using wildcards apply.
+func (n *Network) LinkFault(src, dst string) LinkFault {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.linkFaultLocked(src, dst)
+}
+
+func (n *Network) linkFaultLocked(src, dst string) LinkFault {
+	candidates := []Link{
+		newLink(src, dst),
+		newLink(src, AnyNode),
+		newLink(dst, AnyNode),
+		newLink(AnyNode, AnyNode),
+	}
+	if src == "" {
+		candidates = candidates[2:]
+	}
+	for _, link := range candidates {
+		if f, ok := n.links[link]; ok {
+			return f
+		}
+	}
+	return LinkFault{}
+}
+
+func (n *Network) closeDownConnsLocked() {
+	for c := range n.conns {
+		if f := n.linkFaultLocked(c.src, c.dst); f.Down {
+			c.close()
+		}
+	}
+}
+
+func (n *Network) addConn(c *proxyConn) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.conns[c] = struct{}{}
+}
+
+func (n *Network) removeConn(c *proxyConn) {
+	n.Lock()
+	defer n.Unlock()
+
+	delete(n.conns, c)
+}
+
+// Close stops all proxies and closes all connections.
+func (n *Network) Close() {
+	n.Lock()
+	proxies := n.proxies
+	n.proxies = nil
+	for c := range n.conns {
+		c.close()
+	}
+	n.Unlock()
+
+	for _, p := range proxies {
+		p.close()
+	}
+}
+
+// NewNetwork creates a new set of fault-injecting proxies.
+func NewNetwork(identify IdentifyFunc) *Network {
+	return &Network{
+		identify: identify,
+		links:    make(map[Link]LinkFault),
+		conns:    make(map[*proxyConn]struct{}),
+		logger:   logging.GetLogger("oasis/fault"),
+	}
+}
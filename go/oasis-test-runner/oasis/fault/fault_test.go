@@ -0,0 +1,192 @@
+package fault
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkFault(t *testing.T) {
+	require := require.New(t)
+
+	n := NewNetwork(func(string) string { return "" })
+
+	require.Error(n.SetLinkFault("a", "b", LinkFault{Loss: 2}), "invalid loss should fail")
+	require.Error(n.SetLinkFault("a", "b", LinkFault{Latency: -1}), "negative latency should fail")
+	require.Error(n.SetLinkFault("", "b", LinkFault{Down: true}), "empty node name should fail")
+
+	require.NoError(n.SetLinkFault(AnyNode, AnyNode, LinkFault{Latency: time.Second}))
+	require.NoError(n.SetLinkFault("a", AnyNode, LinkFault{Latency: 2 * time.Second}))
+	require.NoError(n.SetLinkFault("b", "a", LinkFault{Latency: 3 * time.Second}))
+
+	require.Equal(3*time.Second, n.LinkFault("a", "b").Latency, "exact link should take precedence")
+	require.Equal(3*time.Second, n.LinkFault("b", "a").Latency, "links should be undirected")
+	require.Equal(2*time.Second, n.LinkFault("a", "c").Latency)
+	require.Equal(2*time.Second, n.LinkFault("c", "a").Latency)
+	require.Equal(time.Second, n.LinkFault("c", "d").Latency)
+	require.Equal(2*time.Second, n.LinkFault("", "a").Latency, "unknown source should only match wildcards")
+	require.Equal(time.Second, n.LinkFault("", "b").Latency, "unknown source should only match wildcards")
+	require.Len(n.Links(), 3)
+
+	require.NoError(n.SetLinkFault("a", "b", LinkFault{}))
+	require.Equal(2*time.Second, n.LinkFault("a", "b").Latency, "clearing a link should remove it")
+
+	n.Heal()
+	require.Empty(n.Links())
+
+	require.Error(n.Partition([]string{"a"}, []string{AnyNode}), "wildcard in partition should fail")
+	require.NoError(n.Partition([]string{"a", "b"}, []string{"c"}, []string{"d"}))
+	require.False(n.LinkFault("a", "b").Down)
+	require.True(n.LinkFault("a", "c").Down)
+	require.True(n.LinkFault("d", "b").Down)
+	require.True(n.LinkFault("c", "d").Down)
+	require.False(n.LinkFault("a", "e").Down, "nodes not in any group should not be affected")
+	require.Len(n.Links(), 5)
+}
+
+func TestProxy(t *testing.T) {
+	require := require.New(t)
+
+	// Echo server.
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err, "Listen")
+	defer echo.Close()
+	go func() {
+		for {
+			conn, err := echo.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(conn, conn)
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	n := NewNetwork(func(string) string { return "client" })
+	defer n.Close()
+
+	p, err := n.AddProxy("server", "127.0.0.1:0", echo.Addr().String())
+	require.NoError(err, "AddProxy")
+	require.Equal("server", p.Node())
+
+	roundTrip := func(conn net.Conn) (time.Duration, error) {
+		start := time.Now()
+		if _, err := conn.Write([]byte("ping")); err != nil {
+			return 0, err
+		}
+		buf := make([]byte, 4)
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return 0, err
+		}
+		require.Equal([]byte("ping"), buf)
+		return time.Since(start), nil
+	}
+
+	conn, err := net.Dial("tcp", p.Addr())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+
+	_, err = roundTrip(conn)
+	require.NoError(err, "round trip without faults")
+
+	// Latency applies to both directions.
+	require.NoError(n.SetLinkFault("client", "server", LinkFault{Latency: 100 * time.Millisecond}))
+	rtt, err := roundTrip(conn)
+	require.NoError(err, "round trip with latency")
+	require.GreaterOrEqual(rtt, 200*time.Millisecond, "latency should be injected")
+
+	// Partitioning should close existing connections and refuse new ones.
+	require.NoError(n.Partition([]string{"client"}, []string{"server"}))
+	_, err = roundTrip(conn)
+	require.Error(err, "round trip over a partitioned link should fail")
+
+	conn2, err := net.Dial("tcp", p.Addr())
+	require.NoError(err, "Dial")
+	defer conn2.Close()
+	_, err = roundTrip(conn2)
+	require.Error(err, "new connections over a partitioned link should fail")
+
+	n.Heal()
+	conn3, err := net.Dial("tcp", p.Addr())
+	require.NoError(err, "Dial")
+	defer conn3.Close()
+	_, err = roundTrip(conn3)
+	require.NoError(err, "round trip after healing")
+}
+
+func TestSocketOwner(t *testing.T) {
+	require := require.New(t)
+
+	if _, err := os.Stat("/proc/net/tcp"); err != nil {
+		t.Skip("procfs not available")
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err, "Listen")
+	defer l.Close()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+
+	pid, err := SocketOwner(conn.LocalAddr().String(), []int{os.Getpid()})
+	require.NoError(err, "SocketOwner")
+	require.Equal(os.Getpid(), pid)
+
+	_, err = SocketOwner(conn.LocalAddr().String(), nil)
+	require.Error(err, "SocketOwner should fail without candidates")
+}
+
+func TestParseProcAddr(t *testing.T) {
+	require := require.New(t)
+
+	addr, err := parseProcAddr("0100007F:4E20")
+	require.NoError(err, "parseProcAddr")
+	require.Equal("127.0.0.1:20000", addr.String())
+
+	addr, err = parseProcAddr("0000000000000000FFFF00000100007F:0050")
+	require.NoError(err, "parseProcAddr")
+	require.Equal("127.0.0.1:80", addr.String())
+
+	_, err = parseProcAddr("0100007F")
+	require.Error(err, "missing port should fail")
+	_, err = parseProcAddr("zz:0050")
+	require.Error(err, "malformed IP should fail")
+}
+
+func TestFillDisk(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+
+	var st syscall.Statfs_t
+	require.NoError(syscall.Statfs(dir, &st), "Statfs")
+	avail := uint64(st.Bavail) * uint64(st.Bsize) // nolint: unconvert
+
+	// Leave all but a small amount of space free to not disturb other users of the disk.
+	size, err := FillDisk(dir, avail-1024*1024)
+	require.NoError(err, "FillDisk")
+	require.NotZero(size)
+
+	fi, err := os.Stat(filepath.Join(dir, DiskFillFile))
+	require.NoError(err, "Stat")
+	require.EqualValues(size, fi.Size())
+
+	size, err = FillDisk(dir, avail*2)
+	require.NoError(err, "FillDisk")
+	require.Zero(size, "nothing should be allocated when there is not enough space")
+
+	require.NoError(FreeDisk(dir), "FreeDisk")
+	_, err = os.Stat(filepath.Join(dir, DiskFillFile))
+	require.True(os.IsNotExist(err), "fill file should be removed")
+	require.NoError(FreeDisk(dir), "FreeDisk should be idempotent")
+}
@@ -0,0 +1,110 @@
+package fault
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SocketOwner returns the PID of the process (among the given candidates) which owns the local
+// TCP socket bound to the given address.
+func SocketOwner(addr string, pids []int) (int, error) {
+	tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return 0, fmt.Errorf("fault: malformed address: %w", err)
+	}
+
+	inode, err := socketInode(tcpAddr)
+	if err != nil {
+		return 0, err
+	}
+	link := fmt.Sprintf("socket:[%d]", inode)
+
+	for _, pid := range pids {
+		fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+		entries, err := os.ReadDir(fdDir)
+		if err != nil {
+			// The process may have exited in the meantime.
+			continue
+		}
+		for _, entry := range entries {
+			target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			if target == link {
+				return pid, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("fault: owner of socket %s not found", addr)
+}
+
+func socketInode(addr *net.TCPAddr) (uint64, error) {
+	for _, fn := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		inode, err := findSocketInode(fn, addr)
+		if err != nil {
+			return 0, err
+		}
+		if inode != 0 {
+			return inode, nil
+		}
+	}
+	return 0, fmt.Errorf("fault: socket %s not found", addr)
+}
+
+func findSocketInode(fn string, addr *net.TCPAddr) (uint64, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("fault: failed to read sockets: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // Skip the header.
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		local, err := parseProcAddr(fields[1])
+		if err != nil {
+			continue
+		}
+		if local.Port != addr.Port || !local.IP.Equal(addr.IP) {
+			continue
+		}
+		return strconv.ParseUint(fields[9], 10, 64)
+	}
+	return 0, scanner.Err()
+}
+
+// parseProcAddr parses a socket address in the format used by /proc/net/tcp{,6}, where the IP
+// address is encoded as hex-encoded 32-bit words in host (little-endian) byte order.
+func parseProcAddr(s string) (*net.TCPAddr, error) {
+	ipHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed address")
+	}
+	raw, err := hex.DecodeString(ipHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, fmt.Errorf("malformed IP address")
+	}
+	for i := 0; i < len(raw); i += 4 {
+		raw[i], raw[i+1], raw[i+2], raw[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("malformed port")
+	}
+	return &net.TCPAddr{IP: net.IP(raw), Port: int(port)}, nil
+}
@@ -0,0 +1,189 @@
+package fault
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+const proxyBufferSize = 32 * 1024
+
+// Proxy is a TCP proxy forwarding connections to a node's port while injecting faults.
+type Proxy struct {
+	net *Network
+
+	node       string
+	listener   net.Listener
+	targetAddr string
+}
+
+// Node returns the name of the node the proxy forwards connections to.
+func (p *Proxy) Node() string {
+	return p.node
+}
+
+// Addr returns the address the proxy is listening on.
+func (p *Proxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+func (p *Proxy) close() {
+	_ = p.listener.Close()
+}
+
+func (p *Proxy) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				p.net.logger.Error("failed to accept connection",
+					"err", err,
+					"node", p.node,
+				)
+			}
+			return
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *Proxy) handle(conn net.Conn) {
+	src := p.net.identify(conn.RemoteAddr().String())
+	if f := p.net.LinkFault(src, p.node); f.Down {
+		_ = conn.Close()
+		return
+	}
+
+	target, err := net.Dial("tcp", p.targetAddr)
+	if err != nil {
+		p.net.logger.Debug("failed to dial proxy target",
+			"err", err,
+			"node", p.node,
+		)
+		_ = conn.Close()
+		return
+	}
+
+	c := &proxyConn{
+		net:    p.net,
+		src:    src,
+		dst:    p.node,
+		conns:  [2]net.Conn{conn, target},
+		closed: make(chan struct{}),
+	}
+	p.net.addConn(c)
+	defer p.net.removeConn(c)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c.forward(target, conn)
+	}()
+	go func() {
+		defer wg.Done()
+		c.forward(conn, target)
+	}()
+	wg.Wait()
+}
+
+// AddProxy starts a new proxy listening on the given address and forwarding connections to the
+// given target address of the named node.
+func (n *Network) AddProxy(node, listenAddr, targetAddr string) (*Proxy, error) {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Proxy{
+		net:        n,
+		node:       node,
+		listener:   listener,
+		targetAddr: targetAddr,
+	}
+
+	n.Lock()
+	n.proxies = append(n.proxies, p)
+	n.Unlock()
+
+	go p.serve()
+
+	return p, nil
+}
+
+type chunk struct {
+	data    []byte
+	release time.Time
+}
+
+type proxyConn struct {
+	net *Network
+
+	src string
+	dst string
+
+	conns     [2]net.Conn
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func (c *proxyConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		for _, conn := range c.conns {
+			_ = conn.Close()
+		}
+	})
+}
+
+func (c *proxyConn) forward(dst, src net.Conn) {
+	// The writer closes the connection once all chunks read so far have been forwarded.
+	chunks := make(chan *chunk, 64)
+	go func() {
+		defer c.close()
+		for ch := range chunks {
+			if delay := time.Until(ch.release); delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-c.closed:
+					return
+				}
+			}
+			if _, err := dst.Write(ch.data); err != nil {
+				return
+			}
+		}
+	}()
+	defer close(chunks)
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano())) // nolint: gosec
+	var lastRelease time.Time
+	for {
+		buf := make([]byte, proxyBufferSize)
+		n, err := src.Read(buf)
+		if err != nil {
+			return
+		}
+
+		// Faults are looked up for every chunk so that changes apply to existing connections.
+		f := c.net.LinkFault(c.src, c.dst)
+		if f.Down {
+			c.close()
+			return
+		}
+		release := time.Now().Add(f.delay(rng))
+		if release.Before(lastRelease) {
+			// Preserve ordering.
+			release = lastRelease
+		}
+		lastRelease = release
+
+		select {
+		case chunks <- &chunk{data: buf[:n], release: release}:
+		case <-c.closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package oasis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/fault"
+)
+
+// FaultStatus is the status of the injected faults.
+type FaultStatus struct {
+	// Links are the faulty links between nodes.
+	Links []fault.LinkStatus `json:"links,omitempty"`
+	// Paused are the names of the paused nodes.
+	Paused []string `json:"paused,omitempty"`
+	// FilledDisks are the names of the nodes with filled disks.
+	FilledDisks []string `json:"filled_disks,omitempty"`
+	// ClockSkews are the clock skews of the nodes.
+	ClockSkews map[string]time.Duration `json:"clock_skews,omitempty"`
+}
+
+// FaultInjector injects faults into the nodes of a network.
+//
+// Link faults require the network to be created with fault injection enabled so that
+// fault-injecting proxies are placed in front of the P2P ports of all nodes.
+type FaultInjector struct {
+	sync.Mutex
+
+	net   *Network
+	links *fault.Network
+
+	proxies     map[uint16]*fault.Proxy
+	filledDisks map[string]bool
+}
+
+func newFaultInjector(net *Network) *FaultInjector {
+	fi := &FaultInjector{
+		net:         net,
+		proxies:     make(map[uint16]*fault.Proxy),
+		filledDisks: make(map[string]bool),
+	}
+	fi.links = fault.NewNetwork(fi.identify)
+	return fi
+}
+
+// identify returns the name of the node owning the given end of a connection.
+func (fi *FaultInjector) identify(remoteAddr string) string {
+	names := make(map[int]string)
+	var pids []int
+	for _, n := range fi.net.nodes {
+		n.Lock()
+		if n.cmd != nil && n.cmd.Process != nil {
+			names[n.cmd.Process.Pid] = n.Name
+			pids = append(pids, n.cmd.Process.Pid)
+		}
+		n.Unlock()
+	}
+
+	pid, err := fault.SocketOwner(remoteAddr, pids)
+	if err != nil {
+		return ""
+	}
+	return names[pid]
+}
+
+func (fi *FaultInjector) ensureProxy(node string, public, internal uint16) error {
+	fi.Lock()
+	defer fi.Unlock()
+
+	if _, ok := fi.proxies[public]; ok {
+		return nil
+	}
+	p, err := fi.links.AddProxy(
+		node,
+		"127.0.0.1:"+strconv.Itoa(int(public)),
+		"127.0.0.1:"+strconv.Itoa(int(internal)),
+	)
+	if err != nil {
+		return fmt.Errorf("oasis/fault: failed to start proxy for node %s: %w", node, err)
+	}
+	fi.proxies[public] = p
+	return nil
+}
+
+func (fi *FaultInjector) close() {
+	fi.links.Close()
+}
+
+func (fi *FaultInjector) node(name string) (*Node, error) {
+	for _, n := range fi.net.nodes {
+		if n.Name == name {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("oasis/fault: node '%s' not found", name)
+}
+
+func (fi *FaultInjector) checkLinkFaults(names ...string) error {
+	if !fi.net.cfg.FaultInjection {
+		return fmt.Errorf("oasis/fault: link faults require fault injection to be enabled")
+	}
+	for _, name := range names {
+		if name == fault.AnyNode {
+			continue
+		}
+		if _, err := fi.node(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLinkFault sets the faults injected into the traffic between the given nodes, replacing
+// any existing faults. Either of the nodes may be "*" to match all nodes.
+func (fi *FaultInjector) SetLinkFault(a, b string, f fault.LinkFault) error {
+	if err := fi.checkLinkFaults(a, b); err != nil {
+		return err
+	}
+	return fi.links.SetLinkFault(a, b, f)
+}
+
+// Partition partitions the network into the given groups of nodes, so that nodes in different
+// groups are unable to communicate. Nodes not in any of the groups are not affected.
+func (fi *FaultInjector) Partition(groups ...[]string) error {
+	var names []string
+	for _, g := range groups {
+		names = append(names, g...)
+	}
+	if err := fi.checkLinkFaults(names...); err != nil {
+		return err
+	}
+	return fi.links.Partition(groups...)
+}
+
+// Heal removes all link faults.
+func (fi *FaultInjector) Heal() {
+	fi.links.Heal()
+}
+
+// Pause suspends the given node.
+func (fi *FaultInjector) Pause(name string) error {
+	n, err := fi.node(name)
+	if err != nil {
+		return err
+	}
+	return n.Pause()
+}
+
+// Resume resumes the given paused node.
+func (fi *FaultInjector) Resume(name string) error {
+	n, err := fi.node(name)
+	if err != nil {
+		return err
+	}
+	return n.Resume()
+}
+
+// FillDisk fills the disk hosting the data directory of the given node so that only the given
+// number of bytes remain available.
+//
+// Note that all nodes sharing the file system are affected.
+func (fi *FaultInjector) FillDisk(name string, leaveFree uint64) error {
+	n, err := fi.node(name)
+	if err != nil {
+		return err
+	}
+
+	fi.Lock()
+	defer fi.Unlock()
+
+	size, err := fault.FillDisk(n.DataDir(), leaveFree)
+	if err != nil {
+		return err
+	}
+	fi.filledDisks[name] = true
+
+	fi.net.logger.Info("filled disk",
+		"node", name,
+		"size", size,
+		"leave_free", leaveFree,
+	)
+	return nil
+}
+
+// FreeDisk frees the disk space allocated by FillDisk for the given node.
+func (fi *FaultInjector) FreeDisk(name string) error {
+	n, err := fi.node(name)
+	if err != nil {
+		return err
+	}
+
+	fi.Lock()
+	defer fi.Unlock()
+
+	if err = fault.FreeDisk(n.DataDir()); err != nil {
+		return err
+	}
+	delete(fi.filledDisks, name)
+	return nil
+}
+
+// SkewClock sets the clock skew of the given node, restarting the node if it is running.
+//
+// The skew is applied to the timestamps of the consensus votes signed by the node, so it only
+// affects validators.
+func (fi *FaultInjector) SkewClock(ctx context.Context, name string, skew time.Duration) error {
+	n, err := fi.node(name)
+	if err != nil {
+		return err
+	}
+
+	n.Lock()
+	n.clockSkew = skew
+	running := n.cmd != nil
+	n.Unlock()
+
+	fi.net.logger.Info("setting clock skew",
+		"node", name,
+		"clock_skew", skew,
+	)
+
+	if !running {
+		return nil
+	}
+	return n.Restart(ctx)
+}
+
+// Clear removes all link faults, resumes all paused nodes and frees all filled disks.
+//
+// Clock skews are kept as removing them requires the nodes to be restarted.
+func (fi *FaultInjector) Clear() error {
+	fi.Heal()
+
+	for _, n := range fi.net.nodes {
+		if n.IsPaused() {
+			if err := n.Resume(); err != nil {
+				return err
+			}
+		}
+	}
+
+	fi.Lock()
+	var filled []string
+	for name := range fi.filledDisks {
+		filled = append(filled, name)
+	}
+	fi.Unlock()
+	for _, name := range filled {
+		if err := fi.FreeDisk(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status returns the status of the injected faults.
+func (fi *FaultInjector) Status() *FaultStatus {
+	status := FaultStatus{
+		Links:      fi.links.Links(),
+		ClockSkews: make(map[string]time.Duration),
+	}
+	for _, n := range fi.net.nodes {
+		if n.IsPaused() {
+			status.Paused = append(status.Paused, n.Name)
+		}
+		n.Lock()
+		if n.clockSkew != 0 {
+			status.ClockSkews[n.Name] = n.clockSkew
+		}
+		n.Unlock()
+	}
+
+	fi.Lock()
+	for name := range fi.filledDisks {
+		status.FilledDisks = append(status.FilledDisks, name)
+	}
+	fi.Unlock()
+	sort.Strings(status.FilledDisks)
+
+	return &status
+}
+
+// listenPort returns the port the node should listen on for connections made to the given
+// public port. If fault injection is enabled, the node listens on an internal port and a
+// fault-injecting proxy forwards connections made to the public port.
+func (n *Node) listenPort(portName string, public uint16) (uint16, error) {
+	if !n.net.cfg.FaultInjection {
+		return public, nil
+	}
+
+	internal := n.getProvisionedPort(portName + "-internal")
+	if err := n.net.faults.ensureProxy(n.Name, public, internal); err != nil {
+		return 0, err
+	}
+	return internal, nil
+}
+
+// setConsensusPort configures the node's consensus P2P addresses.
+func (n *Node) setConsensusPort(port uint16) error {
+	listenPort, err := n.listenPort(nodePortConsensus, port)
+	if err != nil {
+		return err
+	}
+	n.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(listenPort))
+	n.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(port))
+	return nil
+}
+
+// setP2PPort configures the node's P2P port.
+func (n *Node) setP2PPort(portName string, port uint16) error {
+	listenPort, err := n.listenPort(portName, port)
+	if err != nil {
+		return err
+	}
+	n.Config.P2P.Port = listenPort
+	if listenPort != port {
+		// Make sure the node only advertises the proxied address.
+		n.Config.P2P.Registration.Addresses = []string{"127.0.0.1:" + strconv.Itoa(int(port))}
+		n.Config.P2P.Debug.AnnounceRegistrationAddresses = true
+	}
+	return nil
+}
@@ -329,6 +329,8 @@ type KeymanagerFixture struct {
 	LogWatcherHandlerFactories []log.WatcherHandlerFactory `json:"-"`
 
 	PrivatePeerPubKeys []string `json:"private_peer_pub_keys,omitempty"`
+
+	RecoveryExport bool `json:"recovery_export,omitempty"`
 }
 
 // Create instantiates the key manager described by the fixture.
@@ -367,6 +369,7 @@ func (f *KeymanagerFixture) Create(net *Network) (*Keymanager, error) {
 		Policy:             policy,
 		SentryIndices:      f.Sentries,
 		PrivatePeerPubKeys: f.PrivatePeerPubKeys,
+		RecoveryExport:     f.RecoveryExport,
 	})
 }
 
@@ -21,8 +21,9 @@ import (
 )
 
 const (
-	kmStatusFile = "keymanager_status.json"
-	kmPolicyFile = "keymanager_policy.cbor"
+	kmStatusFile        = "keymanager_status.json"
+	kmPolicyFile        = "keymanager_policy.cbor"
+	kmRecoveryExportDir = "keymanager-backups"
 
 	keymanagerIdentitySeedTemplate = "ekiden node keymanager %d"
 )
@@ -165,6 +166,9 @@ type Keymanager struct { // nolint: maligned
 
 	mayGenerate bool
 
+	recoveryExport     bool
+	recoveryImportFile string
+
 	privatePeerPubKeys []string
 }
 
@@ -180,6 +184,9 @@ type KeymanagerCfg struct {
 
 	// PrivatePeerPubKeys is a list of base64-encoded libp2p public keys of peers who may call non-public methods.
 	PrivatePeerPubKeys []string
+
+	// RecoveryExport enables master secret backup exports.
+	RecoveryExport bool
 }
 
 // IdentityKeyPath returns the paths to the node's identity key.
@@ -217,6 +224,17 @@ func (km *Keymanager) P2PPort() uint16 {
 	return km.p2pPort
 }
 
+// RecoveryExportDir returns the path to the node's master secret backup directory.
+func (km *Keymanager) RecoveryExportDir() string {
+	return filepath.Join(km.DataDir(), kmRecoveryExportDir)
+}
+
+// SetRecoveryImportFile sets the master secret recovery file which the key manager imports,
+// taking effect the next time the node is started. An empty path disables the import.
+func (km *Keymanager) SetRecoveryImportFile(path string) {
+	km.recoveryImportFile = path
+}
+
 func (km *Keymanager) provisionGenesis() error {
 	if km.runtime.excludeFromGenesis {
 		return nil
@@ -274,15 +292,18 @@ func (km *Keymanager) AddArgs(args *argBuilder) error {
 }
 
 func (km *Keymanager) ModifyConfig() error {
-	km.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(km.consensusPort))
-	km.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(km.consensusPort))
+	if err := km.setConsensusPort(km.consensusPort); err != nil {
+		return err
+	}
 
 	if km.supplementarySanityInterval > 0 {
 		km.Config.Consensus.SupplementarySanity.Enabled = true
 		km.Config.Consensus.SupplementarySanity.Interval = km.supplementarySanityInterval
 	}
 
-	km.Config.P2P.Port = km.p2pPort
+	if err := km.setP2PPort(nodePortP2P, km.p2pPort); err != nil {
+		return err
+	}
 
 	if !km.entity.isDebugTestEntity {
 		dir := km.entity.dir.String()
@@ -303,6 +324,12 @@ func (km *Keymanager) ModifyConfig() error {
 		km.Config.Keymanager.MayGenerate = true
 	}
 
+	if km.recoveryExport {
+		km.Config.Keymanager.Recovery.Export = true
+		km.Config.Keymanager.Recovery.ExportDir = km.RecoveryExportDir()
+	}
+	km.Config.Keymanager.Recovery.ImportFile = km.recoveryImportFile
+
 	// Sentry configuration.
 	sentries, err := resolveSentries(km.net, km.sentryIndices)
 	if err != nil {
@@ -356,6 +383,7 @@ func (net *Network) NewKeymanager(cfg *KeymanagerCfg) (*Keymanager, error) {
 		consensusPort:      host.getProvisionedPort(nodePortConsensus),
 		p2pPort:            host.getProvisionedPort(nodePortP2P),
 		mayGenerate:        len(net.keymanagers) == 0,
+		recoveryExport:     cfg.RecoveryExport,
 		privatePeerPubKeys: cfg.PrivatePeerPubKeys,
 	}
 
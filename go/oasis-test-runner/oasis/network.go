@@ -33,6 +33,7 @@ import (
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/genesis"
@@ -75,6 +76,8 @@ type Network struct { // nolint: maligned
 	controller       *Controller
 	clientController *Controller
 
+	faults *FaultInjector
+
 	errCh chan error
 }
 
@@ -137,9 +140,16 @@ type NetworkCfg struct { // nolint: maligned
 	// GovernanceParameters are the governance consensus parameters.
 	GovernanceParameters *governance.ConsensusParameters `json:"governance_parameters,omitempty"`
 
+	// KeyManagerParameters are the key manager consensus parameters.
+	KeyManagerParameters *keymanager.ConsensusParameters `json:"keymanager_parameters,omitempty"`
+
 	// RoothashParameters are the roothash consensus parameters.
 	RoothashParameters *roothash.ConsensusParameters `json:"roothash_parameters,omitempty"`
 
+	// FaultInjection enables fault-injecting proxies in front of the P2P ports of all nodes,
+	// which are required for injecting link faults.
+	FaultInjection bool `json:"fault_injection,omitempty"`
+
 	// SchedulerWeakAlpkaOk is for disabling the VRF alpha entropy requirement.
 	SchedulerWeakAlphaOk bool `json:"scheduler_weak_alpha_ok,omitempty"`
 
@@ -303,6 +313,11 @@ func (net *Network) GetNamedNode(defaultName string, cfg *NodeCfg) (*Node, error
 	return node, nil
 }
 
+// Faults returns the fault injector of the network.
+func (net *Network) Faults() *FaultInjector {
+	return net.faults
+}
+
 // Errors returns the channel by which node failures will be conveyed.
 func (net *Network) Errors() <-chan error {
 	return net.errCh
@@ -687,6 +702,9 @@ func (net *Network) startOasisNode(
 	})
 
 	oasisBinary := net.cfg.NodeBinary
+	if node.binary != "" {
+		oasisBinary = node.binary
+	}
 	cmd := exec.Command(oasisBinary, args...)
 	cmd.SysProcAttr = env.CmdAttrs
 	cmd.Stdout = w
@@ -761,6 +779,9 @@ func (net *Network) MakeGenesis() error {
 		"--" + genesis.CfgConsensusGasCostsTxByte, strconv.FormatUint(uint64(net.cfg.Consensus.Parameters.GasCosts[consensusGenesis.GasOpTxByte]), 10),
 		"--" + genesis.CfgConsensusStateCheckpointInterval, strconv.FormatUint(net.cfg.Consensus.Parameters.StateCheckpointInterval, 10),
 		"--" + genesis.CfgConsensusStateCheckpointNumKept, strconv.FormatUint(net.cfg.Consensus.Parameters.StateCheckpointNumKept, 10),
+		"--" + genesis.CfgConsensusEnableFeePayer, strconv.FormatBool(net.cfg.Consensus.Parameters.EnableFeePayer),
+		"--" + genesis.CfgConsensusEnableBatchTransactions, strconv.FormatBool(net.cfg.Consensus.Parameters.EnableBatchTransactions),
+		"--" + genesis.CfgConsensusEnableTransactionExpiry, strconv.FormatBool(net.cfg.Consensus.Parameters.EnableTransactionExpiry),
 		"--" + genesis.CfgStakingTokenSymbol, genesisTestHelpers.TestStakingTokenSymbol,
 		"--" + genesis.CfgStakingTokenValueExponent, strconv.FormatUint(
 			uint64(genesisTestHelpers.TestStakingTokenValueExponent), 10),
@@ -796,6 +817,13 @@ func (net *Network) MakeGenesis() error {
 			"--" + genesis.CfgGovernanceUpgradeMinEpochDiff, strconv.FormatUint(uint64(cfg.UpgradeMinEpochDiff), 10),
 			"--" + genesis.CfgGovernanceVotingPeriod, strconv.FormatUint(uint64(cfg.VotingPeriod), 10),
 			"--" + genesis.CfgGovernanceEnableChangeParametersProposal, strconv.FormatBool(cfg.EnableChangeParametersProposal),
+			"--" + genesis.CfgGovernanceEnableCommonPoolSpendProposal, strconv.FormatBool(cfg.EnableCommonPoolSpendProposal),
+			"--" + genesis.CfgGovernanceEnableTextProposal, strconv.FormatBool(cfg.EnableTextProposal),
+		}...)
+	}
+	if cfg := net.cfg.KeyManagerParameters; cfg != nil {
+		args = append(args, []string{
+			"--" + genesis.CfgKeyManagerEnableMasterSecretRecovery, strconv.FormatBool(cfg.EnableMasterSecretRecovery),
 		}...)
 	}
 	if cfg := net.cfg.RoothashParameters; cfg != nil {
@@ -1002,6 +1030,8 @@ func New(env *env.Env, cfg *NetworkCfg) (*Network, error) {
 		nextNodePort: baseNodePort,
 		errCh:        make(chan error, maxNodes),
 	}
+	net.faults = newFaultInjector(net)
+	env.AddOnCleanup(net.faults.close)
 
 	// Pre-provision node objects if they were listed in the top-level network fixture.
 	for _, nodeName := range cfg.Nodes {
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -100,8 +101,12 @@ type Node struct { // nolint: maligned
 	termEarlyOk bool
 	termErrorOk bool
 	isStopping  bool
+	isPaused    bool
 	noAutoStart bool
 
+	clockSkew time.Duration
+	binary    string
+
 	crashPointsProbability      float64
 	supplementarySanityInterval uint64
 
@@ -255,6 +260,7 @@ func (n *Node) Start() error {
 	n.Config.Consensus.Submission.GasPrice = n.consensus.SubmissionGasPrice
 	n.Config.Consensus.MinGasPrice = n.consensus.MinGasPrice
 	n.Config.Consensus.HaltEpoch = n.net.cfg.HaltEpoch
+	n.Config.Consensus.Debug.ClockSkew = n.clockSkew
 
 	// Initialize node command-line arguments.
 	args := newArgBuilder().debugDontBlameOasis().debugAllowTestKeys()
@@ -320,6 +326,11 @@ func (n *Node) stopNode(graceful bool) error {
 	// Mark the node as stopping so that we don't abort the scenario when the node exits.
 	n.Lock()
 	n.isStopping = true
+	if n.isPaused {
+		// Make sure a paused node is able to handle the interrupt.
+		_ = n.cmd.Process.Signal(syscall.SIGCONT)
+		n.isPaused = false
+	}
 	n.Unlock()
 
 	// Stop the node and wait for it to stop.
@@ -347,6 +358,44 @@ func (n *Node) StopGracefully() error {
 	return n.stopNode(true)
 }
 
+// Pause suspends the node process by sending it a SIGSTOP signal.
+func (n *Node) Pause() error {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.cmd == nil || n.cmd.Process == nil {
+		return fmt.Errorf("oasis/node: node %s is not running", n.Name)
+	}
+	if err := n.cmd.Process.Signal(syscall.SIGSTOP); err != nil {
+		return fmt.Errorf("oasis/node: failed to pause node %s: %w", n.Name, err)
+	}
+	n.isPaused = true
+	return nil
+}
+
+// Resume resumes a paused node process by sending it a SIGCONT signal.
+func (n *Node) Resume() error {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.cmd == nil || n.cmd.Process == nil {
+		return fmt.Errorf("oasis/node: node %s is not running", n.Name)
+	}
+	if err := n.cmd.Process.Signal(syscall.SIGCONT); err != nil {
+		return fmt.Errorf("oasis/node: failed to resume node %s: %w", n.Name, err)
+	}
+	n.isPaused = false
+	return nil
+}
+
+// IsPaused returns true iff the node process is paused.
+func (n *Node) IsPaused() bool {
+	n.Lock()
+	defer n.Unlock()
+
+	return n.isPaused
+}
+
 // Restart kills the node, waits for it to stop, and starts it again.
 func (n *Node) Restart(ctx context.Context) error {
 	return n.RestartAfter(ctx, 0)
@@ -365,6 +414,15 @@ func (n *Node) RestartAfter(ctx context.Context, startDelay time.Duration) error
 	return n.Start()
 }
 
+// SetBinary overrides the network's node binary for this node, taking effect the next time the
+// node is started (e.g., to test node upgrades). An empty path removes the override.
+func (n *Node) SetBinary(path string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.binary = path
+}
+
 // BinaryPath returns the path to the running node's process' image, or an empty string
 // if the node isn't running yet. This can be used as a replacement for NetworkCfg.NodeBinary
 // in cases where the test runner is actually using a wrapper to start the node.
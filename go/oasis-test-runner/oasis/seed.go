@@ -2,7 +2,6 @@ package oasis
 
 import (
 	"fmt"
-	"strconv"
 
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -33,14 +32,17 @@ func (seed *Seed) AddArgs(args *argBuilder) error {
 func (seed *Seed) ModifyConfig() error {
 	seed.Config.Mode = config.ModeSeed
 
-	seed.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(seed.consensusPort))
-	seed.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(seed.consensusPort))
+	if err := seed.setConsensusPort(seed.consensusPort); err != nil {
+		return err
+	}
 
 	if seed.disableAddrBookFromGenesis {
 		seed.Config.Consensus.Debug.DisableAddrBookFromGenesis = true
 	}
 
-	seed.Config.P2P.Port = seed.libp2pSeedPort
+	if err := seed.setP2PPort(nodePortP2PSeed, seed.libp2pSeedPort); err != nil {
+		return err
+	}
 
 	seed.Config.Registration.RotateCerts = 1
 
@@ -3,7 +3,6 @@ package oasis
 import (
 	"fmt"
 	"net"
-	"strconv"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
@@ -66,8 +65,9 @@ func (sentry *Sentry) AddArgs(args *argBuilder) error {
 }
 
 func (sentry *Sentry) ModifyConfig() error {
-	sentry.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(sentry.consensusPort))
-	sentry.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(sentry.consensusPort))
+	if err := sentry.setConsensusPort(sentry.consensusPort); err != nil {
+		return err
+	}
 
 	if sentry.supplementarySanityInterval > 0 {
 		sentry.Config.Consensus.SupplementarySanity.Enabled = true
@@ -6,7 +6,6 @@ import (
 	netPkg "net"
 	"os"
 	"path/filepath"
-	"strconv"
 
 	"gopkg.in/yaml.v3"
 
@@ -86,15 +85,18 @@ func (val *Validator) AddArgs(args *argBuilder) error {
 func (val *Validator) ModifyConfig() error {
 	val.Config.Consensus.Validator = true
 
-	val.Config.Consensus.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(int(val.consensusPort))
-	val.Config.Consensus.ExternalAddress = "tcp://127.0.0.1:" + strconv.Itoa(int(val.consensusPort))
+	if err := val.setConsensusPort(val.consensusPort); err != nil {
+		return err
+	}
 
 	if val.supplementarySanityInterval > 0 {
 		val.Config.Consensus.SupplementarySanity.Enabled = true
 		val.Config.Consensus.SupplementarySanity.Interval = val.supplementarySanityInterval
 	}
 
-	val.Config.P2P.Port = val.p2pPort
+	if err := val.setP2PPort(nodePortP2P, val.p2pPort); err != nil {
+		return err
+	}
 
 	if !val.entity.isDebugTestEntity {
 		dir := val.entity.dir.String()
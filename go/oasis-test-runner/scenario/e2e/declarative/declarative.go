@@ -0,0 +1,239 @@
+// Package declarative implements end-to-end test scenarios defined in YAML or JSON files.
+//
+// A scenario definition composes a network fixture with a sequence of steps, e.g.:
+//
+//	name: transfer-restart
+//	mock_epoch: true
+//	steps:
+//	  - start_network: {}
+//	  - submit_tx:
+//	      signer: test_entity
+//	      method: staking.Transfer
+//	      body: {to: oasis1qqncl383h8458mr9cytatygctzwsx02n4c5f8ed7, amount: "100"}
+//	  - expect_event: {backend: staking, kind: transfer, fields: {amount: "100"}}
+//	  - restart_node: {node: validator-2}
+//	  - assert_account: {account: oasis1qqncl383h8458mr9cytatygctzwsx02n4c5f8ed7, balance: "100"}
+//
+// Scenarios are registered as e2e/declarative/<name>.
+package declarative
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/cmd"
+)
+
+// ScenarioDirsEnvVar is the name of the environment variable holding a list of directories
+// (or files) containing declarative scenario definitions, separated by the OS-specific path
+// list separator.
+const ScenarioDirsEnvVar = "OASIS_E2E_SCENARIO_DIRS"
+
+//go:embed scenarios/*.yaml
+var builtinScenarios embed.FS
+
+// Definition is a declarative scenario definition.
+type Definition struct {
+	// Name is the name of the scenario.
+	Name string `json:"name"`
+	// Description is an optional human-readable description of the scenario.
+	Description string `json:"description,omitempty"`
+	// Default is true iff the scenario should be run when no scenarios are selected.
+	Default bool `json:"default,omitempty"`
+
+	// FixtureFile is the path to a JSON-encoded network fixture, as used by oasis-net-runner
+	// (relative paths are relative to the definition file). If not set, the default e2e
+	// fixture is used.
+	FixtureFile string `json:"fixture_file,omitempty"`
+	// Fixture are overrides applied to the network fixture.
+	Fixture json.RawMessage `json:"fixture,omitempty"`
+	// MockEpoch enables the mock epoch time backend.
+	MockEpoch bool `json:"mock_epoch,omitempty"`
+
+	// Steps are the steps of the scenario, executed in order.
+	Steps []Step `json:"steps"`
+
+	dir string
+}
+
+// Validate validates the scenario definition.
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("name must be set")
+	}
+	if strings.ContainsAny(d.Name, " /") {
+		return fmt.Errorf("name must not contain spaces or slashes")
+	}
+	if len(d.Steps) == 0 {
+		return fmt.Errorf("at least one step must be defined")
+	}
+	for i := range d.Steps {
+		a, err := d.Steps[i].action()
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+		if err = a.validate(); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i, d.Steps[i].name(), err)
+		}
+	}
+	return nil
+}
+
+// Duration is a duration given in text form (e.g., 30s).
+type Duration time.Duration
+
+// UnmarshalText decodes a text duration.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText encodes a duration into text form.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// Parse parses a YAML or JSON scenario definition.
+func Parse(data []byte) (*Definition, error) {
+	// Convert YAML to JSON so that the JSON field names of the fixture and API types apply.
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("declarative: malformed definition: %w", err)
+	}
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("declarative: malformed definition: %w", err)
+	}
+
+	var d Definition
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&d); err != nil {
+		return nil, fmt.Errorf("declarative: malformed definition: %w", err)
+	}
+	if err = d.Validate(); err != nil {
+		return nil, fmt.Errorf("declarative: invalid definition '%s': %w", d.Name, err)
+	}
+	return &d, nil
+}
+
+// Load loads a scenario definition from the given file.
+func Load(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("declarative: failed to read definition: %w", err)
+	}
+	d, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	d.dir = filepath.Dir(path)
+	return d, nil
+}
+
+// LoadDir loads all scenario definitions (*.yaml, *.yml, *.json) from the given directory.
+// If the path is a file, only that definition is loaded.
+func LoadDir(path string) ([]*Definition, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("declarative: %w", err)
+	}
+	if !fi.IsDir() {
+		d, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*Definition{d}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("declarative: %w", err)
+	}
+	var defs []*Definition
+	for _, entry := range entries {
+		if entry.IsDir() || !isDefinitionFile(entry.Name()) {
+			continue
+		}
+		d, err := Load(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, d)
+	}
+	return defs, nil
+}
+
+func isDefinitionFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func loadBuiltin() ([]*Definition, error) {
+	var defs []*Definition
+	err := fs.WalkDir(builtinScenarios, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := builtinScenarios.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		d, err := Parse(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		defs = append(defs, d)
+		return nil
+	})
+	return defs, err
+}
+
+// RegisterScenarios registers the built-in declarative scenarios and all scenarios found in
+// the directories listed in the OASIS_E2E_SCENARIO_DIRS environment variable.
+func RegisterScenarios() error {
+	defs, err := loadBuiltin()
+	if err != nil {
+		return err
+	}
+	if dirs := os.Getenv(ScenarioDirsEnvVar); dirs != "" {
+		for _, dir := range filepath.SplitList(dirs) {
+			if dir == "" {
+				continue
+			}
+			dirDefs, err := LoadDir(dir)
+			if err != nil {
+				return err
+			}
+			defs = append(defs, dirDefs...)
+		}
+	}
+
+	for _, d := range defs {
+		register := cmd.RegisterNondefault
+		if d.Default {
+			register = cmd.Register
+		}
+		if err = register(New(d)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package declarative
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestBuiltinScenarios(t *testing.T) {
+	require := require.New(t)
+
+	defs, err := loadBuiltin()
+	require.NoError(err, "loadBuiltin")
+	require.NotEmpty(defs, "built-in scenarios should be defined")
+
+	for _, d := range defs {
+		sc := New(d)
+		require.Equal("e2e/declarative/"+d.Name, sc.Name())
+
+		_, err = sc.Fixture()
+		require.NoError(err, "Fixture")
+	}
+}
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	d, err := Parse([]byte(`
+name: test
+mock_epoch: true
+fixture:
+  validators:
+    - entity: 1
+steps:
+  - start_network: {}
+  - submit_tx:
+      signer: test:alice
+      method: staking.Transfer
+      body: {to: oasis1qqncl383h8458mr9cytatygctzwsx02n4c5f8ed7, amount: "10"}
+  - expect_event: {backend: staking, kind: transfer, timeout: 5s}
+  - assert_account: {account: test_entity, balance: "10"}
+`))
+	require.NoError(err, "Parse")
+	require.Equal("test", d.Name)
+	require.True(d.MockEpoch)
+	require.Len(d.Steps, 4)
+	require.Equal("submit_tx", d.Steps[1].name())
+
+	body, err := d.Steps[1].SubmitTx.body()
+	require.NoError(err, "body")
+	xfer, ok := body.(*staking.Transfer)
+	require.True(ok, "body should be a transfer")
+	require.EqualValues(quantity.NewFromUint64(10), &xfer.Amount)
+	require.Equal(5*time.Second, time.Duration(d.Steps[2].ExpectEvent.Timeout))
+
+	sc := New(d)
+	f, err := sc.Fixture()
+	require.NoError(err, "Fixture")
+	require.Len(f.Validators, 1, "fixture overrides should be applied")
+
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{"MissingName", `steps: [{start_network: {}}]`},
+		{"NoSteps", `name: test`},
+		{"UnknownField", `{name: test, foo: bar, steps: [{start_network: {}}]}`},
+		{"UnknownStep", `{name: test, steps: [{foo: {}}]}`},
+		{"MultipleActions", `{name: test, steps: [{start_network: {}, wait_blocks: {count: 1}}]}`},
+		{"InvalidCount", `{name: test, steps: [{wait_blocks: {count: 0}}]}`},
+		{"InvalidSigner", `{name: test, steps: [{submit_tx: {signer: foo, method: staking.Transfer, body: {}}}]}`},
+		{"InvalidMethod", `{name: test, steps: [{submit_tx: {signer: test_entity, method: foo.Bar, body: {}}}]}`},
+		{"InvalidBackend", `{name: test, steps: [{expect_event: {backend: foo, kind: bar}}]}`},
+		{"InvalidAccount", `{name: test, steps: [{assert_account: {account: foo}}]}`},
+		{"InvalidDuration", `{name: test, steps: [{sleep: {duration: foo}}]}`},
+	} {
+		_, err = Parse([]byte(tc.data))
+		require.Error(err, tc.name)
+	}
+}
+
+func TestMatchesSubset(t *testing.T) {
+	require := require.New(t)
+
+	actual := map[string]interface{}{
+		"from":   "a",
+		"amount": "100",
+		"nested": map[string]interface{}{"x": 1.0, "y": true},
+	}
+	require.True(matchesSubset(map[string]interface{}{}, actual))
+	require.True(matchesSubset(map[string]interface{}{"amount": "100"}, actual))
+	require.True(matchesSubset(map[string]interface{}{"nested": map[string]interface{}{"x": 1}}, actual))
+	require.False(matchesSubset(map[string]interface{}{"amount": "101"}, actual))
+	require.False(matchesSubset(map[string]interface{}{"missing": "a"}, actual))
+	require.False(matchesSubset(map[string]interface{}{"from": map[string]interface{}{"x": 1}}, actual))
+}
@@ -0,0 +1,123 @@
+package declarative
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/e2e"
+)
+
+type declarativeImpl struct {
+	e2e.Scenario
+
+	def *Definition
+
+	// markHeight is the latest block height before the last submitted transaction. Expected
+	// events are searched for in blocks after this height.
+	markHeight int64
+}
+
+// New creates a new scenario from the given definition.
+func New(def *Definition) scenario.Scenario {
+	return &declarativeImpl{
+		Scenario: *e2e.NewScenario("declarative/" + def.Name),
+		def:      def,
+	}
+}
+
+func (sc *declarativeImpl) Clone() scenario.Scenario {
+	return &declarativeImpl{
+		Scenario: sc.Scenario.Clone(),
+		def:      sc.def,
+	}
+}
+
+func (sc *declarativeImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	if sc.def.FixtureFile != "" {
+		path := sc.def.FixtureFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(sc.def.dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture file: %w", err)
+		}
+		// Keep the node binary configured for the scenario unless set in the fixture file.
+		nodeBinary := f.Network.NodeBinary
+		f = &oasis.NetworkFixture{}
+		if err = json.Unmarshal(data, f); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fixture file: %w", err)
+		}
+		if f.Network.NodeBinary == "" {
+			f.Network.NodeBinary = nodeBinary
+		}
+	}
+
+	if len(sc.def.Fixture) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(sc.def.Fixture))
+		dec.DisallowUnknownFields()
+		if err = dec.Decode(f); err != nil {
+			return nil, fmt.Errorf("failed to apply fixture overrides: %w", err)
+		}
+	}
+
+	if sc.def.MockEpoch {
+		f.Network.SetMockEpoch()
+	}
+
+	return f, nil
+}
+
+func (sc *declarativeImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	if sc.def.Description != "" {
+		sc.Logger.Info(sc.def.Description)
+	}
+
+	for i := range sc.def.Steps {
+		step := &sc.def.Steps[i]
+		a, err := step.action()
+		if err != nil {
+			return err
+		}
+
+		sc.Logger.Info("running step",
+			"step", i,
+			"action", step.name(),
+		)
+		if err = a.run(ctx, sc); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i, step.name(), err)
+		}
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
+
+func (sc *declarativeImpl) latestHeight(ctx context.Context) (int64, error) {
+	blk, err := sc.Net.Controller().Consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	return blk.Height, nil
+}
+
+func (sc *declarativeImpl) node(name string) (*oasis.Node, error) {
+	for _, n := range sc.Net.Nodes() {
+		if n.Name == name {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("node '%s' not found", name)
+}
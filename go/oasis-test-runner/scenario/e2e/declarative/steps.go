@@ -0,0 +1,538 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// signerTestEntity is the name of the test entity signer.
+	signerTestEntity = "test_entity"
+	// signerTestPrefix is the prefix of deterministic test signers (e.g., test:alice).
+	signerTestPrefix = "test:"
+
+	defaultEventTimeout = 30 * time.Second
+)
+
+// txBodies are the transaction methods supported by the submit_tx step.
+var txBodies = map[transaction.MethodName]func() interface{}{
+	staking.MethodTransfer:      func() interface{} { return &staking.Transfer{} },
+	staking.MethodBurn:          func() interface{} { return &staking.Burn{} },
+	staking.MethodAddEscrow:     func() interface{} { return &staking.Escrow{} },
+	staking.MethodReclaimEscrow: func() interface{} { return &staking.ReclaimEscrow{} },
+	staking.MethodAllow:         func() interface{} { return &staking.Allow{} },
+	staking.MethodWithdraw:      func() interface{} { return &staking.Withdraw{} },
+}
+
+// action is a scenario step action.
+type action interface {
+	validate() error
+	run(ctx context.Context, sc *declarativeImpl) error
+}
+
+// Step is a scenario step. Exactly one of the actions must be set.
+type Step struct {
+	StartNetwork  *StartNetworkStep  `json:"start_network,omitempty"`
+	WaitBlocks    *WaitBlocksStep    `json:"wait_blocks,omitempty"`
+	WaitEpochs    *WaitEpochsStep    `json:"wait_epochs,omitempty"`
+	SetEpoch      *SetEpochStep      `json:"set_epoch,omitempty"`
+	Sleep         *SleepStep         `json:"sleep,omitempty"`
+	SubmitTx      *SubmitTxStep      `json:"submit_tx,omitempty"`
+	StopNode      *StopNodeStep      `json:"stop_node,omitempty"`
+	RestartNode   *RestartNodeStep   `json:"restart_node,omitempty"`
+	ExpectEvent   *ExpectEventStep   `json:"expect_event,omitempty"`
+	AssertAccount *AssertAccountStep `json:"assert_account,omitempty"`
+}
+
+func (s *Step) actions() map[string]action {
+	actions := make(map[string]action)
+	add := func(name string, a action, isSet bool) {
+		if isSet {
+			actions[name] = a
+		}
+	}
+	add("start_network", s.StartNetwork, s.StartNetwork != nil)
+	add("wait_blocks", s.WaitBlocks, s.WaitBlocks != nil)
+	add("wait_epochs", s.WaitEpochs, s.WaitEpochs != nil)
+	add("set_epoch", s.SetEpoch, s.SetEpoch != nil)
+	add("sleep", s.Sleep, s.Sleep != nil)
+	add("submit_tx", s.SubmitTx, s.SubmitTx != nil)
+	add("stop_node", s.StopNode, s.StopNode != nil)
+	add("restart_node", s.RestartNode, s.RestartNode != nil)
+	add("expect_event", s.ExpectEvent, s.ExpectEvent != nil)
+	add("assert_account", s.AssertAccount, s.AssertAccount != nil)
+	return actions
+}
+
+func (s *Step) action() (action, error) {
+	actions := s.actions()
+	if len(actions) != 1 {
+		return nil, fmt.Errorf("exactly one action must be set (got: %d)", len(actions))
+	}
+	for _, a := range actions {
+		return a, nil
+	}
+	panic("unreachable")
+}
+
+func (s *Step) name() string {
+	for name := range s.actions() {
+		return name
+	}
+	return "invalid"
+}
+
+// StartNetworkStep starts the network and waits for the nodes to register.
+type StartNetworkStep struct {
+	// Nodes is the number of nodes to wait for (zero means all nodes that register).
+	Nodes int `json:"nodes,omitempty"`
+}
+
+func (s *StartNetworkStep) validate() error {
+	if s.Nodes < 0 {
+		return fmt.Errorf("nodes must be >= 0")
+	}
+	return nil
+}
+
+func (s *StartNetworkStep) run(ctx context.Context, sc *declarativeImpl) error {
+	if err := sc.Net.Start(); err != nil {
+		return fmt.Errorf("failed to start network: %w", err)
+	}
+
+	nodes := s.Nodes
+	if nodes == 0 {
+		nodes = sc.Net.NumRegisterNodes()
+	}
+	sc.Logger.Info("waiting for nodes to register",
+		"num_nodes", nodes,
+	)
+	return sc.Net.Controller().WaitNodesRegistered(ctx, nodes)
+}
+
+// WaitBlocksStep waits for the given number of blocks.
+type WaitBlocksStep struct {
+	Count int `json:"count"`
+}
+
+func (s *WaitBlocksStep) validate() error {
+	if s.Count <= 0 {
+		return fmt.Errorf("count must be > 0")
+	}
+	return nil
+}
+
+func (s *WaitBlocksStep) run(ctx context.Context, sc *declarativeImpl) error {
+	_, err := sc.WaitBlocks(ctx, s.Count)
+	return err
+}
+
+// WaitEpochsStep waits for the given number of epochs.
+type WaitEpochsStep struct {
+	Count beacon.EpochTime `json:"count"`
+}
+
+func (s *WaitEpochsStep) validate() error {
+	if s.Count == 0 {
+		return fmt.Errorf("count must be > 0")
+	}
+	return nil
+}
+
+func (s *WaitEpochsStep) run(ctx context.Context, sc *declarativeImpl) error {
+	return sc.WaitEpochs(ctx, s.Count)
+}
+
+// SetEpochStep transitions to the given epoch (requires mock epoch time).
+type SetEpochStep struct {
+	Epoch beacon.EpochTime `json:"epoch"`
+}
+
+func (s *SetEpochStep) validate() error {
+	return nil
+}
+
+func (s *SetEpochStep) run(ctx context.Context, sc *declarativeImpl) error {
+	return sc.Net.Controller().SetEpoch(ctx, s.Epoch)
+}
+
+// SleepStep waits for the given duration.
+type SleepStep struct {
+	Duration Duration `json:"duration"`
+}
+
+func (s *SleepStep) validate() error {
+	if s.Duration <= 0 {
+		return fmt.Errorf("duration must be > 0")
+	}
+	return nil
+}
+
+func (s *SleepStep) run(ctx context.Context, _ *declarativeImpl) error {
+	select {
+	case <-time.After(time.Duration(s.Duration)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Fee is a transaction fee.
+type Fee struct {
+	Amount quantity.Quantity `json:"amount"`
+	Gas    transaction.Gas   `json:"gas"`
+}
+
+// SubmitTxStep signs and submits a consensus transaction.
+type SubmitTxStep struct {
+	// Signer is the transaction signer, either test_entity or test:<name> for a deterministic
+	// test signer derived from the given name.
+	Signer string `json:"signer"`
+	// Method is the transaction method (e.g., staking.Transfer).
+	Method transaction.MethodName `json:"method"`
+	// Body is the transaction body.
+	Body json.RawMessage `json:"body"`
+	// Fee is the transaction fee. If not set, gas is estimated and no fee is paid.
+	Fee *Fee `json:"fee,omitempty"`
+	// ExpectError is true iff the transaction is expected to fail.
+	ExpectError bool `json:"expect_error,omitempty"`
+}
+
+func (s *SubmitTxStep) validate() error {
+	if _, err := resolveSigner(s.Signer); err != nil {
+		return err
+	}
+	_, err := s.body()
+	return err
+}
+
+func (s *SubmitTxStep) body() (interface{}, error) {
+	newBody, ok := txBodies[s.Method]
+	if !ok {
+		return nil, fmt.Errorf("unsupported method '%s'", s.Method)
+	}
+	body := newBody()
+	if err := json.Unmarshal(s.Body, body); err != nil {
+		return nil, fmt.Errorf("malformed body: %w", err)
+	}
+	return body, nil
+}
+
+func (s *SubmitTxStep) run(ctx context.Context, sc *declarativeImpl) error {
+	signer, err := resolveSigner(s.Signer)
+	if err != nil {
+		return err
+	}
+	body, err := s.body()
+	if err != nil {
+		return err
+	}
+
+	ctrl := sc.Net.Controller()
+	nonce, err := ctrl.Consensus.GetSignerNonce(ctx, &consensus.GetSignerNonceRequest{
+		AccountAddress: staking.NewAddress(signer.Public()),
+		Height:         consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get signer nonce: %w", err)
+	}
+
+	tx := transaction.NewTransaction(nonce, nil, s.Method, body)
+	if s.Fee != nil {
+		tx.Fee = &transaction.Fee{Amount: s.Fee.Amount, Gas: s.Fee.Gas}
+	} else {
+		gas, err := ctrl.Consensus.EstimateGas(ctx, &consensus.EstimateGasRequest{
+			Signer:      signer.Public(),
+			Transaction: tx,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		tx.Fee = &transaction.Fee{Gas: gas}
+	}
+
+	if sc.markHeight, err = sc.latestHeight(ctx); err != nil {
+		return err
+	}
+
+	sigTx, err := transaction.Sign(signer, tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	err = ctrl.Consensus.SubmitTx(ctx, sigTx)
+	switch {
+	case err == nil && s.ExpectError:
+		return fmt.Errorf("transaction succeeded but should not have")
+	case err != nil && !s.ExpectError:
+		return fmt.Errorf("failed to submit transaction: %w", err)
+	case err != nil:
+		sc.Logger.Info("transaction failed as expected",
+			"err", err,
+		)
+	}
+	return nil
+}
+
+// StopNodeStep stops a node.
+type StopNodeStep struct {
+	// Node is the name of the node (e.g., validator-1).
+	Node string `json:"node"`
+	// Graceful is true iff the node should be given time to shut down gracefully.
+	Graceful bool `json:"graceful,omitempty"`
+}
+
+func (s *StopNodeStep) validate() error {
+	if s.Node == "" {
+		return fmt.Errorf("node must be set")
+	}
+	return nil
+}
+
+func (s *StopNodeStep) run(_ context.Context, sc *declarativeImpl) error {
+	n, err := sc.node(s.Node)
+	if err != nil {
+		return err
+	}
+	if s.Graceful {
+		return n.StopGracefully()
+	}
+	return n.Stop()
+}
+
+// RestartNodeStep restarts a node (or starts a stopped one) and waits for it to become ready.
+type RestartNodeStep struct {
+	// Node is the name of the node (e.g., validator-1).
+	Node string `json:"node"`
+	// Delay is the time to wait before starting the node again.
+	Delay Duration `json:"delay,omitempty"`
+}
+
+func (s *RestartNodeStep) validate() error {
+	if s.Node == "" {
+		return fmt.Errorf("node must be set")
+	}
+	if s.Delay < 0 {
+		return fmt.Errorf("delay must be >= 0")
+	}
+	return nil
+}
+
+func (s *RestartNodeStep) run(ctx context.Context, sc *declarativeImpl) error {
+	n, err := sc.node(s.Node)
+	if err != nil {
+		return err
+	}
+	if err = n.RestartAfter(ctx, time.Duration(s.Delay)); err != nil {
+		return fmt.Errorf("failed to restart node: %w", err)
+	}
+	return n.WaitReady(ctx)
+}
+
+// ExpectEventStep waits for an event emitted after the last submitted transaction.
+type ExpectEventStep struct {
+	// Backend is the backend emitting the event (staking, registry or governance).
+	Backend string `json:"backend"`
+	// Kind is the kind of the event, i.e. the name of the event field (e.g., transfer).
+	Kind string `json:"kind"`
+	// Fields are the expected values of (a subset of) the event fields.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// Timeout is the maximum time to wait for the event (default: 30s).
+	Timeout Duration `json:"timeout,omitempty"`
+}
+
+func (s *ExpectEventStep) validate() error {
+	switch s.Backend {
+	case "staking", "registry", "governance":
+	default:
+		return fmt.Errorf("unsupported backend '%s'", s.Backend)
+	}
+	if s.Kind == "" {
+		return fmt.Errorf("kind must be set")
+	}
+	if s.Timeout < 0 {
+		return fmt.Errorf("timeout must be >= 0")
+	}
+	return nil
+}
+
+func (s *ExpectEventStep) run(ctx context.Context, sc *declarativeImpl) error {
+	timeout := time.Duration(s.Timeout)
+	if timeout == 0 {
+		timeout = defaultEventTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	height := sc.markHeight + 1
+	for {
+		latest, err := sc.latestHeight(ctx)
+		if err != nil {
+			return err
+		}
+		for ; height <= latest; height++ {
+			events, err := s.getEvents(ctx, sc, height)
+			if err != nil {
+				return err
+			}
+			for _, ev := range events {
+				if s.matches(ev) {
+					sc.Logger.Info("found expected event",
+						"height", height,
+						"event", ev,
+					)
+					return nil
+				}
+			}
+		}
+
+		if _, err = sc.WaitBlocks(ctx, 1); err != nil {
+			return fmt.Errorf("expected %s %s event not found", s.Backend, s.Kind)
+		}
+	}
+}
+
+func (s *ExpectEventStep) getEvents(ctx context.Context, sc *declarativeImpl, height int64) ([]interface{}, error) {
+	var (
+		events []interface{}
+		err    error
+	)
+	ctrl := sc.Net.Controller()
+	switch s.Backend {
+	case "staking":
+		var evs []*staking.Event
+		evs, err = ctrl.Staking.GetEvents(ctx, height)
+		for _, ev := range evs {
+			events = append(events, ev)
+		}
+	case "registry":
+		evs, gerr := ctrl.Registry.GetEvents(ctx, height)
+		err = gerr
+		for _, ev := range evs {
+			events = append(events, ev)
+		}
+	case "governance":
+		evs, gerr := ctrl.Governance.GetEvents(ctx, height)
+		err = gerr
+		for _, ev := range evs {
+			events = append(events, ev)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s events at height %d: %w", s.Backend, height, err)
+	}
+	return events, nil
+}
+
+func (s *ExpectEventStep) matches(ev interface{}) bool {
+	// Compare the JSON representations so that fields can be given in the same form as
+	// they are shown by the CLI.
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return false
+	}
+	var fields map[string]interface{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	actual, ok := fields[s.Kind]
+	if !ok || actual == nil {
+		return false
+	}
+	return matchesSubset(s.Fields, actual)
+}
+
+// matchesSubset returns true iff all expected (nested) fields are equal to the actual ones.
+func matchesSubset(expected, actual interface{}) bool {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range e {
+			if !matchesSubset(v, a[k]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return fmt.Sprint(expected) == fmt.Sprint(actual)
+	}
+}
+
+// AssertAccountStep checks the state of a staking account.
+type AssertAccountStep struct {
+	// Account is the account address, test_entity or test:<name>.
+	Account string `json:"account"`
+	// Balance is the expected general balance.
+	Balance *quantity.Quantity `json:"balance,omitempty"`
+	// EscrowActive is the expected active escrow balance.
+	EscrowActive *quantity.Quantity `json:"escrow_active,omitempty"`
+	// Nonce is the expected account nonce.
+	Nonce *uint64 `json:"nonce,omitempty"`
+}
+
+func (s *AssertAccountStep) validate() error {
+	_, err := resolveAccount(s.Account)
+	return err
+}
+
+func (s *AssertAccountStep) run(ctx context.Context, sc *declarativeImpl) error {
+	addr, err := resolveAccount(s.Account)
+	if err != nil {
+		return err
+	}
+	acct, err := sc.Net.Controller().Staking.Account(ctx, &staking.OwnerQuery{
+		Owner:  addr,
+		Height: consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query account: %w", err)
+	}
+
+	if s.Balance != nil && acct.General.Balance.Cmp(s.Balance) != 0 {
+		return fmt.Errorf("account %s balance %s should be %s", addr, acct.General.Balance, s.Balance)
+	}
+	if s.EscrowActive != nil && acct.Escrow.Active.Balance.Cmp(s.EscrowActive) != 0 {
+		return fmt.Errorf("account %s active escrow %s should be %s", addr, acct.Escrow.Active.Balance, s.EscrowActive)
+	}
+	if s.Nonce != nil && acct.General.Nonce != *s.Nonce {
+		return fmt.Errorf("account %s nonce %d should be %d", addr, acct.General.Nonce, *s.Nonce)
+	}
+	return nil
+}
+
+func resolveSigner(name string) (signature.Signer, error) {
+	switch {
+	case name == signerTestEntity:
+		_, signer, err := entity.TestEntity()
+		return signer, err
+	case strings.HasPrefix(name, signerTestPrefix) && len(name) > len(signerTestPrefix):
+		return memorySigner.NewTestSigner(name), nil
+	default:
+		return nil, fmt.Errorf("unsupported signer '%s' (expected %s or %s<name>)", name, signerTestEntity, signerTestPrefix)
+	}
+}
+
+func resolveAccount(name string) (staking.Address, error) {
+	if signer, err := resolveSigner(name); err == nil {
+		return staking.NewAddress(signer.Public()), nil
+	}
+	var addr staking.Address
+	if err := addr.UnmarshalText([]byte(name)); err != nil {
+		return addr, fmt.Errorf("malformed account '%s': %w", name, err)
+	}
+	return addr, nil
+}
@@ -0,0 +1,157 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/fault"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+const (
+	// faultHaltCheckInterval is the time during which no blocks are expected to be produced
+	// while consensus is unable to make progress.
+	faultHaltCheckInterval = 10 * time.Second
+	// faultRecoveryTimeout is the time in which consensus is expected to recover after the
+	// faults have been removed.
+	faultRecoveryTimeout = 2 * time.Minute
+)
+
+// FaultInjection is the fault injection scenario which checks that consensus halts while
+// validators are partitioned or paused and recovers once the faults are removed.
+var FaultInjection scenario.Scenario = &faultInjectionImpl{
+	Scenario: *NewScenario("fault-injection"),
+}
+
+type faultInjectionImpl struct {
+	Scenario
+}
+
+func (sc *faultInjectionImpl) Clone() scenario.Scenario {
+	return &faultInjectionImpl{
+		Scenario: sc.Scenario.Clone(),
+	}
+}
+
+func (sc *faultInjectionImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	f.Network.FaultInjection = true
+
+	return f, nil
+}
+
+func (sc *faultInjectionImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+	if err := sc.Net.Controller().WaitNodesRegistered(ctx, sc.Net.NumRegisterNodes()); err != nil {
+		return err
+	}
+
+	faults := sc.Net.Faults()
+	validators := sc.Net.Validators()
+
+	// All validators have the same voting power, so isolating any of them should prevent
+	// consensus from making progress.
+	sc.Logger.Info("partitioning validators")
+	if err := faults.Partition(
+		[]string{validators[0].Name, validators[1].Name},
+		[]string{validators[2].Name},
+	); err != nil {
+		return err
+	}
+	if err := sc.checkHalted(ctx); err != nil {
+		return err
+	}
+	faults.Heal()
+	if err := sc.waitRecovered(ctx); err != nil {
+		return err
+	}
+
+	sc.Logger.Info("pausing a validator")
+	if err := faults.Pause(validators[2].Name); err != nil {
+		return err
+	}
+	if err := sc.checkHalted(ctx); err != nil {
+		return err
+	}
+	if err := faults.Resume(validators[2].Name); err != nil {
+		return err
+	}
+	if err := sc.waitRecovered(ctx); err != nil {
+		return err
+	}
+
+	// Consensus should make progress over slow links.
+	sc.Logger.Info("adding latency to all links")
+	if err := faults.SetLinkFault(fault.AnyNode, fault.AnyNode, fault.LinkFault{
+		Latency: 100 * time.Millisecond,
+		Jitter:  50 * time.Millisecond,
+		Loss:    0.01,
+	}); err != nil {
+		return err
+	}
+	if err := sc.waitRecovered(ctx); err != nil {
+		return err
+	}
+	if err := faults.Clear(); err != nil {
+		return err
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
+
+func (sc *faultInjectionImpl) latestHeight(ctx context.Context) (int64, error) {
+	blk, err := sc.Net.Controller().Consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query latest block: %w", err)
+	}
+	return blk.Height, nil
+}
+
+func (sc *faultInjectionImpl) checkHalted(ctx context.Context) error {
+	start, err := sc.latestHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-time.After(faultHaltCheckInterval):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	end, err := sc.latestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	// Allow for a block that was already being committed when the fault was injected.
+	if end > start+1 {
+		return fmt.Errorf("consensus should be halted (height advanced from %d to %d)", start, end)
+	}
+
+	sc.Logger.Info("consensus halted as expected",
+		"height", end,
+	)
+	return nil
+}
+
+func (sc *faultInjectionImpl) waitRecovered(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, faultRecoveryTimeout)
+	defer cancel()
+
+	if _, err := sc.WaitBlocks(ctx, 3); err != nil {
+		return fmt.Errorf("consensus failed to recover: %w", err)
+	}
+
+	sc.Logger.Info("consensus is making progress")
+	return nil
+}
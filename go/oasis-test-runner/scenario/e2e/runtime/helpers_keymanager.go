@@ -132,12 +132,16 @@ func (sc *Scenario) UpdateRotationInterval(ctx context.Context, childEnv *env.En
 		return err
 	}
 
-	var policies map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX
+	var (
+		policies map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX
+		recovery *keymanager.MasterSecretRecoveryPolicy
+	)
 	if status != nil && status.Policy != nil {
 		policies = status.Policy.Policy.Enclaves
+		recovery = status.Policy.Policy.MasterSecretRecovery
 	}
 
-	if err := sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, rotationInterval, policies, nonce); err != nil {
+	if err := sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, rotationInterval, policies, recovery, nonce); err != nil {
 		return err
 	}
 
@@ -383,7 +387,7 @@ func (sc *Scenario) BuildEnclavePolicies(childEnv *env.Env) (map[sgx.EnclaveIden
 }
 
 // ApplyKeyManagerPolicy applies the given policy to the simple key manager runtime.
-func (sc *Scenario) ApplyKeyManagerPolicy(ctx context.Context, childEnv *env.Env, cli *cli.Helpers, rotationInterval beacon.EpochTime, policies map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX, nonce uint64) error {
+func (sc *Scenario) ApplyKeyManagerPolicy(ctx context.Context, childEnv *env.Env, cli *cli.Helpers, rotationInterval beacon.EpochTime, policies map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX, recovery *keymanager.MasterSecretRecoveryPolicy, nonce uint64) error {
 	status, err := sc.KeyManagerStatus(ctx)
 	if err != nil && err != keymanager.ErrNoSuchStatus {
 		return err
@@ -402,7 +406,7 @@ func (sc *Scenario) ApplyKeyManagerPolicy(ctx context.Context, childEnv *env.Env
 	txPath := filepath.Join(dir, "km_gen_update.json")
 
 	sc.Logger.Info("generating key manager policy")
-	if err := cli.Keymanager.InitPolicy(KeyManagerRuntimeID, serial, rotationInterval, policies, policyPath); err != nil {
+	if err := cli.Keymanager.InitPolicy(KeyManagerRuntimeID, serial, rotationInterval, policies, recovery, policyPath); err != nil {
 		return err
 	}
 	sc.Logger.Info("signing key manager policy")
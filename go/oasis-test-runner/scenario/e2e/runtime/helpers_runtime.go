@@ -294,7 +294,7 @@ func (sc *Scenario) EnableRuntimeDeployment(ctx context.Context, childEnv *env.E
 		sc.Logger.Info("no SGX runtimes, skipping policy update")
 	default:
 		sc.UpdateEnclavePolicies(rt, deploymentIndex, policies)
-		if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, nonce); err != nil {
+		if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, nil, nonce); err != nil {
 			return fmt.Errorf("updating policies: %w", err)
 		}
 		nonce++
@@ -0,0 +1,196 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// KeymanagerRecovery is the keymanager master secret recovery scenario.
+//
+// In this scenario the first key manager exports a backup of the master secrets
+// encrypted to the recovery custodians. Once the key manager is lost, the custodians
+// re-encrypt their shares to a fresh key manager which imports them and continues
+// to serve the same keys.
+var KeymanagerRecovery scenario.Scenario = newKmRecoveryImpl()
+
+const (
+	// kmRecoveryNumCustodians is the number of recovery custodians.
+	kmRecoveryNumCustodians = 3
+	// kmRecoveryThreshold is the number of custodians needed to recover master secrets.
+	kmRecoveryThreshold = 2
+	// kmRecoveryBackupTimeout is the time to wait for the master secret backup.
+	kmRecoveryBackupTimeout = 2 * time.Minute
+)
+
+type kmRecoveryImpl struct {
+	Scenario
+}
+
+func newKmRecoveryImpl() scenario.Scenario {
+	return &kmRecoveryImpl{
+		Scenario: *NewScenario("keymanager-recovery", nil),
+	}
+}
+
+func (sc *kmRecoveryImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Speed up the test.
+	f.Network.Beacon.VRFParameters = &beacon.VRFParameters{
+		Interval:             10,
+		ProofSubmissionDelay: 2,
+	}
+
+	// Allow policies with a master secret recovery configuration.
+	f.Network.KeyManagerParameters = &keymanager.ConsensusParameters{
+		EnableMasterSecretRecovery: true,
+	}
+
+	// Compute workers are not needed.
+	f.ComputeWorkers = []oasis.ComputeWorkerFixture{}
+
+	// The first key manager exports backups, the second one is started only
+	// after the first one is lost and recovers master secrets from the backup.
+	f.Keymanagers = []oasis.KeymanagerFixture{
+		{Runtime: 0, Entity: 1, RecoveryExport: true},
+		{Runtime: 0, Entity: 1, NodeFixture: oasis.NodeFixture{NoAutoStart: true}},
+	}
+
+	return f, nil
+}
+
+func (sc *kmRecoveryImpl) Clone() scenario.Scenario {
+	return &kmRecoveryImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *kmRecoveryImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+
+	// Start the network.
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	// Wait until the first master secret is generated.
+	status, err := sc.WaitMasterSecret(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	// Generate custodian keys.
+	dir := childEnv.Dir()
+	recovery := keymanager.MasterSecretRecoveryPolicy{
+		Threshold: kmRecoveryThreshold,
+	}
+	keyPaths := make([]string, 0, kmRecoveryNumCustodians)
+	for i := 0; i < kmRecoveryNumCustodians; i++ {
+		keyPath := filepath.Join(dir, fmt.Sprintf("km_custodian_%d.pem", i))
+		pk, err := cli.Keymanager.RecoveryInitCustodian(keyPath)
+		if err != nil {
+			return err
+		}
+		keyPaths = append(keyPaths, keyPath)
+		recovery.Custodians = append(recovery.Custodians, *pk)
+	}
+
+	// Enable master secret exports.
+	var policies map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX
+	if status.Policy != nil {
+		policies = status.Policy.Policy.Enclaves
+	}
+	if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, &recovery, 0); err != nil {
+		return err
+	}
+
+	// Wait for the backup.
+	kms := sc.Net.Keymanagers()
+	backupPath := filepath.Join(kms[0].RecoveryExportDir(), fmt.Sprintf("master-secrets-%s-%d.cbor", KeyManagerRuntimeID, status.Generation))
+	if err = sc.waitFile(ctx, backupPath, kmRecoveryBackupTimeout); err != nil {
+		return fmt.Errorf("master secret backup not exported: %w", err)
+	}
+
+	// Lose the only key manager.
+	if err = sc.StopKeymanagers(ctx, []int{0}); err != nil {
+		return err
+	}
+
+	// Re-encrypt the threshold number of shares to the enclave of the new key manager.
+	// Enclaves without a TEE share the same insecure REK.
+	sharePaths := make([]string, 0, kmRecoveryThreshold)
+	for i := 0; i < kmRecoveryThreshold; i++ {
+		sharePath := filepath.Join(dir, fmt.Sprintf("km_share_%d.cbor", i))
+		if err = cli.Keymanager.RecoveryShare(backupPath, keyPaths[i], keymanager.InsecureREK, sharePath); err != nil {
+			return err
+		}
+		sharePaths = append(sharePaths, sharePath)
+	}
+	recoveryPath := filepath.Join(dir, "km_recovery.cbor")
+	if err = cli.Keymanager.RecoveryBundle(backupPath, sharePaths, recoveryPath); err != nil {
+		return err
+	}
+
+	// Start the new key manager which should import the master secrets.
+	kms[1].SetRecoveryImportFile(recoveryPath)
+	if err = sc.StartAndWaitKeymanagers(ctx, []int{1}); err != nil {
+		return err
+	}
+
+	rsp, err := sc.KeymanagerInitResponse(ctx, 1)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rsp.Checksum, status.Checksum) {
+		return fmt.Errorf("recovered master secret checksum mismatch (expected: %X got: %X)", status.Checksum, rsp.Checksum)
+	}
+
+	// Bring back the lost key manager and verify that both derive the same keys.
+	if err = sc.StartAndWaitKeymanagers(ctx, []int{0}); err != nil {
+		return err
+	}
+
+	return sc.CompareLongtermPublicKeys(ctx, []int{0, 1})
+}
+
+// waitFile waits until the given file exists.
+func (sc *kmRecoveryImpl) waitFile(ctx context.Context, path string, timeout time.Duration) error {
+	sc.Logger.Info("waiting for file", "path", path)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		_, err := os.Stat(path)
+		switch {
+		case err == nil:
+			return nil
+		case !os.IsNotExist(err):
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
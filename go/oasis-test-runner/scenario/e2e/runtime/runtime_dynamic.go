@@ -149,7 +149,7 @@ func (sc *runtimeDynamicImpl) Run(ctx context.Context, childEnv *env.Env) error
 		}
 	default:
 		// In SGX mode, we can update the policy as intended.
-		if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, nonce); err != nil {
+		if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, nil, nonce); err != nil {
 			return err
 		}
 		nonce++
@@ -319,6 +319,7 @@ func RegisterScenarios() error {
 		KeymanagerEphemeralSecrets,
 		KeymanagerDumpRestore,
 		KeymanagerRestart,
+		KeymanagerRecovery,
 		KeymanagerReplicate,
 		KeymanagerReplicateMany,
 		KeymanagerRotationFailure,
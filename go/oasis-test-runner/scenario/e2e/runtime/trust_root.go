@@ -126,7 +126,7 @@ func (sc *TrustRootImpl) PreRun(ctx context.Context, childEnv *env.Env) (err err
 	case nil:
 		sc.Logger.Info("no SGX runtimes, skipping policy update")
 	default:
-		if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, nonce); err != nil {
+		if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, nil, nonce); err != nil {
 			return fmt.Errorf("updating policies: %w", err)
 		}
 		nonce++ // nolint: ineffassign
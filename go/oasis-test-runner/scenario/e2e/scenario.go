@@ -152,6 +152,8 @@ func RegisterScenarios() error {
 		MinTransactBalance,
 		// Consensus governance update parameters tests.
 		ChangeParametersMinCommissionRate,
+		// Fault injection test.
+		FaultInjection,
 	} {
 		if err := cmd.Register(s); err != nil {
 			return err
@@ -7,6 +7,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/cmd"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/e2e"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/e2e/declarative"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/e2e/runtime"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/pluginsigner"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/remotesigner"
@@ -27,6 +28,7 @@ func main() {
 	// Register all scenarios and scenario parameters.
 	for _, register := range []func() error{
 		e2e.RegisterScenarios,
+		declarative.RegisterScenarios,
 		runtime.RegisterScenarios,
 		pluginsigner.RegisterScenarios,
 		remotesigner.RegisterScenarios,
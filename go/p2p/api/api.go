@@ -72,6 +72,11 @@ type Service interface {
 	// BlockPeer blocks a specific peer from being used by the local node.
 	BlockPeer(peerID core.PeerID)
 
+	// RecordPeerReputation records a reputation event for the given peer and protocol.
+	//
+	// Peers whose reputation drops too low are blocked.
+	RecordPeerReputation(peerID core.PeerID, protocolID core.ProtocolID, event rpc.ReputationEvent)
+
 	// Host returns the P2P host.
 	Host() core.Host
 
@@ -115,6 +120,9 @@ type PeerManager interface {
 
 	// PeerTagger returns the peer tagger.
 	PeerTagger() PeerTagger
+
+	// PeerReputation returns the peer reputation tracker.
+	PeerReputation() PeerReputation
 }
 
 // PeerRegistry is an interface for accessing peer information from the registry.
@@ -135,6 +143,28 @@ type PeerTagger interface {
 	SetPeerImportance(kind ImportanceKind, runtimeID common.Namespace, pids []peer.ID)
 }
 
+// PeerReputation is an interface for inspecting peer reputation.
+type PeerReputation interface {
+	// Scores returns the current reputation scores of all peers with a non-negligible
+	// reputation, sorted by peer ID.
+	Scores() []*PeerScore
+}
+
+// PeerScore is the reputation score of a peer.
+type PeerScore struct {
+	// PeerID is the peer ID.
+	PeerID peer.ID `json:"peer_id"`
+
+	// Score is the overall reputation score of the peer, i.e. the sum of its per-protocol scores.
+	Score float64 `json:"score"`
+
+	// Protocols are the per-protocol reputation scores of the peer.
+	Protocols map[core.ProtocolID]float64 `json:"protocols"`
+
+	// Blocked is true iff the peer is currently temporarily blocked due to its low reputation.
+	Blocked bool `json:"blocked"`
+}
+
 // SeedService is a P2P node service interface.
 type SeedService interface {
 	service.BackgroundService
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"fmt"
+
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 )
 
@@ -20,3 +23,25 @@ type CommitteeMessage struct {
 // TxMessage is a message published to nodes via gossipsub on the transaction topic. It contains the
 // raw signed transaction with runtime-dependent semantics.
 type TxMessage []byte
+
+// DecodeTopicMessage decodes a raw message published on a topic of the given kind.
+//
+// Committee messages are decoded into *CommitteeMessage and transaction messages into []byte.
+func DecodeTopicMessage(kind TopicKind, msg []byte) (interface{}, error) {
+	switch kind {
+	case TopicKindCommittee:
+		var dec CommitteeMessage
+		if err := cbor.Unmarshal(msg, &dec); err != nil {
+			return nil, err
+		}
+		return &dec, nil
+	case TopicKindTx:
+		var tx []byte
+		if err := cbor.Unmarshal(msg, &tx); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	default:
+		return nil, fmt.Errorf("p2p: unsupported topic kind '%s'", kind)
+	}
+}
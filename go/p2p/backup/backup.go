@@ -18,3 +18,28 @@ type Backend interface {
 	// Restore returns peers from the last backup.
 	Restore(ctx context.Context) (map[string][]peer.AddrInfo, error)
 }
+
+// PeerScore is a backed up peer score.
+type PeerScore struct {
+	// Score is the value of the score at the time of the last update.
+	Score float64 `json:"score"`
+
+	// UpdatedAt is the UNIX timestamp of the last update.
+	UpdatedAt int64 `json:"updated_at"`
+
+	// BlockedUntil is the UNIX timestamp until which the peer is blocked due to its score,
+	// or zero if the peer is not blocked.
+	BlockedUntil int64 `json:"blocked_until,omitempty"`
+}
+
+// ScoreBackend is an interface used to backup and restore peer scores.
+type ScoreBackend interface {
+	// Delete permanently removes all scores from the backup.
+	Delete(ctx context.Context) error
+
+	// Backup stores given scores possibly overwriting the last backup.
+	Backup(ctx context.Context, nsScores map[string]map[peer.ID]PeerScore) error
+
+	// Restore returns scores from the last backup.
+	Restore(ctx context.Context) (map[string]map[peer.ID]PeerScore, error)
+}
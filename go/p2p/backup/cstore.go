@@ -118,3 +118,93 @@ func (b *commonStoreBackend) Restore(ctx context.Context) (map[string][]peer.Add
 
 	return nsPeers, nil
 }
+
+var _ ScoreBackend = (*commonStoreScoreBackend)(nil)
+
+// commonStoreScoreBackend uses the common store to backup and restore peer scores.
+type commonStoreScoreBackend struct {
+	bucket *persistent.ServiceStore // A handle to a bucket where scores are stored.
+	key    string                   // A key under which scores are stored in the bucket.
+}
+
+// NewCommonStoreScoreBackend creates a new common store score backend.
+//
+// The name of the bucket and the key under which scores are stored should be unique to avoid
+// backups to be overwritten.
+func NewCommonStoreScoreBackend(cs *persistent.CommonStore, bucket string, key string) ScoreBackend {
+	var b *persistent.ServiceStore
+	if cs != nil {
+		b = cs.GetServiceStore(bucket)
+	}
+
+	return &commonStoreScoreBackend{
+		bucket: b,
+		key:    key,
+	}
+}
+
+// Delete implements ScoreBackend.
+func (b *commonStoreScoreBackend) Delete(ctx context.Context) error {
+	if b.bucket == nil {
+		return nil
+	}
+
+	return b.bucket.Delete([]byte(b.key))
+}
+
+// Backup implements ScoreBackend.
+func (b *commonStoreScoreBackend) Backup(ctx context.Context, nsScores map[string]map[peer.ID]PeerScore) error {
+	if b.bucket == nil {
+		return nil
+	}
+
+	// Encode peer identities as strings, skipping empty namespaces. Unlike addresses, scores
+	// are always stored so that scores which decayed away are not restored again.
+	data := make(map[string]map[string]PeerScore)
+	for ns, scores := range nsScores {
+		if len(scores) == 0 {
+			continue
+		}
+		encoded := make(map[string]PeerScore, len(scores))
+		for id, score := range scores {
+			encoded[id.String()] = score
+		}
+		data[ns] = encoded
+	}
+
+	return b.bucket.PutCBOR([]byte(b.key), data)
+}
+
+// Restore implements ScoreBackend.
+func (b *commonStoreScoreBackend) Restore(ctx context.Context) (map[string]map[peer.ID]PeerScore, error) {
+	if b.bucket == nil {
+		return map[string]map[peer.ID]PeerScore{}, nil
+	}
+
+	// Restore scores.
+	data := make(map[string]map[string]PeerScore)
+	if err := b.bucket.GetCBOR([]byte(b.key), &data); err != nil {
+		switch err {
+		case persistent.ErrNotFound:
+			return map[string]map[peer.ID]PeerScore{}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	// Decode peer identities.
+	nsScores := make(map[string]map[peer.ID]PeerScore)
+	for ns, encoded := range data {
+		scores := make(map[peer.ID]PeerScore, len(encoded))
+		for s, score := range encoded {
+			id, err := peer.Decode(s)
+			if err != nil {
+				return nil, err
+			}
+			scores[id] = score
+		}
+		nsScores[ns] = scores
+	}
+
+	return nsScores, nil
+}
@@ -211,6 +211,50 @@ func (s *CommonStoreBackendTestSuite) TestBackupRestore() {
 	})
 }
 
+func (s *CommonStoreBackendTestSuite) TestScoreBackupRestore() {
+	require := require.New(s.T())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backend := NewCommonStoreScoreBackend(s.store, "bucket", "scores")
+
+	restored, err := backend.Restore(ctx)
+	require.NoError(err, "Failed to restore from empty store")
+	require.Empty(restored, "There should be no scores restored from an empty store")
+
+	scores := map[string]map[peer.ID]PeerScore{
+		"protocol-1": {
+			s.addrs[0].ID: {Score: 10.5, UpdatedAt: 1000},
+			s.addrs[1].ID: {Score: -50, UpdatedAt: 2000, BlockedUntil: 5000},
+		},
+		"protocol-2": {
+			s.addrs[0].ID: {Score: -1, UpdatedAt: 3000},
+		},
+		"protocol-3": {},
+	}
+
+	err = backend.Backup(ctx, scores)
+	require.NoError(err, "Failed to backup scores")
+
+	restored, err = backend.Restore(ctx)
+	require.NoError(err, "Failed to restore scores")
+
+	delete(scores, "protocol-3")
+	require.True(reflect.DeepEqual(scores, restored), "Restored scores do not match")
+
+	// Backing up no scores should overwrite the previous backup.
+	err = backend.Backup(ctx, nil)
+	require.NoError(err, "Failed to backup nil map of scores")
+
+	restored, err = backend.Restore(ctx)
+	require.NoError(err, "Failed to restore scores")
+	require.Empty(restored, "There should be no scores restored after an empty backup")
+
+	err = backend.Delete(ctx)
+	require.NoError(err, "Failed to delete the backup")
+}
+
 func (s *CommonStoreBackendTestSuite) TestNilCommonStore() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
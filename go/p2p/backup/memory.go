@@ -48,3 +48,45 @@ func (b *InMemoryBackend) Restore(ctx context.Context) (map[string][]peer.AddrIn
 
 	return b.nsPeers, nil
 }
+
+var _ ScoreBackend = (*InMemoryScoreBackend)(nil)
+
+// InMemoryScoreBackend uses memory to backup and restore peer scores. This backend is not
+// persistent and intended for testing purposes only.
+type InMemoryScoreBackend struct {
+	mu       sync.Mutex
+	nsScores map[string]map[peer.ID]PeerScore
+}
+
+// NewInMemoryScoreBackend creates a new in-memory score backend.
+func NewInMemoryScoreBackend() *InMemoryScoreBackend {
+	return &InMemoryScoreBackend{
+		nsScores: make(map[string]map[peer.ID]PeerScore),
+	}
+}
+
+// Delete implements ScoreBackend.
+func (b *InMemoryScoreBackend) Delete(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nsScores = make(map[string]map[peer.ID]PeerScore)
+	return nil
+}
+
+// Backup implements ScoreBackend.
+func (b *InMemoryScoreBackend) Backup(ctx context.Context, nsScores map[string]map[peer.ID]PeerScore) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nsScores = nsScores
+	return nil
+}
+
+// Restore implements ScoreBackend.
+func (b *InMemoryScoreBackend) Restore(ctx context.Context) (map[string]map[peer.ID]PeerScore, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.nsScores, nil
+}
@@ -0,0 +1,280 @@
+// Package capture implements capturing of P2P messages for debugging purposes.
+package capture
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+// Kind is the kind of a captured message.
+type Kind uint8
+
+const (
+	// KindGossip is a gossipsub message.
+	KindGossip Kind = iota + 1
+	// KindRPC is an RPC request/response exchange.
+	KindRPC
+)
+
+// String returns a string representation of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindGossip:
+		return "gossip"
+	case KindRPC:
+		return "rpc"
+	default:
+		return fmt.Sprintf("[unknown: %d]", k)
+	}
+}
+
+// MarshalText encodes the kind into text form.
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Direction is the direction of a captured message.
+type Direction uint8
+
+const (
+	// DirectionInbound is a message received from a peer.
+	DirectionInbound Direction = iota + 1
+	// DirectionOutbound is a message sent by the local node.
+	DirectionOutbound
+)
+
+// String returns a string representation of the direction.
+func (d Direction) String() string {
+	switch d {
+	case DirectionInbound:
+		return "inbound"
+	case DirectionOutbound:
+		return "outbound"
+	default:
+		return fmt.Sprintf("[unknown: %d]", d)
+	}
+}
+
+// MarshalText encodes the direction into text form.
+func (d Direction) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Record is a captured P2P message.
+type Record struct {
+	// Timestamp is the time when the message was captured (UNIX time in nanoseconds).
+	Timestamp int64 `json:"timestamp"`
+	// Kind is the kind of the captured message.
+	Kind Kind `json:"kind"`
+	// Direction is the direction of the captured message.
+	Direction Direction `json:"direction"`
+	// PeerID is the peer the message was received from or sent by.
+	PeerID string `json:"peer_id"`
+
+	// Topic is the gossipsub topic (gossip only).
+	Topic string `json:"topic,omitempty"`
+	// From is the original publisher of the message (gossip only).
+	From string `json:"from,omitempty"`
+	// Data is the raw message (gossip only).
+	Data []byte `json:"data,omitempty"`
+
+	// Protocol is the RPC protocol identifier (RPC only).
+	Protocol string `json:"protocol,omitempty"`
+	// Method is the RPC method name (RPC only).
+	Method string `json:"method,omitempty"`
+	// RequestSize is the size of the RPC request in bytes (RPC only).
+	RequestSize int `json:"request_size,omitempty"`
+	// ResponseSize is the size of the RPC response in bytes (RPC only).
+	ResponseSize int `json:"response_size,omitempty"`
+	// Duration is the time it took to handle the RPC request (RPC only).
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Error is the error that occurred while handling the message, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Time returns the time when the message was captured.
+func (r *Record) Time() time.Time {
+	return time.Unix(0, r.Timestamp)
+}
+
+// Size returns the number of message bytes the record accounts for.
+func (r *Record) Size() int {
+	switch r.Kind {
+	case KindGossip:
+		return len(r.Data)
+	default:
+		return r.RequestSize + r.ResponseSize
+	}
+}
+
+// Config is the capture configuration.
+type Config struct {
+	// Path is the path of the capture file.
+	Path string
+	// MaxFileSize is the size in bytes after which the capture file is rotated.
+	MaxFileSize uint64
+	// MaxFiles is the number of rotated capture files to keep.
+	MaxFiles int
+
+	// Topics restricts capturing to topics and protocols containing any of the given substrings.
+	Topics []string
+	// Peers restricts capturing to messages exchanged with the given peers.
+	Peers []core.PeerID
+}
+
+// Filter is a capture filter.
+type Filter struct {
+	topics []string
+	peers  map[core.PeerID]struct{}
+}
+
+// Matches returns true iff a message on the given topic or protocol exchanged with any of the
+// given peers passes the filter.
+func (f *Filter) Matches(topic string, peerIDs ...core.PeerID) bool {
+	return f.matchesTopic(topic) && f.matchesPeers(peerIDs...)
+}
+
+func (f *Filter) matchesTopic(topic string) bool {
+	if len(f.topics) == 0 {
+		return true
+	}
+	for _, t := range f.topics {
+		if strings.Contains(topic, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchesPeers(peerIDs ...core.PeerID) bool {
+	if len(f.peers) == 0 {
+		return true
+	}
+	for _, peerID := range peerIDs {
+		if _, ok := f.peers[peerID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// NewFilter creates a new capture filter. Empty lists match everything.
+func NewFilter(topics []string, peers []core.PeerID) *Filter {
+	f := &Filter{
+		topics: topics,
+		peers:  make(map[core.PeerID]struct{}, len(peers)),
+	}
+	for _, peerID := range peers {
+		f.peers[peerID] = struct{}{}
+	}
+	return f
+}
+
+// Capturer records P2P messages to a rotating capture file.
+//
+// A nil capturer is valid and discards all messages.
+type Capturer struct {
+	mu sync.Mutex
+
+	filter *Filter
+	writer *Writer
+	closed bool
+
+	logger *logging.Logger
+}
+
+// CaptureGossip records a gossipsub message.
+func (c *Capturer) CaptureGossip(dir Direction, topic string, peerID, from core.PeerID, data []byte, err error) {
+	if c == nil || !c.filter.Matches(topic, peerID, from) {
+		return
+	}
+
+	c.write(&Record{
+		Timestamp: time.Now().UnixNano(),
+		Kind:      KindGossip,
+		Direction: dir,
+		PeerID:    peerID.String(),
+		Topic:     topic,
+		From:      from.String(),
+		Data:      data,
+		Error:     errorString(err),
+	})
+}
+
+// CaptureRPC records the metadata of an RPC request/response exchange.
+func (c *Capturer) CaptureRPC(dir Direction, protocol string, peerID core.PeerID, method string, requestSize, responseSize int, duration time.Duration, err error) {
+	if c == nil || !c.filter.Matches(protocol, peerID) {
+		return
+	}
+
+	c.write(&Record{
+		Timestamp:    time.Now().UnixNano(),
+		Kind:         KindRPC,
+		Direction:    dir,
+		PeerID:       peerID.String(),
+		Protocol:     protocol,
+		Method:       method,
+		RequestSize:  requestSize,
+		ResponseSize: responseSize,
+		Duration:     duration,
+		Error:        errorString(err),
+	})
+}
+
+func (c *Capturer) write(rec *Record) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	if err := c.writer.Write(rec); err != nil {
+		c.logger.Error("failed to write capture record",
+			"err", err,
+		)
+	}
+}
+
+// Close flushes and closes the capture file.
+func (c *Capturer) Close() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.writer.Close()
+}
+
+// New creates a new capturer.
+func New(cfg *Config) (*Capturer, error) {
+	w, err := NewWriter(cfg.Path, cfg.MaxFileSize, cfg.MaxFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Capturer{
+		filter: NewFilter(cfg.Topics, cfg.Peers),
+		writer: w,
+		logger: logging.GetLogger("p2p/capture"),
+	}, nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
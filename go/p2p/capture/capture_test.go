@@ -0,0 +1,127 @@
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeerID(t *testing.T) core.PeerID {
+	_, pk, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	require.NoError(t, err, "GenerateKeyPair failed")
+
+	id, err := peer.IDFromPublicKey(pk)
+	require.NoError(t, err, "IDFromPublicKey failed")
+
+	return id
+}
+
+func readAll(t *testing.T, path string) []*Record {
+	var records []*Record
+	for _, file := range Files(path) {
+		r, err := NewReader(file)
+		require.NoError(t, err, "NewReader")
+
+		for {
+			rec, err := r.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err, "Next")
+			records = append(records, rec)
+		}
+		require.NoError(t, r.Close(), "Close")
+	}
+	return records
+}
+
+func TestCapturer(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "capture.bin")
+	p1, p2, p3 := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+
+	c, err := New(&Config{
+		Path:   path,
+		Topics: []string{"committee", "storagesync"},
+		Peers:  []core.PeerID{p1, p2},
+	})
+	require.NoError(err, "New")
+
+	c.CaptureGossip(DirectionInbound, "oasis/chain/committee/rt/1.0.0", p1, p3, []byte("msg1"), nil)
+	c.CaptureGossip(DirectionInbound, "oasis/chain/committee/rt/1.0.0", p3, p2, []byte("msg2"), fmt.Errorf("epoch in the past"))
+	c.CaptureGossip(DirectionInbound, "oasis/chain/committee/rt/1.0.0", p3, p3, []byte("msg3"), nil) // Filtered peer.
+	c.CaptureGossip(DirectionOutbound, "oasis/chain/tx/rt/1.0.0", p1, p1, []byte("msg4"), nil)       // Filtered topic.
+	c.CaptureRPC(DirectionInbound, "/oasis/chain/storagesync/rt/1.0.0", p2, "GetDiff", 10, 100, time.Second, nil)
+	require.NoError(c.Close(), "Close")
+
+	// Writes after close should be ignored.
+	c.CaptureGossip(DirectionInbound, "oasis/chain/committee/rt/1.0.0", p1, p1, []byte("msg5"), nil)
+
+	records := readAll(t, path)
+	require.Len(records, 3)
+
+	require.Equal(KindGossip, records[0].Kind)
+	require.Equal(DirectionInbound, records[0].Direction)
+	require.Equal(p1.String(), records[0].PeerID)
+	require.Equal(p3.String(), records[0].From)
+	require.Equal([]byte("msg1"), records[0].Data)
+	require.Empty(records[0].Error)
+	require.Equal(4, records[0].Size())
+
+	require.Equal("epoch in the past", records[1].Error)
+
+	require.Equal(KindRPC, records[2].Kind)
+	require.Equal("GetDiff", records[2].Method)
+	require.Equal(time.Second, records[2].Duration)
+	require.Equal(110, records[2].Size())
+
+	// A nil capturer should discard everything.
+	var nc *Capturer
+	nc.CaptureGossip(DirectionInbound, "topic", p1, p1, nil, nil)
+	require.NoError(nc.Close(), "Close on nil capturer")
+}
+
+func TestWriterRotation(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "capture.bin")
+	w, err := NewWriter(path, 100, 2)
+	require.NoError(err, "NewWriter")
+
+	for i := 0; i < 20; i++ {
+		err = w.Write(&Record{Kind: KindGossip, Topic: "topic", Data: []byte{byte(i)}, Timestamp: int64(i)})
+		require.NoError(err, "Write")
+	}
+	require.NoError(w.Close(), "Close")
+
+	files := Files(path)
+	require.Equal([]string{path + ".2", path + ".1", path}, files, "rotated files should be kept")
+
+	// Only the most recent records should be kept, in order.
+	records := readAll(t, path)
+	require.NotEmpty(records)
+	require.Less(len(records), 20)
+	for i, rec := range records {
+		require.EqualValues(20-len(records)+i, rec.Timestamp)
+	}
+
+	// Reopening should append to the existing capture file.
+	w, err = NewWriter(path, 0, 2)
+	require.NoError(err, "NewWriter")
+	err = w.Write(&Record{Kind: KindGossip, Timestamp: 20})
+	require.NoError(err, "Write")
+	require.NoError(w.Close(), "Close")
+
+	appended := readAll(t, path)
+	require.Len(appended, len(records)+1)
+	require.EqualValues(20, appended[len(appended)-1].Timestamp)
+}
@@ -0,0 +1,171 @@
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+const codecModuleName = "p2p/capture"
+
+// countingFile is a capture file that keeps track of its size.
+type countingFile struct {
+	*os.File
+
+	size uint64
+}
+
+func (f *countingFile) Write(p []byte) (int, error) {
+	n, err := f.File.Write(p)
+	f.size += uint64(n)
+	return n, err
+}
+
+// Writer writes capture records to a capture file, rotating the file once it exceeds the
+// configured size.
+//
+// Rotated files are named by appending a sequence number to the path (e.g., capture.1 is the
+// most recently rotated file).
+type Writer struct {
+	path        string
+	maxFileSize uint64
+	maxFiles    int
+
+	file  *countingFile
+	codec *cbor.MessageCodec
+}
+
+// Write appends a record to the capture file.
+func (w *Writer) Write(rec *Record) error {
+	if err := w.codec.Write(rec); err != nil {
+		return fmt.Errorf("capture: failed to write record: %w", err)
+	}
+
+	if w.maxFileSize > 0 && w.file.size >= w.maxFileSize {
+		if err := w.rotate(); err != nil {
+			return fmt.Errorf("capture: failed to rotate capture file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the capture file.
+func (w *Writer) Close() error {
+	return w.file.Close()
+}
+
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	if w.maxFiles > 0 {
+		for i := w.maxFiles - 1; i > 0; i-- {
+			if err := os.Rename(rotatedPath(w.path, i), rotatedPath(w.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		if err := os.Rename(w.path, rotatedPath(w.path, 1)); err != nil {
+			return err
+		}
+	}
+
+	return w.open(os.O_TRUNC)
+}
+
+func (w *Writer) open(flag int) error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|flag, 0o600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	w.file = &countingFile{File: f, size: uint64(fi.Size())}
+	w.codec = cbor.NewMessageCodec(w.file, codecModuleName)
+	return nil
+}
+
+// NewWriter creates a new capture file writer, appending to an existing capture file if any.
+//
+// A zero maximum file size disables rotation, zero maximum files discards rotated files.
+func NewWriter(path string, maxFileSize uint64, maxFiles int) (*Writer, error) {
+	w := &Writer{
+		path:        path,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+	}
+	if err := w.open(os.O_APPEND); err != nil {
+		return nil, fmt.Errorf("capture: failed to open capture file: %w", err)
+	}
+	return w, nil
+}
+
+// Reader reads capture records from a capture file.
+type Reader struct {
+	file  *os.File
+	codec *cbor.MessageCodec
+}
+
+// Next reads the next record from the capture file. It returns io.EOF when there are no more
+// records.
+func (r *Reader) Next() (*Record, error) {
+	var rec Record
+	switch err := r.codec.Read(&rec); {
+	case err == nil:
+		return &rec, nil
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// The last record may have been truncated in case the node was stopped while writing.
+		return nil, io.EOF
+	default:
+		return nil, err
+	}
+}
+
+// Close closes the capture file.
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
+
+// NewReader opens a capture file for reading.
+func NewReader(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("capture: failed to open capture file: %w", err)
+	}
+	return &Reader{
+		file:  f,
+		codec: cbor.NewMessageCodec(f, codecModuleName),
+	}, nil
+}
+
+// Files returns the existing capture files for the given capture path, oldest first.
+func Files(path string) []string {
+	var rotated []string
+	for i := 1; ; i++ {
+		p := rotatedPath(path, i)
+		if _, err := os.Stat(p); err != nil {
+			break
+		}
+		rotated = append(rotated, p)
+	}
+
+	files := make([]string, 0, len(rotated)+1)
+	for i := len(rotated) - 1; i >= 0; i-- {
+		files = append(files, rotated[i])
+	}
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	}
+	return files
+}
+
+func rotatedPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
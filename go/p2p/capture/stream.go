@@ -0,0 +1,176 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+// maxRecordedStreamBytes is the maximum number of bytes recorded in each direction of an RPC
+// stream. Responses exceeding this size are only accounted for, but not decoded.
+const maxRecordedStreamBytes = 1024 * 1024 // 1 MiB
+
+// recordingStream is a stream that records the bytes read and written.
+type recordingStream struct {
+	network.Stream
+
+	read    recordedBytes
+	written recordedBytes
+}
+
+func (s *recordingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.read.record(p[:n])
+	return n, err
+}
+
+func (s *recordingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	s.written.record(p[:n])
+	return n, err
+}
+
+type recordedBytes struct {
+	buf  bytes.Buffer
+	size int
+}
+
+func (r *recordedBytes) record(p []byte) {
+	r.size += len(p)
+	if free := maxRecordedStreamBytes - r.buf.Len(); free > 0 {
+		if len(p) > free {
+			p = p[:free]
+		}
+		_, _ = r.buf.Write(p)
+	}
+}
+
+// decode decodes the first framed message from the recorded bytes.
+func (r *recordedBytes) decode(msg interface{}) error {
+	return cbor.NewMessageCodec(&r.buf, codecModuleName).Read(msg)
+}
+
+// WrapStreamHandler wraps an RPC server stream handler so that the metadata of served requests
+// is captured.
+func (c *Capturer) WrapStreamHandler(handler network.StreamHandler) network.StreamHandler {
+	if c == nil {
+		return handler
+	}
+
+	return func(stream network.Stream) {
+		protocol := string(stream.Protocol())
+		peerID := stream.Conn().RemotePeer()
+		if !c.filter.Matches(protocol, peerID) {
+			handler(stream)
+			return
+		}
+
+		rs := &recordingStream{Stream: stream}
+		start := time.Now()
+		handler(rs)
+
+		c.captureStream(DirectionInbound, protocol, peerID, &rs.read, &rs.written, time.Since(start))
+	}
+}
+
+// WrapHost wraps a host used by RPC clients so that the metadata of requests sent to peers is
+// captured once the corresponding streams are closed.
+func (c *Capturer) WrapHost(h host.Host) host.Host {
+	if c == nil {
+		return h
+	}
+	return &capturingHost{Host: h, c: c}
+}
+
+// captureStream records the metadata of an RPC request/response exchange from the recorded
+// request and response bytes.
+func (c *Capturer) captureStream(
+	dir Direction,
+	protocol string,
+	peerID core.PeerID,
+	requestBytes *recordedBytes,
+	responseBytes *recordedBytes,
+	duration time.Duration,
+) {
+	var (
+		request  rpc.Request
+		response rpc.Response
+		err      error
+	)
+	_ = requestBytes.decode(&request)
+	switch {
+	case responseBytes.size == 0:
+		err = fmt.Errorf("no response")
+	case responseBytes.decode(&response) == nil && response.Error != nil:
+		err = fmt.Errorf("%s", response.Error)
+	}
+
+	c.CaptureRPC(dir, protocol, peerID, request.Method, requestBytes.size, responseBytes.size, duration, err)
+}
+
+// capturingHost is a host that captures streams opened to peers.
+type capturingHost struct {
+	host.Host
+
+	c *Capturer
+}
+
+func (h *capturingHost) NewStream(ctx context.Context, p core.PeerID, pids ...protocol.ID) (network.Stream, error) {
+	stream, err := h.Host.NewStream(ctx, p, pids...)
+	if err != nil {
+		return nil, err
+	}
+
+	protocolID := string(stream.Protocol())
+	peerID := stream.Conn().RemotePeer()
+	if !h.c.filter.Matches(protocolID, peerID) {
+		return stream, nil
+	}
+
+	return &outboundStream{
+		recordingStream: &recordingStream{Stream: stream},
+		c:               h.c,
+		protocol:        protocolID,
+		peerID:          peerID,
+		start:           time.Now(),
+	}, nil
+}
+
+// outboundStream is a stream opened to a peer which is captured once closed or reset.
+type outboundStream struct {
+	*recordingStream
+
+	c        *Capturer
+	protocol string
+	peerID   core.PeerID
+	start    time.Time
+	once     sync.Once
+}
+
+func (s *outboundStream) Close() error {
+	err := s.Stream.Close()
+	s.capture()
+	return err
+}
+
+func (s *outboundStream) Reset() error {
+	err := s.Stream.Reset()
+	s.capture()
+	return err
+}
+
+func (s *outboundStream) capture() {
+	s.once.Do(func() {
+		s.c.captureStream(DirectionOutbound, s.protocol, s.peerID, &s.written, &s.read, time.Since(s.start))
+	})
+}
@@ -0,0 +1,154 @@
+package capture
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+const testProtocol = "/oasis/chain/test/1.0.0"
+
+type testService struct{}
+
+func (s *testService) HandleRequest(ctx context.Context, method string, body cbor.RawMessage) (interface{}, error) {
+	if method != "Ping" {
+		return nil, fmt.Errorf("unsupported method")
+	}
+	return "pong", nil
+}
+
+func TestWrapStreamHandler(t *testing.T) {
+	require := require.New(t)
+
+	newHost := func() host.Host {
+		listenAddr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/0")
+		require.NoError(err, "NewMultiaddr failed")
+
+		host, err := libp2p.New(
+			libp2p.ListenAddrs(listenAddr),
+		)
+		require.NoError(err, "libp2p.New failed")
+
+		return host
+	}
+
+	path := filepath.Join(t.TempDir(), "capture.bin")
+	c, err := New(&Config{Path: path})
+	require.NoError(err, "New")
+
+	server := rpc.NewServer(testProtocol, &testService{}, rpc.WithServerLimits(&rpc.ServerLimits{}))
+	serverHost := newHost()
+	defer serverHost.Close()
+	serverHost.SetStreamHandler(server.Protocol(), c.WrapStreamHandler(server.HandleStream))
+
+	clientHost := newHost()
+	defer clientHost.Close()
+	client := rpc.NewClient(clientHost, testProtocol)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = clientHost.Connect(ctx, peer.AddrInfo{
+		ID:    serverHost.ID(),
+		Addrs: serverHost.Addrs(),
+	})
+	require.NoError(err, "Connect failed")
+
+	var rsp string
+	_, err = client.CallOne(ctx, []peer.ID{serverHost.ID()}, "Ping", nil, &rsp)
+	require.NoError(err, "CallOne")
+	require.Equal("pong", rsp)
+
+	_, err = client.CallOne(ctx, []peer.ID{serverHost.ID()}, "Unknown", nil, &rsp)
+	require.Error(err, "CallOne should fail for unsupported methods")
+
+	require.Eventually(func() bool {
+		return len(readAll(t, path)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(c.Close(), "Close")
+
+	records := readAll(t, path)
+	for i, method := range []string{"Ping", "Unknown"} {
+		require.Equal(KindRPC, records[i].Kind)
+		require.Equal(DirectionInbound, records[i].Direction)
+		require.Equal(testProtocol, records[i].Protocol)
+		require.Equal(clientHost.ID().String(), records[i].PeerID)
+		require.Equal(method, records[i].Method)
+		require.Positive(records[i].RequestSize)
+		require.Positive(records[i].ResponseSize)
+	}
+	require.Empty(records[0].Error)
+	require.Contains(records[1].Error, "unsupported method")
+}
+
+func TestWrapHost(t *testing.T) {
+	require := require.New(t)
+
+	newHost := func() host.Host {
+		listenAddr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/0")
+		require.NoError(err, "NewMultiaddr failed")
+
+		host, err := libp2p.New(
+			libp2p.ListenAddrs(listenAddr),
+		)
+		require.NoError(err, "libp2p.New failed")
+
+		return host
+	}
+
+	path := filepath.Join(t.TempDir(), "capture.bin")
+	c, err := New(&Config{Path: path})
+	require.NoError(err, "New")
+
+	server := rpc.NewServer(testProtocol, &testService{}, rpc.WithServerLimits(&rpc.ServerLimits{}))
+	serverHost := newHost()
+	defer serverHost.Close()
+	serverHost.SetStreamHandler(server.Protocol(), server.HandleStream)
+
+	clientHost := newHost()
+	defer clientHost.Close()
+	client := rpc.NewClient(c.WrapHost(clientHost), testProtocol)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = clientHost.Connect(ctx, peer.AddrInfo{
+		ID:    serverHost.ID(),
+		Addrs: serverHost.Addrs(),
+	})
+	require.NoError(err, "Connect failed")
+
+	var rsp string
+	_, err = client.CallOne(ctx, []peer.ID{serverHost.ID()}, "Ping", nil, &rsp)
+	require.NoError(err, "CallOne")
+	require.Equal("pong", rsp)
+
+	_, err = client.CallOne(ctx, []peer.ID{serverHost.ID()}, "Unknown", nil, &rsp)
+	require.Error(err, "CallOne should fail for unsupported methods")
+	require.NoError(c.Close(), "Close")
+
+	records := readAll(t, path)
+	require.Len(records, 2)
+	for i, method := range []string{"Ping", "Unknown"} {
+		require.Equal(KindRPC, records[i].Kind)
+		require.Equal(DirectionOutbound, records[i].Direction)
+		require.Equal(testProtocol, records[i].Protocol)
+		require.Equal(serverHost.ID().String(), records[i].PeerID)
+		require.Equal(method, records[i].Method)
+		require.Positive(records[i].RequestSize)
+		require.Positive(records[i].ResponseSize)
+	}
+	require.Empty(records[0].Error)
+	require.Contains(records[1].Error, "unsupported method")
+}
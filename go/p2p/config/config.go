@@ -4,6 +4,8 @@ package config
 import (
 	"fmt"
 	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/bytesize"
 )
 
 // Config is the P2P configuration structure.
@@ -20,6 +22,10 @@ type Config struct {
 	PeerManager       PeerManagerConfig       `yaml:"peer_manager,omitempty"`
 	ConnectionManager ConnectionManagerConfig `yaml:"connection_manager,omitempty"`
 	ConnectionGater   ConnectionGaterConfig   `yaml:"connection_gater,omitempty"`
+	RPC               RPCConfig               `yaml:"rpc,omitempty"`
+	Capture           CaptureConfig           `yaml:"capture,omitempty"`
+
+	Debug DebugConfig `yaml:"debug,omitempty"`
 }
 
 // DiscoveryConfig is the P2P discovery configuration structure.
@@ -77,6 +83,125 @@ type ConnectionGaterConfig struct {
 	BlockedPeerIPs []string `yaml:"blocked_peers"`
 }
 
+// RPCConfig is the P2P RPC server configuration structure.
+type RPCConfig struct {
+	// Limits applied to all served protocols unless overridden.
+	Limits RPCLimitsConfig `yaml:"limits,omitempty"`
+	// Per-protocol limits keyed by protocol name (e.g., storagesync, storagepub, txsync,
+	// keymanager, light), overriding the default limits.
+	ProtocolLimits map[string]RPCLimitsConfig `yaml:"protocol_limits,omitempty"`
+}
+
+// RPCLimitsConfig is the P2P RPC server limits configuration structure.
+//
+// Zero rates mean that the corresponding limit is disabled.
+type RPCLimitsConfig struct {
+	// Maximum number of requests per second served to a single peer.
+	PeerRequestRate float64 `yaml:"peer_request_rate"`
+	// Maximum number of requests in a burst served to a single peer.
+	PeerRequestBurst int `yaml:"peer_request_burst"`
+	// Maximum number of response bytes per second served to a single peer (e.g., 10mb).
+	PeerByteRate string `yaml:"peer_byte_rate"`
+	// Maximum number of response bytes in a burst served to a single peer.
+	PeerByteBurst string `yaml:"peer_byte_burst"`
+	// Maximum number of requests per second served to all peers.
+	RequestRate float64 `yaml:"request_rate"`
+	// Maximum number of requests in a burst served to all peers.
+	RequestBurst int `yaml:"request_burst"`
+	// Maximum number of response bytes per second served to all peers (e.g., 100mb).
+	ByteRate string `yaml:"byte_rate"`
+	// Maximum number of response bytes in a burst served to all peers.
+	ByteBurst string `yaml:"byte_burst"`
+}
+
+// Validate validates the limits configuration.
+func (c *RPCLimitsConfig) Validate() error {
+	if c.PeerRequestRate < 0 {
+		return fmt.Errorf("peer_request_rate must be >= 0")
+	}
+	if c.PeerRequestBurst < 0 {
+		return fmt.Errorf("peer_request_burst must be >= 0")
+	}
+	if c.RequestRate < 0 {
+		return fmt.Errorf("request_rate must be >= 0")
+	}
+	if c.RequestBurst < 0 {
+		return fmt.Errorf("request_burst must be >= 0")
+	}
+	if c.PeerRequestRate > 0 && c.PeerRequestBurst < 1 {
+		return fmt.Errorf("peer_request_burst must be at least 1 when peer_request_rate is set")
+	}
+	if c.RequestRate > 0 && c.RequestBurst < 1 {
+		return fmt.Errorf("request_burst must be at least 1 when request_rate is set")
+	}
+
+	for _, v := range []struct {
+		rateName  string
+		rate      string
+		burstName string
+		burst     string
+	}{
+		{"peer_byte_rate", c.PeerByteRate, "peer_byte_burst", c.PeerByteBurst},
+		{"byte_rate", c.ByteRate, "byte_burst", c.ByteBurst},
+	} {
+		rate, err := bytesize.Parse(v.rate)
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.rateName, err)
+		}
+		burst, err := bytesize.Parse(v.burst)
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.burstName, err)
+		}
+		if rate > 0 && burst == 0 {
+			return fmt.Errorf("%s must be set when %s is set", v.burstName, v.rateName)
+		}
+	}
+	return nil
+}
+
+// CaptureConfig is the P2P message capture configuration structure.
+//
+// Capturing is meant for debugging only as captured messages are written to disk unencrypted.
+type CaptureConfig struct {
+	// Enable capturing of gossipsub messages and RPC requests.
+	Enabled bool `yaml:"enabled"`
+	// Path to the capture file (relative paths are relative to the data directory).
+	Path string `yaml:"path"`
+	// Size of the capture file after which it is rotated (e.g., 100mb).
+	MaxFileSize string `yaml:"max_file_size"`
+	// Number of rotated capture files to keep.
+	MaxFiles int `yaml:"max_files"`
+	// Only capture messages on topics or protocols containing any of the given substrings
+	// (e.g., committee, tx, storagesync).
+	Topics []string `yaml:"topics,omitempty"`
+	// Only capture messages exchanged with the given peers (libp2p peer IDs).
+	Peers []string `yaml:"peers,omitempty"`
+}
+
+// Validate validates the capture configuration.
+func (c *CaptureConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Path == "" {
+		return fmt.Errorf("path must be set")
+	}
+	if c.MaxFiles < 0 {
+		return fmt.Errorf("max_files must be >= 0")
+	}
+	if err := bytesize.Validate(c.MaxFileSize); err != nil {
+		return fmt.Errorf("max_file_size: %w", err)
+	}
+	return nil
+}
+
+// DebugConfig is the P2P debug configuration structure.
+type DebugConfig struct {
+	// Announce only the registration addresses to peers instead of the listen addresses,
+	// e.g., when connections are proxied (UNSAFE).
+	AnnounceRegistrationAddresses bool `yaml:"announce_registration_addresses,omitempty"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
 	if c.ConnectionManager.MaxNumPeers < 0 {
@@ -96,6 +221,19 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("gossipsub.validate_throttle must be >= 0")
 	}
 
+	if err := c.RPC.Limits.Validate(); err != nil {
+		return fmt.Errorf("rpc.limits.%w", err)
+	}
+	for name, limits := range c.RPC.ProtocolLimits {
+		if err := limits.Validate(); err != nil {
+			return fmt.Errorf("rpc.protocol_limits.%s.%w", name, err)
+		}
+	}
+
+	if err := c.Capture.Validate(); err != nil {
+		return fmt.Errorf("capture.%w", err)
+	}
+
 	return nil
 }
 
@@ -130,5 +268,29 @@ func DefaultConfig() Config {
 		ConnectionGater: ConnectionGaterConfig{
 			BlockedPeerIPs: []string{},
 		},
+		RPC: RPCConfig{
+			Limits: RPCLimitsConfig{
+				PeerRequestRate:  0,
+				PeerRequestBurst: 0,
+				PeerByteRate:     "0",
+				PeerByteBurst:    "0",
+				RequestRate:      0,
+				RequestBurst:     0,
+				ByteRate:         "0",
+				ByteBurst:        "0",
+			},
+			ProtocolLimits: map[string]RPCLimitsConfig{},
+		},
+		Capture: CaptureConfig{
+			Enabled:     false,
+			Path:        "p2p-capture.bin",
+			MaxFileSize: "100mb",
+			MaxFiles:    5,
+			Topics:      []string{},
+			Peers:       []string{},
+		},
+		Debug: DebugConfig{
+			AnnounceRegistrationAddresses: false,
+		},
 	}
 }
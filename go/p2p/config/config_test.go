@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCLimitsConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		msg    string
+		limits RPCLimitsConfig
+		valid  bool
+	}{
+		{"no limits", RPCLimitsConfig{}, true},
+		{"request limits", RPCLimitsConfig{PeerRequestRate: 10, PeerRequestBurst: 20, RequestRate: 100, RequestBurst: 200}, true},
+		{"byte limits", RPCLimitsConfig{PeerByteRate: "10mb", PeerByteBurst: "20mb", ByteRate: "100 MB", ByteBurst: "200mb"}, true},
+		{"disabled byte limits", RPCLimitsConfig{PeerByteRate: "0", PeerByteBurst: "0"}, true},
+		{"malformed peer byte rate", RPCLimitsConfig{PeerByteRate: "10 mib", PeerByteBurst: "20mb"}, false},
+		{"malformed byte burst", RPCLimitsConfig{ByteRate: "10mb", ByteBurst: "abc"}, false},
+		{"missing peer byte burst", RPCLimitsConfig{PeerByteRate: "10mb"}, false},
+		{"missing byte burst", RPCLimitsConfig{ByteRate: "10mb", ByteBurst: "0"}, false},
+		{"missing peer request burst", RPCLimitsConfig{PeerRequestRate: 10}, false},
+		{"missing request burst", RPCLimitsConfig{RequestRate: 10}, false},
+	} {
+		err := tc.limits.Validate()
+		if tc.valid {
+			require.NoError(err, tc.msg)
+		} else {
+			require.Error(err, tc.msg)
+		}
+	}
+}
+
+func TestCaptureConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	cfg := CaptureConfig{Enabled: true, Path: "capture.bin", MaxFileSize: "100mb"}
+	require.NoError(cfg.Validate(), "valid capture configuration should be accepted")
+
+	cfg.MaxFileSize = "100 mib"
+	require.Error(cfg.Validate(), "malformed max file size should be rejected")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/p2p/capture"
 	p2pError "github.com/oasisprotocol/oasis-core/go/p2p/error"
 	"github.com/oasisprotocol/oasis-core/go/p2p/peermgmt"
 	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
@@ -66,6 +67,11 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 		"received_from", envelope.ReceivedFrom,
 	)
 
+	var err error
+	defer func() {
+		h.p2p.capture.CaptureGossip(capture.DirectionInbound, h.topic.String(), envelope.ReceivedFrom, peerID, envelope.GetData(), err)
+	}()
+
 	id, err := peerIDToPublicKey(peerID)
 	if err != nil {
 		h.logger.Error("error while extracting public key from peer ID",
@@ -6,10 +6,20 @@ import (
 	"errors"
 
 	"github.com/cenkalti/backoff/v4"
+
+	cmnErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
 )
 
-// ErrUnhandledMessage indicates to the dispatcher that the handler didn't handle the message.
-var ErrUnhandledMessage = Permanent(errors.New("unhandled message"))
+// ModuleName is a unique module name for the P2P error module.
+const ModuleName = "p2p/error"
+
+var (
+	// ErrUnhandledMessage indicates to the dispatcher that the handler didn't handle the message.
+	ErrUnhandledMessage = Permanent(errors.New("unhandled message"))
+
+	// ErrRateLimited is an error raised when a request is rejected due to rate limits.
+	ErrRateLimited = cmnErrors.New(ModuleName, 1, "p2p: rate limited")
+)
 
 // relayError signals that the message should be relayed.
 type relayError struct {
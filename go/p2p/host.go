@@ -28,6 +28,10 @@ type HostConfig struct {
 	ListenAddr multiaddr.Multiaddr
 	Port       uint16
 
+	// AnnounceAddrs are the addresses announced to peers instead of the listen addresses
+	// (if not set, the listen addresses are announced).
+	AnnounceAddrs []multiaddr.Multiaddr
+
 	ConnManagerConfig
 	ConnGaterConfig
 }
@@ -48,13 +52,21 @@ func NewHost(cfg *HostConfig) (host.Host, *conngater.BasicConnectionGater, error
 		return nil, nil, err
 	}
 
-	host, err := libp2p.New(
+	opts := []libp2p.Option{
 		libp2p.UserAgent(cfg.UserAgent),
 		libp2p.ListenAddrs(cfg.ListenAddr),
 		libp2p.Identity(id),
 		libp2p.ConnectionManager(cm),
 		libp2p.ConnectionGater(cg),
-	)
+	}
+	if len(cfg.AnnounceAddrs) > 0 {
+		announceAddrs := cfg.AnnounceAddrs
+		opts = append(opts, libp2p.AddrsFactory(func([]multiaddr.Multiaddr) []multiaddr.Multiaddr {
+			return announceAddrs
+		}))
+	}
+
+	host, err := libp2p.New(opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -67,6 +67,10 @@ func (p *nopP2P) RegisterHandler(topic string, handler api.Handler) {
 func (p *nopP2P) BlockPeer(peerID core.PeerID) {
 }
 
+// Implements api.Service.
+func (p *nopP2P) RecordPeerReputation(peerID core.PeerID, protocolID core.ProtocolID, event rpc.ReputationEvent) {
+}
+
 // Implements api.Service.
 func (p *nopP2P) Host() core.Host {
 	return nil
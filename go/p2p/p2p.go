@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -27,7 +28,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmflags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/p2p/capture"
 	"github.com/oasisprotocol/oasis-core/go/p2p/discovery/bootstrap"
 	"github.com/oasisprotocol/oasis-core/go/p2p/peermgmt"
 	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
@@ -81,9 +84,14 @@ type p2p struct {
 	gater   *conngater.BasicConnectionGater
 	peerMgr *peermgmt.PeerManager
 
+	// blockedPeers are the peers permanently blocked via BlockPeer.
+	blockedPeers map[core.PeerID]struct{}
+
 	registerAddresses []multiaddr.Multiaddr
 	topics            map[string]*topicHandler
 
+	capture *capture.Capturer
+
 	logger *logging.Logger
 }
 
@@ -124,6 +132,7 @@ func (p *p2p) Stop() {
 	go func() {
 		defer wg.Done()
 		_ = p.host.Close() // This blocks until the host stops.
+		_ = p.capture.Close()
 	}()
 
 	go func() {
@@ -269,11 +278,13 @@ func (p *p2p) Publish(ctx context.Context, topic string, msg interface{}) {
 		return
 	}
 
-	if err := h.tryPublishing(rawMsg); err != nil {
+	err := h.tryPublishing(rawMsg)
+	if err != nil {
 		h.logger.Error("failed to publish message to the network",
 			"err", err,
 		)
 	}
+	p.capture.CaptureGossip(capture.DirectionOutbound, topic, p.host.ID(), p.host.ID(), rawMsg, err)
 
 	p.logger.Debug("published message",
 		"topic", topic,
@@ -315,11 +326,49 @@ func (p *p2p) BlockPeer(peerID core.PeerID) {
 		"peer_id", peerID,
 	)
 
+	p.Lock()
+	p.blockedPeers[peerID] = struct{}{}
+	p.Unlock()
+
 	p.pubsub.BlacklistPeer(peerID)
 	_ = p.gater.BlockPeer(peerID)
 	_ = p.host.Network().ClosePeer(peerID)
 }
 
+// blockPeerTemporarily blocks the given peer until it is unblocked via unblockPeer.
+//
+// Unlike BlockPeer, the peer is not blacklisted by pubsub as that cannot be undone.
+func (p *p2p) blockPeerTemporarily(peerID core.PeerID) {
+	p.logger.Warn("temporarily blocking peer",
+		"peer_id", peerID,
+	)
+
+	_ = p.gater.BlockPeer(peerID)
+	_ = p.host.Network().ClosePeer(peerID)
+}
+
+// unblockPeer lifts a block placed by blockPeerTemporarily, unless the peer has also been
+// blocked permanently in the meantime.
+func (p *p2p) unblockPeer(peerID core.PeerID) {
+	p.RLock()
+	_, blocked := p.blockedPeers[peerID]
+	p.RUnlock()
+	if blocked {
+		return
+	}
+
+	p.logger.Info("unblocking peer",
+		"peer_id", peerID,
+	)
+
+	_ = p.gater.UnblockPeer(peerID)
+}
+
+// Implements api.Service.
+func (p *p2p) RecordPeerReputation(peerID core.PeerID, protocolID core.ProtocolID, event rpc.ReputationEvent) {
+	p.peerMgr.RecordPeerReputation(peerID, protocolID, event)
+}
+
 // Implements api.Service.
 func (p *p2p) RegisterProtocol(pid core.ProtocolID, min int, total int) {
 	p.peerMgr.RegisterProtocol(pid, min, total)
@@ -327,7 +376,8 @@ func (p *p2p) RegisterProtocol(pid core.ProtocolID, min int, total int) {
 
 // Implements api.Service.
 func (p *p2p) Host() core.Host {
-	return p.host
+	// Capture requests sent by RPC clients using the host, if enabled.
+	return p.capture.WrapHost(p.host)
 }
 
 // Implements api.Service.
@@ -339,7 +389,7 @@ func (p *p2p) PeerManager() api.PeerManager {
 func (p *p2p) RegisterProtocolServer(srv rpc.Server) {
 	protocol.ValidateProtocolID(srv.Protocol())
 
-	p.host.SetStreamHandler(srv.Protocol(), srv.HandleStream)
+	p.host.SetStreamHandler(srv.Protocol(), p.capture.WrapStreamHandler(srv.HandleStream))
 
 	p.logger.Info("registered protocol server",
 		"protocol_id", srv.Protocol(),
@@ -402,8 +452,18 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		return nil, fmt.Errorf("p2p: failed to get consensus chain context: %w", err)
 	}
 
+	// Initialize message capturing.
+	var capturer *capture.Capturer
+	if cfg.CaptureConfig != nil {
+		if capturer, err = capture.New(cfg.CaptureConfig); err != nil {
+			ctxCancel()
+			_ = host.Close()
+			return nil, fmt.Errorf("p2p: failed to initialize message capture: %w", err)
+		}
+	}
+
 	// Initialize the peer manager.
-	opts := make([]peermgmt.PeerManagerOption, 0, 1)
+	opts := make([]peermgmt.PeerManagerOption, 0, 2)
 
 	if cfg.BootstrapDiscoveryConfig.Enable {
 		seeds := make([]discovery.Discovery, 0, len(cfg.Seeds))
@@ -416,8 +476,6 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		opts = append(opts, peermgmt.WithBootstrapDiscovery(seeds))
 	}
 
-	mgr := peermgmt.NewPeerManager(host, cg, pubsub, consensus, chainContext, store, opts...)
-
 	p := &p2p{
 		ctx:               ctx,
 		ctxCancel:         ctxCancel,
@@ -427,13 +485,18 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		signer:            identity.P2PSigner,
 		host:              host,
 		gater:             cg,
-		peerMgr:           mgr,
+		blockedPeers:      make(map[core.PeerID]struct{}),
 		pubsub:            pubsub,
 		registerAddresses: cfg.Addresses,
 		topics:            make(map[string]*topicHandler),
+		capture:           capturer,
 		logger:            logging.GetLogger("p2p"),
 	}
 
+	// Peers with low reputation are only blocked temporarily.
+	opts = append(opts, peermgmt.WithPeerBlocker(p.blockPeerTemporarily, p.unblockPeer))
+	p.peerMgr = peermgmt.NewPeerManager(host, cg, pubsub, consensus, chainContext, store, opts...)
+
 	p.logger.Info("p2p host initialized",
 		"address", fmt.Sprintf("%+v", host.Addrs()),
 	)
@@ -444,6 +507,12 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		)
 	}
 
+	if capturer != nil {
+		p.logger.Warn("p2p message capture enabled, do not use in production",
+			"path", cfg.CaptureConfig.Path,
+		)
+	}
+
 	return p, nil
 }
 
@@ -454,6 +523,9 @@ type Config struct {
 	HostConfig
 	GossipSubConfig
 	BootstrapDiscoveryConfig
+
+	// CaptureConfig is the message capture configuration (nil if capturing is disabled).
+	CaptureConfig *capture.Config
 }
 
 // Load loads P2P configuration.
@@ -476,6 +548,10 @@ func (cfg *Config) Load() error {
 	if err := hostCfg.Load(); err != nil {
 		return fmt.Errorf("failed to load host config: %w", err)
 	}
+	if config.GlobalConfig.P2P.Debug.AnnounceRegistrationAddresses && cmflags.DebugDontBlameOasis() {
+		// Announce the registered addresses so that peers don't learn any other addresses.
+		hostCfg.AnnounceAddrs = addresses
+	}
 
 	var gossipSubCfg GossipSubConfig
 	if err := gossipSubCfg.Load(); err != nil {
@@ -487,14 +563,49 @@ func (cfg *Config) Load() error {
 		return fmt.Errorf("failed to load bootstrap config: %w", err)
 	}
 
+	captureCfg, err := loadCaptureConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load capture config: %w", err)
+	}
+
 	cfg.Addresses = addresses
 	cfg.HostConfig = hostCfg
 	cfg.GossipSubConfig = gossipSubCfg
 	cfg.BootstrapDiscoveryConfig = bootstrapCfg
+	cfg.CaptureConfig = captureCfg
 
 	return nil
 }
 
+func loadCaptureConfig() (*capture.Config, error) {
+	captureCfg := config.GlobalConfig.P2P.Capture
+	if !captureCfg.Enabled {
+		return nil, nil
+	}
+
+	peers := make([]core.PeerID, 0, len(captureCfg.Peers))
+	for _, rawPeerID := range captureCfg.Peers {
+		peerID, err := peer.Decode(rawPeerID)
+		if err != nil {
+			return nil, fmt.Errorf("malformed peer ID '%s': %w", rawPeerID, err)
+		}
+		peers = append(peers, peerID)
+	}
+
+	path := captureCfg.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(config.GlobalConfig.Common.DataDir, path)
+	}
+
+	return &capture.Config{
+		Path:        path,
+		MaxFileSize: uint64(config.ParseSizeInBytes(captureCfg.MaxFileSize)),
+		MaxFiles:    captureCfg.MaxFiles,
+		Topics:      captureCfg.Topics,
+		Peers:       peers,
+	}, nil
+}
+
 // GossipSubConfig describes a set of settings for a gossip pubsub.
 type GossipSubConfig struct {
 	// XXX: Main config has int64, but here just int -- investigate.
@@ -3,6 +3,7 @@ package peermgmt
 import (
 	"context"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/p2p/api"
 	"github.com/oasisprotocol/oasis-core/go/p2p/backup"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 )
 
 const (
@@ -32,7 +34,9 @@ const (
 
 // PeerManagerOptions are peer manager options.
 type PeerManagerOptions struct {
-	seeds []discovery.Discovery
+	seeds       []discovery.Discovery
+	blockPeer   func(core.PeerID)
+	unblockPeer func(core.PeerID)
 }
 
 // PeerManagerOption is a peer manager option setter.
@@ -45,6 +49,15 @@ func WithBootstrapDiscovery(seeds []discovery.Discovery) PeerManagerOption {
 	}
 }
 
+// WithPeerBlocker configures the functions used to block peers with low reputation and to
+// unblock them once their block expires.
+func WithPeerBlocker(blockPeer, unblockPeer func(core.PeerID)) PeerManagerOption {
+	return func(opts *PeerManagerOptions) {
+		opts.blockPeer = blockPeer
+		opts.unblockPeer = unblockPeer
+	}
+}
+
 type watermark struct {
 	// min is the minimum number of peers from the registry we want to have connected.
 	min int
@@ -64,11 +77,12 @@ type PeerManager struct {
 	host   host.Host
 	pubsub *pubsub.PubSub
 
-	registry  *peerRegistry
-	discovery *peerDiscovery
-	connector *peerConnector
-	tagger    *peerTagger
-	backup    *peerstoreBackup
+	registry   *peerRegistry
+	discovery  *peerDiscovery
+	connector  *peerConnector
+	tagger     *peerTagger
+	reputation *peerReputation
+	backup     *peerstoreBackup
 
 	mu        sync.RWMutex
 	protocols map[core.ProtocolID]*watermark
@@ -95,19 +109,21 @@ func NewPeerManager(
 	l := logging.GetLogger("p2p/peer-manager")
 	cm := h.ConnManager()
 	cstore := backup.NewCommonStoreBackend(cs, peerstoreBucketName, peerstoreBucketKey)
+	rstore := backup.NewCommonStoreScoreBackend(cs, reputationBucketName, reputationBucketKey)
 
 	return &PeerManager{
-		logger:    l,
-		host:      h,
-		pubsub:    ps,
-		registry:  newPeerRegistry(consensus, chainContext),
-		connector: newPeerConnector(h, g),
-		tagger:    newPeerTagger(cm),
-		backup:    newPeerstoreBackup(h.Peerstore(), cstore),
-		discovery: newPeerDiscovery(pmo.seeds),
-		protocols: make(map[core.ProtocolID]*watermark),
-		topics:    make(map[string]*watermark),
-		startOne:  cmSync.NewOne(),
+		logger:     l,
+		host:       h,
+		pubsub:     ps,
+		registry:   newPeerRegistry(consensus, chainContext),
+		connector:  newPeerConnector(h, g),
+		tagger:     newPeerTagger(cm),
+		reputation: newPeerReputation(rstore, pmo.blockPeer, pmo.unblockPeer),
+		backup:     newPeerstoreBackup(h.Peerstore(), cstore),
+		discovery:  newPeerDiscovery(pmo.seeds),
+		protocols:  make(map[core.ProtocolID]*watermark),
+		topics:     make(map[string]*watermark),
+		startOne:   cmSync.NewOne(),
 	}
 }
 
@@ -121,6 +137,18 @@ func (m *PeerManager) PeerTagger() api.PeerTagger {
 	return m.tagger
 }
 
+// PeerReputation implements api.PeerManager.
+func (m *PeerManager) PeerReputation() api.PeerReputation {
+	return m.reputation
+}
+
+// RecordPeerReputation records a reputation event for the given peer and protocol.
+//
+// Peers whose reputation drops too low are blocked.
+func (m *PeerManager) RecordPeerReputation(peerID core.PeerID, protocolID core.ProtocolID, event rpc.ReputationEvent) {
+	m.reputation.record(peerID, protocolID, event)
+}
+
 // Start starts the background services required for the peer manager to work.
 func (m *PeerManager) Start() {
 	m.startOne.TryStart(func(ctx context.Context) {
@@ -268,10 +296,18 @@ func (m *PeerManager) UnregisterTopic(topic string) {
 }
 
 func (m *PeerManager) run(ctx context.Context) {
+	// Restore reputation before starting any background services so that peers with low
+	// reputation are blocked before any connections are made and so that the restored scores
+	// are not overwritten by a backup.
+	_ = m.reputation.restore(ctx)
+
 	// Start background services.
 	m.backup.start()
 	defer m.backup.stop()
 
+	m.reputation.start()
+	defer m.reputation.stop()
+
 	m.registry.start()
 	defer m.registry.stop()
 
@@ -307,7 +343,8 @@ func (m *PeerManager) run(ctx context.Context) {
 }
 
 // connectRestoredPeers connects to a random subset of peers that were restored from the backup
-// and added to the peerstore.
+// and added to the peerstore, preferring peers with a good reputation and skipping peers with
+// a bad one.
 func (m *PeerManager) connectRestoredPeers(ctx context.Context) {
 	m.logger.Debug("connecting to restored peer")
 
@@ -326,9 +363,25 @@ func (m *PeerManager) connectRestoredPeers(ctx context.Context) {
 
 		store := m.host.Peerstore()
 		peers := store.PeersWithAddrs()
-		for _, i := range rand.Perm(len(peers)) {
+		rand.Shuffle(len(peers), func(i, j int) {
+			peers[i], peers[j] = peers[j], peers[i]
+		})
+
+		scores := make(map[core.PeerID]float64, len(peers))
+		for _, p := range peers {
+			scores[p] = m.reputation.score(p)
+		}
+		sort.SliceStable(peers, func(i, j int) bool {
+			return scores[peers[i]] > scores[peers[j]]
+		})
+
+		for _, p := range peers {
+			if scores[p] < reputationConnectThreshold {
+				// Peers are sorted by score, so all remaining peers have a bad reputation.
+				return
+			}
 			select {
-			case peerCh <- store.PeerInfo(peers[i]):
+			case peerCh <- store.PeerInfo(p):
 			case <-doneCh:
 				return
 			}
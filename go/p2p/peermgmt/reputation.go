@@ -0,0 +1,424 @@
+package peermgmt
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/scheduling"
+	"github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/p2p/backup"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+const (
+	// reputationBucketName is the name of the bucket in which reputation scores are stored.
+	reputationBucketName = "p2p/peer_manager/reputation"
+
+	// reputationBucketKey is the bucket key under which reputation scores are stored.
+	reputationBucketKey = "scores"
+
+	// reputationBackupTaskName is the name of the task responsible for periodical backups.
+	reputationBackupTaskName = "reputation-backup"
+
+	// reputationBackupDelay is the initial time delay for reputation backups.
+	reputationBackupDelay = 5 * time.Minute
+
+	// reputationBackupInterval is the time interval between reputation backups.
+	reputationBackupInterval = 5 * time.Minute
+
+	// reputationUnblockTaskName is the name of the task responsible for lifting expired blocks.
+	reputationUnblockTaskName = "reputation-unblock"
+
+	// reputationUnblockInterval is the time interval between checks for expired blocks.
+	reputationUnblockInterval = time.Minute
+
+	// reputationBlockDuration is the time for which peers with low reputation are blocked.
+	reputationBlockDuration = time.Hour
+
+	// reputationHalfLife is the time after which a reputation score decays to half of its value.
+	reputationHalfLife = 24 * time.Hour
+
+	// reputationSuccessDelta is the score change on a successful protocol interaction.
+	reputationSuccessDelta = 1.0
+
+	// reputationFailureDelta is the score change on an unsuccessful protocol interaction.
+	reputationFailureDelta = -1.0
+
+	// reputationBadPeerDelta is the score change on a malicious protocol interaction.
+	reputationBadPeerDelta = -50.0
+
+	// reputationMaxScore is the maximum per-protocol reputation score.
+	reputationMaxScore = 100.0
+
+	// reputationMinScore is the minimum per-protocol reputation score.
+	reputationMinScore = -100.0
+
+	// reputationBlockThreshold is the per-protocol reputation score at or below which peers
+	// are blocked.
+	reputationBlockThreshold = -50.0
+
+	// reputationConnectThreshold is the overall reputation score below which restored peers
+	// are not connected to on startup.
+	reputationConnectThreshold = -10.0
+
+	// reputationPruneThreshold is the absolute reputation score below which scores are
+	// considered negligible and are forgotten.
+	reputationPruneThreshold = 0.01
+)
+
+// reputation is a decaying reputation score.
+type reputation struct {
+	score     float64
+	updatedAt time.Time
+
+	// blockedUntil is the time until which the peer is blocked due to this score.
+	blockedUntil time.Time
+}
+
+// decayed returns the score decayed to the given time.
+func (r *reputation) decayed(now time.Time) float64 {
+	elapsed := now.Sub(r.updatedAt)
+	if elapsed <= 0 {
+		return r.score
+	}
+	return r.score * math.Exp2(-float64(elapsed)/float64(reputationHalfLife))
+}
+
+// update decays the score to the given time and adds the given delta.
+func (r *reputation) update(now time.Time, delta float64) {
+	r.score = math.Max(reputationMinScore, math.Min(reputationMaxScore, r.decayed(now)+delta))
+	r.updatedAt = now
+}
+
+// peerReputation tracks decaying reputation scores of peers per protocol and temporarily blocks
+// peers whose reputation for any protocol drops too low.
+type peerReputation struct {
+	logger *logging.Logger
+
+	blockPeer        func(core.PeerID)
+	unblockPeer      func(core.PeerID)
+	backupBackend    backup.ScoreBackend
+	backupScheduler  scheduling.Scheduler
+	unblockScheduler scheduling.Scheduler
+
+	mu     sync.Mutex
+	scores map[core.ProtocolID]map[core.PeerID]*reputation
+	// blocked maps blocked peers to the time their block expires.
+	blocked map[core.PeerID]time.Time
+}
+
+func newPeerReputation(b backup.ScoreBackend, blockPeer, unblockPeer func(core.PeerID)) *peerReputation {
+	l := logging.GetLogger("p2p/peer-manager/reputation")
+
+	pr := peerReputation{
+		logger:        l,
+		blockPeer:     blockPeer,
+		unblockPeer:   unblockPeer,
+		backupBackend: b,
+		scores:        make(map[core.ProtocolID]map[core.PeerID]*reputation),
+		blocked:       make(map[core.PeerID]time.Time),
+	}
+
+	pr.backupScheduler = scheduling.NewFixedRateScheduler(reputationBackupDelay, reputationBackupInterval)
+	pr.backupScheduler.AddTask(reputationBackupTaskName, pr.backup)
+
+	pr.unblockScheduler = scheduling.NewFixedRateScheduler(reputationUnblockInterval, reputationUnblockInterval)
+	pr.unblockScheduler.AddTask(reputationUnblockTaskName, pr.unblockExpired)
+
+	return &pr
+}
+
+// Scores implements api.PeerReputation.
+func (r *peerReputation) Scores() []*api.PeerScore {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	peers := make(map[core.PeerID]*api.PeerScore)
+	for p, scores := range r.scores {
+		for id, rep := range scores {
+			score := rep.decayed(now)
+			if math.Abs(score) < reputationPruneThreshold {
+				continue
+			}
+
+			ps, ok := peers[id]
+			if !ok {
+				ps = &api.PeerScore{
+					PeerID:    id,
+					Protocols: make(map[core.ProtocolID]float64),
+					Blocked:   r.isBlockedLocked(id, now),
+				}
+				peers[id] = ps
+			}
+			ps.Score += score
+			ps.Protocols[p] = score
+		}
+	}
+
+	result := make([]*api.PeerScore, 0, len(peers))
+	for _, ps := range peers {
+		result = append(result, ps)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare([]byte(result[i].PeerID), []byte(result[j].PeerID)) < 0
+	})
+
+	return result
+}
+
+// record records a reputation event for the given peer and protocol and temporarily blocks
+// the peer if its reputation for the protocol drops too low.
+func (r *peerReputation) record(peerID core.PeerID, protocolID core.ProtocolID, event rpc.ReputationEvent) {
+	var delta float64
+	switch event {
+	case rpc.ReputationEventSuccess:
+		delta = reputationSuccessDelta
+	case rpc.ReputationEventFailure:
+		delta = reputationFailureDelta
+	case rpc.ReputationEventBadPeer:
+		delta = reputationBadPeerDelta
+	default:
+		r.logger.Warn("ignoring unknown reputation event",
+			"peer_id", peerID,
+			"protocol_id", protocolID,
+			"event", event,
+		)
+		return
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	scores, ok := r.scores[protocolID]
+	if !ok {
+		scores = make(map[core.PeerID]*reputation)
+		r.scores[protocolID] = scores
+	}
+	rep, ok := scores[peerID]
+	if !ok {
+		rep = &reputation{updatedAt: now}
+		scores[peerID] = rep
+	}
+	rep.update(now, delta)
+
+	// Only negative events can cause a block, so that a peer whose block expired is not blocked
+	// again until it misbehaves again.
+	var block bool
+	if delta < 0 {
+		block = r.shouldBlockLocked(peerID, rep, now)
+	}
+	r.mu.Unlock()
+
+	if block {
+		r.logger.Warn("blocking peer due to low reputation",
+			"peer_id", peerID,
+			"protocol_id", protocolID,
+			"duration", reputationBlockDuration,
+		)
+		r.block(peerID)
+	}
+}
+
+// score returns the overall reputation score of the given peer.
+func (r *peerReputation) score(peerID core.PeerID) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.scoreLocked(peerID, time.Now())
+}
+
+func (r *peerReputation) scoreLocked(peerID core.PeerID, now time.Time) float64 {
+	var score float64
+	for _, scores := range r.scores {
+		if rep, ok := scores[peerID]; ok {
+			score += rep.decayed(now)
+		}
+	}
+	return score
+}
+
+// isBlockedLocked returns true iff the given peer is currently blocked.
+func (r *peerReputation) isBlockedLocked(peerID core.PeerID, now time.Time) bool {
+	until, ok := r.blocked[peerID]
+	return ok && until.After(now)
+}
+
+// shouldBlockLocked blocks the given peer for a limited time if the given per-protocol
+// reputation dropped to or below the block threshold and returns true iff the peer was not
+// blocked yet.
+func (r *peerReputation) shouldBlockLocked(peerID core.PeerID, rep *reputation, now time.Time) bool {
+	if rep.decayed(now) > reputationBlockThreshold {
+		return false
+	}
+	rep.blockedUntil = now.Add(reputationBlockDuration)
+	return r.markBlockedLocked(peerID, rep.blockedUntil)
+}
+
+// markBlockedLocked marks the given peer as blocked until the given time and returns true iff
+// the peer was not blocked yet.
+func (r *peerReputation) markBlockedLocked(peerID core.PeerID, until time.Time) bool {
+	prev, ok := r.blocked[peerID]
+	if ok && !until.After(prev) {
+		return false
+	}
+	r.blocked[peerID] = until
+	return !ok
+}
+
+func (r *peerReputation) block(peerID core.PeerID) {
+	if r.blockPeer == nil {
+		return
+	}
+	r.blockPeer(peerID)
+}
+
+func (r *peerReputation) unblock(peerID core.PeerID) {
+	if r.unblockPeer == nil {
+		return
+	}
+	r.unblockPeer(peerID)
+}
+
+// unblockExpired lifts all blocks which have expired.
+func (r *peerReputation) unblockExpired(context.Context) error {
+	r.mu.Lock()
+	now := time.Now()
+	var unblocked []core.PeerID
+	for id, until := range r.blocked {
+		if until.After(now) {
+			continue
+		}
+		delete(r.blocked, id)
+		unblocked = append(unblocked, id)
+	}
+	r.mu.Unlock()
+
+	for _, id := range unblocked {
+		r.logger.Info("unblocking peer as its block expired",
+			"peer_id", id,
+		)
+		r.unblock(id)
+	}
+
+	return nil
+}
+
+func (r *peerReputation) backup(ctx context.Context) error {
+	r.logger.Debug("backing up peer reputation")
+
+	r.mu.Lock()
+	now := time.Now()
+	nsScores := make(map[string]map[peer.ID]backup.PeerScore)
+	for p, scores := range r.scores {
+		for id, rep := range scores {
+			blocked := rep.blockedUntil.After(now)
+
+			// Forget negligible scores.
+			if math.Abs(rep.decayed(now)) < reputationPruneThreshold && !blocked {
+				delete(scores, id)
+				continue
+			}
+
+			if nsScores[string(p)] == nil {
+				nsScores[string(p)] = make(map[peer.ID]backup.PeerScore)
+			}
+			score := backup.PeerScore{
+				Score:     rep.score,
+				UpdatedAt: rep.updatedAt.Unix(),
+			}
+			if blocked {
+				score.BlockedUntil = rep.blockedUntil.Unix()
+			}
+			nsScores[string(p)][id] = score
+		}
+		if len(scores) == 0 {
+			delete(r.scores, p)
+		}
+	}
+	r.mu.Unlock()
+
+	if err := r.backupBackend.Backup(ctx, nsScores); err != nil {
+		r.logger.Error("failed to backup peer reputation",
+			"err", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *peerReputation) restore(ctx context.Context) error {
+	r.logger.Debug("restoring peer reputation")
+
+	nsScores, err := r.backupBackend.Restore(ctx)
+	if err != nil {
+		r.logger.Error("failed to restore peer reputation",
+			"err", err,
+		)
+		return err
+	}
+
+	// Only blocks which have not expired yet are restored, so that a restart neither lifts
+	// blocks early nor extends them.
+	r.mu.Lock()
+	now := time.Now()
+	var blocked []core.PeerID
+	for ns, scores := range nsScores {
+		p := core.ProtocolID(ns)
+		if r.scores[p] == nil {
+			r.scores[p] = make(map[core.PeerID]*reputation)
+		}
+		for id, score := range scores {
+			// Scores recorded since startup take precedence over the restored ones.
+			if _, ok := r.scores[p][id]; ok {
+				continue
+			}
+			rep := &reputation{
+				score:     score.Score,
+				updatedAt: time.Unix(score.UpdatedAt, 0),
+			}
+			if score.BlockedUntil != 0 {
+				rep.blockedUntil = time.Unix(score.BlockedUntil, 0)
+			}
+			r.scores[p][id] = rep
+
+			if !rep.blockedUntil.After(now) {
+				continue
+			}
+			if r.markBlockedLocked(id, rep.blockedUntil) {
+				blocked = append(blocked, id)
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	for _, id := range blocked {
+		r.logger.Info("blocking restored peer due to low reputation",
+			"peer_id", id,
+		)
+		r.block(id)
+	}
+
+	return nil
+}
+
+func (r *peerReputation) start() {
+	r.backupScheduler.Start()
+	r.unblockScheduler.Start()
+}
+
+func (r *peerReputation) stop() {
+	r.unblockScheduler.Stop()
+	r.backupScheduler.Stop()
+
+	// Make sure the latest scores survive a restart.
+	_ = r.backup(context.Background())
+}
@@ -0,0 +1,214 @@
+package peermgmt
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/p2p/backup"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+type testPeerBlocker struct {
+	mu        sync.Mutex
+	blocked   []core.PeerID
+	unblocked []core.PeerID
+}
+
+func (b *testPeerBlocker) blockPeer(peerID core.PeerID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blocked = append(b.blocked, peerID)
+}
+
+func (b *testPeerBlocker) unblockPeer(peerID core.PeerID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.unblocked = append(b.unblocked, peerID)
+}
+
+func (b *testPeerBlocker) get() []core.PeerID {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]core.PeerID{}, b.blocked...)
+}
+
+func (b *testPeerBlocker) getUnblocked() []core.PeerID {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]core.PeerID{}, b.unblocked...)
+}
+
+// expireBlocks moves all blocks of the given peer into the past.
+func expireBlocks(r *peerReputation, peerID core.PeerID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	past := time.Now().Add(-time.Second)
+	for _, scores := range r.scores {
+		if rep, ok := scores[peerID]; ok && !rep.blockedUntil.IsZero() {
+			rep.blockedUntil = past
+		}
+	}
+	if _, ok := r.blocked[peerID]; ok {
+		r.blocked[peerID] = past
+	}
+}
+
+func newTestPeerID(t *testing.T) core.PeerID {
+	_, pk, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	require.NoError(t, err, "GenerateKeyPair failed")
+
+	id, err := peer.IDFromPublicKey(pk)
+	require.NoError(t, err, "IDFromPublicKey failed")
+
+	return id
+}
+
+func TestReputationDecay(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	rep := reputation{updatedAt: now}
+
+	rep.update(now, reputationBadPeerDelta)
+	require.Equal(reputationBadPeerDelta, rep.decayed(now))
+	require.InDelta(reputationBadPeerDelta/2, rep.decayed(now.Add(reputationHalfLife)), 1e-9)
+	require.InDelta(reputationBadPeerDelta/4, rep.decayed(now.Add(2*reputationHalfLife)), 1e-9)
+
+	// Scores are clamped.
+	for i := 0; i < 10; i++ {
+		rep.update(now, reputationBadPeerDelta)
+	}
+	require.Equal(reputationMinScore, rep.score)
+}
+
+func TestPeerReputation(t *testing.T) {
+	require := require.New(t)
+
+	p1, p2 := core.ProtocolID("/protocol/1"), core.ProtocolID("/protocol/2")
+	good, flaky, bad := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+
+	var blocker testPeerBlocker
+	backend := backup.NewInMemoryScoreBackend()
+	r := newPeerReputation(backend, blocker.blockPeer, blocker.unblockPeer)
+
+	r.record(good, p1, rpc.ReputationEventSuccess)
+	r.record(good, p2, rpc.ReputationEventSuccess)
+	r.record(flaky, p1, rpc.ReputationEventFailure)
+	r.record(bad, p1, rpc.ReputationEventSuccess)
+	r.record(bad, p2, rpc.ReputationEventBadPeer)
+	r.record(bad, p2, rpc.ReputationEventBadPeer)
+
+	require.InDelta(2, r.score(good), 1e-3)
+	require.InDelta(-1, r.score(flaky), 1e-3)
+	require.InDelta(-99, r.score(bad), 1e-3)
+	require.Equal([]core.PeerID{bad}, blocker.get(), "bad peer should be blocked exactly once")
+
+	scores := r.Scores()
+	require.Len(scores, 3)
+	for _, ps := range scores {
+		switch ps.PeerID {
+		case good:
+			require.Len(ps.Protocols, 2)
+			require.False(ps.Blocked)
+		case flaky:
+			require.Len(ps.Protocols, 1)
+			require.False(ps.Blocked)
+		case bad:
+			require.Len(ps.Protocols, 2)
+			require.True(ps.Blocked)
+		}
+	}
+
+	// Restored reputation should block bad peers again.
+	err := r.backup(context.Background())
+	require.NoError(err, "backup")
+
+	var restoredBlocker testPeerBlocker
+	restored := newPeerReputation(backend, restoredBlocker.blockPeer, restoredBlocker.unblockPeer)
+	err = restored.restore(context.Background())
+	require.NoError(err, "restore")
+
+	require.InDelta(2, restored.score(good), 1e-3)
+	require.InDelta(-1, restored.score(flaky), 1e-3)
+	require.InDelta(-99, restored.score(bad), 1e-3)
+	require.Equal([]core.PeerID{bad}, restoredBlocker.get(), "restored bad peer should be blocked")
+}
+
+func TestPeerReputationPerProtocol(t *testing.T) {
+	require := require.New(t)
+
+	p1, p2 := core.ProtocolID("/protocol/1"), core.ProtocolID("/protocol/2")
+	flaky := newTestPeerID(t)
+
+	var blocker testPeerBlocker
+	r := newPeerReputation(backup.NewInMemoryScoreBackend(), blocker.blockPeer, blocker.unblockPeer)
+
+	// Failures spread across protocols should not add up to a block.
+	for i := 0; i < 30; i++ {
+		r.record(flaky, p1, rpc.ReputationEventFailure)
+		r.record(flaky, p2, rpc.ReputationEventFailure)
+	}
+	require.Less(r.score(flaky), reputationBlockThreshold)
+	require.Empty(blocker.get(), "peer should not be blocked due to its overall score")
+
+	// Failures of a single protocol should.
+	for i := 0; i < 25; i++ {
+		r.record(flaky, p1, rpc.ReputationEventFailure)
+	}
+	require.Equal([]core.PeerID{flaky}, blocker.get(), "peer should be blocked due to its protocol score")
+}
+
+func TestPeerReputationBlockExpiry(t *testing.T) {
+	require := require.New(t)
+
+	p1 := core.ProtocolID("/protocol/1")
+	bad := newTestPeerID(t)
+
+	var blocker testPeerBlocker
+	backend := backup.NewInMemoryScoreBackend()
+	r := newPeerReputation(backend, blocker.blockPeer, blocker.unblockPeer)
+
+	r.record(bad, p1, rpc.ReputationEventBadPeer)
+	require.Equal([]core.PeerID{bad}, blocker.get(), "bad peer should be blocked")
+
+	// Blocks which have not expired yet should not be lifted.
+	err := r.unblockExpired(context.Background())
+	require.NoError(err, "unblockExpired")
+	require.Empty(blocker.getUnblocked())
+
+	expireBlocks(r, bad)
+	err = r.unblockExpired(context.Background())
+	require.NoError(err, "unblockExpired")
+	require.Equal([]core.PeerID{bad}, blocker.getUnblocked(), "bad peer should be unblocked once the block expires")
+	require.False(r.Scores()[0].Blocked)
+
+	// Successful interactions should not block the peer again.
+	r.record(bad, p1, rpc.ReputationEventSuccess)
+	require.Len(blocker.get(), 1, "peer should not be blocked again on success")
+
+	// Expired blocks should not be restored.
+	err = r.backup(context.Background())
+	require.NoError(err, "backup")
+
+	var restoredBlocker testPeerBlocker
+	restored := newPeerReputation(backend, restoredBlocker.blockPeer, restoredBlocker.unblockPeer)
+	err = restored.restore(context.Background())
+	require.NoError(err, "restore")
+	require.Empty(restoredBlocker.get(), "expired block should not be restored")
+
+	// Misbehaving again should block the peer again.
+	restored.record(bad, p1, rpc.ReputationEventBadPeer)
+	require.Equal([]core.PeerID{bad}, restoredBlocker.get(), "misbehaving peer should be blocked again")
+}
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core"
@@ -67,3 +68,12 @@ func NewTopicKindTxID(chainContext string, runtimeID common.Namespace) string {
 func NewTopicKindCommitteeID(chainContext string, runtimeID common.Namespace) string {
 	return NewTopicIDForRuntime(chainContext, runtimeID, api.TopicKindCommittee, version.RuntimeCommitteeProtocol)
 }
+
+// TopicKindFromID extracts the topic kind from a topic id constructed by NewTopicIDForRuntime.
+func TopicKindFromID(topic string) (api.TopicKind, error) {
+	parts := strings.Split(topic, "/")
+	if len(parts) != 5 || parts[0] != "oasis" {
+		return "", fmt.Errorf("p2p/protocol: malformed topic id '%s'", topic)
+	}
+	return api.TopicKind(parts[2]), nil
+}
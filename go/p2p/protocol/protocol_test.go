@@ -56,6 +56,21 @@ func TestProtocolID(t *testing.T) {
 		require.Equal(expected, NewTopicIDForRuntime(chainContext, runtimeID, kind, version))
 	})
 
+	t.Run("TopicKindFromID", func(t *testing.T) {
+		require := require.New(t)
+
+		var runtimeID common.Namespace
+		err := runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+		require.NoError(err, "failed to unmarshal runtime id")
+
+		kind, err := TopicKindFromID(NewTopicIDForRuntime(chainContext, runtimeID, api.TopicKindTx, version))
+		require.NoError(err, "TopicKindFromID")
+		require.Equal(api.TopicKindTx, kind)
+
+		_, err = TopicKindFromID("/oasis/chain/storagesync/1.0.0")
+		require.Error(err, "TopicKindFromID should fail for protocol ids")
+	})
+
 	registry = newProtocolRegistry()
 
 	t.Run("ValidateProtocolID", func(t *testing.T) {
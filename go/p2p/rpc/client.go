@@ -16,6 +16,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/workerpool"
+	p2pError "github.com/oasisprotocol/oasis-core/go/p2p/error"
 )
 
 const (
@@ -38,6 +39,8 @@ type PeerFeedback interface {
 	RecordSuccess()
 
 	// RecordFailure records an unsuccessful protocol interaction with the given peer.
+	//
+	// Requests rejected by the peer due to rate limits are not recorded as failures.
 	RecordFailure()
 
 	// RecordBadPeer records a malicious protocol interaction with the given peer.
@@ -50,9 +53,10 @@ type PeerFeedback interface {
 }
 
 type peerFeedback struct {
-	client  *client
-	peerID  core.PeerID
-	latency time.Duration
+	client      *client
+	peerID      core.PeerID
+	latency     time.Duration
+	rateLimited bool
 }
 
 func (pf *peerFeedback) RecordSuccess() {
@@ -60,6 +64,10 @@ func (pf *peerFeedback) RecordSuccess() {
 }
 
 func (pf *peerFeedback) RecordFailure() {
+	// The peer is protecting itself by rate limiting us, so it should not be degraded.
+	if pf.rateLimited {
+		return
+	}
 	pf.client.recordFailure(pf.peerID, pf.latency)
 }
 
@@ -307,6 +315,7 @@ func (c *client) CallOne(
 	var pf PeerFeedback
 	tryPeers := func() error {
 		// Iterate through the list of peers and attempt to execute the request.
+		var lastErr error
 		for _, peer := range peers {
 			c.logger.Debug("trying peer",
 				"method", method,
@@ -316,11 +325,13 @@ func (c *client) CallOne(
 			var err error
 			pf, err = c.timeCall(ctx, peer, &request, rsp, co.maxPeerResponseTime)
 			if err != nil {
+				lastErr = err
 				continue
 			}
 			if co.validationFn != nil {
 				err := co.validationFn(pf)
 				if err != nil {
+					lastErr = err
 					c.logger.Debug("failed to validate peer response",
 						"method", method,
 						"peer_id", peer,
@@ -337,7 +348,7 @@ func (c *client) CallOne(
 			"method", method,
 		)
 
-		return fmt.Errorf("call failed on all peers")
+		return fmt.Errorf("call failed on all peers: %w", lastErr)
 	}
 
 	err := retryFn(ctx, tryPeers, co.maxRetries, co.retryInterval)
@@ -448,8 +459,9 @@ func (c *client) timeCall(
 	latency := time.Since(start)
 
 	if err != nil {
-		// If the caller canceled the context we should not degrade the peer.
-		if !errors.Is(err, context.Canceled) {
+		// If the caller canceled the context or the peer is rate limiting us, we should not
+		// degrade the peer.
+		if !errors.Is(err, context.Canceled) && !errors.Is(err, p2pError.ErrRateLimited) {
 			c.recordFailure(peerID, latency)
 		}
 
@@ -461,9 +473,10 @@ func (c *client) timeCall(
 	}
 
 	return &peerFeedback{
-		client:  c,
-		peerID:  peerID,
-		latency: latency,
+		client:      c,
+		peerID:      peerID,
+		latency:     latency,
+		rateLimited: errors.Is(err, p2pError.ErrRateLimited),
 	}, err
 }
 
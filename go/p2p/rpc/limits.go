@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/oasisprotocol/oasis-core/go/common/ratelimit"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	p2pConfig "github.com/oasisprotocol/oasis-core/go/p2p/config"
+)
+
+const (
+	limitReasonPeerRequests = "peer_requests"
+	limitReasonPeerBytes    = "peer_bytes"
+	limitReasonRequests     = "requests"
+	limitReasonBytes        = "bytes"
+)
+
+// ServerLimits are limits enforced by an RPC server.
+//
+// Byte limits apply to response sizes. As the size of a response is only known once the request
+// has been handled, a peer exceeding its byte limit is only throttled on subsequent requests.
+type ServerLimits struct {
+	// PeerRequestRate is the maximum number of requests per second per peer (0 = unlimited).
+	PeerRequestRate float64
+	// PeerRequestBurst is the maximum number of requests in a burst per peer.
+	PeerRequestBurst int
+	// PeerByteRate is the maximum number of response bytes per second per peer (0 = unlimited).
+	PeerByteRate float64
+	// PeerByteBurst is the maximum number of response bytes in a burst per peer.
+	PeerByteBurst int
+
+	// RequestRate is the maximum number of requests per second for all peers (0 = unlimited).
+	RequestRate float64
+	// RequestBurst is the maximum number of requests in a burst for all peers.
+	RequestBurst int
+	// ByteRate is the maximum number of response bytes per second for all peers (0 = unlimited).
+	ByteRate float64
+	// ByteBurst is the maximum number of response bytes in a burst for all peers.
+	ByteBurst int
+}
+
+// NewServerLimits creates server limits from the given limits configuration.
+func NewServerLimits(cfg *p2pConfig.RPCLimitsConfig) *ServerLimits {
+	return &ServerLimits{
+		PeerRequestRate:  cfg.PeerRequestRate,
+		PeerRequestBurst: cfg.PeerRequestBurst,
+		PeerByteRate:     float64(config.ParseSizeInBytes(cfg.PeerByteRate)),
+		PeerByteBurst:    int(config.ParseSizeInBytes(cfg.PeerByteBurst)),
+		RequestRate:      cfg.RequestRate,
+		RequestBurst:     cfg.RequestBurst,
+		ByteRate:         float64(config.ParseSizeInBytes(cfg.ByteRate)),
+		ByteBurst:        int(config.ParseSizeInBytes(cfg.ByteBurst)),
+	}
+}
+
+// configuredServerLimits returns the server limits configured for the given protocol.
+//
+// Per-protocol limits are matched against the path segments of the protocol identifier so that
+// e.g. limits for "storagesync" apply to storage sync protocols of all runtimes.
+func configuredServerLimits(protocolID protocol.ID) *ServerLimits {
+	cfg := config.GlobalConfig.P2P.RPC
+	for _, segment := range strings.Split(string(protocolID), "/") {
+		if limits, ok := cfg.ProtocolLimits[segment]; ok {
+			return NewServerLimits(&limits)
+		}
+	}
+	return NewServerLimits(&cfg.Limits)
+}
+
+type serverLimiter struct {
+	peerRequests *ratelimit.KeyedLimiter
+	peerBytes    *ratelimit.KeyedLimiter
+	requests     *ratelimit.Limiter
+	bytes        *ratelimit.Limiter
+}
+
+// allowRequest returns an empty string iff a request from the given peer may be served now.
+// Otherwise, it returns the reason for throttling the request.
+func (l *serverLimiter) allowRequest(peerID core.PeerID, now time.Time) string {
+	key := string(peerID)
+
+	// Check byte limits first as these do not consume any tokens.
+	if !l.peerBytes.AllowN(key, now, 0) {
+		return limitReasonPeerBytes
+	}
+	if !l.bytes.AllowN(now, 0) {
+		return limitReasonBytes
+	}
+	if !l.peerRequests.AllowN(key, now, 1) {
+		return limitReasonPeerRequests
+	}
+	if !l.requests.AllowN(now, 1) {
+		return limitReasonRequests
+	}
+	return ""
+}
+
+// recordResponse accounts for a response of the given size sent to the given peer.
+func (l *serverLimiter) recordResponse(peerID core.PeerID, now time.Time, size int) {
+	l.peerBytes.ConsumeN(string(peerID), now, size)
+	l.bytes.ConsumeN(now, size)
+}
+
+func newServerLimiter(limits *ServerLimits) *serverLimiter {
+	return &serverLimiter{
+		peerRequests: ratelimit.NewKeyedLimiter(limits.PeerRequestRate, limits.PeerRequestBurst),
+		peerBytes:    ratelimit.NewKeyedLimiter(limits.PeerByteRate, limits.PeerByteBurst),
+		requests:     ratelimit.NewLimiter(limits.RequestRate, limits.RequestBurst),
+		bytes:        ratelimit.NewLimiter(limits.ByteRate, limits.ByteBurst),
+	}
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// topPeersInterval is the time interval over which peer traffic is aggregated before
+	// the top peers metric is updated.
+	topPeersInterval = time.Minute
+
+	// topPeersCount is the number of peers reported by the top peers metric.
+	topPeersCount = 10
+)
+
+var (
+	serverRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_p2p_rpc_server_requests",
+			Help: "Number of P2P RPC requests served.",
+		},
+		[]string{"protocol"},
+	)
+	serverResponseBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_p2p_rpc_server_response_bytes",
+			Help: "Number of P2P RPC response bytes served.",
+		},
+		[]string{"protocol"},
+	)
+	serverRateLimitedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_p2p_rpc_server_rate_limited_requests",
+			Help: "Number of P2P RPC requests rejected due to rate limits.",
+		},
+		[]string{"protocol", "reason"},
+	)
+	serverTopPeerBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_p2p_rpc_server_top_peer_bytes",
+			Help: "Number of P2P RPC response bytes served to the top peers by traffic in the last minute.",
+		},
+		[]string{"protocol", "peer_id"},
+	)
+
+	serverCollectors = []prometheus.Collector{
+		serverRequests,
+		serverResponseBytes,
+		serverRateLimitedRequests,
+		serverTopPeerBytes,
+	}
+
+	serverMetricsOnce sync.Once
+)
+
+// serverPeerTraffic aggregates the response traffic of all P2P RPC servers.
+var serverPeerTraffic = newPeerTraffic()
+
+// peerTraffic aggregates per-peer response traffic of all protocols and periodically reports
+// the top peers by traffic of each protocol.
+//
+// Traffic of all protocols is reported at once as the top peers metric needs to be reset before
+// each report so that peers which dropped out of the top peers do not keep their old values.
+type peerTraffic struct {
+	sync.Mutex
+
+	bytes      map[protocol.ID]map[core.PeerID]uint64
+	lastReport time.Time
+}
+
+func (t *peerTraffic) record(protocolID protocol.ID, peerID core.PeerID, now time.Time, size int) {
+	t.Lock()
+	defer t.Unlock()
+
+	peers, ok := t.bytes[protocolID]
+	if !ok {
+		peers = make(map[core.PeerID]uint64)
+		t.bytes[protocolID] = peers
+	}
+	peers[peerID] += uint64(size)
+
+	if now.Sub(t.lastReport) < topPeersInterval {
+		return
+	}
+	t.reportLocked()
+	t.bytes = make(map[protocol.ID]map[core.PeerID]uint64)
+	t.lastReport = now
+}
+
+func (t *peerTraffic) reportLocked() {
+	serverTopPeerBytes.Reset()
+
+	for protocolID, bytes := range t.bytes {
+		peers := make([]core.PeerID, 0, len(bytes))
+		for peerID := range bytes {
+			peers = append(peers, peerID)
+		}
+		sort.Slice(peers, func(i, j int) bool {
+			return bytes[peers[i]] > bytes[peers[j]]
+		})
+		if len(peers) > topPeersCount {
+			peers = peers[:topPeersCount]
+		}
+
+		for _, peerID := range peers {
+			serverTopPeerBytes.With(prometheus.Labels{
+				"protocol": string(protocolID),
+				"peer_id":  peerID.String(),
+			}).Set(float64(bytes[peerID]))
+		}
+	}
+}
+
+func newPeerTraffic() *peerTraffic {
+	return &peerTraffic{
+		bytes:      make(map[protocol.ID]map[core.PeerID]uint64),
+		lastReport: time.Now(),
+	}
+}
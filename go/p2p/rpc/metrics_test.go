@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerTrafficTopPeers(t *testing.T) {
+	require := require.New(t)
+
+	p1, p2 := protocol.ID("/protocol/1"), protocol.ID("/protocol/2")
+	peerID := func(i int) core.PeerID {
+		return core.PeerID(fmt.Sprintf("peer-%d", i))
+	}
+	topPeerBytes := func(protocolID protocol.ID, peerID core.PeerID) float64 {
+		return testutil.ToFloat64(serverTopPeerBytes.With(prometheus.Labels{
+			"protocol": string(protocolID),
+			"peer_id":  peerID.String(),
+		}))
+	}
+
+	serverTopPeerBytes.Reset()
+	traffic := newPeerTraffic()
+	now := time.Now()
+
+	// First interval: more peers than reported on the first protocol.
+	for i := 0; i <= topPeersCount; i++ {
+		traffic.record(p1, peerID(i), now, 100*(i+1))
+	}
+	traffic.record(p2, peerID(0), now, 1)
+	now = now.Add(topPeersInterval)
+	traffic.record(p1, peerID(topPeersCount), now, 1)
+
+	require.Equal(topPeersCount+1, testutil.CollectAndCount(serverTopPeerBytes))
+	require.EqualValues(100*(topPeersCount+1)+1, topPeerBytes(p1, peerID(topPeersCount)))
+	require.EqualValues(1, topPeerBytes(p2, peerID(0)))
+
+	// Second interval: only a single peer on the first protocol, others should be dropped.
+	now = now.Add(topPeersInterval)
+	traffic.record(p1, peerID(42), now, 7)
+
+	require.Equal(1, testutil.CollectAndCount(serverTopPeerBytes), "stale top peers should be dropped")
+	require.EqualValues(7, topPeerBytes(p1, peerID(42)))
+}
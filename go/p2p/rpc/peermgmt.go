@@ -216,6 +216,7 @@ func (mgr *peerManager) RecordSuccess(peerID core.PeerID, latency time.Duration)
 	}
 	ps.successes++
 	ps.recordLatency(latency)
+	mgr.p2p.RecordPeerReputation(peerID, mgr.protocolID, ReputationEventSuccess)
 
 	// Update global stats.
 	if mgr.avgRequestLatency == 0 {
@@ -243,6 +244,7 @@ func (mgr *peerManager) RecordFailure(peerID core.PeerID, latency time.Duration)
 	}
 	ps.failures++
 	ps.recordLatency(latency)
+	mgr.p2p.RecordPeerReputation(peerID, mgr.protocolID, ReputationEventFailure)
 	mgr.unstickPeerLocked(peerID)
 }
 
@@ -250,6 +252,7 @@ func (mgr *peerManager) RecordBadPeer(peerID core.PeerID) {
 	mgr.Lock()
 	defer mgr.Unlock()
 
+	mgr.p2p.RecordPeerReputation(peerID, mgr.protocolID, ReputationEventBadPeer)
 	mgr.p2p.BlockPeer(peerID)
 	mgr.ignoredPeers[peerID] = true
 
@@ -30,6 +30,10 @@ func (t *testP2P) Host() host.Host {
 func (*testP2P) RegisterProtocol(p protocol.ID, min int, total int) {
 }
 
+// RecordPeerReputation implements P2P.
+func (*testP2P) RecordPeerReputation(peerID peer.ID, protocolID protocol.ID, event ReputationEvent) {
+}
+
 func TestWatchUpdates(t *testing.T) {
 	require := require.New(t)
 
@@ -20,6 +20,35 @@ type P2P interface {
 
 	// Host returns the P2P host.
 	Host() core.Host
+
+	// RecordPeerReputation records a reputation event for the given peer and protocol.
+	RecordPeerReputation(peerID core.PeerID, protocolID core.ProtocolID, event ReputationEvent)
+}
+
+// ReputationEvent is a peer reputation event.
+type ReputationEvent uint8
+
+const (
+	// ReputationEventSuccess is the reputation event of a successful protocol interaction.
+	ReputationEventSuccess ReputationEvent = iota + 1
+	// ReputationEventFailure is the reputation event of an unsuccessful protocol interaction.
+	ReputationEventFailure
+	// ReputationEventBadPeer is the reputation event of a malicious protocol interaction.
+	ReputationEventBadPeer
+)
+
+// String returns a string representation of the reputation event.
+func (e ReputationEvent) String() string {
+	switch e {
+	case ReputationEventSuccess:
+		return "success"
+	case ReputationEventFailure:
+		return "failure"
+	case ReputationEventBadPeer:
+		return "bad peer"
+	default:
+		return "[unknown reputation event]"
+	}
 }
 
 // contextKeyPeerAddrInfo is the context key used for storing the peer addr info.
@@ -8,10 +8,12 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	p2pError "github.com/oasisprotocol/oasis-core/go/p2p/error"
 )
 
 const (
@@ -34,10 +36,28 @@ type Server interface {
 	HandleStream(stream network.Stream)
 }
 
+// ServerOptions are RPC server options.
+type ServerOptions struct {
+	limits *ServerLimits
+}
+
+// ServerOption is an RPC server option setter.
+type ServerOption func(opts *ServerOptions)
+
+// WithServerLimits configures the limits enforced by the server.
+//
+// If not set, the limits are taken from the global P2P configuration.
+func WithServerLimits(limits *ServerLimits) ServerOption {
+	return func(opts *ServerOptions) {
+		opts.limits = limits
+	}
+}
+
 type server struct {
 	Service
 
 	protocolID protocol.ID
+	limiter    *serverLimiter
 
 	logger *logging.Logger
 }
@@ -73,17 +93,37 @@ func (s *server) HandleStream(stream network.Stream) {
 		Addrs: []core.Multiaddr{stream.Conn().RemoteMultiaddr()},
 	}
 
-	// Handle request.
-	ctx, cancel := context.WithTimeout(context.Background(), RequestHandleTimeout)
-	ctx = WithPeerAddrInfo(ctx, addr)
-	rsp, err := s.HandleRequest(ctx, request.Method, request.Body)
-	cancel()
+	// Handle request unless rate limited.
+	var (
+		rsp interface{}
+		err error
+	)
+	switch reason := s.limiter.allowRequest(addr.ID, time.Now()); reason {
+	case "":
+		ctx, cancel := context.WithTimeout(context.Background(), RequestHandleTimeout)
+		ctx = WithPeerAddrInfo(ctx, addr)
+		rsp, err = s.HandleRequest(ctx, request.Method, request.Body)
+		cancel()
+	default:
+		serverRateLimitedRequests.With(prometheus.Labels{
+			"protocol": string(s.protocolID),
+			"reason":   reason,
+		}).Inc()
+		err = p2pError.ErrRateLimited
+	}
 
 	// Generate response.
 	var response Response
 	switch err {
 	case nil:
 		response.Ok = cbor.Marshal(rsp)
+
+		now := time.Now()
+		size := len(response.Ok)
+		s.limiter.recordResponse(addr.ID, now, size)
+		serverPeerTraffic.record(s.protocolID, addr.ID, now, size)
+		serverRequests.With(prometheus.Labels{"protocol": string(s.protocolID)}).Inc()
+		serverResponseBytes.With(prometheus.Labels{"protocol": string(s.protocolID)}).Add(float64(size))
 	default:
 		logger.Debug("failed to process request",
 			"err", err,
@@ -110,10 +150,23 @@ func (s *server) HandleStream(stream network.Stream) {
 }
 
 // NewServer creates a new RPC server for the given protocol.
-func NewServer(protocolID protocol.ID, srv Service) Server {
+func NewServer(protocolID protocol.ID, srv Service, opts ...ServerOption) Server {
+	var so ServerOptions
+	for _, opt := range opts {
+		opt(&so)
+	}
+	if so.limits == nil {
+		so.limits = configuredServerLimits(protocolID)
+	}
+
+	serverMetricsOnce.Do(func() {
+		prometheus.MustRegister(serverCollectors...)
+	})
+
 	return &server{
 		Service:    srv,
 		protocolID: protocolID,
+		limiter:    newServerLimiter(so.limits),
 		logger:     logging.GetLogger("p2p/rpc/server").With("protocol", protocolID),
 	}
 }
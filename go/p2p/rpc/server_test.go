@@ -0,0 +1,124 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	p2pConfig "github.com/oasisprotocol/oasis-core/go/p2p/config"
+	p2pError "github.com/oasisprotocol/oasis-core/go/p2p/error"
+)
+
+func TestServerLimits(t *testing.T) {
+	newHost := func() host.Host {
+		listenAddr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/0")
+		require.NoError(t, err, "NewMultiaddr failed")
+
+		host, err := libp2p.New(
+			libp2p.ListenAddrs(listenAddr),
+		)
+		require.NoError(t, err, "libp2p.New failed")
+
+		return host
+	}
+
+	for _, tc := range []struct {
+		name    string
+		limits  *ServerLimits
+		allowed int
+	}{
+		{"Unlimited", &ServerLimits{}, 5},
+		{"Peer requests", &ServerLimits{PeerRequestRate: 0.001, PeerRequestBurst: 2}, 2},
+		{"Requests", &ServerLimits{RequestRate: 0.001, RequestBurst: 3}, 3},
+		{"Peer bytes", &ServerLimits{PeerByteRate: 0.001, PeerByteBurst: 1}, 1},
+		{"Bytes", &ServerLimits{ByteRate: 0.001, ByteBurst: 1}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			server := NewServer(testProtocol, &testService{id: 2}, WithServerLimits(tc.limits))
+			serverHost := newHost()
+			defer serverHost.Close()
+			serverHost.SetStreamHandler(server.Protocol(), server.HandleStream)
+
+			clientHost := newHost()
+			defer clientHost.Close()
+			c := NewClient(clientHost, testProtocol).(*client)
+			listener := &testListener{}
+			c.RegisterListener(listener)
+			request := Request{
+				Method: testMethod,
+				Body:   cbor.Marshal(&testRequest{}),
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := clientHost.Connect(ctx, peer.AddrInfo{
+				ID:    serverHost.ID(),
+				Addrs: serverHost.Addrs(),
+			})
+			require.NoError(err, "Connect failed")
+
+			for i := 0; i < 5; i++ {
+				var rsp testResponse
+				var pf PeerFeedback
+				pf, err = c.timeCall(ctx, serverHost.ID(), &request, &rsp, RequestReadDeadline)
+				switch {
+				case i < tc.allowed:
+					require.NoError(err, "request %d should be served", i)
+					require.Equal(2, rsp.ID)
+				default:
+					require.ErrorIs(err, p2pError.ErrRateLimited, "request %d should be rate limited", i)
+					pf.RecordFailure()
+				}
+			}
+
+			// Callers should be able to tell that the peer is rate limiting them.
+			if tc.allowed < 5 {
+				var pf PeerFeedback
+				pf, err = c.Call(ctx, serverHost.ID(), testMethod, &testRequest{}, &testResponse{})
+				require.ErrorIs(err, p2pError.ErrRateLimited, "call should be rate limited")
+				pf.RecordFailure()
+			}
+
+			// Rate limited requests should not degrade the peer.
+			require.Equal(0, listener.failures)
+		})
+	}
+}
+
+func TestConfiguredServerLimits(t *testing.T) {
+	require := require.New(t)
+
+	defer func(cfg p2pConfig.RPCConfig) {
+		config.GlobalConfig.P2P.RPC = cfg
+	}(config.GlobalConfig.P2P.RPC)
+
+	config.GlobalConfig.P2P.RPC = p2pConfig.RPCConfig{
+		Limits: p2pConfig.RPCLimitsConfig{
+			PeerRequestRate: 10,
+			PeerByteRate:    "1mb",
+		},
+		ProtocolLimits: map[string]p2pConfig.RPCLimitsConfig{
+			"storagesync": {
+				PeerRequestRate: 1,
+				ByteRate:        "10mb",
+			},
+		},
+	}
+
+	limits := configuredServerLimits("/oasis/chain/storagesync/8000000000000000000000000000000000000000000000000000000000000000/1")
+	require.Equal(&ServerLimits{PeerRequestRate: 1, ByteRate: 10 << 20}, limits, "per-protocol limits should be used")
+
+	limits = configuredServerLimits("/oasis/chain/txsync/8000000000000000000000000000000000000000000000000000000000000000/1")
+	require.Equal(&ServerLimits{PeerRequestRate: 10, PeerByteRate: 1 << 20}, limits, "default limits should be used")
+}
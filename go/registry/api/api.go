@@ -20,6 +20,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
@@ -169,6 +170,13 @@ type Backend interface {
 	// GetEntity gets an entity by ID.
 	GetEntity(context.Context, *IDQuery) (*entity.Entity, error)
 
+	// GetEntityWithProof gets an entity by ID together with a proof that can
+	// be used to verify it against a light block.
+	//
+	// In case the entity does not exist, the returned entity is nil and the
+	// proof proves its absence.
+	GetEntityWithProof(context.Context, *IDQuery) (*EntityWithProof, error)
+
 	// GetEntities gets a list of all registered entities.
 	GetEntities(context.Context, int64) ([]*entity.Entity, error)
 
@@ -179,6 +187,13 @@ type Backend interface {
 	// GetNode gets a node by ID.
 	GetNode(context.Context, *IDQuery) (*node.Node, error)
 
+	// GetNodeWithProof gets a node by ID together with a proof that can be
+	// used to verify it against a light block.
+	//
+	// In case the node does not exist, the returned node is nil and the proof
+	// proves its absence.
+	GetNodeWithProof(context.Context, *IDQuery) (*NodeWithProof, error)
+
 	// GetNodeStatus returns a node's status.
 	GetNodeStatus(context.Context, *IDQuery) (*NodeStatus, error)
 
@@ -235,6 +250,24 @@ type IDQuery struct {
 	ID     signature.PublicKey `json:"id"`
 }
 
+// EntityWithProof is an entity descriptor together with a proof of it being
+// part of the consensus state.
+type EntityWithProof struct {
+	// Entity is the entity descriptor.
+	Entity *entity.Entity `json:"entity,omitempty"`
+	// Proof is the proof of the entity descriptor.
+	Proof *proof.StateProof `json:"proof"`
+}
+
+// NodeWithProof is a node descriptor together with a proof of it being part
+// of the consensus state.
+type NodeWithProof struct {
+	// Node is the node descriptor.
+	Node *node.Node `json:"node,omitempty"`
+	// Proof is the proof of the node descriptor.
+	Proof *proof.StateProof `json:"proof"`
+}
+
 // NamespaceQuery is a registry query by namespace (Runtime ID).
 type NamespaceQuery struct {
 	Height int64            `json:"height"`
@@ -1441,6 +1474,9 @@ type ConsensusParameters struct {
 
 	// MaxRuntimeDeployments is the maximum number of runtime deployments.
 	MaxRuntimeDeployments uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// EnableEntityMetadata is true iff entity descriptors may contain metadata.
+	EnableEntityMetadata bool `json:"enable_entity_metadata,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed registry consensus parameter changes.
@@ -1465,6 +1501,9 @@ type ConsensusParameterChanges struct {
 
 	// MaxRuntimeDeployments is the new maximum number of runtime deployments.
 	MaxRuntimeDeployments *uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// EnableEntityMetadata is the new enable entity metadata flag.
+	EnableEntityMetadata *bool `json:"enable_entity_metadata,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -1490,6 +1529,9 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxRuntimeDeployments != nil {
 		params.MaxRuntimeDeployments = *c.MaxRuntimeDeployments
 	}
+	if c.EnableEntityMetadata != nil {
+		params.EnableEntityMetadata = *c.EnableEntityMetadata
+	}
 	return nil
 }
 
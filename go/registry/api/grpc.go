@@ -17,10 +17,14 @@ var (
 
 	// methodGetEntity is the GetEntity method.
 	methodGetEntity = serviceName.NewMethod("GetEntity", IDQuery{})
+	// methodGetEntityWithProof is the GetEntityWithProof method.
+	methodGetEntityWithProof = serviceName.NewMethod("GetEntityWithProof", IDQuery{})
 	// methodGetEntities is the GetEntities method.
 	methodGetEntities = serviceName.NewMethod("GetEntities", int64(0))
 	// methodGetNode is the GetNode method.
 	methodGetNode = serviceName.NewMethod("GetNode", IDQuery{})
+	// methodGetNodeWithProof is the GetNodeWithProof method.
+	methodGetNodeWithProof = serviceName.NewMethod("GetNodeWithProof", IDQuery{})
 	// methodGetNodeByConsensusAddress is the GetNodeByConsensusAddress method.
 	methodGetNodeByConsensusAddress = serviceName.NewMethod("GetNodeByConsensusAddress", ConsensusAddressQuery{})
 	// methodGetNodeStatus is the GetNodeStatus method.
@@ -58,6 +62,10 @@ var (
 				MethodName: methodGetEntity.ShortName(),
 				Handler:    handlerGetEntity,
 			},
+			{
+				MethodName: methodGetEntityWithProof.ShortName(),
+				Handler:    handlerGetEntityWithProof,
+			},
 			{
 				MethodName: methodGetEntities.ShortName(),
 				Handler:    handlerGetEntities,
@@ -66,6 +74,10 @@ var (
 				MethodName: methodGetNode.ShortName(),
 				Handler:    handlerGetNode,
 			},
+			{
+				MethodName: methodGetNodeWithProof.ShortName(),
+				Handler:    handlerGetNodeWithProof,
+			},
 			{
 				MethodName: methodGetNodeByConsensusAddress.ShortName(),
 				Handler:    handlerGetNodeByConsensusAddress,
@@ -152,6 +164,29 @@ func handlerGetEntity(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetEntityWithProof(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query IDQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetEntityWithProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEntityWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetEntityWithProof(ctx, req.(*IDQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetEntities(
 	srv interface{},
 	ctx context.Context,
@@ -198,6 +233,29 @@ func handlerGetNode(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetNodeWithProof(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query IDQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetNodeWithProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetNodeWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetNodeWithProof(ctx, req.(*IDQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetNodeByConsensusAddress(
 	srv interface{},
 	ctx context.Context,
@@ -538,6 +596,14 @@ func (c *registryClient) GetEntity(ctx context.Context, query *IDQuery) (*entity
 	return &rsp, nil
 }
 
+func (c *registryClient) GetEntityWithProof(ctx context.Context, query *IDQuery) (*EntityWithProof, error) {
+	var rsp EntityWithProof
+	if err := c.conn.Invoke(ctx, methodGetEntityWithProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) GetEntities(ctx context.Context, height int64) ([]*entity.Entity, error) {
 	var rsp []*entity.Entity
 	if err := c.conn.Invoke(ctx, methodGetEntities.FullName(), height, &rsp); err != nil {
@@ -589,6 +655,14 @@ func (c *registryClient) GetNode(ctx context.Context, query *IDQuery) (*node.Nod
 	return &rsp, nil
 }
 
+func (c *registryClient) GetNodeWithProof(ctx context.Context, query *IDQuery) (*NodeWithProof, error) {
+	var rsp NodeWithProof
+	if err := c.conn.Invoke(ctx, methodGetNodeWithProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) GetNodeByConsensusAddress(ctx context.Context, query *ConsensusAddressQuery) (*node.Node, error) {
 	var rsp node.Node
 	if err := c.conn.Invoke(ctx, methodGetNodeByConsensusAddress.FullName(), query, &rsp); err != nil {
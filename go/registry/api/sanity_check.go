@@ -36,7 +36,8 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.GasCosts == nil &&
 		c.MaxNodeExpiration == nil &&
 		c.EnableRuntimeGovernanceModels == nil &&
-		c.TEEFeatures == nil {
+		c.TEEFeatures == nil &&
+		c.EnableEntityMetadata == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
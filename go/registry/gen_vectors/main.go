@@ -46,22 +46,34 @@ func main() {
 			// Generate register entity transactions.
 			for _, v := range []uint16{entity.LatestDescriptorVersion} {
 				for _, numNodes := range []int{0, 1, 2, 5} {
-					entitySigner := memorySigner.NewTestSigner("oasis-core registry test vectors: RegisterEntity signer")
-					ent := entity.Entity{
-						Versioned: cbor.NewVersioned(v),
-						ID:        entitySigner.Public(),
+					for _, md := range []*entity.Metadata{
+						nil,
+						{Name: "Entity"},
+						{
+							Name:    "Entity",
+							URL:     "https://example.com",
+							Email:   "entity@example.com",
+							Keybase: "entity",
+						},
+					} {
+						entitySigner := memorySigner.NewTestSigner("oasis-core registry test vectors: RegisterEntity signer")
+						ent := entity.Entity{
+							Versioned: cbor.NewVersioned(v),
+							ID:        entitySigner.Public(),
+							Metadata:  md,
+						}
+						for i := 0; i < numNodes; i++ {
+							nodeSigner := memorySigner.NewTestSigner(fmt.Sprintf("oasis core registry test vectors: node signer %d", i))
+							ent.Nodes = append(ent.Nodes, nodeSigner.Public())
+						}
+						sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+						if err != nil {
+							panic(err)
+						}
+						tx := registry.NewRegisterEntityTx(nonce, fee, sigEnt)
+						valid := valideRegisterEntity(v)
+						vectors = append(vectors, testvectors.MakeTestVectorWithSigner("RegisterEntity", tx, valid, entitySigner))
 					}
-					for i := 0; i < numNodes; i++ {
-						nodeSigner := memorySigner.NewTestSigner(fmt.Sprintf("oasis core registry test vectors: node signer %d", i))
-						ent.Nodes = append(ent.Nodes, nodeSigner.Public())
-					}
-					sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
-					if err != nil {
-						panic(err)
-					}
-					tx := registry.NewRegisterEntityTx(nonce, fee, sigEnt)
-					valid := valideRegisterEntity(v)
-					vectors = append(vectors, testvectors.MakeTestVectorWithSigner("RegisterEntity", tx, valid, entitySigner))
 				}
 			}
 
@@ -249,6 +249,25 @@ func testRegistryEntityNodes( // nolint: gocyclo
 			require.EqualValues(v.Entity, ent, "retrieved entity")
 		}
 
+		for _, v := range entities {
+			var entWithProof *api.EntityWithProof
+			entWithProof, err = backend.GetEntityWithProof(ctx, &api.IDQuery{ID: v.Entity.ID, Height: consensusAPI.HeightLatest})
+			require.NoError(err, "GetEntityWithProof")
+			require.NotNil(entWithProof.Proof, "GetEntityWithProof - proof")
+
+			// The proven state may precede the registration.
+			var ent *entity.Entity
+			ent, err = backend.GetEntity(ctx, &api.IDQuery{ID: v.Entity.ID, Height: entWithProof.Proof.Height})
+			switch err {
+			case nil:
+				require.EqualValues(ent, entWithProof.Entity, "GetEntityWithProof - entity")
+			case api.ErrNoSuchEntity:
+				require.Nil(entWithProof.Entity, "GetEntityWithProof - entity")
+			default:
+				require.NoError(err, "GetEntity")
+			}
+		}
+
 		var registeredEntities []*entity.Entity
 		registeredEntities, err = backend.GetEntities(ctx, consensusAPI.HeightLatest)
 		require.NoError(err, "GetEntities")
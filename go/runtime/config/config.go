@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/bytesize"
 	tpConfig "github.com/oasisprotocol/oasis-core/go/runtime/txpool/config"
 )
 
@@ -90,6 +92,46 @@ type Config struct {
 	// Number of epochs before runtime activation epoch when to start the runtime to warm it up and
 	// prepare any required attestations. Zero disables pre-warming.
 	PreWarmEpochs uint64 `yaml:"pre_warm_epochs,omitempty"`
+
+	// Runtime local storage configuration.
+	LocalStorage LocalStorageConfig `yaml:"local_storage,omitempty"`
+}
+
+// LocalStorageConfig is the runtime local storage configuration structure.
+type LocalStorageConfig struct {
+	// Default quota applied to all runtimes without an explicit quota.
+	Quota LocalStorageQuotaConfig `yaml:"quota,omitempty"`
+	// Runtime ID -> quota overriding the default quota.
+	Runtimes map[string]LocalStorageQuotaConfig `yaml:"runtimes,omitempty"`
+}
+
+// LocalStorageQuotaConfig is the runtime local storage quota configuration structure.
+//
+// Sizes can be given with a unit suffix (e.g., 64kb, 10mb). An empty size means no limit.
+type LocalStorageQuotaConfig struct {
+	// Maximum size of a single key.
+	MaxKeySize string `yaml:"max_key_size,omitempty"`
+	// Maximum size of a single value.
+	MaxValueSize string `yaml:"max_value_size,omitempty"`
+	// Maximum total size of all keys and values.
+	MaxTotalSize string `yaml:"max_total_size,omitempty"`
+}
+
+// Validate validates the local storage quota configuration.
+func (q *LocalStorageQuotaConfig) Validate() error {
+	for _, v := range []struct {
+		name string
+		size string
+	}{
+		{"max_key_size", q.MaxKeySize},
+		{"max_value_size", q.MaxValueSize},
+		{"max_total_size", q.MaxTotalSize},
+	} {
+		if err := bytesize.Validate(v.size); err != nil {
+			return fmt.Errorf("%s: %w", v.name, err)
+		}
+	}
+	return nil
 }
 
 // HistoryPrunerConfig is the history pruner configuration structure.
@@ -136,6 +178,19 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("unknown runtime history pruner strategy: %s", c.Environment)
 	}
 
+	if err := c.LocalStorage.Quota.Validate(); err != nil {
+		return fmt.Errorf("local_storage.quota: %w", err)
+	}
+	for id, quota := range c.LocalStorage.Runtimes {
+		var ns common.Namespace
+		if err := ns.UnmarshalHex(id); err != nil {
+			return fmt.Errorf("local_storage.runtimes: malformed runtime ID '%s': %w", id, err)
+		}
+		if err := quota.Validate(); err != nil {
+			return fmt.Errorf("local_storage.runtimes.%s: %w", id, err)
+		}
+	}
+
 	return nil
 }
 
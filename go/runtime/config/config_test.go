@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorageQuotaValidate(t *testing.T) {
+	require := require.New(t)
+
+	quota := LocalStorageQuotaConfig{MaxTotalSize: "10 MB"}
+	require.NoError(quota.Validate(), "valid quota should be accepted")
+	quota = LocalStorageQuotaConfig{MaxValueSize: "1.5mb"}
+	require.Error(quota.Validate(), "malformed quota should be rejected")
+
+	cfg := DefaultConfig()
+	cfg.LocalStorage.Quota.MaxKeySize = "64kbb"
+	require.Error(cfg.Validate(), "malformed default quota should be rejected")
+
+	cfg = DefaultConfig()
+	cfg.LocalStorage.Runtimes = map[string]LocalStorageQuotaConfig{
+		"8000000000000000000000000000000000000000000000000000000000000000": {MaxTotalSize: "1 gigabyte"},
+	}
+	require.Error(cfg.Validate(), "malformed per-runtime quota should be rejected")
+}
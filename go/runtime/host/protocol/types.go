@@ -263,6 +263,8 @@ type RuntimeRPCCallRequest struct {
 type RuntimeRPCCallResponse struct {
 	// Response.
 	Response []byte `json:"response"`
+	// AuditMetadata is the request metadata disclosed by the runtime for access auditing.
+	AuditMetadata cbor.RawMessage `json:"audit_metadata,omitempty"`
 }
 
 // RuntimeLocalRPCCallRequest is a worker local RPC call request message body.
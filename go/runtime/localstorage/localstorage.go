@@ -7,36 +7,86 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/dgraph-io/badger/v3/options"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	cmnErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 )
 
+// ModuleName is the local storage module name.
+const ModuleName = "runtime/localstorage"
+
 var (
 	errInvalidKey = errors.New("invalid local storage key")
 
+	// ErrQuotaExceeded is the error returned when a write would exceed the
+	// local storage quota configured for the runtime.
+	ErrQuotaExceeded = cmnErrors.New(ModuleName, 1, "localstorage: quota exceeded")
+
 	_ LocalStorage = (*localStorage)(nil)
 )
 
+// Quota is the per-runtime local storage quota. A zero value for any of the
+// limits means that the given limit is not enforced.
+type Quota struct {
+	// MaxKeySize is the maximum size of a single key (in bytes).
+	MaxKeySize uint64
+	// MaxValueSize is the maximum size of a single value (in bytes).
+	MaxValueSize uint64
+	// MaxTotalSize is the maximum total size of all stored keys and values
+	// (in bytes).
+	MaxTotalSize uint64
+}
+
+// Usage is the local storage usage.
+type Usage struct {
+	// Keys is the number of stored keys.
+	Keys uint64 `json:"keys"`
+	// Size is the total size of all stored keys and values (in bytes).
+	Size uint64 `json:"size"`
+}
+
 // LocalStorage is the untrusted local storage interface.
 type LocalStorage interface {
 	// Get retrieves a previously stored value under the given key.
 	Get(key []byte) ([]byte, error)
 
 	// Set sets a key to a specific value.
+	//
+	// In case the write would exceed the configured quota, ErrQuotaExceeded
+	// is returned and nothing is written.
 	Set(key, value []byte) error
 
+	// Iterate calls the given function for each stored key/value pair in key
+	// order. Iteration stops at the first error returned by the function.
+	//
+	// Writes are blocked during iteration, so the function must not modify
+	// local storage.
+	Iterate(fn func(key, value []byte) error) error
+
+	// Wipe removes all stored key/value pairs.
+	Wipe() error
+
+	// Usage returns the current local storage usage.
+	Usage() Usage
+
 	// Stop stops local storage.
 	Stop()
 }
 
 type localStorage struct {
+	sync.Mutex
+
 	logger *logging.Logger
 
+	quota Quota
+	usage Usage
+
 	db *badger.DB
 	gc *cmnBadger.GCWorker
 }
@@ -76,10 +126,44 @@ func (s *localStorage) Set(key, value []byte) error {
 	if len(key) == 0 {
 		return errInvalidKey
 	}
+	if s.quota.MaxKeySize > 0 && uint64(len(key)) > s.quota.MaxKeySize {
+		return fmt.Errorf("%w: key size %d exceeds limit %d", ErrQuotaExceeded, len(key), s.quota.MaxKeySize)
+	}
+	if s.quota.MaxValueSize > 0 && uint64(len(value)) > s.quota.MaxValueSize {
+		return fmt.Errorf("%w: value size %d exceeds limit %d", ErrQuotaExceeded, len(value), s.quota.MaxValueSize)
+	}
+
+	// Serialize writes so that usage accounting remains consistent.
+	s.Lock()
+	defer s.Unlock()
 
+	usage := s.usage
 	if err := s.db.Update(func(tx *badger.Txn) error {
+		item, txErr := tx.Get(key)
+		switch txErr {
+		case nil:
+			usage.Size -= uint64(len(key)) + uint64(item.ValueSize())
+		case badger.ErrKeyNotFound:
+			usage.Keys++
+		default:
+			return txErr
+		}
+		usage.Size += uint64(len(key)) + uint64(len(value))
+
+		if s.quota.MaxTotalSize > 0 && usage.Size > s.quota.MaxTotalSize {
+			return fmt.Errorf("%w: total size %d exceeds limit %d", ErrQuotaExceeded, usage.Size, s.quota.MaxTotalSize)
+		}
+
 		return tx.Set(key, value)
 	}); err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			s.logger.Warn("local storage quota exceeded",
+				"err", err,
+				"key", hex.EncodeToString(key),
+			)
+			return err
+		}
+
 		s.logger.Error("failed put",
 			"err", err,
 			"key", hex.EncodeToString(key),
@@ -87,10 +171,74 @@ func (s *localStorage) Set(key, value []byte) error {
 		)
 		return err
 	}
+	s.usage = usage
+
+	return nil
+}
+
+func (s *localStorage) Iterate(fn func(key, value []byte) error) error {
+	// Block writes so that iteration observes the same state as usage accounting.
+	s.Lock()
+	defer s.Unlock()
+
+	return s.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if err = fn(item.KeyCopy(nil), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *localStorage) Wipe() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.db.DropAll(); err != nil {
+		s.logger.Error("failed to wipe local storage",
+			"err", err,
+		)
+		return err
+	}
+	s.usage = Usage{}
 
 	return nil
 }
 
+func (s *localStorage) Usage() Usage {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.usage
+}
+
+func (s *localStorage) computeUsage() error {
+	return s.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		var usage Usage
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			usage.Keys++
+			usage.Size += uint64(len(item.Key())) + uint64(item.ValueSize())
+		}
+		s.usage = usage
+		return nil
+	})
+}
+
 func (s *localStorage) Stop() {
 	s.gc.Close()
 	if err := s.db.Close(); err != nil {
@@ -102,9 +250,10 @@ func (s *localStorage) Stop() {
 }
 
 // New creates new untrusted local storage.
-func New(dataDir, fn string, runtimeID common.Namespace) (LocalStorage, error) {
+func New(dataDir, fn string, runtimeID common.Namespace, quota Quota) (LocalStorage, error) {
 	s := &localStorage{
 		logger: logging.GetLogger("runtime/localstorage").With("runtime_id", runtimeID),
+		quota:  quota,
 	}
 
 	opts := badger.DefaultOptions(filepath.Join(dataDir, fn))
@@ -116,6 +265,10 @@ func New(dataDir, fn string, runtimeID common.Namespace) (LocalStorage, error) {
 	if s.db, err = badger.Open(opts); err != nil {
 		return nil, fmt.Errorf("failed to open local storage database: %w", err)
 	}
+	if err = s.computeUsage(); err != nil {
+		_ = s.db.Close()
+		return nil, fmt.Errorf("failed to compute local storage usage: %w", err)
+	}
 	s.gc = cmnBadger.NewGCWorker(s.logger, s.db)
 
 	// TODO: The file format could be versioned, but it's not like this
@@ -0,0 +1,74 @@
+package localstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+func TestLocalStorageQuota(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	quota := Quota{
+		MaxKeySize:   8,
+		MaxValueSize: 16,
+		MaxTotalSize: 32,
+	}
+	ls, err := New(dataDir, "local-storage.badger.db", common.Namespace{}, quota)
+	require.NoError(err, "New")
+
+	err = ls.Set([]byte("key1"), []byte("value1"))
+	require.NoError(err, "Set")
+	require.Equal(Usage{Keys: 1, Size: 10}, ls.Usage())
+
+	err = ls.Set([]byte("too long key"), []byte("value"))
+	require.ErrorIs(err, ErrQuotaExceeded, "Set should fail with too long key")
+	module, code := errors.Code(err)
+	require.Equal(ModuleName, module)
+	require.EqualValues(1, code)
+
+	err = ls.Set([]byte("key2"), []byte("value that is too long"))
+	require.ErrorIs(err, ErrQuotaExceeded, "Set should fail with too long value")
+
+	// Overwriting a key should only account for the difference.
+	err = ls.Set([]byte("key1"), []byte("new value1"))
+	require.NoError(err, "Set")
+	require.Equal(Usage{Keys: 1, Size: 14}, ls.Usage())
+
+	err = ls.Set([]byte("key2"), []byte("value2"))
+	require.NoError(err, "Set")
+	require.Equal(Usage{Keys: 2, Size: 24}, ls.Usage())
+
+	err = ls.Set([]byte("key3"), []byte("value3"))
+	require.ErrorIs(err, ErrQuotaExceeded, "Set should fail when total size is exceeded")
+	require.Equal(Usage{Keys: 2, Size: 24}, ls.Usage())
+	value, err := ls.Get([]byte("key3"))
+	require.NoError(err, "Get")
+	require.Nil(value, "rejected write should not be persisted")
+
+	var keys []string
+	err = ls.Iterate(func(key, value []byte) error {
+		keys = append(keys, string(key))
+		return nil
+	})
+	require.NoError(err, "Iterate")
+	require.Equal([]string{"key1", "key2"}, keys)
+
+	// Usage should be recomputed after reopening.
+	ls.Stop()
+	ls, err = New(dataDir, "local-storage.badger.db", common.Namespace{}, quota)
+	require.NoError(err, "New")
+	defer ls.Stop()
+	require.Equal(Usage{Keys: 2, Size: 24}, ls.Usage())
+
+	err = ls.Wipe()
+	require.NoError(err, "Wipe")
+	require.Equal(Usage{}, ls.Usage())
+	value, err = ls.Get([]byte("key1"))
+	require.NoError(err, "Get")
+	require.Nil(value, "Wipe should remove all keys")
+}
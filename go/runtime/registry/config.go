@@ -27,6 +27,7 @@ import (
 	hostProtocol "github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	hostSandbox "github.com/oasisprotocol/oasis-core/go/runtime/host/sandbox"
 	hostSgx "github.com/oasisprotocol/oasis-core/go/runtime/host/sgx"
+	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
 )
 
 const (
@@ -46,6 +47,28 @@ type RuntimeConfig struct {
 
 	// History configures the runtime history keeper.
 	History history.Config
+
+	// LocalStorageQuota is the default runtime local storage quota.
+	LocalStorageQuota localstorage.Quota
+
+	// LocalStorageQuotas contains per-runtime local storage quotas which override the default.
+	LocalStorageQuotas map[common.Namespace]localstorage.Quota
+}
+
+// LocalStorageQuotaFor returns the local storage quota for the given runtime.
+func (cfg *RuntimeConfig) LocalStorageQuotaFor(id common.Namespace) localstorage.Quota {
+	if quota, ok := cfg.LocalStorageQuotas[id]; ok {
+		return quota
+	}
+	return cfg.LocalStorageQuota
+}
+
+func newLocalStorageQuota(cfg *rtConfig.LocalStorageQuotaConfig) localstorage.Quota {
+	return localstorage.Quota{
+		MaxKeySize:   uint64(config.ParseSizeInBytes(cfg.MaxKeySize)),
+		MaxValueSize: uint64(config.ParseSizeInBytes(cfg.MaxValueSize)),
+		MaxTotalSize: uint64(config.ParseSizeInBytes(cfg.MaxTotalSize)),
+	}
 }
 
 // Runtimes returns a list of configured runtimes.
@@ -310,6 +333,18 @@ func newConfig(dataDir string, commonStore *persistent.CommonStore, consensus co
 		cfg.History.PruneInterval = minPruneInterval
 	}
 
+	// Configure local storage quotas.
+	cfg.LocalStorageQuota = newLocalStorageQuota(&config.GlobalConfig.Runtime.LocalStorage.Quota)
+	cfg.LocalStorageQuotas = make(map[common.Namespace]localstorage.Quota)
+	for idStr, quotaCfg := range config.GlobalConfig.Runtime.LocalStorage.Runtimes {
+		var id common.Namespace
+		if err := id.UnmarshalHex(idStr); err != nil {
+			return nil, fmt.Errorf("runtime/registry: malformed local storage quota runtime ID '%s': %w", idStr, err)
+		}
+		quotaCfg := quotaCfg
+		cfg.LocalStorageQuotas[id] = newLocalStorageQuota(&quotaCfg)
+	}
+
 	return &cfg, nil
 }
 
@@ -540,7 +540,7 @@ func newRuntime(
 	}
 
 	// Create runtime-specific local storage backend.
-	localStorage, err := localstorage.New(rtDataDir, LocalStorageFile, id)
+	localStorage, err := localstorage.New(rtDataDir, LocalStorageFile, id, cfg.LocalStorageQuotaFor(id))
 	if err != nil {
 		return nil, fmt.Errorf("runtime/registry: cannot create local storage for runtime %s: %w", id, err)
 	}
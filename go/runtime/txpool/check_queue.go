@@ -62,6 +62,36 @@ func (cq *checkTxQueue) pop() []*PendingCheckTransaction {
 	return batch
 }
 
+func (cq *checkTxQueue) getAll() []*PendingCheckTransaction {
+	cq.l.Lock()
+	defer cq.l.Unlock()
+
+	result := make([]*PendingCheckTransaction, 0, cq.txs.Len())
+	for i := 0; i < cq.txs.Len(); i++ {
+		result = append(result, cq.txs.At(i))
+	}
+	return result
+}
+
+// removeFunc removes all transactions for which the given function returns true and returns
+// the removed transactions.
+func (cq *checkTxQueue) removeFunc(f func(*PendingCheckTransaction) bool) []*PendingCheckTransaction {
+	cq.l.Lock()
+	defer cq.l.Unlock()
+
+	var removed []*PendingCheckTransaction
+	for i := 0; i < cq.txs.Len(); {
+		pct := cq.txs.At(i)
+		if !f(pct) {
+			i++
+			continue
+		}
+		cq.txs.Remove(i)
+		removed = append(removed, pct)
+	}
+	return removed
+}
+
 func (cq *checkTxQueue) size() int {
 	cq.l.Lock()
 	defer cq.l.Unlock()
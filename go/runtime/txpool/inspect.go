@@ -0,0 +1,151 @@
+package txpool
+
+import (
+	"errors"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+const (
+	// QueueCheck is the name of the queue holding transactions pending checks.
+	QueueCheck = "check"
+	// QueueLocal is the name of the queue holding checked transactions from local clients.
+	QueueLocal = "local"
+	// QueueMain is the name of the queue holding checked transactions.
+	QueueMain = "main"
+	// QueueRoothashIncoming is the name of the queue holding transactions from roothash
+	// incoming messages.
+	QueueRoothashIncoming = "roothash_incoming"
+)
+
+// ErrTransactionEvicted is the error returned when a transaction is evicted from the transaction
+// pool while its submitter is waiting for checks to complete.
+var ErrTransactionEvicted = errors.New("txpool: transaction evicted")
+
+// TransactionInfo is information about a transaction queued in the transaction pool.
+type TransactionInfo struct {
+	// Hash is the transaction hash.
+	Hash hash.Hash `json:"hash"`
+	// Size is the size of the raw transaction in bytes.
+	Size int `json:"size"`
+	// Queue is the name of the queue holding the transaction.
+	Queue string `json:"queue"`
+	// FirstSeen is the time the transaction was first seen. It is the zero time for transactions
+	// not submitted directly to this node.
+	FirstSeen time.Time `json:"first_seen"`
+	// Recheck is true iff the transaction is pending checks after having already passed them.
+	Recheck bool `json:"recheck,omitempty"`
+
+	// Priority is the transaction priority as specified by the runtime (main queue only).
+	Priority uint64 `json:"priority,omitempty"`
+	// Sender is the transaction sender identifier as specified by the runtime (main queue only).
+	Sender []byte `json:"sender,omitempty"`
+	// SenderSeq is the per-sender sequence number as specified by the runtime (main queue only).
+	SenderSeq uint64 `json:"sender_seq,omitempty"`
+}
+
+// Age returns the time elapsed since the transaction was first seen, or zero if unknown.
+func (ti *TransactionInfo) Age(now time.Time) time.Duration {
+	if ti.FirstSeen.IsZero() {
+		return 0
+	}
+	return now.Sub(ti.FirstSeen)
+}
+
+func newTransactionInfo(tx *TxQueueMeta, queue string) *TransactionInfo {
+	return &TransactionInfo{
+		Hash:      tx.Hash(),
+		Size:      tx.Size(),
+		Queue:     queue,
+		FirstSeen: tx.FirstSeen(),
+	}
+}
+
+func (t *txPool) GetTransactions() []*TransactionInfo {
+	var txs []*TransactionInfo
+	for _, pct := range t.checkTxQueue.getAll() {
+		ti := newTransactionInfo(pct.TxQueueMeta, QueueCheck)
+		ti.Recheck = pct.flags.isRecheck()
+		txs = append(txs, ti)
+	}
+	for _, tx := range t.localQueue.GetTxsToPublish() {
+		txs = append(txs, newTransactionInfo(tx, QueueLocal))
+	}
+	for _, tx := range t.mainQueue.inner.getAll() {
+		ti := newTransactionInfo(&tx.TxQueueMeta, QueueMain)
+		ti.Priority = tx.priority
+		ti.Sender = []byte(tx.sender)
+		ti.SenderSeq = tx.senderSeq
+		txs = append(txs, ti)
+	}
+	for _, tx := range t.rimQueue.getAll() {
+		txs = append(txs, newTransactionInfo(tx, QueueRoothashIncoming))
+	}
+	return txs
+}
+
+func (t *txPool) GetTransaction(h hash.Hash) *TransactionInfo {
+	for _, ti := range t.GetTransactions() {
+		if ti.Hash.Equal(&h) {
+			return ti
+		}
+	}
+	return nil
+}
+
+func (t *txPool) EvictTransactions(cond func(*TransactionInfo) bool) []*TransactionInfo {
+	var (
+		evicted    []*TransactionInfo
+		evictLocal []hash.Hash
+		evictMain  []hash.Hash
+	)
+
+	// Transactions pending checks are removed from the check queue and their submitters (if any)
+	// are notified by closing the notification channel.
+	for _, pct := range t.checkTxQueue.removeFunc(func(pct *PendingCheckTransaction) bool {
+		ti := newTransactionInfo(pct.TxQueueMeta, QueueCheck)
+		ti.Recheck = pct.flags.isRecheck()
+		if !cond(ti) {
+			return false
+		}
+		evicted = append(evicted, ti)
+		return true
+	}) {
+		if pct.notifyCh != nil {
+			close(pct.notifyCh)
+			pct.notifyCh = nil
+		}
+	}
+	pendingCheckSize.With(t.getMetricLabels()).Set(float64(t.PendingCheckSize()))
+
+	// Transactions from roothash incoming messages are managed by the roothash service and
+	// cannot be evicted.
+	for _, ti := range t.GetTransactions() {
+		if !cond(ti) {
+			continue
+		}
+		switch ti.Queue {
+		case QueueLocal:
+			evictLocal = append(evictLocal, ti.Hash)
+		case QueueMain:
+			evictMain = append(evictMain, ti.Hash)
+		default:
+			continue
+		}
+		evicted = append(evicted, ti)
+	}
+	t.localQueue.HandleTxsUsed(evictLocal)
+	t.mainQueue.HandleTxsUsed(evictMain)
+
+	// Evicted transactions are deliberately kept in the seen cache so that they are not
+	// immediately re-added when gossiped again by peers.
+	for _, ti := range evicted {
+		t.logger.Info("evicted transaction from the transaction pool",
+			"tx_hash", ti.Hash,
+			"queue", ti.Queue,
+		)
+	}
+
+	return evicted
+}
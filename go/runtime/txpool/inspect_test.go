@@ -0,0 +1,95 @@
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/txpool/config"
+)
+
+func TestInspectAndEvict(t *testing.T) {
+	require := require.New(t)
+
+	pool, err := New(common.Namespace{}, config.Config{
+		MaxPoolSize:          10,
+		MaxLastSeenCacheSize: 10,
+		MaxCheckTxBatchSize:  10,
+	}, nil, nil, nil)
+	require.NoError(err, "New")
+	tp := pool.(*txPool)
+
+	now := time.Now()
+	newTx := func(raw string, age time.Duration) *TxQueueMeta {
+		return &TxQueueMeta{
+			raw:       []byte(raw),
+			hash:      hash.NewFromBytes([]byte(raw)),
+			firstSeen: now.Add(-age),
+		}
+	}
+
+	checkTx := newTx("check", time.Minute)
+	notifyCh := make(chan *protocol.CheckTxResult, 1)
+	err = tp.checkTxQueue.add(&PendingCheckTransaction{TxQueueMeta: checkTx, notifyCh: notifyCh})
+	require.NoError(err, "add to check queue")
+
+	localTx := newTx("local", time.Second)
+	err = tp.localQueue.OfferChecked(localTx, nil)
+	require.NoError(err, "OfferChecked local")
+
+	mainTx := newTx("main", time.Hour)
+	err = tp.mainQueue.OfferChecked(mainTx, &protocol.CheckTxMetadata{
+		Priority:  5,
+		Sender:    []byte("sender"),
+		SenderSeq: 1,
+	})
+	require.NoError(err, "OfferChecked main")
+
+	tp.rimQueue.Load([]*message.IncomingMessage{{Data: []byte("incoming")}})
+
+	txs := tp.GetTransactions()
+	require.Len(txs, 4)
+	queues := make(map[string]*TransactionInfo)
+	for _, ti := range txs {
+		queues[ti.Queue] = ti
+	}
+	require.Equal(checkTx.Hash(), queues[QueueCheck].Hash)
+	require.Equal(localTx.Hash(), queues[QueueLocal].Hash)
+	require.Equal(mainTx.Hash(), queues[QueueMain].Hash)
+	require.EqualValues(5, queues[QueueMain].Priority)
+	require.Equal([]byte("sender"), queues[QueueMain].Sender)
+	require.EqualValues(len("incoming"), queues[QueueRoothashIncoming].Size)
+	require.Zero(queues[QueueRoothashIncoming].Age(now))
+
+	ti := tp.GetTransaction(mainTx.Hash())
+	require.NotNil(ti, "GetTransaction")
+	require.Equal(QueueMain, ti.Queue)
+	require.Nil(tp.GetTransaction(hash.NewFromBytes([]byte("missing"))), "GetTransaction for unknown tx")
+
+	// Evict by age.
+	evicted := tp.EvictTransactions(func(ti *TransactionInfo) bool {
+		return ti.Age(now) >= time.Minute
+	})
+	require.Len(evicted, 2)
+	require.Nil(tp.GetTransaction(checkTx.Hash()), "evicted check tx should be gone")
+	require.Nil(tp.GetTransaction(mainTx.Hash()), "evicted main tx should be gone")
+	_, ok := <-notifyCh
+	require.False(ok, "submitter of evicted tx should be notified")
+
+	// Evict by hash.
+	evicted = tp.EvictTransactions(func(ti *TransactionInfo) bool {
+		return ti.Hash.Equal(&localTx.hash)
+	})
+	require.Len(evicted, 1)
+	require.Equal(QueueLocal, evicted[0].Queue)
+
+	// Roothash incoming message transactions are never evicted.
+	evicted = tp.EvictTransactions(func(*TransactionInfo) bool { return true })
+	require.Empty(evicted)
+	require.Len(tp.GetTransactions(), 1)
+}
@@ -51,6 +51,17 @@ func (rq *rimQueue) Load(inMsgs []*message.IncomingMessage) {
 	rq.txs = newTxs
 }
 
+func (rq *rimQueue) getAll() []*TxQueueMeta {
+	rq.l.RLock()
+	defer rq.l.RUnlock()
+
+	result := make([]*TxQueueMeta, 0, len(rq.txs))
+	for _, tx := range rq.txs {
+		result = append(result, tx)
+	}
+	return result
+}
+
 func (rq *rimQueue) size() int {
 	rq.l.Lock()
 	defer rq.l.Unlock()
@@ -127,6 +127,20 @@ type TransactionPool interface {
 
 	// PendingCheckSize returns the number of transactions currently pending to be checked.
 	PendingCheckSize() int
+
+	// GetTransactions returns information about all transactions currently queued in the
+	// transaction pool.
+	GetTransactions() []*TransactionInfo
+
+	// GetTransaction returns information about the given queued transaction or nil if the
+	// transaction is not queued in the transaction pool.
+	GetTransaction(h hash.Hash) *TransactionInfo
+
+	// EvictTransactions evicts all queued transactions for which the given condition holds and
+	// returns information about the evicted transactions.
+	//
+	// Transactions from roothash incoming messages are never evicted.
+	EvictTransactions(cond func(*TransactionInfo) bool) []*TransactionInfo
 }
 
 // RuntimeHostProvisioner is a runtime host provisioner.
@@ -236,7 +250,10 @@ func (t *txPool) SubmitTx(ctx context.Context, rawTx []byte, meta *TransactionMe
 		return nil, ctx.Err()
 	case <-t.stopCh:
 		return nil, fmt.Errorf("shutting down")
-	case result := <-notifyCh:
+	case result, ok := <-notifyCh:
+		if !ok {
+			return nil, ErrTransactionEvicted
+		}
 		return result, nil
 	}
 }
@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/accessctl"
@@ -21,8 +22,42 @@ type ServicePolicies struct {
 	AccessPolicies map[common.Namespace]accessctl.Policy `json:"access_policies"`
 }
 
+// SentryStatus is the status of a sentry node used by upstream nodes to assess its health.
+type SentryStatus struct {
+	// Synced is true iff the sentry node's consensus backend is synced.
+	Synced bool `json:"synced"`
+	// LatestHeight is the height of the latest block seen by the sentry node.
+	LatestHeight int64 `json:"latest_height"`
+	// LatestTime is the timestamp of the latest block seen by the sentry node.
+	LatestTime time.Time `json:"latest_time"`
+	// NumPeers is the number of consensus peers of the sentry node.
+	NumPeers int `json:"num_peers"`
+}
+
+// SentryHealth is the health of a sentry node as observed by an upstream node.
+type SentryHealth struct {
+	// Address is the sentry node's control address.
+	Address node.TLSAddress `json:"address"`
+	// Healthy is true iff the sentry node is considered healthy.
+	Healthy bool `json:"healthy"`
+	// Status is the sentry node's status as of the last successful probe.
+	Status *SentryStatus `json:"status,omitempty"`
+	// HeightLag is the number of blocks the sentry node lagged behind the local node as of the
+	// last successful probe.
+	HeightLag int64 `json:"height_lag"`
+	// LastProbe is the time of the last probe.
+	LastProbe time.Time `json:"last_probe"`
+	// LastError is the error of the last probe, if it failed.
+	LastError string `json:"last_error,omitempty"`
+	// ConsecutiveFailures is the number of consecutive failed probes.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+}
+
 // Backend is a sentry backend implementation.
 type Backend interface {
 	// Get addresses returns the list of consensus and TLS addresses of the sentry node.
 	GetAddresses(context.Context) (*SentryAddresses, error)
+
+	// GetStatus returns the status of the sentry node.
+	GetStatus(context.Context) (*SentryStatus, error)
 }
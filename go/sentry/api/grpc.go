@@ -14,6 +14,8 @@ var (
 
 	// methodGetAddresses is the GetAddresses method.
 	methodGetAddresses = serviceName.NewMethod("GetAddresses", nil)
+	// methodGetStatus is the GetStatus method.
+	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -24,6 +26,10 @@ var (
 				MethodName: methodGetAddresses.ShortName(),
 				Handler:    handlerGetAddresses,
 			},
+			{
+				MethodName: methodGetStatus.ShortName(),
+				Handler:    handlerGetStatus,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -48,6 +54,25 @@ func handlerGetAddresses(
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetStatus(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(Backend).GetStatus(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetStatus(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 // RegisterService registers a new sentry service with the given gRPC server.
 func RegisterService(server *grpc.Server, service Backend) {
 	server.RegisterService(&serviceDesc, service)
@@ -65,6 +90,14 @@ func (c *sentryClient) GetAddresses(ctx context.Context) (*SentryAddresses, erro
 	return &rsp, nil
 }
 
+func (c *sentryClient) GetStatus(ctx context.Context) (*SentryStatus, error) {
+	var rsp SentryStatus
+	if err := c.conn.Invoke(ctx, methodGetStatus.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 // NewSentryClient creates a new gRPC sentry client service.
 func NewSentryClient(c *grpc.ClientConn) Backend {
 	return &sentryClient{c}
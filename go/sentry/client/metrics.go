@@ -0,0 +1,43 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	sentryHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_sentry_upstream_healthy",
+			Help: "Whether a sentry node is considered healthy by the upstream node (1 = healthy).",
+		},
+		[]string{"sentry"},
+	)
+	sentryHeightLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_sentry_upstream_height_lag",
+			Help: "Number of blocks a sentry node lags behind the upstream node.",
+		},
+		[]string{"sentry"},
+	)
+	sentryFailovers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_sentry_upstream_failovers",
+			Help: "Number of times a sentry node became unhealthy and was failed over.",
+		},
+		[]string{"sentry"},
+	)
+
+	monitorCollectors = []prometheus.Collector{
+		sentryHealthy,
+		sentryHeightLag,
+		sentryFailovers,
+	}
+
+	metricsOnce sync.Once
+)
+
+func sentryLabels(address string) prometheus.Labels {
+	return prometheus.Labels{"sentry": address}
+}
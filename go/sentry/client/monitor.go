@@ -0,0 +1,414 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/sentry/api"
+)
+
+// MonitorConfig is the sentry health monitor configuration.
+type MonitorConfig struct {
+	// Interval is the interval between sentry node probes.
+	Interval time.Duration
+	// Timeout is the timeout of a single sentry node probe.
+	Timeout time.Duration
+	// MaxHeightLag is the maximum number of blocks a sentry node may lag behind the local node.
+	MaxHeightLag int64
+	// FailureThreshold is the number of consecutive failed probes after which a sentry node is
+	// considered unhealthy.
+	FailureThreshold int
+}
+
+type monitoredSentry struct {
+	health         api.SentryHealth
+	consensusAddrs []node.ConsensusAddress
+
+	// disconnected is true iff the sentry node has been disconnected due to being unhealthy.
+	disconnected bool
+
+	client api.Backend
+	close  func()
+}
+
+// Monitor periodically probes sentry nodes over their authenticated control channel and tracks
+// which of them are healthy, i.e. reachable, synced and not lagging behind the local node.
+//
+// Sentry nodes are assumed healthy until enough consecutive probes fail.
+type Monitor struct {
+	sync.RWMutex
+
+	cfg      MonitorConfig
+	sentries []*monitoredSentry
+
+	dial        func(node.TLSAddress) (api.Backend, func(), error)
+	localHeight func(context.Context) (int64, error)
+	peers       consensus.P2PController
+	// peersLock serializes peer actions so that they are applied in the order they were decided.
+	peersLock sync.Mutex
+
+	notifier *pubsub.Broker
+
+	initCh chan struct{}
+	stopCh chan struct{}
+	quitCh chan struct{}
+
+	logger *logging.Logger
+}
+
+// Start starts the monitor.
+func (m *Monitor) Start() {
+	go m.worker()
+}
+
+// Stop stops the monitor.
+func (m *Monitor) Stop() {
+	close(m.stopCh)
+}
+
+// Quit returns a channel that will be closed when the monitor terminates.
+func (m *Monitor) Quit() <-chan struct{} {
+	return m.quitCh
+}
+
+// Initialized returns a channel that will be closed once all sentry nodes have been probed once.
+func (m *Monitor) Initialized() <-chan struct{} {
+	return m.initCh
+}
+
+// Health returns the health of all monitored sentry nodes.
+func (m *Monitor) Health() []*api.SentryHealth {
+	m.RLock()
+	defer m.RUnlock()
+
+	health := make([]*api.SentryHealth, 0, len(m.sentries))
+	for _, s := range m.sentries {
+		h := s.health
+		health = append(health, &h)
+	}
+	return health
+}
+
+// ConsensusAddresses returns the consensus addresses of healthy sentry nodes.
+//
+// In case no sentry node is healthy, addresses of all sentry nodes are returned as hiding the node
+// completely would be worse than advertising unhealthy sentry nodes.
+func (m *Monitor) ConsensusAddresses() []node.ConsensusAddress {
+	m.RLock()
+	defer m.RUnlock()
+
+	var healthy, all []node.ConsensusAddress
+	for _, s := range m.sentries {
+		all = append(all, s.consensusAddrs...)
+		if s.health.Healthy {
+			healthy = append(healthy, s.consensusAddrs...)
+		}
+	}
+	if len(healthy) == 0 {
+		return all
+	}
+	return healthy
+}
+
+// WatchHealth returns a channel that produces a stream of sentry node health changes.
+func (m *Monitor) WatchHealth() (<-chan *api.SentryHealth, pubsub.ClosableSubscription) {
+	sub := m.notifier.Subscribe()
+	ch := make(chan *api.SentryHealth)
+	sub.Unwrap(ch)
+	return ch, sub
+}
+
+func (m *Monitor) worker() {
+	defer close(m.quitCh)
+	defer func() {
+		for _, s := range m.sentries {
+			if s.close != nil {
+				s.close()
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-m.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	m.probeAll(ctx)
+	close(m.initCh)
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		m.probeAll(ctx)
+	}
+}
+
+func (m *Monitor) probeAll(ctx context.Context) {
+	localHeight, err := m.localHeight(ctx)
+	if err != nil {
+		m.logger.Warn("failed to query local consensus height, not checking sentry lag",
+			"err", err,
+		)
+		localHeight = 0
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range m.sentries {
+		wg.Add(1)
+		go func(s *monitoredSentry) {
+			defer wg.Done()
+			m.probe(ctx, s, localHeight)
+		}(s)
+	}
+	wg.Wait()
+}
+
+func (m *Monitor) probe(ctx context.Context, s *monitoredSentry, localHeight int64) {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	status, addrs, err := m.query(ctx, s)
+	if err != nil && m.stopped() {
+		// Do not account probes interrupted due to the monitor stopping.
+		return
+	}
+
+	var lag int64
+	if err == nil && localHeight > 0 {
+		lag = localHeight - status.LatestHeight
+	}
+	switch {
+	case err != nil:
+	case !status.Synced:
+		err = fmt.Errorf("sentry node is not synced")
+	case lag > m.cfg.MaxHeightLag:
+		err = fmt.Errorf("sentry node is lagging %d blocks behind", lag)
+	}
+
+	m.Lock()
+	wasHealthy := s.health.Healthy
+	s.health.LastProbe = time.Now()
+	switch err {
+	case nil:
+		s.health.Healthy = true
+		s.health.Status = status
+		s.health.HeightLag = lag
+		s.health.LastError = ""
+		s.health.ConsecutiveFailures = 0
+		s.consensusAddrs = addrs
+	default:
+		if status != nil {
+			s.health.Status = status
+			s.health.HeightLag = lag
+		}
+		s.health.LastError = err.Error()
+		s.health.ConsecutiveFailures++
+		if s.health.ConsecutiveFailures >= m.cfg.FailureThreshold {
+			s.health.Healthy = false
+		}
+	}
+	health := s.health
+	var dial, disconnect []node.ConsensusAddress
+	if wasHealthy != health.Healthy {
+		dial, disconnect = m.reconcilePeersLocked()
+		m.peersLock.Lock()
+		defer m.peersLock.Unlock()
+	}
+	m.Unlock()
+
+	address := health.Address.String()
+	if health.Healthy {
+		sentryHealthy.With(sentryLabels(address)).Set(1)
+	} else {
+		sentryHealthy.With(sentryLabels(address)).Set(0)
+	}
+	sentryHeightLag.With(sentryLabels(address)).Set(float64(health.HeightLag))
+
+	if err != nil {
+		m.logger.Debug("sentry node probe failed",
+			"err", err,
+			"sentry_address", health.Address,
+			"consecutive_failures", health.ConsecutiveFailures,
+		)
+	}
+
+	if wasHealthy == health.Healthy {
+		return
+	}
+
+	switch health.Healthy {
+	case true:
+		m.logger.Info("sentry node is healthy again",
+			"sentry_address", health.Address,
+		)
+	case false:
+		m.logger.Warn("sentry node is unhealthy, failing over to other sentry nodes",
+			"err", err,
+			"sentry_address", health.Address,
+		)
+		sentryFailovers.With(sentryLabels(address)).Inc()
+	}
+
+	if m.peers != nil {
+		if len(dial) > 0 {
+			if perr := m.peers.DialPeers(dial); perr != nil {
+				m.logger.Warn("failed to dial sentry nodes",
+					"err", perr,
+					"addresses", dial,
+				)
+			}
+		}
+		for _, addr := range disconnect {
+			if perr := m.peers.DisconnectPeer(addr.ID); perr != nil {
+				m.logger.Warn("failed to disconnect unhealthy sentry node",
+					"err", perr,
+					"address", addr,
+				)
+			}
+		}
+	}
+
+	m.notifier.Broadcast(&health)
+}
+
+// reconcilePeersLocked marks sentry nodes as disconnected or connected so that the node is only
+// connected to healthy sentry nodes and returns the addresses that need to be dialed and
+// disconnected.
+//
+// In case no sentry node is healthy, all sentry nodes are kept connected as isolating the node
+// completely would be worse than using unhealthy sentry nodes.
+func (m *Monitor) reconcilePeersLocked() (dial, disconnect []node.ConsensusAddress) {
+	var anyHealthy bool
+	for _, s := range m.sentries {
+		if s.health.Healthy {
+			anyHealthy = true
+			break
+		}
+	}
+
+	for _, s := range m.sentries {
+		connect := s.health.Healthy || !anyHealthy
+		switch {
+		case connect && s.disconnected:
+			s.disconnected = false
+			dial = append(dial, s.consensusAddrs...)
+		case !connect && !s.disconnected:
+			s.disconnected = true
+			disconnect = append(disconnect, s.consensusAddrs...)
+		}
+	}
+	return
+}
+
+func (m *Monitor) query(ctx context.Context, s *monitoredSentry) (*api.SentryStatus, []node.ConsensusAddress, error) {
+	if s.client == nil {
+		client, closeFn, err := m.dial(s.health.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create client: %w", err)
+		}
+		s.client, s.close = client, closeFn
+	}
+
+	status, err := s.client.GetStatus(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to obtain status: %w", err)
+	}
+	addrs, err := s.client.GetAddresses(ctx)
+	if err != nil {
+		return status, nil, fmt.Errorf("failed to obtain addresses: %w", err)
+	}
+	return status, addrs.Consensus, nil
+}
+
+func (m *Monitor) stopped() bool {
+	select {
+	case <-m.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func newMonitor(
+	sentryAddresses []node.TLSAddress,
+	cfg *MonitorConfig,
+	dial func(node.TLSAddress) (api.Backend, func(), error),
+	localHeight func(context.Context) (int64, error),
+	peers consensus.P2PController,
+) *Monitor {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(monitorCollectors...)
+	})
+
+	m := &Monitor{
+		cfg:         *cfg,
+		dial:        dial,
+		localHeight: localHeight,
+		peers:       peers,
+		notifier:    pubsub.NewBroker(false),
+		initCh:      make(chan struct{}),
+		stopCh:      make(chan struct{}),
+		quitCh:      make(chan struct{}),
+		logger:      logging.GetLogger("sentry/client/monitor"),
+	}
+	for _, addr := range sentryAddresses {
+		m.sentries = append(m.sentries, &monitoredSentry{
+			health: api.SentryHealth{
+				Address: addr,
+				Healthy: true,
+			},
+		})
+	}
+	return m
+}
+
+// NewMonitor creates a new sentry health monitor.
+//
+// If the consensus backend supports controlling consensus peering, unhealthy sentry nodes are
+// disconnected (as long as at least one sentry node is healthy) and recovered sentry nodes are
+// dialed again.
+func NewMonitor(
+	sentryAddresses []node.TLSAddress,
+	identity *identity.Identity,
+	consensusBackend consensus.Backend,
+	cfg *MonitorConfig,
+) *Monitor {
+	dial := func(addr node.TLSAddress) (api.Backend, func(), error) {
+		client, err := New(addr, identity)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, client.Close, nil
+	}
+	localHeight := func(ctx context.Context) (int64, error) {
+		status, err := consensusBackend.GetStatus(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return status.LatestHeight, nil
+	}
+	peers, _ := consensusBackend.(consensus.P2PController)
+
+	return newMonitor(sentryAddresses, cfg, dial, localHeight, peers)
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/sentry/api"
+)
+
+type testSentry struct {
+	sync.Mutex
+
+	status *api.SentryStatus
+	err    error
+	addrs  []node.ConsensusAddress
+}
+
+func (s *testSentry) set(status *api.SentryStatus, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.status, s.err = status, err
+}
+
+func (s *testSentry) GetAddresses(context.Context) (*api.SentryAddresses, error) {
+	return &api.SentryAddresses{Consensus: s.addrs}, nil
+}
+
+func (s *testSentry) GetStatus(context.Context) (*api.SentryStatus, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.status, s.err
+}
+
+// testPeerController models the consensus switch: all sentry nodes are persistent peers which
+// are redialed unless blocked via DisconnectPeer.
+type testPeerController struct {
+	sync.Mutex
+
+	dialed    []node.ConsensusAddress
+	connected map[signature.PublicKey]bool
+	blocked   map[signature.PublicKey]bool
+}
+
+func newTestPeerController(addrs ...node.ConsensusAddress) *testPeerController {
+	c := &testPeerController{
+		connected: make(map[signature.PublicKey]bool),
+		blocked:   make(map[signature.PublicKey]bool),
+	}
+	for _, addr := range addrs {
+		c.connected[addr.ID] = true
+	}
+	return c
+}
+
+func (c *testPeerController) DialPeers(addrs []node.ConsensusAddress) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.dialed = append(c.dialed, addrs...)
+	for _, addr := range addrs {
+		delete(c.blocked, addr.ID)
+		c.connected[addr.ID] = true
+	}
+	return nil
+}
+
+func (c *testPeerController) DisconnectPeer(id signature.PublicKey) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.blocked[id] = true
+	c.connected[id] = false
+	return nil
+}
+
+// redial simulates the switch redialing all persistent peers.
+func (c *testPeerController) redial() {
+	c.Lock()
+	defer c.Unlock()
+
+	for id := range c.connected {
+		if !c.blocked[id] {
+			c.connected[id] = true
+		}
+	}
+}
+
+func (c *testPeerController) isConnected(id signature.PublicKey) bool {
+	c.redial()
+
+	c.Lock()
+	defer c.Unlock()
+
+	return c.connected[id]
+}
+
+func newTestAddress(t *testing.T, port int64) (node.TLSAddress, node.ConsensusAddress) {
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(t, err, "NewSigner")
+
+	addr := node.Address{IP: net.ParseIP("127.0.0.1"), Port: port}
+	return node.TLSAddress{PubKey: signer.Public(), Address: addr},
+		node.ConsensusAddress{ID: signer.Public(), Address: addr}
+}
+
+func TestMonitor(t *testing.T) {
+	require := require.New(t)
+
+	tlsAddr1, consAddr1 := newTestAddress(t, 1)
+	tlsAddr2, consAddr2 := newTestAddress(t, 2)
+	sentries := map[signature.PublicKey]*testSentry{
+		tlsAddr1.PubKey: {addrs: []node.ConsensusAddress{consAddr1}},
+		tlsAddr2.PubKey: {addrs: []node.ConsensusAddress{consAddr2}},
+	}
+	synced := &api.SentryStatus{Synced: true, LatestHeight: 100}
+	for _, s := range sentries {
+		s.set(synced, nil)
+	}
+
+	dial := func(addr node.TLSAddress) (api.Backend, func(), error) {
+		return sentries[addr.PubKey], func() {}, nil
+	}
+	localHeight := func(context.Context) (int64, error) {
+		return 105, nil
+	}
+	peers := newTestPeerController(consAddr1, consAddr2)
+
+	m := newMonitor([]node.TLSAddress{tlsAddr1, tlsAddr2}, &MonitorConfig{
+		Interval:         time.Hour,
+		Timeout:          time.Second,
+		MaxHeightLag:     10,
+		FailureThreshold: 2,
+	}, dial, localHeight, peers)
+	ch, sub := m.WatchHealth()
+	defer sub.Close()
+
+	ctx := context.Background()
+	m.probeAll(ctx)
+	require.ElementsMatch([]node.ConsensusAddress{consAddr1, consAddr2}, m.ConsensusAddresses())
+	for _, h := range m.Health() {
+		require.True(h.Healthy)
+		require.EqualValues(5, h.HeightLag)
+	}
+
+	// A lagging sentry should only be failed over after enough failed probes.
+	sentries[tlsAddr2.PubKey].set(&api.SentryStatus{Synced: true, LatestHeight: 50}, nil)
+	m.probeAll(ctx)
+	require.ElementsMatch([]node.ConsensusAddress{consAddr1, consAddr2}, m.ConsensusAddresses())
+
+	m.probeAll(ctx)
+	require.Equal([]node.ConsensusAddress{consAddr1}, m.ConsensusAddresses())
+	select {
+	case h := <-ch:
+		require.Equal(tlsAddr2, h.Address)
+		require.False(h.Healthy)
+		require.Equal(2, h.ConsecutiveFailures)
+		require.EqualValues(55, h.HeightLag)
+	case <-time.After(time.Second):
+		t.Fatalf("failed to receive health event")
+	}
+
+	// The unhealthy sentry should stay disconnected even though it is a persistent peer.
+	for i := 0; i < 3; i++ {
+		require.False(peers.isConnected(consAddr2.ID), "unhealthy sentry should stay disconnected")
+		require.True(peers.isConnected(consAddr1.ID), "healthy sentry should stay connected")
+		m.probeAll(ctx)
+		require.Equal([]node.ConsensusAddress{consAddr1}, m.ConsensusAddresses())
+	}
+
+	// If no sentry is healthy, all sentry addresses should be used and all sentries connected.
+	sentries[tlsAddr1.PubKey].set(nil, fmt.Errorf("connection refused"))
+	m.probeAll(ctx)
+	m.probeAll(ctx)
+	require.ElementsMatch([]node.ConsensusAddress{consAddr1, consAddr2}, m.ConsensusAddresses())
+	<-ch
+	require.True(peers.isConnected(consAddr1.ID))
+	require.True(peers.isConnected(consAddr2.ID))
+	require.Equal([]node.ConsensusAddress{consAddr2}, peers.dialed)
+
+	// A recovered sentry should be used and other unhealthy sentries disconnected again.
+	sentries[tlsAddr2.PubKey].set(synced, nil)
+	m.probeAll(ctx)
+	require.Equal([]node.ConsensusAddress{consAddr2}, m.ConsensusAddresses())
+	select {
+	case h := <-ch:
+		require.Equal(tlsAddr2, h.Address)
+		require.True(h.Healthy)
+	case <-time.After(time.Second):
+		t.Fatalf("failed to receive health event")
+	}
+	require.False(peers.isConnected(consAddr1.ID), "unhealthy sentry should be disconnected")
+	require.True(peers.isConnected(consAddr2.ID))
+
+	// Once healthy again, the sentry should be dialed and used again.
+	sentries[tlsAddr1.PubKey].set(synced, nil)
+	m.probeAll(ctx)
+	require.ElementsMatch([]node.ConsensusAddress{consAddr1, consAddr2}, m.ConsensusAddresses())
+	<-ch
+	require.True(peers.isConnected(consAddr1.ID))
+	require.Equal([]node.ConsensusAddress{consAddr2, consAddr1}, peers.dialed)
+}
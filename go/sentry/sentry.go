@@ -38,6 +38,25 @@ func (b *backend) GetAddresses(ctx context.Context) (*api.SentryAddresses, error
 	}, nil
 }
 
+func (b *backend) GetStatus(ctx context.Context) (*api.SentryStatus, error) {
+	status, err := b.consensus.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sentry: error obtaining consensus status: %w", err)
+	}
+
+	var numPeers int
+	if status.P2P != nil {
+		numPeers = len(status.P2P.Peers)
+	}
+
+	return &api.SentryStatus{
+		Synced:       status.Status == consensus.StatusStateReady,
+		LatestHeight: status.LatestHeight,
+		LatestTime:   status.LatestTime,
+		NumPeers:     numPeers,
+	}, nil
+}
+
 // func (b *backend) GetPolicyChecker(ctx context.Context, service cmnGrpc.ServiceName) (*policy.DynamicRuntimePolicyChecker, error) {
 
 // New constructs a new sentry Backend instance.
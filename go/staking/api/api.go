@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/proof"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
@@ -132,6 +133,10 @@ type Backend interface {
 	// GovernanceDeposits returns the governance deposits account balance.
 	GovernanceDeposits(ctx context.Context, height int64) (*quantity.Quantity, error)
 
+	// BaseFee returns the dynamic base fee that applied in the given block and the projected base
+	// fee for the following block.
+	BaseFee(ctx context.Context, height int64) (*BaseFee, error)
+
 	// Threshold returns the specific staking threshold by kind.
 	Threshold(ctx context.Context, query *ThresholdQuery) (*quantity.Quantity, error)
 
@@ -150,6 +155,15 @@ type Backend interface {
 	// owner (delegator).
 	DelegationsFor(ctx context.Context, query *OwnerQuery) (map[Address]*Delegation, error)
 
+	// AccountWithProof returns the account descriptor for the given account
+	// together with a proof that can be used to verify it against a light block.
+	AccountWithProof(ctx context.Context, query *OwnerQuery) (*AccountWithProof, error)
+
+	// DelegationsForWithProof returns the list of (outgoing) delegations for
+	// the given owner (delegator) together with a proof that can be used to
+	// verify them against a light block.
+	DelegationsForWithProof(ctx context.Context, query *OwnerQuery) (*DelegationsWithProof, error)
+
 	// DelegationsInfosFor returns (outgoing) delegations with additional
 	// information for the given owner (delegator).
 	DelegationInfosFor(ctx context.Context, query *OwnerQuery) (map[Address]*DelegationInfo, error)
@@ -201,6 +215,24 @@ type OwnerQuery struct {
 	Owner  Address `json:"owner"`
 }
 
+// AccountWithProof is an account descriptor together with a proof of it being
+// part of the consensus state.
+type AccountWithProof struct {
+	// Account is the account descriptor.
+	Account *Account `json:"account"`
+	// Proof is the proof of the account descriptor.
+	Proof *proof.StateProof `json:"proof"`
+}
+
+// DelegationsWithProof is a list of delegations together with a proof of them
+// being the complete set of delegations in the consensus state.
+type DelegationsWithProof struct {
+	// Delegations are the delegations by escrow account address.
+	Delegations map[Address]*Delegation `json:"delegations"`
+	// Proof is the proof of the delegations.
+	Proof *proof.StateProof `json:"proof"`
+}
+
 // AllowanceQuery is an allowance query.
 type AllowanceQuery struct {
 	Height      int64   `json:"height"`
@@ -1167,6 +1199,8 @@ type Genesis struct {
 	LastBlockFees quantity.Quantity `json:"last_block_fees"`
 	// GovernanceDeposits are network's governance deposits.
 	GovernanceDeposits quantity.Quantity `json:"governance_deposits"`
+	// BaseFee is the base fee that applies to the first block.
+	BaseFee *quantity.Quantity `json:"base_fee,omitempty"`
 
 	// Ledger is a map of staking accounts.
 	Ledger map[Address]*Account `json:"ledger,omitempty"`
@@ -1217,6 +1251,87 @@ type ConsensusParameters struct { // nolint: maligned
 	// RewardFactorBlockProposed is the factor for a reward distributed per block
 	// to the entity that proposed the block.
 	RewardFactorBlockProposed quantity.Quantity `json:"reward_factor_block_proposed"`
+
+	// BaseFeeTargetGas is the amount of gas used per block that the dynamic base fee targets.
+	// Zero disables the base fee.
+	BaseFeeTargetGas transaction.Gas `json:"base_fee_target_gas,omitempty"`
+	// BaseFeeMaxChangeDenominator bounds the change of the base fee between two blocks to
+	// 1/BaseFeeMaxChangeDenominator of the base fee.
+	BaseFeeMaxChangeDenominator uint64 `json:"base_fee_max_change_denominator,omitempty"`
+	// MinBaseFee is the minimum base fee.
+	MinBaseFee uint64 `json:"min_base_fee,omitempty"`
+	// BurnBaseFee specifies whether the base fee portion of transaction fees is burned instead of
+	// being transferred to the common pool.
+	BurnBaseFee bool `json:"burn_base_fee,omitempty"`
+}
+
+// BaseFeeEnabled returns true iff the dynamic base fee is enabled.
+func (p *ConsensusParameters) BaseFeeEnabled() bool {
+	return p.BaseFeeTargetGas > 0
+}
+
+// NextBaseFee computes the base fee for the next block given the base fee of the current block
+// and the amount of gas used in the current block.
+//
+// The base fee increases when more gas than targeted has been used and decreases otherwise, by at
+// most 1/BaseFeeMaxChangeDenominator of the current base fee. It never falls below MinBaseFee.
+func (p *ConsensusParameters) NextBaseFee(current *quantity.Quantity, gasUsed transaction.Gas) (*quantity.Quantity, error) {
+	if !p.BaseFeeEnabled() {
+		return quantity.NewQuantity(), nil
+	}
+	if p.BaseFeeMaxChangeDenominator == 0 {
+		return nil, fmt.Errorf("staking: base fee max change denominator must be non-zero")
+	}
+
+	target := p.BaseFeeTargetGas
+	var gasDelta transaction.Gas
+	switch {
+	case gasUsed > target:
+		gasDelta = gasUsed - target
+	default:
+		gasDelta = target - gasUsed
+	}
+
+	// delta = current * gasDelta / target / BaseFeeMaxChangeDenominator
+	delta := current.Clone()
+	if err := delta.Mul(quantity.NewFromUint64(uint64(gasDelta))); err != nil {
+		return nil, err
+	}
+	if err := delta.Quo(quantity.NewFromUint64(uint64(target))); err != nil {
+		return nil, err
+	}
+	if err := delta.Quo(quantity.NewFromUint64(p.BaseFeeMaxChangeDenominator)); err != nil {
+		return nil, err
+	}
+
+	next := current.Clone()
+	switch {
+	case gasUsed > target:
+		// Always increase by at least one so that a zero base fee can grow.
+		if delta.IsZero() {
+			delta = quantity.NewFromUint64(1)
+		}
+		if err := next.Add(delta); err != nil {
+			return nil, err
+		}
+	case gasUsed < target:
+		if _, err := next.SubUpTo(delta); err != nil {
+			return nil, err
+		}
+	}
+
+	if minBaseFee := quantity.NewFromUint64(p.MinBaseFee); next.Cmp(minBaseFee) < 0 {
+		next = minBaseFee
+	}
+	return next, nil
+}
+
+// BaseFee is the dynamic consensus base fee.
+type BaseFee struct {
+	// Current is the base fee (gas price) that applied to transactions in the block.
+	Current quantity.Quantity `json:"current"`
+	// Next is the projected base fee that applies to transactions in the following block.
+	Next quantity.Quantity `json:"next"`
 }
 
 // ConsensusParameterChanges are allowed staking consensus parameter changes.
@@ -1258,6 +1373,15 @@ type ConsensusParameterChanges struct {
 	RewardFactorEpochSigned *quantity.Quantity `json:"reward_factor_epoch_signed"`
 	// RewardFactorBlockProposed is the new block proposed reward factor.
 	RewardFactorBlockProposed *quantity.Quantity `json:"reward_factor_block_proposed"`
+
+	// BaseFeeTargetGas is the new base fee target gas.
+	BaseFeeTargetGas *transaction.Gas `json:"base_fee_target_gas,omitempty"`
+	// BaseFeeMaxChangeDenominator is the new base fee max change denominator.
+	BaseFeeMaxChangeDenominator *uint64 `json:"base_fee_max_change_denominator,omitempty"`
+	// MinBaseFee is the new minimum base fee.
+	MinBaseFee *uint64 `json:"min_base_fee,omitempty"`
+	// BurnBaseFee is the new burn base fee flag.
+	BurnBaseFee *bool `json:"burn_base_fee,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -1307,6 +1431,18 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.RewardFactorBlockProposed != nil {
 		params.RewardFactorBlockProposed = *c.RewardFactorBlockProposed
 	}
+	if c.BaseFeeTargetGas != nil {
+		params.BaseFeeTargetGas = *c.BaseFeeTargetGas
+	}
+	if c.BaseFeeMaxChangeDenominator != nil {
+		params.BaseFeeMaxChangeDenominator = *c.BaseFeeMaxChangeDenominator
+	}
+	if c.MinBaseFee != nil {
+		params.MinBaseFee = *c.MinBaseFee
+	}
+	if c.BurnBaseFee != nil {
+		params.BurnBaseFee = *c.BurnBaseFee
+	}
 	return nil
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
 
 func TestConsensusParameters(t *testing.T) {
@@ -45,6 +46,14 @@ func TestConsensusParameters(t *testing.T) {
 		FeeSplitWeightNextPropose: mustInitQuantity(t, 0),
 	}
 	require.Error(degenerateFeeSplit.SanityCheck(), "consensus parameters with degenerate fee split should be invalid")
+
+	// Base fee without a max change denominator.
+	invalidBaseFee := ConsensusParameters{
+		Thresholds:         validThresholds,
+		FeeSplitWeightVote: mustInitQuantity(t, 1),
+		BaseFeeTargetGas:   1_000,
+	}
+	require.Error(invalidBaseFee.SanityCheck(), "consensus parameters with base fee and zero max change denominator should be invalid")
 }
 
 func TestThresholdKind(t *testing.T) {
@@ -656,3 +665,43 @@ func TestEventsSerialization(t *testing.T) {
 		require.EqualValues(tc.ev, dec, "Event serialization should round-trip")
 	}
 }
+
+func TestNextBaseFee(t *testing.T) {
+	require := require.New(t)
+
+	// Disabled base fee.
+	var params ConsensusParameters
+	next, err := params.NextBaseFee(quantity.NewFromUint64(100), 1_000)
+	require.NoError(err, "NextBaseFee")
+	require.True(next.IsZero(), "disabled base fee should be zero")
+
+	params = ConsensusParameters{
+		BaseFeeTargetGas:            1_000,
+		BaseFeeMaxChangeDenominator: 8,
+		MinBaseFee:                  10,
+	}
+	for _, tc := range []struct {
+		current  uint64
+		gasUsed  uint64
+		expected uint64
+		msg      string
+	}{
+		{800, 1_000, 800, "base fee should not change at target"},
+		{800, 2_000, 900, "base fee should increase by max change when block is at twice the target"},
+		{800, 1_500, 850, "base fee should increase proportionally"},
+		{800, 0, 700, "base fee should decrease by max change when block is empty"},
+		{800, 500, 750, "base fee should decrease proportionally"},
+		{10, 0, 10, "base fee should not fall below minimum"},
+		{0, 1_001, 10, "zero base fee should be raised to minimum"},
+		{20, 1_001, 21, "base fee should increase by at least one above target"},
+	} {
+		next, err = params.NextBaseFee(quantity.NewFromUint64(tc.current), transaction.Gas(tc.gasUsed))
+		require.NoError(err, tc.msg)
+		require.EqualValues(quantity.NewFromUint64(tc.expected), next, tc.msg)
+	}
+
+	// Sanity checks.
+	params.BaseFeeMaxChangeDenominator = 0
+	_, err = params.NextBaseFee(quantity.NewFromUint64(100), 1_000)
+	require.Error(err, "NextBaseFee should fail with a zero denominator")
+}
@@ -26,6 +26,8 @@ var (
 	methodLastBlockFees = serviceName.NewMethod("LastBlockFees", int64(0))
 	// methodGovernanceDeposits is the GovernanceDeposits method.
 	methodGovernanceDeposits = serviceName.NewMethod("GovernanceDeposits", int64(0))
+	// methodBaseFee is the BaseFee method.
+	methodBaseFee = serviceName.NewMethod("BaseFee", int64(0))
 	// methodThreshold is the Threshold method.
 	methodThreshold = serviceName.NewMethod("Threshold", ThresholdQuery{})
 	// methodAddresses is the Addresses method.
@@ -36,6 +38,10 @@ var (
 	methodAccount = serviceName.NewMethod("Account", OwnerQuery{})
 	// methodDelegationsFor is the DelegationsFor method.
 	methodDelegationsFor = serviceName.NewMethod("DelegationsFor", OwnerQuery{})
+	// methodAccountWithProof is the AccountWithProof method.
+	methodAccountWithProof = serviceName.NewMethod("AccountWithProof", OwnerQuery{})
+	// methodDelegationsForWithProof is the DelegationsForWithProof method.
+	methodDelegationsForWithProof = serviceName.NewMethod("DelegationsForWithProof", OwnerQuery{})
 	// methodDelegationInfosFor is the DelegationInfosFor method.
 	methodDelegationInfosFor = serviceName.NewMethod("DelegationInfosFor", OwnerQuery{})
 	// methodDelegationsTo is the DelegationsTo method.
@@ -87,6 +93,10 @@ var (
 				MethodName: methodGovernanceDeposits.ShortName(),
 				Handler:    handlerGovernanceDeposits,
 			},
+			{
+				MethodName: methodBaseFee.ShortName(),
+				Handler:    handlerBaseFee,
+			},
 			{
 				MethodName: methodThreshold.ShortName(),
 				Handler:    handlerThreshold,
@@ -107,6 +117,14 @@ var (
 				MethodName: methodDelegationsFor.ShortName(),
 				Handler:    handlerDelegationsFor,
 			},
+			{
+				MethodName: methodAccountWithProof.ShortName(),
+				Handler:    handlerAccountWithProof,
+			},
+			{
+				MethodName: methodDelegationsForWithProof.ShortName(),
+				Handler:    handlerDelegationsForWithProof,
+			},
 			{
 				MethodName: methodDelegationInfosFor.ShortName(),
 				Handler:    handlerDelegationInfosFor,
@@ -284,6 +302,29 @@ func handlerGovernanceDeposits(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerBaseFee(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).BaseFee(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodBaseFee.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).BaseFee(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerThreshold(
 	srv interface{},
 	ctx context.Context,
@@ -399,6 +440,52 @@ func handlerDelegationsFor(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerAccountWithProof(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).AccountWithProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodAccountWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).AccountWithProof(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerDelegationsForWithProof(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DelegationsForWithProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDelegationsForWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DelegationsForWithProof(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerDelegationInfosFor(
 	srv interface{},
 	ctx context.Context,
@@ -691,6 +778,14 @@ func (c *stakingClient) GovernanceDeposits(ctx context.Context, height int64) (*
 	return &rsp, nil
 }
 
+func (c *stakingClient) BaseFee(ctx context.Context, height int64) (*BaseFee, error) {
+	var rsp BaseFee
+	if err := c.conn.Invoke(ctx, methodBaseFee.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) Threshold(ctx context.Context, query *ThresholdQuery) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodThreshold.FullName(), query, &rsp); err != nil {
@@ -731,6 +826,22 @@ func (c *stakingClient) DelegationsFor(ctx context.Context, query *OwnerQuery) (
 	return rsp, nil
 }
 
+func (c *stakingClient) AccountWithProof(ctx context.Context, query *OwnerQuery) (*AccountWithProof, error) {
+	var rsp AccountWithProof
+	if err := c.conn.Invoke(ctx, methodAccountWithProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *stakingClient) DelegationsForWithProof(ctx context.Context, query *OwnerQuery) (*DelegationsWithProof, error) {
+	var rsp DelegationsWithProof
+	if err := c.conn.Invoke(ctx, methodDelegationsForWithProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) DelegationInfosFor(ctx context.Context, query *OwnerQuery) (map[Address]*DelegationInfo, error) {
 	var rsp map[Address]*DelegationInfo
 	if err := c.conn.Invoke(ctx, methodDelegationInfosFor.FullName(), query, &rsp); err != nil {
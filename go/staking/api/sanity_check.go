@@ -42,6 +42,11 @@ func (p *ConsensusParameters) SanityCheck() error {
 		return fmt.Errorf("minimum commission %v/%v over unity", p.CommissionScheduleRules, CommissionRateDenominator)
 	}
 
+	// Base fee.
+	if p.BaseFeeEnabled() && p.BaseFeeMaxChangeDenominator == 0 {
+		return fmt.Errorf("base fee max change denominator must be non-zero when base fee is enabled")
+	}
+
 	return nil
 }
 
@@ -61,7 +66,11 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.FeeSplitWeightVote == nil &&
 		c.FeeSplitWeightNextPropose == nil &&
 		c.RewardFactorEpochSigned == nil &&
-		c.RewardFactorBlockProposed == nil {
+		c.RewardFactorBlockProposed == nil &&
+		c.BaseFeeTargetGas == nil &&
+		c.BaseFeeMaxChangeDenominator == nil &&
+		c.MinBaseFee == nil &&
+		c.BurnBaseFee == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
@@ -298,6 +307,10 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime) error { // nolint: gocyclo
 		return fmt.Errorf("staking: sanity check failed: last block fees is invalid")
 	}
 
+	if g.BaseFee != nil && !g.BaseFee.IsValid() {
+		return fmt.Errorf("staking: sanity check failed: base fee is invalid")
+	}
+
 	// Check if the total supply adds up:
 	// common pool + last block fees + all balances in the ledger.
 	// Check all commission schedules.
@@ -156,7 +156,9 @@ func StakingImplementationTests(
 		{"CommonPool", testCommonPool},
 		{"LastBlockFees", testLastBlockFees},
 		{"GovernanceDeposits", testGovernanceDeposits},
+		{"BaseFee", testBaseFee},
 		{"Delegations", testDelegations},
+		{"Proofs", testProofs},
 		{"Transfer", testTransfer},
 		{"TransferSelf", testSelfTransfer},
 		{"Burn", testBurn},
@@ -237,6 +239,16 @@ func testLastBlockFees(t *testing.T, state *stakingTestsState, backend api.Backe
 	require.True(lastBlockFeesAcc.General.Balance.IsZero(), "LastBlockFees Account - initial value")
 }
 
+func testBaseFee(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+
+	// Base fee is disabled in the test network.
+	baseFee, err := backend.BaseFee(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "BaseFee")
+	require.True(baseFee.Current.IsZero(), "BaseFee - current")
+	require.True(baseFee.Next.IsZero(), "BaseFee - next")
+}
+
 func testGovernanceDeposits(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
 	require := require.New(t)
 
@@ -249,6 +261,31 @@ func testGovernanceDeposits(t *testing.T, state *stakingTestsState, backend api.
 	require.True(governanceDepositsAcc.General.Balance.IsZero(), "GovernaceDeposits Account - initial value")
 }
 
+func testProofs(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	addr := state.accounts.GetAddress(2)
+
+	accWithProof, err := backend.AccountWithProof(ctx, &api.OwnerQuery{Owner: addr, Height: consensusAPI.HeightLatest})
+	require.NoError(err, "AccountWithProof")
+	require.NotNil(accWithProof.Proof, "AccountWithProof - proof")
+	require.NotEmpty(accWithProof.Proof.LightBlock, "AccountWithProof - light block")
+	require.NotEmpty(accWithProof.Proof.Proof.Entries, "AccountWithProof - proof entries")
+
+	acc, err := backend.Account(ctx, &api.OwnerQuery{Owner: addr, Height: accWithProof.Proof.Height})
+	require.NoError(err, "Account")
+	require.EqualValues(acc, accWithProof.Account, "AccountWithProof - account")
+
+	delWithProof, err := backend.DelegationsForWithProof(ctx, &api.OwnerQuery{Owner: addr, Height: consensusAPI.HeightLatest})
+	require.NoError(err, "DelegationsForWithProof")
+	require.NotNil(delWithProof.Proof, "DelegationsForWithProof - proof")
+
+	dels, err := backend.DelegationsFor(ctx, &api.OwnerQuery{Owner: addr, Height: delWithProof.Proof.Height})
+	require.NoError(err, "DelegationsFor")
+	require.EqualValues(dels, delWithProof.Delegations, "DelegationsForWithProof - delegations")
+}
+
 func testDelegations(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
 	require := require.New(t)
 
@@ -87,3 +87,28 @@ func (r *nopReadSyncer) SyncGetPrefixes(ctx context.Context, request *GetPrefixe
 func (r *nopReadSyncer) SyncIterate(ctx context.Context, request *IterateRequest) (*ProofResponse, error) {
 	return nil, ErrUnsupported
 }
+
+// proofReadSyncer is a read syncer that answers all requests with a fixed proof.
+type proofReadSyncer struct {
+	proof *Proof
+}
+
+// NewProofReadSyncer creates a new read syncer that answers all requests with the given proof.
+//
+// A tree backed by such a read syncer can only access the nodes contained in the proof, which
+// makes it possible to read state which has been proven against a trusted root.
+func NewProofReadSyncer(proof *Proof) ReadSyncer {
+	return &proofReadSyncer{proof}
+}
+
+func (r *proofReadSyncer) SyncGet(context.Context, *GetRequest) (*ProofResponse, error) {
+	return &ProofResponse{Proof: *r.proof}, nil
+}
+
+func (r *proofReadSyncer) SyncGetPrefixes(context.Context, *GetPrefixesRequest) (*ProofResponse, error) {
+	return &ProofResponse{Proof: *r.proof}, nil
+}
+
+func (r *proofReadSyncer) SyncIterate(context.Context, *IterateRequest) (*ProofResponse, error) {
+	return &ProofResponse{Proof: *r.proof}, nil
+}
@@ -183,7 +183,17 @@ func New(grpcInternal *grpc.Server, commonWorker *workerCommon.Worker) (*Worker,
 	}
 
 	// Attach the runtime client worker's internal GRPC interface.
-	api.RegisterService(grpcInternal.Server(), &service{w: w})
+	w.RegisterService(grpcInternal)
 
 	return w, nil
 }
+
+// RegisterService registers the runtime client gRPC service with the given server.
+//
+// Does nothing if the worker is disabled.
+func (w *Worker) RegisterService(server *grpc.Server) {
+	if !w.enabled {
+		return
+	}
+	api.RegisterService(server.Server(), &service{w: w})
+}
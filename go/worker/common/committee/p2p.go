@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/config"
+	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	p2pError "github.com/oasisprotocol/oasis-core/go/p2p/error"
 	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 )
@@ -17,11 +17,7 @@ type txMsgHandler struct {
 }
 
 func (h *txMsgHandler) DecodeMessage(msg []byte) (interface{}, error) {
-	var tx []byte
-	if err := cbor.Unmarshal(msg, &tx); err != nil {
-		return nil, err
-	}
-	return tx, nil
+	return p2p.DecodeTopicMessage(p2p.TopicKindTx, msg)
 }
 
 func (h *txMsgHandler) AuthorizeMessage(ctx context.Context, peerID signature.PublicKey, msg interface{}) error {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
@@ -16,11 +15,7 @@ type committeeMsgHandler struct {
 }
 
 func (h *committeeMsgHandler) DecodeMessage(msg []byte) (interface{}, error) {
-	var dec p2p.CommitteeMessage
-	if err := cbor.Unmarshal(msg, &dec); err != nil {
-		return nil, err
-	}
-	return &dec, nil
+	return p2p.DecodeTopicMessage(p2p.TopicKindCommittee, msg)
 }
 
 func (h *committeeMsgHandler) AuthorizeMessage(ctx context.Context, peerID signature.PublicKey, msg interface{}) error {
@@ -134,6 +134,10 @@ type MasterSecretStats struct {
 	NumGenerated int `json:"num_generated"`
 	// LastGenerated is the generation of the last generated secret.
 	LastGenerated uint64 `json:"last_generated_generation"`
+	// NumExported is the number of exported master secret backups.
+	NumExported int `json:"num_exported"`
+	// LastExported is the generation of the last exported master secret backup.
+	LastExported uint64 `json:"last_exported_generation"`
 }
 
 // EphemeralSecretStats are the ephemeral secret generation and replication stats.
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+)
+
+// AuditRecord is a key manager access audit log record.
+//
+// Requests sent over encrypted sessions only reveal the method, so key pair identifiers,
+// generations and epochs are only recorded for plaintext (insecure) queries. For the same
+// reason, requests sent over encrypted sessions are only recorded as denied if they were
+// rejected by the node's access control or failed to be processed by the enclave.
+type AuditRecord struct {
+	// Timestamp is the time when the request was received (UNIX time in nanoseconds).
+	Timestamp int64 `json:"timestamp"`
+
+	// PeerID is the peer that sent the request.
+	PeerID core.PeerID `json:"peer_id"`
+	// NodeID is the identifier of the node that sent the request, if known.
+	NodeID *signature.PublicKey `json:"node_id,omitempty"`
+	// RuntimeID is the identifier of the runtime the request was made for, if known.
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+
+	// Method is the requested method.
+	Method string `json:"method"`
+	// KeyPairID is the requested key pair identifier, if known.
+	KeyPairID *api.KeyPairID `json:"key_pair_id,omitempty"`
+	// Generation is the requested master secret generation, if known.
+	Generation *uint64 `json:"generation,omitempty"`
+	// Epoch is the requested ephemeral key epoch, if known.
+	Epoch *beacon.EpochTime `json:"epoch,omitempty"`
+
+	// Allowed is true iff the request was allowed by the node's access control and successfully
+	// processed by the key manager enclave.
+	Allowed bool `json:"allowed"`
+	// Reason is the reason why the request was denied or failed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Time returns the time when the request was received.
+func (r *AuditRecord) Time() time.Time {
+	return time.Unix(0, r.Timestamp)
+}
+
+// AuditQuery is a key manager access audit log query.
+type AuditQuery struct {
+	// RuntimeID restricts the results to requests made for the given runtime.
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+	// From restricts the results to requests received at or after the given time.
+	From time.Time `json:"from,omitempty"`
+	// To restricts the results to requests received before the given time.
+	To time.Time `json:"to,omitempty"`
+	// Limit is the maximum number of returned records, most recent first (zero means no limit).
+	Limit int `json:"limit,omitempty"`
+}
+
+// Matches returns true iff the given record matches the query.
+func (q *AuditQuery) Matches(rec *AuditRecord) bool {
+	if q.RuntimeID != nil && (rec.RuntimeID == nil || !rec.RuntimeID.Equal(q.RuntimeID)) {
+		return false
+	}
+	t := rec.Time()
+	if !q.From.IsZero() && t.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !t.Before(q.To) {
+		return false
+	}
+	return true
+}
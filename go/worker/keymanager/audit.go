@@ -0,0 +1,133 @@
+package keymanager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
+	workerKeymanager "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+)
+
+// insecureRequest is an EnclaveRPC request with undecoded arguments.
+type insecureRequest struct {
+	Method string          `json:"method"`
+	Args   cbor.RawMessage `json:"args"`
+}
+
+// GetAuditLog returns the access audit log records matching the given query.
+func (w *Worker) GetAuditLog(_ context.Context, query *workerKeymanager.AuditQuery) ([]*workerKeymanager.AuditRecord, error) {
+	return w.auditLog.Query(query)
+}
+
+// auditSessionCall records a request sent over an encrypted session together with its outcome.
+//
+// As the request arguments and the response are encrypted, the request details are only known
+// if the enclave disclosed them in the audit metadata. Otherwise, the runtime is only known if
+// the peer is allowed to query keys for a single runtime. Requests rejected by the enclave are
+// only recorded as denied if the enclave failed to process the frame.
+func (w *Worker) auditSessionCall(peerID core.PeerID, method string, metadata cbor.RawMessage, err error) {
+	if w.auditLog == nil {
+		return
+	}
+
+	rec := w.newAuditRecord(peerID, method, err)
+	if metadata != nil {
+		setAuditRecordArgs(rec, method, metadata)
+	}
+
+	if rec.RuntimeID == nil {
+		w.RLock()
+		if runtimes := w.accessList[peerID]; len(runtimes) == 1 {
+			for runtimeID := range runtimes {
+				runtimeID := runtimeID
+				rec.RuntimeID = &runtimeID
+			}
+		}
+		w.RUnlock()
+	}
+
+	w.appendAuditRecord(rec)
+}
+
+// auditInsecureCall records a plaintext request together with its outcome.
+func (w *Worker) auditInsecureCall(peerID core.PeerID, data []byte, response []byte, err error) {
+	if w.auditLog == nil {
+		return
+	}
+
+	var req insecureRequest
+	if err := cbor.Unmarshal(data, &req); err != nil {
+		// Malformed requests are rejected by the enclave.
+		return
+	}
+
+	if err == nil {
+		// Plaintext responses reveal whether the enclave rejected the request.
+		var rsp enclaverpc.Response
+		switch rerr := cbor.Unmarshal(response, &rsp); {
+		case rerr != nil:
+			err = errMalformedResponse
+		case rsp.Body.Error != nil:
+			err = errors.New(*rsp.Body.Error)
+		}
+	}
+
+	rec := w.newAuditRecord(peerID, req.Method, err)
+
+	setAuditRecordArgs(rec, req.Method, req.Args)
+
+	w.appendAuditRecord(rec)
+}
+
+// setAuditRecordArgs records the runtime and key details from the given method arguments.
+func setAuditRecordArgs(rec *workerKeymanager.AuditRecord, method string, args cbor.RawMessage) {
+	switch method {
+	case api.RPCMethodGetOrCreateKeys, api.RPCMethodGetPublicKey:
+		var req api.LongTermKeyRequest
+		if err := cbor.Unmarshal(args, &req); err == nil {
+			rec.RuntimeID = &req.ID
+			rec.KeyPairID = &req.KeyPairID
+			rec.Generation = &req.Generation
+		}
+	case api.RPCMethodGetOrCreateEphemeralKeys, api.RPCMethodGetPublicEphemeralKey:
+		var req api.EphemeralKeyRequest
+		if err := cbor.Unmarshal(args, &req); err == nil {
+			rec.RuntimeID = &req.ID
+			rec.KeyPairID = &req.KeyPairID
+			rec.Epoch = &req.Epoch
+		}
+	}
+}
+
+func (w *Worker) newAuditRecord(peerID core.PeerID, method string, err error) *workerKeymanager.AuditRecord {
+	rec := &workerKeymanager.AuditRecord{
+		Timestamp: time.Now().UnixNano(),
+		PeerID:    peerID,
+		Method:    method,
+		Allowed:   err == nil,
+	}
+	if err != nil {
+		rec.Reason = err.Error()
+	}
+
+	w.RLock()
+	if nodeID, ok := w.accessNodes[peerID]; ok {
+		rec.NodeID = &nodeID
+	}
+	w.RUnlock()
+
+	return rec
+}
+
+func (w *Worker) appendAuditRecord(rec *workerKeymanager.AuditRecord) {
+	if err := w.auditLog.Append(rec); err != nil {
+		w.logger.Error("failed to append audit log record",
+			"err", err,
+		)
+	}
+}
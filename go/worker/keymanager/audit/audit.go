@@ -0,0 +1,320 @@
+// Package audit implements the key manager access audit log.
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+)
+
+const (
+	codecModuleName = "worker/keymanager/audit"
+
+	// syncInterval is the interval at which appended records are synced to disk.
+	syncInterval = time.Second
+)
+
+// countingFile is an audit log file that keeps track of its size.
+type countingFile struct {
+	*os.File
+
+	size uint64
+}
+
+func (f *countingFile) Write(p []byte) (int, error) {
+	n, err := f.File.Write(p)
+	f.size += uint64(n)
+	return n, err
+}
+
+// Log is an append-only key manager access audit log, rotated once the log file exceeds
+// the configured size.
+//
+// Rotated files are named by appending a sequence number to the path (e.g., audit.log.1 is
+// the most recently rotated file).
+//
+// Records are synced to disk in batches, so the records appended during the last sync interval
+// may be lost in case the node crashes.
+//
+// A nil log is valid and discards all records.
+type Log struct {
+	mu sync.Mutex
+
+	path        string
+	maxFileSize uint64
+	maxFiles    int
+
+	file  *countingFile
+	codec *cbor.MessageCodec
+	dirty bool
+
+	stopCh chan struct{}
+}
+
+// Append appends a record to the audit log.
+func (l *Log) Append(rec *api.AuditRecord) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("audit: log closed")
+	}
+	if err := l.codec.Write(rec); err != nil {
+		return fmt.Errorf("audit: failed to write record: %w", err)
+	}
+	l.dirty = true
+
+	if l.maxFileSize > 0 && l.file.size >= l.maxFileSize {
+		if err := l.rotate(); err != nil {
+			return fmt.Errorf("audit: failed to rotate log file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Query returns the records matching the given query, most recent first.
+func (l *Log) Query(query *api.AuditQuery) ([]*api.AuditRecord, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	// Only take a snapshot of the log while holding the lock so that reading the files doesn't
+	// block appending new records.
+	files, err := l.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	// Read the files newest first, stopping as soon as enough records have been found. As records
+	// can only be read oldest first within a file, only the most recent matching records that may
+	// still be returned are kept while reading each file, so that memory use is bounded by the limit.
+	var records []*api.AuditRecord
+	for i := len(files) - 1; i >= 0; i-- {
+		remaining := 0
+		if query.Limit > 0 {
+			remaining = query.Limit - len(records)
+		}
+
+		var (
+			matched []*api.AuditRecord
+			next    int
+		)
+		if err = readRecords(files[i], func(rec *api.AuditRecord) {
+			if !query.Matches(rec) {
+				return
+			}
+			if remaining == 0 || len(matched) < remaining {
+				matched = append(matched, rec)
+				return
+			}
+			// Overwrite the oldest kept record.
+			matched[next] = rec
+			next = (next + 1) % remaining
+		}); err != nil {
+			return nil, err
+		}
+
+		// Append the kept records, most recent first.
+		for j := len(matched) - 1; j >= 0; j-- {
+			records = append(records, matched[(next+j)%len(matched)])
+		}
+		if query.Limit > 0 && len(records) >= query.Limit {
+			break
+		}
+	}
+	return records, nil
+}
+
+// Close closes the audit log.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	close(l.stopCh)
+
+	err := l.file.Sync()
+	if cerr := l.file.Close(); err == nil {
+		err = cerr
+	}
+	l.file = nil
+	return err
+}
+
+func (l *Log) syncWorker() {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		l.mu.Lock()
+		f, dirty := l.file, l.dirty
+		l.dirty = false
+		l.mu.Unlock()
+
+		if dirty && f != nil {
+			// Sync without holding the lock so that appending is not blocked. Errors are ignored
+			// as the file is synced again when rotated or closed.
+			_ = f.Sync()
+		}
+	}
+}
+
+func (l *Log) rotate() error {
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.dirty = false
+
+	if l.maxFiles > 0 {
+		for i := l.maxFiles - 1; i > 0; i-- {
+			if err := os.Rename(rotatedPath(l.path, i), rotatedPath(l.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		if err := os.Rename(l.path, rotatedPath(l.path, 1)); err != nil {
+			return err
+		}
+	}
+
+	return l.open(os.O_TRUNC)
+}
+
+func (l *Log) open(flag int) error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|flag, 0o600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	l.file = &countingFile{File: f, size: uint64(fi.Size())}
+	l.codec = cbor.NewMessageCodec(l.file, codecModuleName)
+	return nil
+}
+
+// files returns the existing audit log files, oldest first.
+func (l *Log) files() []string {
+	var files []string
+	for i := l.maxFiles; i > 0; i-- {
+		fn := rotatedPath(l.path, i)
+		if _, err := os.Stat(fn); err == nil {
+			files = append(files, fn)
+		}
+	}
+	return append(files, l.path)
+}
+
+// snapshot opens the existing audit log files, oldest first, limiting the current file to the
+// records appended so far.
+//
+// Rotated files are not modified and the opened files remain readable even if they are renamed
+// or removed by a subsequent rotation, so the returned readers can be used without holding the lock.
+func (l *Log) snapshot() ([]*snapshotFile, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil, fmt.Errorf("audit: log closed")
+	}
+
+	var files []*snapshotFile
+	for _, fn := range l.files() {
+		f, err := os.Open(fn)
+		if err != nil {
+			for _, f := range files {
+				_ = f.Close()
+			}
+			return nil, fmt.Errorf("audit: failed to open log file: %w", err)
+		}
+		files = append(files, &snapshotFile{File: f, r: f})
+	}
+
+	// The current file is always the last one.
+	current := files[len(files)-1]
+	current.r = io.LimitReader(current.File, int64(l.file.size))
+
+	return files, nil
+}
+
+// snapshotFile is an audit log file opened for reading.
+type snapshotFile struct {
+	*os.File
+
+	r io.Reader
+}
+
+func (f *snapshotFile) Read(p []byte) (int, error) {
+	return f.r.Read(p)
+}
+
+func readRecords(f *snapshotFile, cb func(*api.AuditRecord)) error {
+	codec := cbor.NewMessageCodec(f, codecModuleName)
+	for {
+		var rec api.AuditRecord
+		switch err := codec.Read(&rec); {
+		case err == nil:
+			cb(&rec)
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			// The last record may have been truncated in case the node was stopped while writing.
+			return nil
+		default:
+			return fmt.Errorf("audit: failed to read record: %w", err)
+		}
+	}
+}
+
+// New creates a new audit log, appending to an existing log file if any.
+//
+// A zero maximum file size disables rotation, zero maximum files discards rotated files.
+func New(path string, maxFileSize uint64, maxFiles int) (*Log, error) {
+	l := &Log{
+		path:        path,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+		stopCh:      make(chan struct{}),
+	}
+	if err := l.open(os.O_APPEND); err != nil {
+		return nil, fmt.Errorf("audit: failed to open log file: %w", err)
+	}
+
+	go l.syncWorker()
+
+	return l, nil
+}
+
+func rotatedPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
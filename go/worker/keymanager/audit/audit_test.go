@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+)
+
+func TestLog(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID1, runtimeID2 common.Namespace
+	_ = runtimeID1.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	_ = runtimeID2.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000002")
+
+	peerID, err := p2p.PublicKeyToPeerID(signature.NewPublicKey("47aadd91516ac548decdb436fde957992610facc09ba2f850da0fe1b2be96119"))
+	require.NoError(err, "PublicKeyToPeerID")
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log, err := New(path, 512, 100)
+	require.NoError(err, "New")
+
+	start := time.Unix(1_700_000_000, 0)
+	for i := 0; i < 50; i++ {
+		runtimeID := runtimeID1
+		if i%2 == 1 {
+			runtimeID = runtimeID2
+		}
+		err = log.Append(&api.AuditRecord{
+			Timestamp: start.Add(time.Duration(i) * time.Second).UnixNano(),
+			PeerID:    peerID,
+			RuntimeID: &runtimeID,
+			Method:    "get_public_key",
+			Allowed:   i%5 != 0,
+		})
+		require.NoError(err, "Append")
+	}
+	require.Greater(len(log.files()), 1, "log should be rotated")
+
+	// All records.
+	records, err := log.Query(&api.AuditQuery{})
+	require.NoError(err, "Query")
+	require.Len(records, 50)
+	require.Equal(start.Add(49*time.Second).UnixNano(), records[0].Timestamp, "most recent record should be first")
+
+	// Filter by runtime and time.
+	records, err = log.Query(&api.AuditQuery{
+		RuntimeID: &runtimeID2,
+		From:      start.Add(10 * time.Second),
+		To:        start.Add(20 * time.Second),
+	})
+	require.NoError(err, "Query")
+	require.Len(records, 5)
+	for _, rec := range records {
+		require.EqualValues(runtimeID2, *rec.RuntimeID)
+	}
+
+	// Limit.
+	records, err = log.Query(&api.AuditQuery{Limit: 3})
+	require.NoError(err, "Query")
+	require.Len(records, 3)
+	for i, rec := range records {
+		require.Equal(start.Add(time.Duration(49-i)*time.Second).UnixNano(), rec.Timestamp, "most recent records should be returned")
+	}
+
+	// Limit spanning multiple log files.
+	records, err = log.Query(&api.AuditQuery{Limit: 45})
+	require.NoError(err, "Query")
+	require.Len(records, 45)
+	for i, rec := range records {
+		require.Equal(start.Add(time.Duration(49-i)*time.Second).UnixNano(), rec.Timestamp, "most recent records should be returned")
+	}
+
+	// Limit with a filter.
+	records, err = log.Query(&api.AuditQuery{RuntimeID: &runtimeID1, Limit: 20})
+	require.NoError(err, "Query")
+	require.Len(records, 20)
+	for i, rec := range records {
+		require.Equal(start.Add(time.Duration(48-2*i)*time.Second).UnixNano(), rec.Timestamp, "most recent matching records should be returned")
+	}
+
+	// Records should survive reopening the log.
+	require.NoError(log.Close(), "Close")
+	log, err = New(path, 512, 100)
+	require.NoError(err, "New")
+	defer log.Close()
+
+	records, err = log.Query(&api.AuditQuery{})
+	require.NoError(err, "Query")
+	require.Len(records, 50)
+}
+
+func TestLogQueryConcurrentAppend(t *testing.T) {
+	require := require.New(t)
+
+	peerID, err := p2p.PublicKeyToPeerID(signature.NewPublicKey("47aadd91516ac548decdb436fde957992610facc09ba2f850da0fe1b2be96119"))
+	require.NoError(err, "PublicKeyToPeerID")
+
+	log, err := New(filepath.Join(t.TempDir(), "audit.log"), 512, 100)
+	require.NoError(err, "New")
+	defer log.Close()
+
+	const numRecords = 200
+	appendCh := make(chan error, 1)
+	go func() {
+		for i := 0; i < numRecords; i++ {
+			if err := log.Append(&api.AuditRecord{
+				Timestamp: int64(i),
+				PeerID:    peerID,
+				Method:    "get_public_key",
+				Allowed:   true,
+			}); err != nil {
+				appendCh <- err
+				return
+			}
+		}
+		appendCh <- nil
+	}()
+
+	// Queries running concurrently with appends and rotations should always see a consistent
+	// prefix of the appended records.
+	for done := false; !done; {
+		select {
+		case err = <-appendCh:
+			require.NoError(err, "Append")
+			done = true
+		default:
+		}
+
+		records, err := log.Query(&api.AuditQuery{})
+		require.NoError(err, "Query")
+		for i, rec := range records {
+			require.EqualValues(len(records)-1-i, rec.Timestamp, "records should be consistent")
+		}
+	}
+
+	records, err := log.Query(&api.AuditQuery{})
+	require.NoError(err, "Query")
+	require.Len(records, numRecords)
+}
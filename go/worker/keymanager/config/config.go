@@ -1,6 +1,8 @@
 // Package config implements global configuration options.
 package config
 
+import "fmt"
+
 // Config is the keymanager worker configuration structure.
 type Config struct {
 	// Key manager Runtime ID.
@@ -9,10 +11,50 @@ type Config struct {
 	MayGenerate bool `yaml:"may_generate"`
 	// Base64-encoded public keys of unadvertised peers that may call protected methods.
 	PrivatePeerPubKeys []string `yaml:"private_peer_pub_keys"`
+
+	// Master secret disaster recovery.
+	Recovery RecoveryConfig `yaml:"recovery,omitempty"`
+	// Access audit log.
+	Audit AuditConfig `yaml:"audit,omitempty"`
+}
+
+// RecoveryConfig is the master secret disaster recovery configuration structure.
+type RecoveryConfig struct {
+	// Export master secret backups, encrypted to the recovery custodians defined in the key
+	// manager policy, whenever the enclave is initialized with a new master secret generation.
+	Export bool `yaml:"export"`
+	// Directory where master secret backups are written (relative to the data directory).
+	ExportDir string `yaml:"export_dir"`
+	// Path to a master secret recovery file that should be imported into the enclave
+	// before initialization (relative to the data directory).
+	ImportFile string `yaml:"import_file"`
+}
+
+// AuditConfig is the access audit log configuration structure.
+type AuditConfig struct {
+	// Enable recording of requests served to other nodes.
+	Enabled bool `yaml:"enabled"`
+	// Path to the audit log file (relative paths are relative to the data directory).
+	Path string `yaml:"path"`
+	// Size of the audit log file after which it is rotated (e.g., 100mb).
+	MaxFileSize string `yaml:"max_file_size"`
+	// Number of rotated audit log files to keep.
+	MaxFiles int `yaml:"max_files"`
 }
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	if c.Recovery.Export && c.Recovery.ExportDir == "" {
+		return fmt.Errorf("recovery.export_dir must be set when exporting master secrets")
+	}
+	if c.Audit.Enabled {
+		if c.Audit.Path == "" {
+			return fmt.Errorf("audit.path must be set when the audit log is enabled")
+		}
+		if c.Audit.MaxFiles < 0 {
+			return fmt.Errorf("audit.max_files must be >= 0")
+		}
+	}
 	return nil
 }
 
@@ -22,5 +64,16 @@ func DefaultConfig() Config {
 		RuntimeID:          "",
 		MayGenerate:        false,
 		PrivatePeerPubKeys: []string{},
+		Recovery: RecoveryConfig{
+			Export:     false,
+			ExportDir:  "keymanager-backups",
+			ImportFile: "",
+		},
+		Audit: AuditConfig{
+			Enabled:     false,
+			Path:        "keymanager-audit.log",
+			MaxFileSize: "100mb",
+			MaxFiles:    10,
+		},
 	}
 }
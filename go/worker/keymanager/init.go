@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math"
+	"path/filepath"
 
 	"github.com/libp2p/go-libp2p/core"
 
@@ -19,6 +20,7 @@ import (
 	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
+	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/audit"
 	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/p2p"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
@@ -52,6 +54,7 @@ func New(
 		accessList:          make(map[core.PeerID]map[common.Namespace]struct{}),
 		privatePeers:        make(map[core.PeerID]struct{}),
 		accessListByRuntime: make(map[common.Namespace][]core.PeerID),
+		accessNodes:         make(map[core.PeerID]signature.PublicKey),
 		commonWorker:        commonWorker,
 		backend:             backend,
 		enabled:             enabled,
@@ -85,6 +88,31 @@ func New(
 		w.privatePeers[peerID] = struct{}{}
 	}
 
+	// Configure master secret disaster recovery.
+	recoveryCfg := config.GlobalConfig.Keymanager.Recovery
+	w.recoveryExport = recoveryCfg.Export
+	w.recoveryExportDir = recoveryCfg.ExportDir
+	if !filepath.IsAbs(w.recoveryExportDir) {
+		w.recoveryExportDir = filepath.Join(config.GlobalConfig.Common.DataDir, w.recoveryExportDir)
+	}
+	w.recoveryImportFile = recoveryCfg.ImportFile
+	if w.recoveryImportFile != "" && !filepath.IsAbs(w.recoveryImportFile) {
+		w.recoveryImportFile = filepath.Join(config.GlobalConfig.Common.DataDir, w.recoveryImportFile)
+	}
+
+	// Open the access audit log.
+	if auditCfg := config.GlobalConfig.Keymanager.Audit; auditCfg.Enabled {
+		path := auditCfg.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(config.GlobalConfig.Common.DataDir, path)
+		}
+		var err error
+		w.auditLog, err = audit.New(path, uint64(config.ParseSizeInBytes(auditCfg.MaxFileSize)), auditCfg.MaxFiles)
+		if err != nil {
+			return nil, fmt.Errorf("worker/keymanager: failed to open audit log: %w", err)
+		}
+	}
+
 	// Parse runtime ID.
 	if err := w.runtimeID.UnmarshalHex(config.GlobalConfig.Keymanager.RuntimeID); err != nil {
 		return nil, fmt.Errorf("worker/keymanager: failed to parse runtime ID: %w", err)
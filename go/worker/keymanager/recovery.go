@@ -0,0 +1,129 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+// importMasterSecrets imports the master secrets from the configured recovery file into
+// the enclave. The import is done at most once, before the first enclave initialization.
+func (w *Worker) importMasterSecrets(kmStatus *api.Status) error {
+	if w.recoveryImportFile == "" || w.recoveryImported {
+		return nil
+	}
+
+	raw, err := os.ReadFile(w.recoveryImportFile)
+	if err != nil {
+		return fmt.Errorf("failed to read recovery file: %w", err)
+	}
+	var req api.ImportMasterSecretsRequest
+	if err = cbor.Unmarshal(raw, &req); err != nil {
+		return fmt.Errorf("malformed recovery file: %w", err)
+	}
+
+	if !req.ID.Equal(&w.runtimeID) {
+		return fmt.Errorf("recovery file runtime ID mismatch (expected: %s got: %s)", w.runtimeID, req.ID)
+	}
+	if req.Generation != kmStatus.Generation {
+		return fmt.Errorf("recovery file generation mismatch (expected: %d got: %d)", kmStatus.Generation, req.Generation)
+	}
+
+	w.logger.Info("importing master secrets",
+		"generation", req.Generation,
+		"shares", len(req.Shares),
+	)
+
+	if err = w.localCallEnclave(api.RPCMethodImportMasterSecrets, req, &protocol.Empty{}); err != nil {
+		return err
+	}
+
+	w.recoveryImported = true
+
+	w.logger.Info("master secrets imported",
+		"generation", req.Generation,
+	)
+
+	return nil
+}
+
+// handleExportMasterSecrets exports a backup of the master secrets if the enclave has been
+// initialized with a master secret generation which hasn't been exported yet.
+func (w *Worker) handleExportMasterSecrets(rsp *api.SignedInitResponse) {
+	if !w.recoveryExport || w.kmStatus == nil || w.kmStatus.Policy == nil {
+		return
+	}
+	if w.kmStatus.Policy.Policy.MasterSecretRecovery == nil {
+		return
+	}
+	if len(w.kmStatus.Checksum) == 0 || !bytes.Equal(w.kmStatus.Checksum, rsp.InitResponse.Checksum) {
+		// Enclave is not yet up-to-date with the latest master secret.
+		return
+	}
+	if bytes.Equal(w.exportedChecksum, rsp.InitResponse.Checksum) &&
+		bytes.Equal(w.exportedPolicyChecksum, rsp.InitResponse.PolicyChecksum) {
+		// Backup is up-to-date.
+		return
+	}
+
+	if err := w.exportMasterSecrets(w.kmStatus.Generation, w.kmStatus.Checksum); err != nil {
+		w.logger.Error("failed to export master secrets",
+			"err", err,
+			"generation", w.kmStatus.Generation,
+		)
+		return
+	}
+
+	w.exportedChecksum = rsp.InitResponse.Checksum
+	w.exportedPolicyChecksum = rsp.InitResponse.PolicyChecksum
+}
+
+func (w *Worker) exportMasterSecrets(generation uint64, checksum []byte) error {
+	w.logger.Info("exporting master secrets",
+		"generation", generation,
+	)
+
+	args := api.ExportMasterSecretsRequest{
+		Generation: generation,
+	}
+
+	var rsp api.ExportMasterSecretsResponse
+	if err := w.localCallEnclave(api.RPCMethodExportMasterSecrets, args, &rsp); err != nil {
+		return err
+	}
+
+	backup := rsp.Backup
+	switch {
+	case !backup.ID.Equal(&w.runtimeID):
+		return fmt.Errorf("backup runtime ID mismatch (expected: %s got: %s)", w.runtimeID, backup.ID)
+	case backup.Generation != generation:
+		return fmt.Errorf("backup generation mismatch (expected: %d got: %d)", generation, backup.Generation)
+	case !bytes.Equal(backup.Checksum, checksum):
+		return fmt.Errorf("backup checksum mismatch (expected: %s got: %s)", hex.EncodeToString(checksum), hex.EncodeToString(backup.Checksum))
+	case len(backup.Shares) < int(backup.Threshold) || backup.Threshold == 0:
+		return fmt.Errorf("backup has not enough shares")
+	}
+
+	if err := os.MkdirAll(w.recoveryExportDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	fn := filepath.Join(w.recoveryExportDir, fmt.Sprintf("master-secrets-%s-%d.cbor", w.runtimeID, generation))
+	if err := os.WriteFile(fn, cbor.Marshal(backup), 0o600); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	w.setLastExportedMasterSecretGeneration(generation)
+
+	w.logger.Info("master secrets exported",
+		"generation", generation,
+		"path", fn,
+	)
+
+	return nil
+}
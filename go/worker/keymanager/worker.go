@@ -38,6 +38,7 @@ import (
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
 	workerKeymanager "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/audit"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
@@ -53,6 +54,7 @@ var (
 	_ service.BackgroundService = (*Worker)(nil)
 
 	errMalformedResponse = fmt.Errorf("worker/keymanager: malformed response from worker")
+	errPeerNotAllowed    = fmt.Errorf("peer not on the access list")
 )
 
 type runtimeStatus struct {
@@ -85,6 +87,7 @@ type Worker struct { // nolint: maligned
 
 	accessList          map[core.PeerID]map[common.Namespace]struct{}
 	accessListByRuntime map[common.Namespace][]core.PeerID
+	accessNodes         map[core.PeerID]signature.PublicKey
 	privatePeers        map[core.PeerID]struct{}
 
 	commonWorker *workerCommon.Worker
@@ -95,12 +98,21 @@ type Worker struct { // nolint: maligned
 	enclaveStatus *api.SignedInitResponse
 	policy        *api.SignedPolicySGX
 
+	auditLog *audit.Log
+
 	masterSecretStats    workerKeymanager.MasterSecretStats
 	ephemeralSecretStats workerKeymanager.EphemeralSecretStats
 
 	enabled     bool
 	mayGenerate bool
 
+	recoveryExport         bool
+	recoveryExportDir      string
+	recoveryImportFile     string
+	recoveryImported       bool
+	exportedChecksum       []byte
+	exportedPolicyChecksum []byte
+
 	kmStatus *api.Status
 	rtStatus *runtimeStatus
 
@@ -168,6 +180,11 @@ func (w *Worker) Quit() <-chan struct{} {
 }
 
 func (w *Worker) Cleanup() {
+	if err := w.auditLog.Close(); err != nil {
+		w.logger.Error("failed to close audit log",
+			"err", err,
+		)
+	}
 }
 
 // Initialized returns a channel that will be closed when the worker is initialized, ready to
@@ -183,6 +200,9 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 		return nil, fmt.Errorf("not initialized")
 	}
 
+	// Audit the request once its outcome is known.
+	var audit func(rsp *protocol.RuntimeRPCCallResponse, err error)
+
 	switch kind {
 	case enclaverpc.KindNoiseSession:
 		// Handle access control as only peers on the access list can call this method.
@@ -199,6 +219,7 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 
 		// Note that the untrusted plaintext is also checked in the enclave, so if the node lied about
 		// what method it's using, we will know and the request will get rejected.
+		allowed := true
 		switch frame.UntrustedPlaintext {
 		case "":
 			// Anyone can connect.
@@ -208,20 +229,53 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 			if _, privatePeered := w.privatePeers[peerID]; !privatePeered {
 				// Defer to access control to check the policy.
 				w.RLock()
-				_, allowed := w.accessList[peerID]
+				_, allowed = w.accessList[peerID]
 				w.RUnlock()
-				if !allowed {
-					return nil, fmt.Errorf("not authorized")
+			}
+		}
+		if frame.UntrustedPlaintext != "" {
+			// Handshake frames are not audited as they don't request any keys.
+			audit = func(rsp *protocol.RuntimeRPCCallResponse, err error) {
+				var metadata cbor.RawMessage
+				if rsp != nil {
+					metadata = rsp.AuditMetadata
 				}
+				w.auditSessionCall(peerID, frame.UntrustedPlaintext, metadata, err)
+			}
+		}
+		if !allowed {
+			if audit != nil {
+				audit(nil, errPeerNotAllowed)
 			}
+			return nil, fmt.Errorf("not authorized")
 		}
 	case enclaverpc.KindInsecureQuery:
 		// Insecure queries are always allowed.
+		if peerID, ok := rpc.PeerIDFromContext(ctx); ok {
+			audit = func(rsp *protocol.RuntimeRPCCallResponse, err error) {
+				var response []byte
+				if rsp != nil {
+					response = rsp.Response
+				}
+				w.auditInsecureCall(peerID, data, response, err)
+			}
+		}
 	default:
 		// Local queries are not allowed.
 		return nil, fmt.Errorf("unsupported RPC kind")
 	}
 
+	rsp, err := w.callEnclave(ctx, data, kind)
+	if audit != nil {
+		audit(rsp, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Response, nil
+}
+
+func (w *Worker) callEnclave(ctx context.Context, data []byte, kind enclaverpc.Kind) (*protocol.RuntimeRPCCallResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, rpcCallTimeout)
 	defer cancel()
 
@@ -250,7 +304,7 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 		return nil, errMalformedResponse
 	}
 
-	return resp.Response, nil
+	return resp, nil
 }
 
 func (w *Worker) localCallEnclave(method string, args interface{}, rsp interface{}) error {
@@ -345,6 +399,12 @@ func (w *Worker) initEnclave(kmStatus *api.Status, rtStatus *runtimeStatus) (*ap
 		}
 	}
 
+	// Import recovered master secrets, if configured, so that the enclave can initialize
+	// without replicating them from other key manager enclaves.
+	if err = w.importMasterSecrets(kmStatus); err != nil {
+		return nil, fmt.Errorf("worker/keymanager: failed to import master secrets: %w", err)
+	}
+
 	var signedInitResp api.SignedInitResponse
 	if err := w.localCallEnclave(api.RPCMethodInit, args, &signedInitResp); err != nil {
 		w.logger.Error("failed to initialize enclave",
@@ -456,6 +516,14 @@ func (w *Worker) setLastLoadedMasterSecretGeneration(generation uint64) {
 	w.masterSecretStats.LastLoaded = generation
 }
 
+func (w *Worker) setLastExportedMasterSecretGeneration(generation uint64) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.masterSecretStats.NumExported++
+	w.masterSecretStats.LastExported = generation
+}
+
 func (w *Worker) setLastGeneratedEphemeralSecretEpoch(epoch beacon.EpochTime) {
 	w.Lock()
 	defer w.Unlock()
@@ -598,6 +666,7 @@ func (w *Worker) setAccessList(runtimeID common.Namespace, nodes []*node.Node) {
 		delete(entry, runtimeID)
 		if len(entry) == 0 {
 			delete(w.accessList, peerID)
+			delete(w.accessNodes, peerID)
 		}
 	}
 
@@ -620,6 +689,7 @@ func (w *Worker) setAccessList(runtimeID common.Namespace, nodes []*node.Node) {
 		}
 
 		entry[runtimeID] = struct{}{}
+		w.accessNodes[peerID] = node.ID
 		peers = append(peers, peerID)
 	}
 	w.accessListByRuntime[runtimeID] = peers
@@ -1048,6 +1118,7 @@ func (w *Worker) handleInitEnclaveDone(rsp *api.SignedInitResponse) {
 	// (Re)Register the node with the latest init response.
 	if rsp != nil {
 		w.registerNode(rsp)
+		w.handleExportMasterSecrets(rsp)
 	}
 }
 
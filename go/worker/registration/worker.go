@@ -185,6 +185,7 @@ type Worker struct { // nolint: maligned
 	registrationSigner signature.Signer
 
 	sentryAddresses []node.TLSAddress
+	sentryMonitor   *sentryClient.Monitor
 
 	runtimeRegistry runtimeRegistry.Registry
 	beacon          beacon.Backend
@@ -765,6 +766,10 @@ func (w *Worker) GetRegistrationStatus(ctx context.Context) (*control.Registrati
 	}
 	status.NodeStatus = ns
 
+	if w.sentryMonitor != nil {
+		status.Sentries = w.sentryMonitor.Health()
+	}
+
 	return status, nil
 }
 
@@ -986,33 +991,19 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (er
 }
 
 func (w *Worker) querySentries() []node.ConsensusAddress {
-	var consensusAddrs []node.ConsensusAddress
-	var err error
-
-	for _, sentryAddr := range w.sentryAddresses {
-		var client *sentryClient.Client
-		client, err = sentryClient.New(sentryAddr, w.identity)
-		if err != nil {
-			w.logger.Warn("failed to create client to a sentry node",
-				"err", err,
-				"sentry_address", sentryAddr,
-			)
-			continue
-		}
-		defer client.Close()
+	if w.sentryMonitor == nil {
+		return nil
+	}
 
-		// Query sentry node for addresses.
-		sentryAddresses, err := client.GetAddresses(w.ctx)
-		if err != nil {
-			w.logger.Warn("failed to obtain addresses from sentry node",
-				"err", err,
-				"sentry_address", sentryAddr,
-			)
-			continue
-		}
-		consensusAddrs = append(consensusAddrs, sentryAddresses.Consensus...)
+	// Wait for all sentry nodes to be probed at least once.
+	select {
+	case <-w.sentryMonitor.Initialized():
+	case <-w.stopCh:
+		return nil
 	}
 
+	// Only advertise addresses of healthy sentry nodes.
+	consensusAddrs := w.sentryMonitor.ConsensusAddresses()
 	if len(consensusAddrs) == 0 {
 		w.logger.Error("failed to obtain any consensus address from the configured sentry nodes",
 			"sentry_addresses", w.sentryAddresses,
@@ -1022,6 +1013,30 @@ func (w *Worker) querySentries() []node.ConsensusAddress {
 	return consensusAddrs
 }
 
+func (w *Worker) sentryHealthWorker() {
+	ch, sub := w.sentryMonitor.WatchHealth()
+	defer sub.Close()
+
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case health := <-ch:
+			w.logger.Info("sentry node health changed, re-registering",
+				"sentry_address", health.Address,
+				"healthy", health.Healthy,
+			)
+
+			// Re-register so that the published addresses only include healthy sentry nodes.
+			select {
+			case w.registerCh <- struct{}{}:
+			case <-w.stopCh:
+				return
+			}
+		}
+	}
+}
+
 // RequestDeregistration requests that the node not register itself in the next epoch.
 func (w *Worker) RequestDeregistration() error {
 	if !atomic.CompareAndSwapUint32(&w.deregRequested, 0, 1) {
@@ -1132,6 +1147,16 @@ func New(
 
 	w.storedDeregister = storedDeregister
 
+	if len(w.sentryAddresses) > 0 {
+		hcCfg := config.GlobalConfig.Sentry.HealthCheck
+		w.sentryMonitor = sentryClient.NewMonitor(w.sentryAddresses, identity, consensus, &sentryClient.MonitorConfig{
+			Interval:         hcCfg.Interval,
+			Timeout:          hcCfg.Timeout,
+			MaxHeightLag:     hcCfg.MaxHeightLag,
+			FailureThreshold: hcCfg.FailureThreshold,
+		})
+	}
+
 	if config.GlobalConfig.Consensus.Validator || config.GlobalConfig.Mode == config.ModeValidator {
 		rp, err := w.NewRoleProvider(node.RoleValidator)
 		if err != nil {
@@ -1170,6 +1195,11 @@ func (w *Worker) Start() error {
 		return nil
 	}
 
+	if w.sentryMonitor != nil {
+		w.sentryMonitor.Start()
+		go w.sentryHealthWorker()
+	}
+
 	go w.doNodeRegistration()
 	if cmmetrics.Enabled() {
 		go w.metricsWorker()
@@ -1183,6 +1213,9 @@ func (w *Worker) Stop() {
 	if !atomic.CompareAndSwapUint32(&w.stopped, 0, 1) {
 		return
 	}
+	if w.sentryMonitor != nil {
+		w.sentryMonitor.Stop()
+	}
 	close(w.stopCh)
 }
 
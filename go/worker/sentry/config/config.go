@@ -1,6 +1,11 @@
 // Package config implements global configuration options.
 package config
 
+import (
+	"fmt"
+	"time"
+)
+
 // Config is the sentry worker configuration structure.
 type Config struct {
 	// Enable Sentry worker.
@@ -8,6 +13,9 @@ type Config struct {
 	Enabled bool `yaml:"enabled"`
 
 	Control ControlConfig `yaml:"control,omitempty"`
+
+	// Health checks of sentry nodes performed by nodes behind sentries.
+	HealthCheck HealthCheckConfig `yaml:"health_check,omitempty"`
 }
 
 // ControlConfig is the sentry worker control configuration structure.
@@ -19,8 +27,34 @@ type ControlConfig struct {
 	AuthorizedPubkeys []string `yaml:"authorized_pubkeys"`
 }
 
+// HealthCheckConfig is the sentry health check configuration structure.
+//
+// Health checks are performed by nodes configured to use sentry nodes.
+type HealthCheckConfig struct {
+	// Interval between sentry node probes.
+	Interval time.Duration `yaml:"interval"`
+	// Timeout of a single sentry node probe.
+	Timeout time.Duration `yaml:"timeout"`
+	// Maximum number of blocks a sentry node may lag behind the local node to be considered healthy.
+	MaxHeightLag int64 `yaml:"max_height_lag"`
+	// Number of consecutive failed probes after which a sentry node is considered unhealthy.
+	FailureThreshold int `yaml:"failure_threshold"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	if c.HealthCheck.Interval <= 0 {
+		return fmt.Errorf("health_check.interval must be > 0")
+	}
+	if c.HealthCheck.Timeout <= 0 {
+		return fmt.Errorf("health_check.timeout must be > 0")
+	}
+	if c.HealthCheck.MaxHeightLag < 0 {
+		return fmt.Errorf("health_check.max_height_lag must be >= 0")
+	}
+	if c.HealthCheck.FailureThreshold < 1 {
+		return fmt.Errorf("health_check.failure_threshold must be >= 1")
+	}
 	return nil
 }
 
@@ -32,5 +66,11 @@ func DefaultConfig() Config {
 			Port:              9009,
 			AuthorizedPubkeys: []string{},
 		},
+		HealthCheck: HealthCheckConfig{
+			Interval:         10 * time.Second,
+			Timeout:          5 * time.Second,
+			MaxHeightLag:     10,
+			FailureThreshold: 3,
+		},
 	}
 }
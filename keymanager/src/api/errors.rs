@@ -59,6 +59,10 @@ pub enum KeyManagerError {
     EphemeralSecretChecksumMismatch,
     #[error("invalid ciphertext")]
     InvalidCiphertext,
+    #[error("master secret recovery not allowed by policy")]
+    MasterSecretRecoveryNotAllowed,
+    #[error("invalid master secret shares")]
+    InvalidShares,
     #[error("status not found")]
     StatusNotFound,
     #[error("runtime mismatch")]
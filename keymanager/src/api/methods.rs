@@ -21,3 +21,7 @@ pub const LOCAL_METHOD_GENERATE_EPHEMERAL_SECRET: &str = "generate_ephemeral_sec
 pub const LOCAL_METHOD_LOAD_MASTER_SECRET: &str = "load_master_secret";
 /// Name of the `load_ephemeral_secret` local method.
 pub const LOCAL_METHOD_LOAD_EPHEMERAL_SECRET: &str = "load_ephemeral_secret";
+/// Name of the `export_master_secrets` local method.
+pub const LOCAL_METHOD_EXPORT_MASTER_SECRETS: &str = "export_master_secrets";
+/// Name of the `import_master_secrets` local method.
+pub const LOCAL_METHOD_IMPORT_MASTER_SECRETS: &str = "import_master_secrets";
@@ -1,10 +1,13 @@
-use std::sync::Arc;
+use std::{collections::HashMap, sync::Arc};
 
 use anyhow::Result;
 
 use oasis_core_runtime::{
     common::{
-        crypto::signature::{self, Signature, Signer},
+        crypto::{
+            signature::{self, Signature, Signer},
+            x25519,
+        },
         namespace::Namespace,
     },
     consensus::{
@@ -151,6 +154,58 @@ pub struct LoadEphemeralSecretRequest {
     pub signed_secret: SignedEncryptedEphemeralSecret,
 }
 
+/// Export master secrets request.
+#[derive(Clone, Default, cbor::Encode, cbor::Decode)]
+pub struct ExportMasterSecretsRequest {
+    /// Generation of the latest master secret which should be exported.
+    pub generation: u64,
+}
+
+/// Export master secrets response.
+#[derive(Clone, Default, cbor::Encode, cbor::Decode)]
+pub struct ExportMasterSecretsResponse {
+    /// Master secret backup.
+    pub backup: MasterSecretBackup,
+}
+
+/// Backup of all master secret generations, split into Shamir shares encrypted
+/// to the recovery custodians.
+#[derive(Clone, Default, cbor::Encode, cbor::Decode)]
+pub struct MasterSecretBackup {
+    /// Runtime ID of the key manager.
+    pub runtime_id: Namespace,
+    /// Generation of the latest master secret in the backup.
+    pub generation: u64,
+    /// Checksum of the latest master secret in the backup.
+    pub checksum: Vec<u8>,
+    /// Number of shares needed to recover the master secrets.
+    pub threshold: u8,
+    /// Public key used to derive the symmetric keys for decryption.
+    pub pub_key: x25519::PublicKey,
+    /// Custodian encrypted shares.
+    pub shares: HashMap<x25519::PublicKey, Vec<u8>>,
+}
+
+/// Master secret share encrypted to the REK of the importing enclave.
+#[derive(Clone, Default, cbor::Encode, cbor::Decode)]
+pub struct EncryptedMasterSecretShare {
+    /// Public key used to derive the symmetric key for decryption.
+    pub pub_key: x25519::PublicKey,
+    /// REK encrypted share.
+    pub ciphertext: Vec<u8>,
+}
+
+/// Import master secrets request.
+#[derive(Clone, Default, cbor::Encode, cbor::Decode)]
+pub struct ImportMasterSecretsRequest {
+    /// Runtime ID of the key manager.
+    pub runtime_id: Namespace,
+    /// Generation of the latest master secret in the backup.
+    pub generation: u64,
+    /// Master secret shares encrypted to the importing enclave.
+    pub shares: Vec<EncryptedMasterSecretShare>,
+}
+
 /// Long-term key request for private/public key generation and retrieval.
 ///
 /// Long-term keys are runtime-scoped long-lived keys derived by the key manager
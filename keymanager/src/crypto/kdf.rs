@@ -752,6 +752,73 @@ impl Kdf {
         Ok(())
     }
 
+    /// Load all master secret generations up to and including the given one, which must be
+    /// the latest known generation, together with the checksum of the latest generation.
+    pub fn export_master_secrets(
+        &self,
+        storage: &dyn KeyValue,
+        generation: u64,
+    ) -> Result<(Vec<Secret>, Vec<u8>)> {
+        let inner = self.inner.read().unwrap();
+
+        // Export only complete state so that backups can be verified against the consensus.
+        let last_generation = inner.get_generation()?;
+        if generation != last_generation {
+            return Err(KeyManagerError::InvalidGeneration(last_generation, generation).into());
+        }
+        let runtime_id = inner.get_runtime_id()?;
+        let checksum = inner.get_checksum()?;
+
+        let mut secrets = Vec::new();
+        for generation in 0..=last_generation {
+            let secret = Self::load_master_secret(storage, &runtime_id, generation)
+                .ok_or(KeyManagerError::MasterSecretNotFound(generation))?;
+            secrets.push(secret);
+        }
+
+        Ok((secrets, checksum))
+    }
+
+    /// Verify master secrets recovered from a backup and store them encrypted in untrusted
+    /// local storage, so that they are loaded on the next initialization.
+    ///
+    /// The secrets must contain all generations, starting with the first one, and are trusted
+    /// only if the checksum of the last generation matches the given one, which should be
+    /// the checksum published in the consensus layer.
+    pub fn import_master_secrets(
+        &self,
+        storage: &dyn KeyValue,
+        runtime_id: Namespace,
+        secrets: Vec<Secret>,
+        checksum: &Vec<u8>,
+    ) -> Result<()> {
+        {
+            let mut inner = self.inner.write().unwrap();
+            inner.set_runtime_id(runtime_id)?;
+        }
+
+        let mut prev_checksums = Vec::with_capacity(secrets.len());
+        let mut last_checksum = runtime_id.0.to_vec();
+        for secret in secrets.iter() {
+            let next_checksum = Self::checksum_master_secret(secret, &last_checksum);
+            prev_checksums.push(last_checksum);
+            last_checksum = next_checksum;
+        }
+        if &last_checksum != checksum {
+            return Err(KeyManagerError::MasterSecretChecksumMismatch.into());
+        }
+
+        for (generation, (secret, prev_checksum)) in
+            secrets.iter().zip(prev_checksums.into_iter()).enumerate()
+        {
+            let generation = generation as u64;
+            Self::store_master_secret(storage, &runtime_id, secret, generation);
+            Self::store_checksum(storage, prev_checksum, generation);
+        }
+
+        Ok(())
+    }
+
     /// Load master secret from untrusted local storage.
     ///
     /// Loaded secrets are authenticated so there is no need to calculate and verify the checksum
@@ -1846,6 +1913,70 @@ mod tests {
         }
     }
 
+    #[test]
+    fn master_secrets_can_be_exported_and_imported() {
+        let kdf = Kdf::new();
+        let storage = InMemoryKeyValue::new();
+        let runtime_id = Namespace::from(vec![1u8; 32]);
+        let epoch = 0;
+        let generation = 4;
+        let provider = MockSecretProvider::new(runtime_id, false);
+        let checksum = provider.checksum_master_secret(generation);
+
+        // KDF needs to be initialized.
+        let result = kdf.export_master_secrets(&storage, generation);
+        assert!(result.is_err());
+
+        let result = kdf.init(
+            &storage,
+            runtime_id,
+            generation,
+            checksum.clone(),
+            epoch,
+            &provider,
+        );
+        assert!(result.is_ok());
+
+        // Only the latest generation can be exported.
+        let result = kdf.export_master_secrets(&storage, generation - 1);
+        assert!(result.is_err());
+
+        let (secrets, exported_checksum) = kdf
+            .export_master_secrets(&storage, generation)
+            .expect("master secrets should be exported");
+        assert_eq!(secrets.len(), generation as usize + 1);
+        assert_eq!(exported_checksum, checksum);
+
+        // Import into a fresh KDF which cannot replicate secrets from other enclaves.
+        let kdf = Kdf::new();
+        let storage = InMemoryKeyValue::new();
+        let provider = MockSecretProvider::new(runtime_id, true);
+
+        let invalid_checksum = vec![0u8; 32];
+        let result =
+            kdf.import_master_secrets(&storage, runtime_id, secrets.clone(), &invalid_checksum);
+        assert!(result.is_err());
+
+        let result =
+            kdf.import_master_secrets(&storage, runtime_id, secrets[1..].to_vec(), &checksum);
+        assert!(result.is_err());
+
+        let result = kdf.import_master_secrets(&storage, runtime_id, secrets, &checksum);
+        assert!(result.is_ok());
+
+        let state = kdf
+            .init(
+                &storage,
+                runtime_id,
+                generation,
+                checksum.clone(),
+                epoch,
+                &provider,
+            )
+            .expect("imported master secrets should be loaded");
+        assert_eq!(state.checksum, checksum);
+    }
+
     #[test]
     fn master_secret_save_load() {
         let storage = InMemoryKeyValue::new();
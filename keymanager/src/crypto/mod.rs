@@ -1,6 +1,7 @@
 //! Key manager crypto types and primitives.
 pub mod kdf;
 mod packing;
+pub mod shamir;
 mod types;
 
 // Re-exports.
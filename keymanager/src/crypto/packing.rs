@@ -150,6 +150,18 @@ pub fn unpack_encrypted_secret_nonce(data: &Vec<u8>) -> Option<(Vec<u8>, [u8; NO
     Some((ciphertext, nonce))
 }
 
+/// Unpack the concatenation of ciphertext of arbitrary length and nonce (ciphertext || nonce).
+pub fn unpack_ciphertext_nonce(data: &[u8]) -> Option<(Vec<u8>, [u8; NONCE_SIZE])> {
+    if data.len() < TAG_SIZE + NONCE_SIZE {
+        return None;
+    }
+
+    let (ciphertext, nonce) = data.split_at(data.len() - NONCE_SIZE);
+    let nonce: [u8; NONCE_SIZE] = nonce.try_into().expect("slice with incorrect length");
+
+    Some((ciphertext.to_vec(), nonce))
+}
+
 #[cfg(test)]
 mod test {
     use oasis_core_runtime::{
@@ -0,0 +1,194 @@
+//! Shamir's secret sharing over GF(2^8).
+//!
+//! Every byte of the secret is shared independently using a random polynomial of degree
+//! `threshold - 1`, so shares are as long as the secret plus one byte holding the evaluation
+//! point.
+use anyhow::Result;
+use rand::{rngs::OsRng, Rng};
+use zeroize::Zeroize;
+
+use crate::api::KeyManagerError;
+
+/// A share of a secret.
+#[derive(Clone, Default, Debug, PartialEq, Eq, Zeroize)]
+#[zeroize(drop)]
+pub struct Share {
+    /// Non-zero point at which the polynomials were evaluated.
+    pub x: u8,
+    /// Evaluations of the polynomials, one per byte of the secret.
+    pub y: Vec<u8>,
+}
+
+impl Share {
+    /// Serialize the share (x || y).
+    pub fn to_vec(&self) -> Vec<u8> {
+        let mut data = Vec::with_capacity(1 + self.y.len());
+        data.push(self.x);
+        data.extend_from_slice(&self.y);
+        data
+    }
+
+    /// Deserialize the share (x || y).
+    pub fn from_slice(data: &[u8]) -> Option<Self> {
+        match data.split_first() {
+            Some((&x, y)) if x != 0 => Some(Self { x, y: y.to_vec() }),
+            _ => None,
+        }
+    }
+}
+
+/// Split the secret into `n` shares, any `threshold` of which can reconstruct it.
+pub fn split(secret: &[u8], threshold: u8, n: u8) -> Result<Vec<Share>> {
+    if threshold == 0 || threshold > n {
+        return Err(KeyManagerError::InvalidShares.into());
+    }
+
+    let mut rng = OsRng {};
+    let mut shares: Vec<Share> = (1..=n)
+        .map(|x| Share {
+            x,
+            y: Vec::with_capacity(secret.len()),
+        })
+        .collect();
+
+    let mut coefficients = vec![0u8; threshold as usize];
+    for &byte in secret {
+        coefficients[0] = byte;
+        rng.fill(&mut coefficients[1..]);
+
+        for share in shares.iter_mut() {
+            share.y.push(evaluate(&coefficients, share.x));
+        }
+    }
+    coefficients.zeroize();
+
+    Ok(shares)
+}
+
+/// Reconstruct the secret from the given shares.
+///
+/// Note that the result is garbage if fewer than `threshold` shares are given, so the caller
+/// needs to verify the reconstructed secret.
+pub fn combine(shares: &[Share]) -> Result<Vec<u8>> {
+    let len = match shares.first() {
+        Some(share) => share.y.len(),
+        None => return Err(KeyManagerError::InvalidShares.into()),
+    };
+
+    for (i, share) in shares.iter().enumerate() {
+        if share.x == 0 || share.y.len() != len {
+            return Err(KeyManagerError::InvalidShares.into());
+        }
+        if shares[..i].iter().any(|other| other.x == share.x) {
+            return Err(KeyManagerError::InvalidShares.into());
+        }
+    }
+
+    let mut secret = vec![0u8; len];
+    for (i, share) in shares.iter().enumerate() {
+        // Lagrange basis polynomial evaluated at zero.
+        let mut basis = 1u8;
+        for (j, other) in shares.iter().enumerate() {
+            if i != j {
+                basis = mul(basis, mul(other.x, inv(other.x ^ share.x)));
+            }
+        }
+
+        for (s, &y) in secret.iter_mut().zip(share.y.iter()) {
+            *s ^= mul(basis, y);
+        }
+    }
+
+    Ok(secret)
+}
+
+/// Evaluate the polynomial at the given point using Horner's method.
+fn evaluate(coefficients: &[u8], x: u8) -> u8 {
+    coefficients
+        .iter()
+        .rev()
+        .fold(0u8, |acc, &c| mul(acc, x) ^ c)
+}
+
+/// Multiply two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x + 1, in constant time.
+fn mul(a: u8, b: u8) -> u8 {
+    let (mut a, mut b, mut p) = (a, b, 0u8);
+    for _ in 0..8 {
+        p ^= a & (b & 1).wrapping_neg();
+        let carry = (a >> 7).wrapping_neg();
+        a = (a << 1) ^ (0x1b & carry);
+        b >>= 1;
+    }
+    p
+}
+
+/// Compute the multiplicative inverse of a non-zero element of GF(2^8), i.e. a^254.
+fn inv(a: u8) -> u8 {
+    let (mut base, mut exp, mut result) = (a, 254u8, 1u8);
+    while exp > 0 {
+        if exp & 1 == 1 {
+            result = mul(result, base);
+        }
+        base = mul(base, base);
+        exp >>= 1;
+    }
+    result
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn field_arithmetic() {
+        assert_eq!(mul(0x53, 0xca), 0x01);
+        assert_eq!(mul(0x57, 0x83), 0xc1);
+        for a in 1..=255u8 {
+            assert_eq!(mul(a, inv(a)), 1, "inverse of {} should be correct", a);
+            assert_eq!(mul(a, 0), 0);
+            assert_eq!(mul(a, 1), a);
+        }
+    }
+
+    #[test]
+    fn split_and_combine() {
+        let secret: Vec<u8> = (0..64).collect();
+        let shares = split(&secret, 3, 5).expect("split should succeed");
+        assert_eq!(shares.len(), 5);
+
+        // Any threshold of shares should reconstruct the secret.
+        for subset in [[0, 1, 2], [0, 2, 4], [4, 3, 1]] {
+            let subset: Vec<Share> = subset.iter().map(|&i| shares[i].clone()).collect();
+            let recovered = combine(&subset).expect("combine should succeed");
+            assert_eq!(recovered, secret);
+        }
+
+        // All shares should reconstruct the secret as well.
+        let recovered = combine(&shares).expect("combine should succeed");
+        assert_eq!(recovered, secret);
+
+        // Not enough shares.
+        let recovered = combine(&shares[..2]).expect("combine should succeed");
+        assert_ne!(recovered, secret);
+
+        // Serialization.
+        let share = Share::from_slice(&shares[0].to_vec()).expect("share should deserialize");
+        assert_eq!(share, shares[0]);
+        assert_eq!(Share::from_slice(&[0, 1, 2]), None);
+        assert_eq!(Share::from_slice(&[]), None);
+    }
+
+    #[test]
+    fn invalid_parameters() {
+        split(&[1, 2, 3], 0, 3).expect_err("zero threshold should fail");
+        split(&[1, 2, 3], 4, 3).expect_err("threshold above number of shares should fail");
+
+        let shares = split(&[1, 2, 3], 2, 3).expect("split should succeed");
+        combine(&[]).expect_err("no shares should fail");
+        combine(&[shares[0].clone(), shares[0].clone()]).expect_err("duplicate shares should fail");
+
+        let mut short = shares[1].clone();
+        short.y.pop();
+        combine(&[shares[0].clone(), short]).expect_err("shares of different lengths should fail");
+    }
+}
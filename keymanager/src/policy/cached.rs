@@ -18,7 +18,10 @@ use oasis_core_runtime::{
             EnclaveIdentity,
         },
     },
-    consensus::{beacon::EpochTime, keymanager::SignedPolicySGX},
+    consensus::{
+        beacon::EpochTime,
+        keymanager::{MasterSecretRecoveryPolicy, SignedPolicySGX},
+    },
     storage::KeyValue,
 };
 
@@ -169,6 +172,16 @@ impl Policy {
         }
     }
 
+    /// Return the master secret recovery policy if exporting master secrets is allowed.
+    pub fn master_secret_recovery(&self) -> Result<MasterSecretRecoveryPolicy> {
+        let inner = self.inner.read().unwrap();
+        inner
+            .policy
+            .as_ref()
+            .and_then(|policy| policy.master_secret_recovery.clone())
+            .ok_or_else(|| KeyManagerError::MasterSecretRecoveryNotAllowed.into())
+    }
+
     fn load_policy(storage: &dyn KeyValue) -> Option<CachedPolicy> {
         let ciphertext = storage.get(POLICY_STORAGE_KEY.to_vec()).unwrap();
 
@@ -198,6 +211,7 @@ struct CachedPolicy {
     pub may_replicate_from: HashSet<EnclaveIdentity>,
     pub master_secret_rotation_interval: EpochTime,
     pub max_ephemeral_secret_age: EpochTime,
+    pub master_secret_recovery: Option<MasterSecretRecoveryPolicy>,
 }
 
 impl CachedPolicy {
@@ -215,6 +229,9 @@ impl CachedPolicy {
         cached_policy.runtime_id = policy.id;
         cached_policy.checksum = checksum;
 
+        // The recovery policy applies to all enclaves of the key manager.
+        cached_policy.master_secret_recovery = policy.master_secret_recovery.clone();
+
         // Convert the policy into a cached one.
         //
         // TODO: Need a mock enclave identity for non-sgx builds if we want to
@@ -9,8 +9,9 @@ use oasis_core_runtime::{
 
 use crate::{
     api::{
-        LOCAL_METHOD_GENERATE_EPHEMERAL_SECRET, LOCAL_METHOD_GENERATE_MASTER_SECRET,
-        LOCAL_METHOD_INIT, LOCAL_METHOD_LOAD_EPHEMERAL_SECRET, LOCAL_METHOD_LOAD_MASTER_SECRET,
+        LOCAL_METHOD_EXPORT_MASTER_SECRETS, LOCAL_METHOD_GENERATE_EPHEMERAL_SECRET,
+        LOCAL_METHOD_GENERATE_MASTER_SECRET, LOCAL_METHOD_IMPORT_MASTER_SECRETS, LOCAL_METHOD_INIT,
+        LOCAL_METHOD_LOAD_EPHEMERAL_SECRET, LOCAL_METHOD_LOAD_MASTER_SECRET,
         METHOD_GET_OR_CREATE_EPHEMERAL_KEYS, METHOD_GET_OR_CREATE_KEYS,
         METHOD_GET_PUBLIC_EPHEMERAL_KEY, METHOD_GET_PUBLIC_KEY, METHOD_REPLICATE_EPHEMERAL_SECRET,
         METHOD_REPLICATE_MASTER_SECRET,
@@ -107,6 +108,20 @@ pub fn new_keymanager(signers: TrustedPolicySigners) -> Box<dyn Initializer> {
             },
             methods::load_ephemeral_secret,
         ));
+        state.rpc_dispatcher.add_method(RpcMethod::new(
+            RpcMethodDescriptor {
+                name: LOCAL_METHOD_EXPORT_MASTER_SECRETS.to_string(),
+                kind: RpcKind::LocalQuery,
+            },
+            methods::export_master_secrets,
+        ));
+        state.rpc_dispatcher.add_method(RpcMethod::new(
+            RpcMethodDescriptor {
+                name: LOCAL_METHOD_IMPORT_MASTER_SECRETS.to_string(),
+                kind: RpcKind::LocalQuery,
+            },
+            methods::import_master_secrets,
+        ));
 
         let runtime_id = state.protocol.get_runtime_id();
         let protocol = state.protocol.clone(); // Shut up the borrow checker.
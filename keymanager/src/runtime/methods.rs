@@ -6,6 +6,7 @@ use std::{
 };
 
 use anyhow::Result;
+use zeroize::Zeroize;
 
 use oasis_core_runtime::{
     common::{
@@ -40,17 +41,21 @@ use oasis_core_runtime::{
 
 use crate::{
     api::{
-        EphemeralKeyRequest, GenerateEphemeralSecretRequest, GenerateEphemeralSecretResponse,
-        GenerateMasterSecretRequest, GenerateMasterSecretResponse, InitRequest, InitResponse,
-        KeyManagerError, LoadEphemeralSecretRequest, LoadMasterSecretRequest, LongTermKeyRequest,
+        EphemeralKeyRequest, ExportMasterSecretsRequest, ExportMasterSecretsResponse,
+        GenerateEphemeralSecretRequest, GenerateEphemeralSecretResponse,
+        GenerateMasterSecretRequest, GenerateMasterSecretResponse, ImportMasterSecretsRequest,
+        InitRequest, InitResponse, KeyManagerError, LoadEphemeralSecretRequest,
+        LoadMasterSecretRequest, LongTermKeyRequest, MasterSecretBackup,
         ReplicateEphemeralSecretRequest, ReplicateEphemeralSecretResponse,
         ReplicateMasterSecretRequest, ReplicateMasterSecretResponse, SignedInitResponse,
     },
     client::RemoteClient,
     crypto::{
         kdf::{Kdf, State},
-        pack_runtime_id_epoch, pack_runtime_id_generation_epoch, unpack_encrypted_secret_nonce,
-        KeyPair, Secret, SignedPublicKey, SECRET_SIZE,
+        pack_runtime_id_epoch, pack_runtime_id_generation, pack_runtime_id_generation_epoch,
+        shamir::{self, Share},
+        unpack_ciphertext_nonce, unpack_encrypted_secret_nonce, KeyPair, Secret, SignedPublicKey,
+        SECRET_SIZE,
     },
     policy::Policy,
     runtime::context::Context as KmContext,
@@ -97,6 +102,7 @@ pub fn init_kdf(ctx: &mut RpcContext, req: &InitRequest) -> Result<SignedInitRes
 
 /// See `Kdf::get_or_create_keys`.
 pub fn get_or_create_keys(ctx: &mut RpcContext, req: &LongTermKeyRequest) -> Result<KeyPair> {
+    ctx.audit_metadata = Some(cbor::to_value(req.clone()));
     authorize_private_key_generation(ctx, &req.runtime_id)?;
     validate_height_freshness(ctx, req.height)?;
 
@@ -129,6 +135,7 @@ pub fn get_or_create_ephemeral_keys(
     ctx: &mut RpcContext,
     req: &EphemeralKeyRequest,
 ) -> Result<KeyPair> {
+    ctx.audit_metadata = Some(cbor::to_value(req.clone()));
     authorize_private_key_generation(ctx, &req.runtime_id)?;
     validate_ephemeral_key_epoch(ctx, req.epoch)?;
     validate_height_freshness(ctx, req.height)?;
@@ -353,6 +360,129 @@ pub fn load_ephemeral_secret(ctx: &mut RpcContext, req: &LoadEphemeralSecretRequ
     )
 }
 
+/// Export all master secret generations, split into Shamir shares encrypted to the recovery
+/// custodians defined in the key manager policy.
+pub fn export_master_secrets(
+    ctx: &mut RpcContext,
+    req: &ExportMasterSecretsRequest,
+) -> Result<ExportMasterSecretsResponse> {
+    // Exports must be explicitly allowed by the policy.
+    let recovery = Policy::global().master_secret_recovery()?;
+    let num_shares: u8 = recovery
+        .custodians
+        .len()
+        .try_into()
+        .map_err(|_| KeyManagerError::InvalidShares)?;
+
+    let kdf = Kdf::global();
+    let runtime_id = kdf.runtime_id()?;
+    let generation = req.generation;
+    let (secrets, checksum) = kdf.export_master_secrets(ctx.untrusted_local_storage, generation)?;
+
+    // Split all generations at once so that every custodian holds a single share.
+    let mut plaintext: Vec<u8> = secrets.iter().flat_map(|s| s.0.iter().copied()).collect();
+    let shares = shamir::split(&plaintext, recovery.threshold, num_shares);
+    plaintext.zeroize();
+    let shares = shares?;
+
+    // Encrypt the shares.
+    let priv_key = x25519::PrivateKey::generate();
+    let pub_key = x25519::PublicKey::from(&priv_key);
+    let mut nonce = Nonce::generate();
+    let additional_data = pack_runtime_id_generation(&runtime_id, generation);
+    let mut ciphertexts = HashMap::new();
+    for (custodian, share) in recovery.custodians.iter().zip(shares.iter()) {
+        nonce.increment()?;
+
+        let mut plaintext = share.to_vec();
+        let ciphertext = deoxysii::box_seal(
+            &nonce,
+            plaintext.clone(),
+            additional_data.clone(),
+            &custodian.0,
+            &priv_key.0,
+        );
+        plaintext.zeroize();
+        let mut ciphertext = ciphertext?;
+        ciphertext.extend_from_slice(&nonce.to_vec());
+
+        ciphertexts.insert(*custodian, ciphertext);
+    }
+
+    Ok(ExportMasterSecretsResponse {
+        backup: MasterSecretBackup {
+            runtime_id,
+            generation,
+            checksum,
+            threshold: recovery.threshold,
+            pub_key,
+            shares: ciphertexts,
+        },
+    })
+}
+
+/// Decrypt and combine master secret shares re-encrypted to our REK by the recovery custodians,
+/// and store the recovered master secrets if they match the checksum published in the consensus
+/// layer.
+pub fn import_master_secrets(ctx: &mut RpcContext, req: &ImportMasterSecretsRequest) -> Result<()> {
+    let rctx = runtime_context!(ctx, KmContext);
+    let runtime_id = rctx.runtime_id;
+    if req.runtime_id != runtime_id {
+        return Err(KeyManagerError::RuntimeMismatch.into());
+    }
+
+    // Only the latest generation can be recovered as the consensus layer publishes
+    // the checksum of the latest master secret only.
+    let status = key_manager_status(ctx, runtime_id)?;
+    if status.checksum.is_empty() {
+        return Err(KeyManagerError::NotInitialized.into());
+    }
+    if status.generation != req.generation {
+        return Err(KeyManagerError::InvalidGeneration(status.generation, req.generation).into());
+    }
+
+    // Decrypt the shares.
+    let additional_data = pack_runtime_id_generation(&runtime_id, req.generation);
+    let mut shares = Vec::with_capacity(req.shares.len());
+    for share in req.shares.iter() {
+        let (ciphertext, nonce) =
+            unpack_ciphertext_nonce(&share.ciphertext).ok_or(KeyManagerError::InvalidCiphertext)?;
+        let mut plaintext = ctx.identity.box_open(
+            &nonce,
+            ciphertext,
+            additional_data.clone(),
+            &share.pub_key.0,
+        )?;
+        let share = Share::from_slice(&plaintext);
+        plaintext.zeroize();
+
+        shares.push(share.ok_or(KeyManagerError::InvalidShares)?);
+    }
+
+    // Recover all generations.
+    let mut plaintext = shamir::combine(&shares)?;
+    let num_secrets = req
+        .generation
+        .checked_add(1)
+        .ok_or(KeyManagerError::InvalidShares)?;
+    if plaintext.len() as u64 != num_secrets.saturating_mul(SECRET_SIZE as u64) {
+        plaintext.zeroize();
+        return Err(KeyManagerError::InvalidShares.into());
+    }
+    let secrets = plaintext
+        .chunks_exact(SECRET_SIZE)
+        .map(|chunk| Secret(chunk.try_into().expect("slice with incorrect length")))
+        .collect();
+    plaintext.zeroize();
+
+    Kdf::global().import_master_secrets(
+        ctx.untrusted_local_storage,
+        runtime_id,
+        secrets,
+        &status.checksum,
+    )
+}
+
 /// Decrypt master secret with local REK key.
 fn decrypt_master_secret(
     ctx: &mut RpcContext,
@@ -563,14 +693,21 @@ fn validate_signed_ephemeral_secret(
     Ok(published_signed_secret.secret)
 }
 
-/// Fetch the identities of the key manager nodes.
-fn key_manager_nodes(ctx: &RpcContext, id: Namespace) -> Result<Vec<signature::PublicKey>> {
+/// Fetch the key manager status from the consensus layer.
+fn key_manager_status(ctx: &RpcContext, id: Namespace) -> Result<Status> {
     let consensus_state = block_on(ctx.consensus_verifier.latest_state())?;
     let km_state = KeyManagerState::new(&consensus_state);
     let status = km_state
         .status(id)?
         .ok_or(KeyManagerError::StatusNotFound)?;
 
+    Ok(status)
+}
+
+/// Fetch the identities of the key manager nodes.
+fn key_manager_nodes(ctx: &RpcContext, id: Namespace) -> Result<Vec<signature::PublicKey>> {
+    let status = key_manager_status(ctx, id)?;
+
     Ok(status.nodes)
 }
 
@@ -42,6 +42,17 @@ pub struct PolicySGX {
     pub master_secret_rotation_interval: EpochTime,
     #[cbor(optional)]
     pub max_ephemeral_secret_age: EpochTime,
+    #[cbor(optional)]
+    pub master_secret_recovery: Option<MasterSecretRecoveryPolicy>,
+}
+
+/// Master secret disaster recovery policy.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct MasterSecretRecoveryPolicy {
+    /// Number of custodians needed to recover the master secrets.
+    pub threshold: u8,
+    /// Public keys of the recovery custodians.
+    pub custodians: Vec<x25519::PublicKey>,
 }
 
 /// Per enclave key manager access control policy.
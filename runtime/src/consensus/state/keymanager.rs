@@ -249,6 +249,7 @@ mod test {
                         )]),
                         master_secret_rotation_interval: 0,
                         max_ephemeral_secret_age: 10,
+                        master_secret_recovery: None,
                     },
                     signatures: vec![
                         SignatureBundle {
@@ -790,7 +790,7 @@ impl Dispatcher {
             match message {
                 RpcMessage::Request(req) => {
                     // Request, dispatch.
-                    let response = self
+                    let (response, audit_metadata) = self
                         .dispatch_rpc(req, RpcKind::NoiseSession, session.info(), &state)
                         .await?;
                     let response = RpcMessage::Response(response);
@@ -808,7 +808,10 @@ impl Dispatcher {
                             error!(self.logger, "Error while writing response"; "err" => %err);
                             Error::new("rhp/dispatcher", 1, &format!("{err}"))
                         })
-                        .map(|_| Body::RuntimeRPCCallResponse { response: buffer })
+                        .map(|_| Body::RuntimeRPCCallResponse {
+                            response: buffer,
+                            audit_metadata,
+                        })
                 }
                 RpcMessage::Close => {
                     // Session close.
@@ -820,7 +823,10 @@ impl Dispatcher {
                             error!(self.logger, "Error while closing session"; "err" => %err);
                             Error::new("rhp/dispatcher", 1, &format!("{err}"))
                         })
-                        .map(|_| Body::RuntimeRPCCallResponse { response: buffer })
+                        .map(|_| Body::RuntimeRPCCallResponse {
+                            response: buffer,
+                            audit_metadata: None,
+                        })
                 }
                 msg => {
                     warn!(self.logger, "Ignoring invalid RPC message type"; "msg" => ?msg);
@@ -829,7 +835,10 @@ impl Dispatcher {
             }
         } else {
             // Send back any handshake frames.
-            Ok(Body::RuntimeRPCCallResponse { response: buffer })
+            Ok(Body::RuntimeRPCCallResponse {
+                response: buffer,
+                audit_metadata: None,
+            })
         }
     }
 
@@ -841,8 +850,8 @@ impl Dispatcher {
         let request: RpcRequest = cbor::from_slice(&request)
             .map_err(|_| Error::new("rhp/dispatcher", 1, "malformed request"))?;
 
-        // Request, dispatch.
-        let response = self
+        // Request, dispatch. Plaintext requests are audited by the host directly.
+        let (response, _) = self
             .dispatch_rpc(request, RpcKind::InsecureQuery, None, &state)
             .await?;
         let response = cbor::to_vec(response);
@@ -853,7 +862,10 @@ impl Dispatcher {
             "kind" => ?Kind::InsecureQuery,
         );
 
-        Ok(Body::RuntimeRPCCallResponse { response })
+        Ok(Body::RuntimeRPCCallResponse {
+            response,
+            audit_metadata: None,
+        })
     }
 
     async fn dispatch_local_rpc(&self, state: State, request: Vec<u8>) -> Result<Body, Error> {
@@ -865,7 +877,7 @@ impl Dispatcher {
             .map_err(|_| Error::new("rhp/dispatcher", 1, "malformed request"))?;
 
         // Request, dispatch.
-        let response = self
+        let (response, _) = self
             .dispatch_rpc(request, RpcKind::LocalQuery, None, &state)
             .await?;
         let response = RpcMessage::Response(response);
@@ -884,7 +896,7 @@ impl Dispatcher {
         kind: RpcKind,
         session_info: Option<Arc<SessionInfo>>,
         state: &State,
-    ) -> Result<RpcResponse, Error> {
+    ) -> Result<(RpcResponse, Option<cbor::Value>), Error> {
         let identity = self.identity.clone();
         let protocol = state.protocol.clone();
         let consensus_verifier = state.consensus_verifier.clone();
@@ -892,10 +904,11 @@ impl Dispatcher {
 
         let response = tokio::task::spawn_blocking(move || {
             let untrusted_local = Arc::new(ProtocolUntrustedLocalStorage::new(protocol.clone()));
-            let rpc_ctx =
+            let mut rpc_ctx =
                 RpcContext::new(identity, session_info, consensus_verifier, &untrusted_local);
 
-            rpc_dispatcher.dispatch(rpc_ctx, request, kind)
+            let response = rpc_dispatcher.dispatch(&mut rpc_ctx, request, kind);
+            (response, rpc_ctx.audit_metadata)
         })
         .await?;
 
@@ -18,6 +18,8 @@ pub struct Context<'a> {
     pub runtime: Box<dyn Any>,
     /// Untrusted local storage.
     pub untrusted_local_storage: &'a dyn KeyValue,
+    /// Request metadata disclosed to the host for access auditing, if any.
+    pub audit_metadata: Option<cbor::Value>,
 }
 
 impl<'a> Context<'a> {
@@ -34,6 +36,7 @@ impl<'a> Context<'a> {
             consensus_verifier,
             runtime: Box::new(NoRuntimeContext),
             untrusted_local_storage,
+            audit_metadata: None,
         }
     }
 }
@@ -167,12 +167,12 @@ impl Dispatcher {
     }
 
     /// Dispatch request.
-    pub fn dispatch(&self, mut ctx: Context, request: Request, kind: Kind) -> Response {
+    pub fn dispatch(&self, ctx: &mut Context, request: Request, kind: Kind) -> Response {
         if let Some(ref ctx_init) = self.ctx_initializer {
-            ctx_init.init(&mut ctx);
+            ctx_init.init(ctx);
         }
 
-        match self.dispatch_fallible(&mut ctx, request, kind) {
+        match self.dispatch_fallible(ctx, request, kind) {
             Ok(response) => response,
             Err(error) => Response {
                 body: Body::Error(format!("{error}")),
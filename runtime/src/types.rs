@@ -148,6 +148,8 @@ pub enum Body {
     },
     RuntimeRPCCallResponse {
         response: Vec<u8>,
+        #[cbor(optional)]
+        audit_metadata: Option<cbor::Value>,
     },
     RuntimeLocalRPCCallRequest {
         request: Vec<u8>,